@@ -3,13 +3,16 @@ package apply
 import (
 	"bufio"
 	"context"
-	"database/sql"
 	"fmt"
 	"os"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	planCmd "github.com/pgplex/pgschema/cmd/plan"
 	"github.com/pgplex/pgschema/cmd/util"
+	"github.com/pgplex/pgschema/internal/color"
+	"github.com/pgplex/pgschema/internal/diff"
 	"github.com/pgplex/pgschema/internal/fingerprint"
 	"github.com/pgplex/pgschema/internal/plan"
 	"github.com/pgplex/pgschema/internal/postgres"
@@ -29,8 +32,30 @@ var (
 	applyPlan            string
 	applyAutoApprove     bool
 	applyNoColor         bool
+	applyColor           string
 	applyLockTimeout     string
 	applyApplicationName string
+	applyKeepTempSchema  bool
+	applyConcurrency     int
+	applyAllowUnsafe     bool
+	applySafeFK          bool
+	applyIgnoreExtension bool
+	applySemanticBody    bool
+	applyCascadeDrops    []string
+	applyTablespace      string
+	applySearchPath      string
+	applyAnalyzeAfter    bool
+	applyOnError         string
+	applyLockWaitPolicy  string
+	applyLockRetries     int
+	applyPreSQL          string
+	applyPostSQL         string
+	applyFailOnWarning   bool
+
+	// Target database connection tuning
+	applySSLMode        string
+	applyConnectTimeout time.Duration
+	applyMaxConns       int
 
 	// Plan database connection flags (optional - for using external database instead of embedded postgres)
 	applyPlanDBHost     string
@@ -57,17 +82,38 @@ func init() {
 	ApplyCmd.Flags().StringVar(&applyUser, "user", "", "Database user name (required) (env: PGUSER)")
 	ApplyCmd.Flags().StringVar(&applyPassword, "password", "", "Database password (optional, can also use PGPASSWORD env var)")
 	ApplyCmd.Flags().StringVar(&applySchema, "schema", "public", "Schema name")
+	ApplyCmd.Flags().StringVar(&applySSLMode, "sslmode", util.DefaultSSLMode, "SSL mode for the target database connection: disable, allow, prefer, require, verify-ca, verify-full (env: PGSSLMODE)")
+	ApplyCmd.Flags().DurationVar(&applyConnectTimeout, "connection-timeout", util.DefaultConnectTimeout, "Timeout for connecting to the target database (env: PGCONNECT_TIMEOUT, in seconds)")
+	ApplyCmd.Flags().IntVar(&applyMaxConns, "max-conns", 0, "Maximum number of connections to the target database (0 means unlimited; must be at least --concurrency when set)")
 
 	// Desired state schema file flag
 	ApplyCmd.Flags().StringVar(&applyFile, "file", "", "Path to desired state SQL schema file")
 
 	// Plan file flag
 	ApplyCmd.Flags().StringVar(&applyPlan, "plan", "", "Path to plan JSON file")
+	ApplyCmd.Flags().StringVar(&applyPlan, "plan-file", "", "Path to plan JSON file (alias for --plan)")
 
 	// Apply behavior flags
 	ApplyCmd.Flags().BoolVar(&applyAutoApprove, "auto-approve", false, "Apply changes without prompting for approval")
-	ApplyCmd.Flags().BoolVar(&applyNoColor, "no-color", false, "Disable colored output")
+	ApplyCmd.Flags().StringVar(&applyColor, "color", color.ModeAuto, "Color the plan display: auto (only on a terminal), always, or never")
+	ApplyCmd.Flags().BoolVar(&applyNoColor, "no-color", false, "Disable colored output (same as --color=never)")
 	ApplyCmd.Flags().StringVar(&applyLockTimeout, "lock-timeout", "", "Maximum time to wait for database locks (e.g., 30s, 5m, 1h)")
+	ApplyCmd.Flags().IntVar(&applyConcurrency, "concurrency", 1, "Maximum number of non-transactional operations on different tables to run in parallel (e.g., CREATE INDEX CONCURRENTLY)")
+	ApplyCmd.Flags().BoolVar(&applyAllowUnsafe, "allow-unsafe-type-changes", false, "Allow column type changes without an implicit cast when generating the plan from --file")
+	ApplyCmd.Flags().BoolVar(&applySemanticBody, "semantic-body-compare", false, "When generating the plan from --file, ignore whitespace and comment differences in SQL and PL/pgSQL function and procedure bodies")
+	ApplyCmd.Flags().StringSliceVar(&applyCascadeDrops, "cascade-drops", nil, "When generating the plan from --file, drop objects of these categories with CASCADE instead of RESTRICT (comma-separated): "+strings.Join(diff.CascadeDropCategories(), ", "))
+	ApplyCmd.Flags().StringVar(&applyTablespace, "default-tablespace", "", "When generating the plan from --file, create new tables and indexes in this tablespace")
+	ApplyCmd.Flags().StringVar(&applySearchPath, "search-path", "", "search_path to run the migration with (e.g., \"app, extensions\"); when generating the plan from --file, references to schemas on it are compared unqualified")
+	ApplyCmd.Flags().BoolVar(&applyIgnoreExtension, "ignore-extension-objects", true, "Leave out tables, views, sequences and types that are members of an extension when generating the plan from --file and checking the plan's fingerprint (match the setting used by plan)")
+	ApplyCmd.Flags().BoolVar(&applyFailOnWarning, "fail-on-warning", false, "Fail if generating the plan from --file reports any warning, such as a change that drops data, before applying anything")
+	ApplyCmd.Flags().BoolVar(&applySafeFK, "safe-fk", false, "When generating the plan from --file, add all foreign keys on existing tables as NOT VALID first, then validate each one in its own transaction")
+	ApplyCmd.Flags().StringVar(&applyOnError, "on-error", OnErrorStop, "What to do when a statement fails: stop (stop at the first failure) or continue (run each statement on its own and report all failures at the end)")
+	ApplyCmd.Flags().StringVar(&applyLockWaitPolicy, "lock-wait-policy", LockWaitFail, "What to do when a statement cannot acquire its locks within --lock-timeout: fail, retry (with exponential backoff, up to --lock-retries times), or skip (report the statement and continue; requires --on-error=continue)")
+	ApplyCmd.Flags().IntVar(&applyLockRetries, "lock-retries", 3, "Maximum number of retries of a statement that cannot acquire its locks, with --lock-wait-policy=retry")
+	ApplyCmd.Flags().StringVar(&applyPreSQL, "pre-sql", "", "Path to a SQL file to run in the migration session before the generated statements (e.g., SET maintenance_work_mem)")
+	ApplyCmd.Flags().StringVar(&applyPostSQL, "post-sql", "", "Path to a SQL file to run in the migration session after the generated statements (e.g., a data backfill)")
+	ApplyCmd.Flags().BoolVar(&applyAnalyzeAfter, "analyze-after", false, "Run ANALYZE on each table touched by the migration after all changes are applied, outside the DDL transactions")
+	ApplyCmd.Flags().BoolVar(&applyKeepTempSchema, "keep-temp-schema", false, "Keep the temporary pgschema_tmp_* schema used to validate the desired state (for debugging)")
 	ApplyCmd.Flags().StringVar(&applyApplicationName, "application-name", "pgschema", "Application name for database connection (visible in pg_stat_activity) (env: PGAPPNAME)")
 
 	// Plan database connection flags (optional - for using external database instead of embedded postgres when using --file)
@@ -79,6 +125,8 @@ func init() {
 
 	// Mark file and plan as mutually exclusive
 	ApplyCmd.MarkFlagsMutuallyExclusive("file", "plan")
+	ApplyCmd.MarkFlagsMutuallyExclusive("file", "plan-file")
+	ApplyCmd.MarkFlagsMutuallyExclusive("plan", "plan-file")
 }
 
 // ApplyConfig holds configuration for apply execution
@@ -93,9 +141,47 @@ type ApplyConfig struct {
 	Plan            *plan.Plan // Pre-generated plan (optional, alternative to File)
 	AutoApprove     bool
 	NoColor         bool
-	Quiet           bool // Suppress plan display and progress messages (useful for tests)
+	Color           string // Color mode of the plan display: auto (the default if empty), always, or never
+	Quiet           bool   // Suppress plan display and progress messages (useful for tests)
 	LockTimeout     string
 	ApplicationName string
+	Concurrency     int    // Maximum parallel operations on different objects (0 or 1 runs serially)
+	AnalyzeAfter    bool   // Run ANALYZE on each touched table once the migration has been applied
+	OnError         string // OnErrorStop (default when empty) or OnErrorContinue
+	LockWaitPolicy  string // LockWaitFail (default when empty), LockWaitRetry, or LockWaitSkip
+	LockRetries     int    // Maximum number of retries with LockWaitRetry
+	PreSQL          string // SQL run in the migration session before the generated statements
+	PostSQL         string // SQL run in the migration session after the generated statements
+
+	// Target database connection tuning (optional - defaults to sslmode=prefer, a 30s timeout, and an unlimited pool)
+	SSLMode        string
+	ConnectTimeout time.Duration
+	MaxConns       int
+
+	AllowUnsafeTypeChanges bool     // Permit column type changes that need a USING clause (File mode only)
+	SafeFK                 bool     // Batch NOT VALID foreign key adds before their validations (File mode only)
+	SemanticBodyCompare    bool     // Ignore whitespace and comment differences in function and procedure bodies (File mode only)
+	CascadeDrops           []string // Object categories dropped with CASCADE instead of RESTRICT (File mode only)
+	DefaultTablespace      string   // Tablespace new tables and indexes are created in (File mode only)
+	SearchPath             string   // search_path the migration runs with; also used to compare references when generating the plan
+	FailOnWarning          bool     // Fail without applying if generating the plan reports any warning (File mode only)
+
+	IncludeExtensionObjects bool // Inspect the tables, views, sequences and types that are members of an extension
+}
+
+// connectionConfig returns the connection configuration for the target database
+func (c *ApplyConfig) connectionConfig() *util.ConnectionConfig {
+	return &util.ConnectionConfig{
+		Host:            c.Host,
+		Port:            c.Port,
+		Database:        c.DB,
+		User:            c.User,
+		Password:        c.Password,
+		SSLMode:         c.SSLMode,
+		ApplicationName: c.ApplicationName,
+		ConnectTimeout:  c.ConnectTimeout,
+		MaxConns:        c.MaxConns,
+	}
 }
 
 // ApplyMigration applies a migration plan to update a database schema.
@@ -127,6 +213,19 @@ func ApplyMigration(config *ApplyConfig, provider postgres.DesiredStateProvider)
 			Schema:          config.Schema,
 			File:            config.File,
 			ApplicationName: config.ApplicationName,
+			SSLMode:         config.SSLMode,
+			ConnectTimeout:  config.ConnectTimeout,
+			MaxConns:        config.MaxConns,
+			// Type change handling
+			AllowUnsafeTypeChanges: config.AllowUnsafeTypeChanges,
+			SafeFK:                 config.SafeFK,
+			SemanticBodyCompare:    config.SemanticBodyCompare,
+			CascadeDrops:           config.CascadeDrops,
+			DefaultTablespace:      config.DefaultTablespace,
+			SearchPath:             config.SearchPath,
+			FailOnWarning:          config.FailOnWarning,
+			// Extension members
+			IncludeExtensionObjects: config.IncludeExtensionObjects,
 		}
 
 		// Generate plan using shared logic
@@ -134,19 +233,27 @@ func ApplyMigration(config *ApplyConfig, provider postgres.DesiredStateProvider)
 		if err != nil {
 			return err
 		}
+		planCmd.PrintWarnings(migrationPlan)
 	} else {
 		return fmt.Errorf("either config.Plan or config.File must be provided")
 	}
 
+	// The plan leaves references to schemas on its search path unqualified, so it runs with that path
+	runSearchPath, err := planSearchPath(migrationPlan, config.SearchPath)
+	if err != nil {
+		return err
+	}
+
 	// Load ignore configuration for fingerprint validation
 	ignoreConfig, err := util.LoadIgnoreFileWithStructure()
 	if err != nil {
 		return fmt.Errorf("failed to load .pgschemaignore: %w", err)
 	}
+	ignoreConfig = util.WithExtensionObjects(ignoreConfig, !config.IncludeExtensionObjects)
 
 	// Validate schema fingerprint if plan has one
 	if migrationPlan.SourceFingerprint != nil {
-		err := validateSchemaFingerprint(migrationPlan, config.Host, config.Port, config.DB, config.User, config.Password, config.Schema, config.ApplicationName, ignoreConfig)
+		err := validateSchemaFingerprint(migrationPlan, config.connectionConfig(), config.Schema, ignoreConfig)
 		if err != nil {
 			return err
 		}
@@ -160,7 +267,11 @@ func ApplyMigration(config *ApplyConfig, provider postgres.DesiredStateProvider)
 
 	// Display the plan (unless quiet mode is enabled)
 	if !config.Quiet {
-		fmt.Print(migrationPlan.HumanColored(!config.NoColor))
+		colorMode := config.Color
+		if config.NoColor {
+			colorMode = color.ModeNever
+		}
+		fmt.Print(migrationPlan.HumanColored(color.Enabled(colorMode, os.Stdout)))
 	}
 
 	// Prompt for approval if not auto-approved
@@ -185,17 +296,7 @@ func ApplyMigration(config *ApplyConfig, provider postgres.DesiredStateProvider)
 	}
 
 	// Build database connection for applying changes
-	connConfig := &util.ConnectionConfig{
-		Host:            config.Host,
-		Port:            config.Port,
-		Database:        config.DB,
-		User:            config.User,
-		Password:        config.Password,
-		SSLMode:         "prefer",
-		ApplicationName: config.ApplicationName,
-	}
-
-	conn, err := util.Connect(connConfig)
+	conn, err := util.Connect(config.connectionConfig())
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -203,6 +304,10 @@ func ApplyMigration(config *ApplyConfig, provider postgres.DesiredStateProvider)
 
 	ctx := context.Background()
 
+	// Session settings are recorded so they can be replayed on the extra
+	// connections used for concurrent execution
+	var sessionSQL []string
+
 	// Set lock timeout before executing changes
 	if config.LockTimeout != "" {
 		lockTimeoutSQL := fmt.Sprintf("SET lock_timeout = '%s'", config.LockTimeout)
@@ -210,17 +315,19 @@ func ApplyMigration(config *ApplyConfig, provider postgres.DesiredStateProvider)
 		if err != nil {
 			return fmt.Errorf("failed to set lock timeout: %w", err)
 		}
+		sessionSQL = append(sessionSQL, lockTimeoutSQL)
 	}
 
-	// Set search_path to target schema for unqualified table references
-	if config.Schema != "" && config.Schema != "public" {
-		quotedSchema := ir.QuoteIdentifier(config.Schema)
-		searchPathSQL := fmt.Sprintf("SET search_path TO %s, public", quotedSchema)
+	// Set search_path to target schema for unqualified table references, followed by the configured
+	// search path (public by default) that the plan left references to unqualified for
+	if searchPath := sessionSearchPath(config.Schema, runSearchPath); searchPath != "" {
+		searchPathSQL := fmt.Sprintf("SET search_path TO %s", searchPath)
 		_, err = util.ExecContextWithLogging(ctx, conn, searchPathSQL, "set search_path to target schema")
 		if err != nil {
 			return fmt.Errorf("failed to set search_path to target schema '%s': %w", config.Schema, err)
 		}
-		fmt.Printf("Set search_path to: %s, public\n", quotedSchema)
+		sessionSQL = append(sessionSQL, searchPathSQL)
+		fmt.Printf("Set search_path to: %s\n", searchPath)
 	}
 
 	// Generate SQL statements from the plan
@@ -232,24 +339,175 @@ func ApplyMigration(config *ApplyConfig, provider postgres.DesiredStateProvider)
 		return nil
 	}
 
-	// Execute by groups with wait directive support
-	for i, group := range migrationPlan.Groups {
-		if !config.Quiet {
-			fmt.Printf("\nExecuting group %d/%d...\n", i+1, len(migrationPlan.Groups))
+	// Run the setup SQL in the same session, in its own implicit transaction like a plan group
+	if config.PreSQL != "" {
+		if err := executeHookSQL(ctx, conn, config.PreSQL, "pre-sql", config.Quiet); err != nil {
+			return fmt.Errorf("no changes were applied: --pre-sql failed: %w", err)
 		}
+		// Replay its leading session settings on the connections used for concurrent execution
+		sessionSQL = append(sessionSQL, leadingSetStatements(config.PreSQL)...)
+	}
 
-		err = executeGroup(ctx, conn, group, i+1, config.Quiet)
+	lockWait := lockWaitPolicy{mode: config.LockWaitPolicy, retries: config.LockRetries, backoff: defaultLockRetryBackoff}
+	if config.OnError == OnErrorContinue {
+		// Run each statement in its own transaction and report every failure at the end
+		failures, attempted := executeContinueOnError(ctx, conn, migrationPlan.Groups, lockWait, config.Quiet)
+		if len(failures) > 0 {
+			return failureSummaryError(failures, attempted)
+		}
+	} else {
+		// Execute by groups with wait directive support. Non-transactional operations on
+		// different tables may run in parallel when concurrency is greater than 1.
+		stages := buildExecutionStages(migrationPlan.Groups)
+		err = executeStages(ctx, conn, stages, len(migrationPlan.Groups), config.Concurrency, sessionSQL, lockWait, config.Quiet)
 		if err != nil {
 			return err
 		}
 	}
 
+	// Run the teardown SQL once all DDL has been committed, before statistics are refreshed so
+	// that they include rows a backfill writes
+	if config.PostSQL != "" {
+		if err := executeHookSQL(ctx, conn, config.PostSQL, "post-sql", config.Quiet); err != nil {
+			return fmt.Errorf("changes were applied, but --post-sql failed: %w", err)
+		}
+	}
+
+	// Refresh planner statistics of the touched tables, now that all DDL has been committed
+	if config.AnalyzeAfter {
+		if err := analyzeTouchedTables(ctx, conn, migrationPlan, config.Quiet); err != nil {
+			return err
+		}
+	}
+
 	if !config.Quiet {
 		fmt.Println("Changes applied successfully!")
 	}
 	return nil
 }
 
+// analyzeTouchedTables issues ANALYZE for each table touched by the migration plan
+func analyzeTouchedTables(ctx context.Context, conn dbExecutor, migrationPlan *plan.Plan, quiet bool) error {
+	for _, analyzeSQL := range migrationPlan.AnalyzeStatements() {
+		if !quiet {
+			fmt.Printf("  Executing: %s\n", analyzeSQL)
+		}
+		if _, err := util.ExecContextWithLogging(ctx, conn, analyzeSQL, "analyze touched table"); err != nil {
+			return fmt.Errorf("changes were applied, but %s failed: %w", strings.TrimSuffix(analyzeSQL, ";"), err)
+		}
+	}
+	return nil
+}
+
+// executeHookSQL runs the contents of a --pre-sql or --post-sql file as a single implicit transaction
+func executeHookSQL(ctx context.Context, conn dbExecutor, hookSQL, flag string, quiet bool) error {
+	if !quiet {
+		fmt.Printf("\nExecuting --%s...\n", flag)
+	}
+	_, err := util.ExecContextWithLogging(ctx, conn, hookSQL, "execute "+flag)
+	return err
+}
+
+// readHookFile reads the SQL file given to --pre-sql or --post-sql; an empty path yields no SQL
+func readHookFile(path, flag string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read --%s file: %w", flag, err)
+	}
+	if strings.TrimSpace(string(content)) == "" {
+		return "", nil
+	}
+	return string(content), nil
+}
+
+// leadingSetStatements returns the SET statements at the start of a --pre-sql file, up to its first
+// other statement. SET LOCAL only lasts for the hook's own transaction, so it is not included.
+func leadingSetStatements(hookSQL string) []string {
+	var statements []string
+	for _, stmt := range splitHookStatements(hookSQL) {
+		fields := strings.Fields(strings.ToUpper(stmt))
+		if len(fields) < 2 || fields[0] != "SET" {
+			break
+		}
+		if fields[1] == "LOCAL" {
+			continue
+		}
+		statements = append(statements, stmt)
+	}
+	return statements
+}
+
+// splitHookStatements splits hook SQL into statements at semicolons outside of quotes, dropping
+// comment lines and empty statements
+func splitHookStatements(hookSQL string) []string {
+	var statements []string
+	var current strings.Builder
+	var quote rune
+	flush := func() {
+		if stmt := strings.TrimSpace(current.String()); stmt != "" {
+			statements = append(statements, stmt)
+		}
+		current.Reset()
+	}
+	for _, line := range strings.Split(hookSQL, "\n") {
+		if quote == 0 && strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		for _, r := range line {
+			switch {
+			case quote != 0:
+				if r == quote {
+					quote = 0
+				}
+			case r == '\'' || r == '"':
+				quote = r
+			case r == ';':
+				flush()
+				continue
+			}
+			current.WriteRune(r)
+		}
+		current.WriteString("\n")
+	}
+	flush()
+	return statements
+}
+
+// planSearchPath returns the search path to run a plan with: the one the plan was generated with,
+// if any, or else the configured one. It returns an error if both are set and differ.
+func planSearchPath(migrationPlan *plan.Plan, configured string) (string, error) {
+	if migrationPlan.SearchPath == "" {
+		return configured, nil
+	}
+	if configured != "" && strings.Join(ir.ParseSearchPath(configured), ",") != strings.Join(ir.ParseSearchPath(migrationPlan.SearchPath), ",") {
+		return "", fmt.Errorf("the plan was generated with --search-path %q, but --search-path is %q; apply it with the same search path or regenerate the plan", migrationPlan.SearchPath, configured)
+	}
+	return migrationPlan.SearchPath, nil
+}
+
+// sessionSearchPath returns the search_path the migration runs with: the target schema followed by
+// the configured search path, or by public when none is configured. It returns "" when the
+// database default can be kept.
+func sessionSearchPath(schema, searchPath string) string {
+	if searchPath == "" {
+		if schema == "" || schema == "public" {
+			return ""
+		}
+		return ir.QuoteIdentifier(schema) + ", public"
+	}
+
+	schemas := []string{ir.QuoteIdentifier(schema)}
+	for _, name := range ir.ParseSearchPath(searchPath) {
+		if name != schema {
+			schemas = append(schemas, ir.QuoteIdentifier(name))
+		}
+	}
+	return strings.Join(schemas, ", ")
+}
+
 // RunApply executes the apply command logic. Exported for testing.
 func RunApply(cmd *cobra.Command, args []string) error {
 	// Validate that either --file or --plan is provided
@@ -257,6 +515,62 @@ func RunApply(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --file or --plan must be specified")
 	}
 
+	if applyConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got %d", applyConcurrency)
+	}
+
+	switch applyOnError {
+	case OnErrorStop:
+	case OnErrorContinue:
+		// Statements run one at a time so that each failure is isolated
+		if applyConcurrency > 1 {
+			return fmt.Errorf("--on-error=continue cannot be combined with --concurrency greater than 1")
+		}
+	default:
+		return fmt.Errorf("invalid --on-error value %q: must be %q or %q", applyOnError, OnErrorStop, OnErrorContinue)
+	}
+
+	switch applyLockWaitPolicy {
+	case LockWaitFail:
+	case LockWaitRetry:
+		if applyLockRetries < 1 {
+			return fmt.Errorf("--lock-retries must be at least 1, got %d", applyLockRetries)
+		}
+	case LockWaitSkip:
+		// Only statements that run on their own can be skipped without abandoning the rest of their group
+		if applyOnError != OnErrorContinue {
+			return fmt.Errorf("--lock-wait-policy=skip requires --on-error=continue")
+		}
+	default:
+		return fmt.Errorf("invalid --lock-wait-policy value %q: must be %q, %q, or %q", applyLockWaitPolicy, LockWaitFail, LockWaitRetry, LockWaitSkip)
+	}
+
+	if err := diff.ValidateCascadeDrops(applyCascadeDrops); err != nil {
+		return err
+	}
+
+	if err := color.ValidateMode(applyColor); err != nil {
+		return err
+	}
+
+	// Apply environment variables to connection tuning flags and validate them
+	util.ApplyConnectionEnvVars(cmd, &applySSLMode, &applyConnectTimeout)
+	if err := util.ValidateConnectionFlags(applySSLMode, applyConnectTimeout, applyMaxConns); err != nil {
+		return err
+	}
+	if applyMaxConns > 0 && applyConcurrency > applyMaxConns {
+		return fmt.Errorf("--concurrency (%d) must not exceed --max-conns (%d)", applyConcurrency, applyMaxConns)
+	}
+
+	preSQL, err := readHookFile(applyPreSQL, "pre-sql")
+	if err != nil {
+		return err
+	}
+	postSQL, err := readHookFile(applyPostSQL, "post-sql")
+	if err != nil {
+		return err
+	}
+
 	// Derive final password: use provided password or check environment variable
 	finalPassword := applyPassword
 	if finalPassword == "" {
@@ -275,12 +589,32 @@ func RunApply(cmd *cobra.Command, args []string) error {
 		Schema:          applySchema,
 		AutoApprove:     applyAutoApprove,
 		NoColor:         applyNoColor,
+		Color:           applyColor,
 		LockTimeout:     applyLockTimeout,
 		ApplicationName: applyApplicationName,
+		Concurrency:     applyConcurrency,
+		AnalyzeAfter:    applyAnalyzeAfter,
+		OnError:         applyOnError,
+		LockWaitPolicy:  applyLockWaitPolicy,
+		LockRetries:     applyLockRetries,
+		PreSQL:          preSQL,
+		PostSQL:         postSQL,
+		SSLMode:         applySSLMode,
+		ConnectTimeout:  applyConnectTimeout,
+		MaxConns:        applyMaxConns,
+
+		AllowUnsafeTypeChanges: applyAllowUnsafe,
+		SafeFK:                 applySafeFK,
+		SemanticBodyCompare:    applySemanticBody,
+		CascadeDrops:           applyCascadeDrops,
+		DefaultTablespace:      applyTablespace,
+		SearchPath:             applySearchPath,
+		FailOnWarning:          applyFailOnWarning,
+
+		IncludeExtensionObjects: !applyIgnoreExtension,
 	}
 
 	var provider postgres.DesiredStateProvider
-	var err error
 
 	// If using --plan flag, load plan from JSON file
 	if applyPlan != "" {
@@ -343,6 +677,7 @@ func RunApply(cmd *cobra.Command, args []string) error {
 			PlanDBDatabase: applyPlanDBDatabase,
 			PlanDBUser:     applyPlanDBUser,
 			PlanDBPassword: finalPlanPassword,
+			KeepTempSchema: applyKeepTempSchema,
 		}
 		provider, err = planCmd.CreateDesiredStateProvider(planConfig)
 		if err != nil {
@@ -356,10 +691,10 @@ func RunApply(cmd *cobra.Command, args []string) error {
 }
 
 // validateSchemaFingerprint validates that the current database schema matches the expected fingerprint
-func validateSchemaFingerprint(migrationPlan *plan.Plan, host string, port int, db, user, password, schema, applicationName string, ignoreConfig *ir.IgnoreConfig) error {
+func validateSchemaFingerprint(migrationPlan *plan.Plan, connConfig *util.ConnectionConfig, schema string, ignoreConfig *ir.IgnoreConfig) error {
 	// Get current state from target database with ignore config
 	// This ensures ignored objects are excluded from fingerprint calculation
-	currentStateIR, err := util.GetIRFromDatabase(host, port, db, user, password, schema, applicationName, ignoreConfig)
+	currentStateIR, err := util.GetIRFromDatabaseWithConfig(connConfig, schema, ignoreConfig)
 	if err != nil {
 		return fmt.Errorf("failed to get current database state for fingerprint validation: %w", err)
 	}
@@ -379,44 +714,28 @@ func validateSchemaFingerprint(migrationPlan *plan.Plan, host string, port int,
 }
 
 // executeGroup executes all steps in a group, handling directives separately from SQL statements
-func executeGroup(ctx context.Context, conn *sql.DB, group plan.ExecutionGroup, groupNum int, quiet bool) error {
-	// Check if this group has directives
-	hasDirectives := false
-
-	for _, step := range group.Steps {
-		if step.Directive != nil {
-			hasDirectives = true
-			break
-		}
-	}
-
-	if !hasDirectives {
+func executeGroup(ctx context.Context, conn dbExecutor, group plan.ExecutionGroup, groupNum int, lockWait lockWaitPolicy, quiet bool) error {
+	if !group.HasDirectives() {
 		// No directives - concatenate all SQL and execute in implicit transaction
-		return executeGroupConcatenated(ctx, conn, group, groupNum, quiet)
+		return executeGroupConcatenated(ctx, conn, group, groupNum, lockWait, quiet)
 	} else {
 		// Has directives - execute statements individually
-		return executeGroupIndividually(ctx, conn, group, groupNum, quiet)
+		return executeGroupIndividually(ctx, conn, group, groupNum, lockWait, quiet)
 	}
 }
 
 // executeGroupConcatenated concatenates all SQL statements and executes them in an implicit transaction
-func executeGroupConcatenated(ctx context.Context, conn *sql.DB, group plan.ExecutionGroup, groupNum int, quiet bool) error {
-	var sqlStatements []string
-
-	// Collect all SQL statements
-	for _, step := range group.Steps {
-		sqlStatements = append(sqlStatements, step.SQL)
-	}
-
+func executeGroupConcatenated(ctx context.Context, conn dbExecutor, group plan.ExecutionGroup, groupNum int, lockWait lockWaitPolicy, quiet bool) error {
 	// Concatenate all SQL statements
-	concatenatedSQL := strings.Join(sqlStatements, ";\n") + ";"
+	concatenatedSQL := group.ConcatenatedSQL()
 
 	if !quiet {
-		fmt.Printf("  Executing %d statements in implicit transaction\n", len(sqlStatements))
+		fmt.Printf("  Executing %d statements in implicit transaction\n", len(group.Steps))
 	}
 
-	// Execute all statements in a single call (implicit transaction)
-	_, err := util.ExecContextWithLogging(ctx, conn, concatenatedSQL, fmt.Sprintf("execute %d statements in group %d", len(sqlStatements), groupNum))
+	// Execute all statements in a single call (implicit transaction), which is rolled back as a
+	// whole if it has to be retried
+	err := execWithLockWait(ctx, conn, concatenatedSQL, fmt.Sprintf("execute %d statements in group %d", len(group.Steps), groupNum), lockWait, quiet)
 	if err != nil {
 		return fmt.Errorf("failed to execute concatenated statements in group %d: %w", groupNum, err)
 	}
@@ -425,7 +744,7 @@ func executeGroupConcatenated(ctx context.Context, conn *sql.DB, group plan.Exec
 }
 
 // executeGroupIndividually executes statements individually without transactions
-func executeGroupIndividually(ctx context.Context, conn *sql.DB, group plan.ExecutionGroup, groupNum int, quiet bool) error {
+func executeGroupIndividually(ctx context.Context, conn dbExecutor, group plan.ExecutionGroup, groupNum int, lockWait lockWaitPolicy, quiet bool) error {
 	for stepIdx, step := range group.Steps {
 		if step.Directive != nil {
 			// Handle directive execution
@@ -439,7 +758,7 @@ func executeGroupIndividually(ctx context.Context, conn *sql.DB, group plan.Exec
 				fmt.Printf("  Executing: %s\n", truncateSQL(step.SQL, 80))
 			}
 
-			_, err := util.ExecContextWithLogging(ctx, conn, step.SQL, fmt.Sprintf("execute statement in group %d, step %d", groupNum, stepIdx+1))
+			err := execWithLockWait(ctx, conn, step.SQL, fmt.Sprintf("execute statement in group %d, step %d", groupNum, stepIdx+1), lockWait, quiet)
 			if err != nil {
 				return fmt.Errorf("failed to execute statement in group %d, step %d: %w", groupNum, stepIdx+1, err)
 			}
@@ -463,5 +782,10 @@ func truncateSQL(sql string, maxLen int) string {
 		return cleaned
 	}
 
-	return cleaned[:maxLen-3] + "..."
+	// Cut on a character boundary so multibyte characters are not split
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(cleaned[cut]) {
+		cut--
+	}
+	return cleaned[:cut] + "..."
 }
@@ -1344,3 +1344,215 @@ CREATE TABLE users (
 	require.NoError(t, err, "should find users table")
 	assert.Equal(t, "users", tableName, "table should be created")
 }
+
+// TestApplyCommand_AnalyzeAfter verifies that --analyze-after refreshes planner statistics
+// of a table that got a new index once the migration has been applied.
+func TestApplyCommand_AnalyzeAfter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	embeddedPG := testutil.SetupPostgres(t)
+	defer embeddedPG.Stop()
+	conn, host, port, dbname, user, password := testutil.ConnectToPostgres(t, embeddedPG)
+	defer conn.Close()
+
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE users (
+			id SERIAL PRIMARY KEY,
+			email VARCHAR(255)
+		);
+
+		INSERT INTO users (email) SELECT 'user' || i || '@example.com' FROM generate_series(1, 100) i;
+	`)
+	require.NoError(t, err, "should set up initial schema")
+
+	tmpDir := t.TempDir()
+	desiredStateFile := filepath.Join(tmpDir, "desired_state.sql")
+	desiredStateSQL := `
+		CREATE TABLE users (
+			id SERIAL PRIMARY KEY,
+			email VARCHAR(255)
+		);
+
+		CREATE INDEX idx_users_email ON users (email);
+	`
+	require.NoError(t, os.WriteFile(desiredStateFile, []byte(desiredStateSQL), 0644))
+
+	applyConfig := &ApplyConfig{
+		Host:            host,
+		Port:            port,
+		DB:              dbname,
+		User:            user,
+		Password:        password,
+		Schema:          "public",
+		File:            desiredStateFile,
+		AutoApprove:     true,
+		Quiet:           true, // Suppress output in tests
+		ApplicationName: "pgschema",
+		AnalyzeAfter:    true,
+	}
+
+	err = ApplyMigration(applyConfig, sharedEmbeddedPG)
+	require.NoError(t, err, "apply with --analyze-after should succeed")
+
+	// analyze_count only counts manual ANALYZE runs, so autovacuum can't make this pass
+	var analyzeCount int
+	err = conn.QueryRowContext(ctx, `
+		SELECT analyze_count FROM pg_stat_user_tables
+		WHERE schemaname = 'public' AND relname = 'users'
+	`).Scan(&analyzeCount)
+	require.NoError(t, err, "should query table statistics")
+	assert.Equal(t, 1, analyzeCount, "expected ANALYZE to be issued for users after its new index was created")
+}
+
+// TestApplyCommand_OnError verifies that --on-error=stop stops at the first failing statement and
+// rolls back its transaction, while --on-error=continue runs every statement on its own, keeps the
+// statements that succeeded, and reports the failures at the end.
+func TestApplyCommand_OnError(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	newPlan := func() *plan.Plan {
+		return &plan.Plan{
+			Groups: []plan.ExecutionGroup{{Steps: []plan.Step{
+				{SQL: "CREATE TABLE first_table (id integer);", Type: "table", Operation: "create", Path: "public.first_table"},
+				{SQL: "ALTER TABLE nonexistent_table ADD COLUMN note text;", Type: "table.column", Operation: "create", Path: "public.nonexistent_table.note"},
+				{SQL: "CREATE TABLE last_table (id integer);", Type: "table", Operation: "create", Path: "public.last_table"},
+			}}},
+		}
+	}
+
+	tableExists := func(t *testing.T, conn *sql.DB, table string) bool {
+		var exists bool
+		err := conn.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM information_schema.tables
+				WHERE table_schema = 'public' AND table_name = $1
+			)
+		`, table).Scan(&exists)
+		require.NoError(t, err, "should check table existence")
+		return exists
+	}
+
+	tests := []struct {
+		name        string
+		onError     string
+		errContains string
+		wantTables  bool
+	}{
+		{name: "stop", onError: OnErrorStop, errContains: "failed to execute concatenated statements in group 1", wantTables: false},
+		{name: "continue", onError: OnErrorContinue, errContains: "1 of 3 statements failed", wantTables: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embeddedPG := testutil.SetupPostgres(t)
+			defer embeddedPG.Stop()
+			conn, host, port, dbname, user, password := testutil.ConnectToPostgres(t, embeddedPG)
+			defer conn.Close()
+
+			applyConfig := &ApplyConfig{
+				Host:            host,
+				Port:            port,
+				DB:              dbname,
+				User:            user,
+				Password:        password,
+				Schema:          "public",
+				Plan:            newPlan(),
+				AutoApprove:     true,
+				Quiet:           true, // Suppress output in tests
+				ApplicationName: "pgschema",
+				OnError:         tt.onError,
+			}
+
+			err := ApplyMigration(applyConfig, nil)
+			require.Error(t, err, "apply should report the failing statement")
+			assert.Contains(t, err.Error(), tt.errContains)
+
+			// With stop the whole group is rolled back; with continue the other statements are kept
+			assert.Equal(t, tt.wantTables, tableExists(t, conn, "first_table"))
+			assert.Equal(t, tt.wantTables, tableExists(t, conn, "last_table"))
+		})
+	}
+}
+
+// TestApplyCommand_PreAndPostSQL verifies that --pre-sql runs in the migration session before the
+// generated DDL and --post-sql after it, and that a failing --pre-sql prevents any change.
+func TestApplyCommand_PreAndPostSQL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	embeddedPG := testutil.SetupPostgres(t)
+	defer embeddedPG.Stop()
+	conn, host, port, dbname, user, password := testutil.ConnectToPostgres(t, embeddedPG)
+	defer conn.Close()
+
+	// The log lives outside the target schema so the plan leaves it alone
+	_, err := conn.ExecContext(ctx, `
+		CREATE SCHEMA audit;
+		CREATE TABLE audit.hook_log (seq serial PRIMARY KEY, entry text NOT NULL);
+		CREATE TABLE users (id integer PRIMARY KEY);
+	`)
+	require.NoError(t, err, "should set up initial schema")
+
+	tmpDir := t.TempDir()
+	desiredStateFile := filepath.Join(tmpDir, "desired_state.sql")
+	require.NoError(t, os.WriteFile(desiredStateFile, []byte(`
+		CREATE TABLE users (id integer PRIMARY KEY, email text);
+	`), 0644))
+
+	// Each hook records whether the email column exists yet, and the pre-sql session setting
+	// is still visible to the post-sql
+	hookSQL := func(entry string) string {
+		return `INSERT INTO audit.hook_log (entry)
+			SELECT '` + entry + `:' || count(*) || ':' || current_setting('maintenance_work_mem')
+			FROM information_schema.columns
+			WHERE table_schema = 'public' AND table_name = 'users' AND column_name = 'email';`
+	}
+
+	applyConfig := &ApplyConfig{
+		Host:            host,
+		Port:            port,
+		DB:              dbname,
+		User:            user,
+		Password:        password,
+		Schema:          "public",
+		File:            desiredStateFile,
+		AutoApprove:     true,
+		Quiet:           true, // Suppress output in tests
+		ApplicationName: "pgschema",
+		PreSQL:          "SET maintenance_work_mem = '64MB';\n" + hookSQL("pre"),
+		PostSQL:         hookSQL("post"),
+	}
+
+	// A failing pre-sql stops the migration before any DDL runs
+	failing := *applyConfig
+	failing.PreSQL = "SELECT * FROM audit.missing_table;"
+	err = ApplyMigration(&failing, sharedEmbeddedPG)
+	require.Error(t, err, "apply with a failing --pre-sql should fail")
+	assert.Contains(t, err.Error(), "no changes were applied")
+
+	err = ApplyMigration(applyConfig, sharedEmbeddedPG)
+	require.NoError(t, err, "apply with --pre-sql and --post-sql should succeed")
+
+	rows, err := conn.QueryContext(ctx, "SELECT entry FROM audit.hook_log ORDER BY seq")
+	require.NoError(t, err, "should query the hook log")
+	defer rows.Close()
+	var entries []string
+	for rows.Next() {
+		var entry string
+		require.NoError(t, rows.Scan(&entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"pre:0:64MB", "post:1:64MB"}, entries)
+}
@@ -6,7 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
+	"github.com/pgplex/pgschema/internal/plan"
 	"github.com/pgplex/pgschema/internal/version"
 	"github.com/spf13/cobra"
 )
@@ -74,6 +76,16 @@ func TestApplyCommand(t *testing.T) {
 		t.Error("Expected --file flag to be defined")
 	}
 
+	// Test plan flag and its --plan-file alias
+	planFlag := flags.Lookup("plan")
+	if planFlag == nil {
+		t.Error("Expected --plan flag to be defined")
+	}
+	planFileFlag := flags.Lookup("plan-file")
+	if planFileFlag == nil {
+		t.Error("Expected --plan-file flag to be defined")
+	}
+
 	// Test auto-approve flag
 	autoApproveFlag := flags.Lookup("auto-approve")
 	if autoApproveFlag == nil {
@@ -109,6 +121,15 @@ func TestApplyCommand(t *testing.T) {
 	if applicationNameFlag.DefValue != "pgschema" {
 		t.Errorf("Expected default application-name to be 'pgschema', got '%s'", applicationNameFlag.DefValue)
 	}
+
+	// Test concurrency flag
+	concurrencyFlag := flags.Lookup("concurrency")
+	if concurrencyFlag == nil {
+		t.Error("Expected --concurrency flag to be defined")
+	}
+	if concurrencyFlag.DefValue != "1" {
+		t.Errorf("Expected default concurrency to be '1', got '%s'", concurrencyFlag.DefValue)
+	}
 }
 
 func TestApplyCommandRequiredFlags(t *testing.T) {
@@ -312,6 +333,21 @@ func TestApplyCommandFlagValidation(t *testing.T) {
 	})
 }
 
+func TestApplyCommandPlanFileAlias(t *testing.T) {
+	origPlan := applyPlan
+	defer func() {
+		applyPlan = origPlan
+		ApplyCmd.Flags().Lookup("plan-file").Changed = false
+	}()
+
+	if err := ApplyCmd.Flags().Set("plan-file", "reviewed-plan.json"); err != nil {
+		t.Fatalf("Failed to set --plan-file: %v", err)
+	}
+	if applyPlan != "reviewed-plan.json" {
+		t.Errorf("Expected --plan-file to set the plan path, got '%s'", applyPlan)
+	}
+}
+
 func TestApplyCommandVersionMismatch(t *testing.T) {
 	// Save original values
 	origDB := applyDB
@@ -541,3 +577,82 @@ func TestApplyCommand_PlanDatabaseFlags(t *testing.T) {
 		t.Errorf("Expected default plan-password to be empty, got '%s'", planPasswordFlag.DefValue)
 	}
 }
+
+func TestSessionSearchPath(t *testing.T) {
+	tests := []struct {
+		schema     string
+		searchPath string
+		expected   string
+	}{
+		{"public", "", ""},
+		{"app", "", `app, public`},
+		{"app", "app, extensions", `app, extensions`},
+		{"public", `"$user", public, "Ext"`, `public, "Ext"`},
+	}
+
+	for _, tt := range tests {
+		if got := sessionSearchPath(tt.schema, tt.searchPath); got != tt.expected {
+			t.Errorf("sessionSearchPath(%q, %q) = %q, want %q", tt.schema, tt.searchPath, got, tt.expected)
+		}
+	}
+}
+
+func TestPlanSearchPath(t *testing.T) {
+	tests := []struct {
+		planned    string
+		configured string
+		expected   string
+		wantErr    bool
+	}{
+		{"", "", "", false},
+		{"", "app", "app", false},
+		{"app, extensions", "", "app, extensions", false},
+		{"app, extensions", `app,"extensions"`, "app, extensions", false},
+		{"app, extensions", "app", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := planSearchPath(&plan.Plan{SearchPath: tt.planned}, tt.configured)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("planSearchPath(%q, %q) error = %v, wantErr %v", tt.planned, tt.configured, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("planSearchPath(%q, %q) = %q, want %q", tt.planned, tt.configured, got, tt.expected)
+		}
+	}
+}
+
+func TestLeadingSetStatements(t *testing.T) {
+	hookSQL := `-- Session settings for the index builds
+SET maintenance_work_mem = '1GB';
+set LOCAL statement_timeout = 0;
+SET application_name = 'a;b';
+CREATE FUNCTION helper() RETURNS integer LANGUAGE sql AS 'SELECT 1';
+SET work_mem = '64MB';`
+
+	got := leadingSetStatements(hookSQL)
+	expected := []string{"SET maintenance_work_mem = '1GB'", "SET application_name = 'a;b'"}
+	if strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("leadingSetStatements() = %q, want %q", got, expected)
+	}
+
+	if got := leadingSetStatements("INSERT INTO audit VALUES (1);\nSET work_mem = '64MB';"); len(got) != 0 {
+		t.Errorf("expected no statements after a leading non-SET statement, got %q", got)
+	}
+}
+
+func TestTruncateSQL(t *testing.T) {
+	if got := truncateSQL("CREATE TABLE users (\n    id integer\n);", 80); got != "CREATE TABLE users ( id integer );" {
+		t.Errorf("expected whitespace to be collapsed, got %q", got)
+	}
+
+	// The cut falls inside the two-byte "é" and moves back before it
+	got := truncateSQL("COMMENT ON TABLE users IS 'café au lait';", 34)
+	if got != "COMMENT ON TABLE users IS 'caf..." {
+		t.Errorf("expected the truncation not to split a character, got %q", got)
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("expected valid UTF-8, got %q", got)
+	}
+}
@@ -0,0 +1,208 @@
+package apply
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pgplex/pgschema/cmd/util"
+	"github.com/pgplex/pgschema/internal/plan"
+)
+
+// dbExecutor is implemented by *sql.DB and *sql.Conn
+type dbExecutor interface {
+	util.SQLExecutor
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// executionUnit is a sequence of execution groups that lock the same object.
+// Groups within a unit always run in plan order on a single connection.
+type executionUnit struct {
+	lockTarget string // Object locked by the groups (e.g., "public.users"), empty for transactional groups
+	groups     []plan.ExecutionGroup
+	groupNums  []int // 1-based group numbers in the plan, for progress and error messages
+}
+
+// executionStage is a set of units that may run in parallel.
+// Stages run strictly in order, so every stage acts as a barrier for the next one.
+type executionStage struct {
+	units []*executionUnit
+}
+
+// buildExecutionStages partitions the plan groups into stages for execution.
+//
+// Transactional groups always form a stage of their own. Consecutive isolated groups
+// (non-transactional statements such as CREATE INDEX CONCURRENTLY and their wait
+// directives) are collected into a single stage and split into units by the object
+// they lock, so that operations on the same table serialize while operations on
+// different tables may run concurrently.
+func buildExecutionStages(groups []plan.ExecutionGroup) []executionStage {
+	var stages []executionStage
+	var current *executionStage
+	unitsByTarget := make(map[string]*executionUnit)
+
+	flush := func() {
+		if current != nil {
+			stages = append(stages, *current)
+			current = nil
+			unitsByTarget = make(map[string]*executionUnit)
+		}
+	}
+
+	for i, group := range groups {
+		groupNum := i + 1
+
+		if !isIsolatedGroup(group) {
+			flush()
+			stages = append(stages, executionStage{units: []*executionUnit{{
+				groups:    []plan.ExecutionGroup{group},
+				groupNums: []int{groupNum},
+			}}})
+			continue
+		}
+
+		if current == nil {
+			current = &executionStage{}
+		}
+		target := lockTarget(group.Steps[0])
+		unit, exists := unitsByTarget[target]
+		if !exists {
+			unit = &executionUnit{lockTarget: target}
+			unitsByTarget[target] = unit
+			current.units = append(current.units, unit)
+		}
+		unit.groups = append(unit.groups, group)
+		unit.groupNums = append(unit.groupNums, groupNum)
+	}
+	flush()
+
+	return stages
+}
+
+// isIsolatedGroup reports whether a group holds a single statement that was split out of
+// the transactional flow, either because it cannot run in a transaction or because it is
+// a directive. Only such groups are eligible for concurrent execution.
+func isIsolatedGroup(group plan.ExecutionGroup) bool {
+	if len(group.Steps) != 1 {
+		return false
+	}
+	step := group.Steps[0]
+	if step.Directive != nil {
+		return true
+	}
+	return strings.Contains(strings.ToUpper(step.SQL), " CONCURRENTLY ")
+}
+
+// lockTarget returns the object whose locks a step takes.
+// Sub-objects such as indexes and constraints lock their parent table or materialized view,
+// so "public.users.idx_users_email" maps to "public.users".
+func lockTarget(step plan.Step) string {
+	if strings.Contains(step.Type, ".") {
+		if idx := strings.LastIndex(step.Path, "."); idx > 0 {
+			return step.Path[:idx]
+		}
+	}
+	return step.Path
+}
+
+// executeStages runs the stages in order. Units within a stage run on up to concurrency
+// dedicated connections; sessionSQL (lock_timeout, search_path, ...) is replayed on each of them.
+// With a concurrency of 1 every group runs serially on conn.
+func executeStages(ctx context.Context, conn *sql.DB, stages []executionStage, totalGroups, concurrency int, sessionSQL []string, lockWait lockWaitPolicy, quiet bool) error {
+	for _, stage := range stages {
+		if concurrency <= 1 || len(stage.units) == 1 {
+			for _, unit := range stage.units {
+				if err := executeUnit(ctx, conn, unit, totalGroups, lockWait, quiet); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := executeStageConcurrently(ctx, conn, stage, totalGroups, concurrency, sessionSQL, lockWait, quiet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeUnit runs the groups of a unit in order
+func executeUnit(ctx context.Context, conn dbExecutor, unit *executionUnit, totalGroups int, lockWait lockWaitPolicy, quiet bool) error {
+	for i, group := range unit.groups {
+		groupNum := unit.groupNums[i]
+		if !quiet {
+			fmt.Printf("\nExecuting group %d/%d...\n", groupNum, totalGroups)
+		}
+		if err := executeGroup(ctx, conn, group, groupNum, lockWait, quiet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeStageConcurrently runs the units of a stage on a bounded pool of workers.
+// The first failure cancels the remaining work and is returned.
+func executeStageConcurrently(ctx context.Context, conn *sql.DB, stage executionStage, totalGroups, concurrency int, sessionSQL []string, lockWait lockWaitPolicy, quiet bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := min(concurrency, len(stage.units))
+	units := make(chan *executionUnit)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			workerConn, err := conn.Conn(ctx)
+			if err != nil {
+				fail(fmt.Errorf("failed to acquire connection for concurrent execution: %w", err))
+				return
+			}
+			defer workerConn.Close()
+
+			for _, stmt := range sessionSQL {
+				if _, err := util.ExecContextWithLogging(ctx, workerConn, stmt, "apply session settings"); err != nil {
+					fail(fmt.Errorf("failed to apply session settings: %w", err))
+					return
+				}
+			}
+
+			for unit := range units {
+				if err := executeUnit(ctx, workerConn, unit, totalGroups, lockWait, quiet); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for _, unit := range stage.units {
+		select {
+		case units <- unit:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(units)
+	wg.Wait()
+
+	return firstErr
+}
@@ -0,0 +1,129 @@
+package apply
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/internal/plan"
+)
+
+// concurrentIndexGroups returns the two isolated groups generated for an online index creation:
+// the CREATE INDEX CONCURRENTLY statement and its wait directive
+func concurrentIndexGroups(table, index string) []plan.ExecutionGroup {
+	path := "public." + table + "." + index
+	return []plan.ExecutionGroup{
+		{Steps: []plan.Step{{
+			SQL:       "CREATE INDEX CONCURRENTLY IF NOT EXISTS " + index + " ON " + table + " (id);",
+			Type:      "table.index",
+			Operation: "create",
+			Path:      path,
+		}}},
+		{Steps: []plan.Step{{
+			SQL:       "SELECT true AS done;",
+			Directive: &plan.Directive{Type: plan.DirectiveTypeWait, Message: "Creating index " + index},
+			Type:      "table.index",
+			Operation: "create",
+			Path:      path,
+		}}},
+	}
+}
+
+func transactionalGroup(sqls ...string) plan.ExecutionGroup {
+	var steps []plan.Step
+	for _, sql := range sqls {
+		steps = append(steps, plan.Step{SQL: sql, Type: "table", Operation: "create", Path: "public.t"})
+	}
+	return plan.ExecutionGroup{Steps: steps}
+}
+
+// stageLayout summarizes stages as lock targets and group numbers per unit
+func stageLayout(stages []executionStage) [][]string {
+	var layout [][]string
+	for _, stage := range stages {
+		var units []string
+		for _, unit := range stage.units {
+			units = append(units, unit.lockTarget+":"+fmtGroupNums(unit.groupNums))
+		}
+		layout = append(layout, units)
+	}
+	return layout
+}
+
+func fmtGroupNums(nums []int) string {
+	var parts []string
+	for _, n := range nums {
+		parts = append(parts, strconv.Itoa(n))
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestBuildExecutionStagesIndexesOnDifferentTablesRunConcurrently(t *testing.T) {
+	var groups []plan.ExecutionGroup
+	groups = append(groups, concurrentIndexGroups("users", "idx_users_email")...)
+	groups = append(groups, concurrentIndexGroups("orders", "idx_orders_user_id")...)
+
+	stages := buildExecutionStages(groups)
+
+	expected := [][]string{
+		{"public.users:1,2", "public.orders:3,4"},
+	}
+	if got := stageLayout(stages); !reflect.DeepEqual(got, expected) {
+		t.Errorf("buildExecutionStages() = %v, want %v", got, expected)
+	}
+}
+
+func TestBuildExecutionStagesIndexesOnSameTableSerialize(t *testing.T) {
+	var groups []plan.ExecutionGroup
+	groups = append(groups, concurrentIndexGroups("users", "idx_users_email")...)
+	groups = append(groups, concurrentIndexGroups("users", "idx_users_name")...)
+
+	stages := buildExecutionStages(groups)
+
+	expected := [][]string{
+		{"public.users:1,2,3,4"},
+	}
+	if got := stageLayout(stages); !reflect.DeepEqual(got, expected) {
+		t.Errorf("buildExecutionStages() = %v, want %v", got, expected)
+	}
+}
+
+func TestBuildExecutionStagesTransactionalGroupsAreBarriers(t *testing.T) {
+	var groups []plan.ExecutionGroup
+	groups = append(groups, transactionalGroup("CREATE TABLE t (id int);", "ALTER TABLE t ADD COLUMN name text;"))
+	groups = append(groups, concurrentIndexGroups("users", "idx_users_email")...)
+	groups = append(groups, transactionalGroup("CREATE TABLE u (id int);"))
+	groups = append(groups, concurrentIndexGroups("orders", "idx_orders_user_id")...)
+
+	stages := buildExecutionStages(groups)
+
+	// Indexes separated by a transactional group must not be merged into one stage
+	expected := [][]string{
+		{":1"},
+		{"public.users:2,3"},
+		{":4"},
+		{"public.orders:5,6"},
+	}
+	if got := stageLayout(stages); !reflect.DeepEqual(got, expected) {
+		t.Errorf("buildExecutionStages() = %v, want %v", got, expected)
+	}
+}
+
+func TestLockTarget(t *testing.T) {
+	tests := []struct {
+		step     plan.Step
+		expected string
+	}{
+		{plan.Step{Type: "table.index", Path: "public.users.idx_users_email"}, "public.users"},
+		{plan.Step{Type: "materialized_view.index", Path: "public.mv.idx_mv_id"}, "public.mv"},
+		{plan.Step{Type: "table.constraint", Path: "public.orders.orders_user_fk"}, "public.orders"},
+		{plan.Step{Type: "table", Path: "public.users"}, "public.users"},
+	}
+
+	for _, tt := range tests {
+		if got := lockTarget(tt.step); got != tt.expected {
+			t.Errorf("lockTarget(%s %s) = %q, want %q", tt.step.Type, tt.step.Path, got, tt.expected)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package apply
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"time"
 
@@ -22,7 +21,7 @@ const (
 )
 
 // executeDirective executes a directive based on its type
-func executeDirective(ctx context.Context, conn *sql.DB, directive *plan.Directive, query string) error {
+func executeDirective(ctx context.Context, conn dbExecutor, directive *plan.Directive, query string) error {
 	switch directive.Type {
 	case "wait":
 		return executeWaitDirective(ctx, conn, directive, query)
@@ -32,7 +31,7 @@ func executeDirective(ctx context.Context, conn *sql.DB, directive *plan.Directi
 }
 
 // checkWaitStatus executes the wait query and extracts done/progress values
-func checkWaitStatus(ctx context.Context, conn *sql.DB, query string) (done bool, progress int, err error) {
+func checkWaitStatus(ctx context.Context, conn dbExecutor, query string) (done bool, progress int, err error) {
 	rows, err := conn.QueryContext(ctx, query)
 	if err != nil {
 		return false, -1, fmt.Errorf("failed to execute wait query: %w", err)
@@ -98,7 +97,7 @@ func checkWaitStatus(ctx context.Context, conn *sql.DB, query string) (done bool
 }
 
 // executeWaitDirective monitors a long-running operation until completion
-func executeWaitDirective(ctx context.Context, conn *sql.DB, directive *plan.Directive, query string) error {
+func executeWaitDirective(ctx context.Context, conn dbExecutor, directive *plan.Directive, query string) error {
 	if directive.Message != "" {
 		fmt.Printf("  Waiting: %s\n", directive.Message)
 	} else {
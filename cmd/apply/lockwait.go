@@ -0,0 +1,70 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pgplex/pgschema/cmd/util"
+)
+
+// Values of the --lock-wait-policy flag
+const (
+	LockWaitFail  = "fail"  // Fail when a lock cannot be acquired within lock_timeout (default)
+	LockWaitRetry = "retry" // Retry the statement or group with exponential backoff
+	LockWaitSkip  = "skip"  // Skip the statement and report it at the end (--on-error=continue only)
+)
+
+// lockNotAvailable is the SQLSTATE of a statement canceled by lock_timeout
+const lockNotAvailable = "55P03"
+
+// defaultLockRetryBackoff is the wait before the first retry, doubled after each attempt
+const defaultLockRetryBackoff = time.Second
+
+// lockWaitPolicy decides what happens when a statement cannot acquire its locks in time
+type lockWaitPolicy struct {
+	mode    string        // LockWaitFail (default when empty), LockWaitRetry, or LockWaitSkip
+	retries int           // Maximum number of retries with LockWaitRetry
+	backoff time.Duration // Wait before the first retry, doubled after each one
+}
+
+// isLockNotAvailable reports whether err is a lock_timeout cancellation
+func isLockNotAvailable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == lockNotAvailable
+}
+
+// canRetry reports whether a failed attempt at sqlStmt leaves nothing behind, so that it can be
+// run again. Statements that run in a transaction are rolled back on failure, but a failed
+// concurrent index build can leave an invalid index behind.
+func canRetry(sqlStmt string) bool {
+	return !strings.Contains(strings.ToUpper(sqlStmt), " CONCURRENTLY ")
+}
+
+// execWithLockWait executes sqlStmt, retrying it with exponential backoff when the policy is
+// LockWaitRetry and the statement fails to acquire its locks within lock_timeout
+func execWithLockWait(ctx context.Context, conn dbExecutor, sqlStmt, description string, policy lockWaitPolicy, quiet bool) error {
+	backoff := policy.backoff
+	for attempt := 0; ; attempt++ {
+		_, err := util.ExecContextWithLogging(ctx, conn, sqlStmt, description)
+		if err == nil {
+			return nil
+		}
+		if policy.mode != LockWaitRetry || attempt >= policy.retries || !isLockNotAvailable(err) || !canRetry(sqlStmt) {
+			return err
+		}
+
+		if !quiet {
+			fmt.Printf("    Lock not available, retrying in %s (%d/%d)\n", backoff, attempt+1, policy.retries)
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,159 @@
+package apply
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pgplex/pgschema/internal/plan"
+)
+
+// lockedExecutor simulates lock_timeout: statements containing lockedOn fail with
+// lock_not_available for the first failures attempts
+type lockedExecutor struct {
+	lockedOn string
+	failures int
+	executed []string
+}
+
+func (e *lockedExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	e.executed = append(e.executed, query)
+	if strings.Contains(query, e.lockedOn) && e.failures > 0 {
+		e.failures--
+		return nil, fmt.Errorf("execute: %w", &pgconn.PgError{Code: lockNotAvailable, Message: "canceling statement due to lock timeout"})
+	}
+	return nil, nil
+}
+
+func (e *lockedExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+// attempts counts the executions of statements containing s
+func (e *lockedExecutor) attempts(s string) int {
+	n := 0
+	for _, query := range e.executed {
+		if strings.Contains(query, s) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestLockWaitPolicyFail(t *testing.T) {
+	conn := &lockedExecutor{lockedOn: "users", failures: 1}
+	group := transactionalGroup("ALTER TABLE users ADD COLUMN note text;")
+
+	err := executeGroup(context.Background(), conn, group, 1, lockWaitPolicy{mode: LockWaitFail}, true)
+	if !isLockNotAvailable(err) {
+		t.Errorf("expected a lock timeout error, got %v", err)
+	}
+	if got := conn.attempts("users"); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestLockWaitPolicyRetry(t *testing.T) {
+	policy := lockWaitPolicy{mode: LockWaitRetry, retries: 3}
+
+	// The whole group is retried until it acquires its locks
+	conn := &lockedExecutor{lockedOn: "users", failures: 2}
+	group := transactionalGroup("CREATE TABLE audit (id integer);", "ALTER TABLE users ADD COLUMN note text;")
+	if err := executeGroup(context.Background(), conn, group, 1, policy, true); err != nil {
+		t.Fatalf("expected the group to succeed after retrying, got %v", err)
+	}
+	if got := conn.attempts("audit"); got != 3 {
+		t.Errorf("expected the group to run 3 times, got %d", got)
+	}
+
+	// Retries are bounded
+	conn = &lockedExecutor{lockedOn: "users", failures: 10}
+	if err := executeGroup(context.Background(), conn, group, 1, policy, true); !isLockNotAvailable(err) {
+		t.Errorf("expected a lock timeout error once retries are exhausted, got %v", err)
+	}
+	if got := conn.attempts("users"); got != 4 {
+		t.Errorf("expected 1 attempt and 3 retries, got %d attempts", got)
+	}
+
+	// A failed concurrent index build may leave an invalid index behind, so it is not retried
+	conn = &lockedExecutor{lockedOn: "CONCURRENTLY", failures: 1}
+	if err := executeGroup(context.Background(), conn, concurrentIndexGroups("users", "idx_users_id")[0], 1, policy, true); !isLockNotAvailable(err) {
+		t.Errorf("expected a lock timeout error, got %v", err)
+	}
+	if got := conn.attempts("CONCURRENTLY"); got != 1 {
+		t.Errorf("expected the concurrent index build to run once, got %d", got)
+	}
+
+	// Other errors are not retried
+	other := &recordingExecutor{failOn: "missing"}
+	if err := executeGroup(context.Background(), other, transactionalGroup("ALTER TABLE missing ADD COLUMN note text;"), 1, policy, true); err == nil {
+		t.Error("expected the error to be returned")
+	}
+	if len(other.executed) != 1 {
+		t.Errorf("expected a single attempt, got %v", other.executed)
+	}
+}
+
+func TestLockWaitPolicySkip(t *testing.T) {
+	groups := []plan.ExecutionGroup{
+		transactionalGroup(
+			"CREATE TABLE a (id integer);",
+			"ALTER TABLE users ADD COLUMN note text;",
+			"ALTER TABLE missing ADD COLUMN note text;",
+		),
+		transactionalGroup("CREATE TABLE b (id integer);"),
+	}
+	conn := &lockedExecutor{lockedOn: "users", failures: 1}
+
+	failures, attempted := executeContinueOnError(context.Background(), conn, groups, lockWaitPolicy{mode: LockWaitSkip}, true)
+
+	// The locked statement is skipped and the rest of the plan still runs
+	if attempted != 4 || len(conn.executed) != 4 {
+		t.Fatalf("expected every statement to be attempted once, executed %v", conn.executed)
+	}
+	if len(failures) != 1 || !failures[0].skipped || failures[0].stepNum != 2 {
+		t.Fatalf("expected the ALTER TABLE users statement to be skipped, got %+v", failures)
+	}
+
+	err := failureSummaryError(failures, attempted)
+	if strings.Contains(err.Error(), "failed") || !strings.Contains(err.Error(), "1 of 4 statements were skipped") ||
+		!strings.Contains(err.Error(), "group 1, step 2: ALTER TABLE users") {
+		t.Errorf("unexpected summary: %v", err)
+	}
+}
+
+func TestApplyCommandLockWaitPolicyValidation(t *testing.T) {
+	origDB, origUser, origFile, origPlan := applyDB, applyUser, applyFile, applyPlan
+	origOnError, origPolicy, origRetries := applyOnError, applyLockWaitPolicy, applyLockRetries
+	defer func() {
+		applyDB, applyUser, applyFile, applyPlan = origDB, origUser, origFile, origPlan
+		applyOnError, applyLockWaitPolicy, applyLockRetries = origOnError, origPolicy, origRetries
+	}()
+
+	applyDB = "testdb"
+	applyUser = "testuser"
+	applyFile = "schema.sql"
+	applyPlan = ""
+	applyOnError = OnErrorStop
+	applyLockRetries = 3
+
+	applyLockWaitPolicy = "wait"
+	if err := RunApply(ApplyCmd, []string{}); err == nil || !strings.Contains(err.Error(), "invalid --lock-wait-policy value") {
+		t.Errorf("expected invalid --lock-wait-policy error, got %v", err)
+	}
+
+	applyLockWaitPolicy = LockWaitSkip
+	if err := RunApply(ApplyCmd, []string{}); err == nil || !strings.Contains(err.Error(), "requires --on-error=continue") {
+		t.Errorf("expected --lock-wait-policy=skip to require --on-error=continue, got %v", err)
+	}
+
+	applyLockWaitPolicy = LockWaitRetry
+	applyLockRetries = 0
+	if err := RunApply(ApplyCmd, []string{}); err == nil || !strings.Contains(err.Error(), "--lock-retries must be at least 1") {
+		t.Errorf("expected --lock-retries validation error, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pgplex/pgschema/internal/plan"
+)
+
+// Values of the --on-error flag
+const (
+	OnErrorStop     = "stop"     // Stop at the first failing statement (default)
+	OnErrorContinue = "continue" // Run every statement on its own and report all failures at the end
+)
+
+// statementFailure records a statement that failed with --on-error=continue
+type statementFailure struct {
+	groupNum int
+	stepNum  int
+	sql      string
+	err      error
+	skipped  bool // The statement could not acquire its locks and was skipped with --lock-wait-policy=skip
+}
+
+// executeContinueOnError runs every statement of the plan independently, each in its own
+// implicit transaction, and keeps going after a failure. Wait directives of an object whose
+// statement failed are skipped, since the operation they would wait for never started.
+// Statements that cannot acquire their locks are retried or skipped as the lock wait policy says.
+// It returns the failures in plan order and the number of statements attempted.
+func executeContinueOnError(ctx context.Context, conn dbExecutor, groups []plan.ExecutionGroup, lockWait lockWaitPolicy, quiet bool) ([]statementFailure, int) {
+	var failures []statementFailure
+	failedPaths := make(map[string]bool)
+	attempted := 0
+
+	for groupIdx, group := range groups {
+		groupNum := groupIdx + 1
+		if !quiet {
+			fmt.Printf("\nExecuting group %d/%d...\n", groupNum, len(groups))
+		}
+
+		for stepIdx, step := range group.Steps {
+			if step.Directive != nil {
+				if step.Path != "" && failedPaths[step.Path] {
+					if !quiet {
+						fmt.Printf("  Skipping: %s (statement failed)\n", step.Directive.Message)
+					}
+					continue
+				}
+				attempted++
+				if err := executeDirective(ctx, conn, step.Directive, step.SQL); err != nil {
+					failures = append(failures, statementFailure{groupNum: groupNum, stepNum: stepIdx + 1, sql: step.SQL, err: err})
+				}
+				continue
+			}
+
+			attempted++
+			if !quiet {
+				fmt.Printf("  Executing: %s\n", truncateSQL(step.SQL, 80))
+			}
+			err := execWithLockWait(ctx, conn, step.SQL, fmt.Sprintf("execute statement in group %d, step %d", groupNum, stepIdx+1), lockWait, quiet)
+			if err != nil {
+				skipped := lockWait.mode == LockWaitSkip && isLockNotAvailable(err)
+				if !quiet {
+					if skipped {
+						fmt.Printf("    Skipped: %v\n", err)
+					} else {
+						fmt.Printf("    Failed: %v\n", err)
+					}
+				}
+				failures = append(failures, statementFailure{groupNum: groupNum, stepNum: stepIdx + 1, sql: step.SQL, err: err, skipped: skipped})
+				if step.Path != "" {
+					failedPaths[step.Path] = true
+				}
+			}
+		}
+	}
+
+	return failures, attempted
+}
+
+// failureSummaryError builds the error reported when statements failed or were skipped with
+// --on-error=continue
+func failureSummaryError(failures []statementFailure, attempted int) error {
+	var failed, skipped []statementFailure
+	for _, failure := range failures {
+		if failure.skipped {
+			skipped = append(skipped, failure)
+		} else {
+			failed = append(failed, failure)
+		}
+	}
+
+	var summary strings.Builder
+	if len(failed) > 0 {
+		fmt.Fprintf(&summary, "%d of %d statements failed:", len(failed), attempted)
+		writeFailures(&summary, failed)
+	}
+	if len(skipped) > 0 {
+		if len(failed) > 0 {
+			summary.WriteString("\n")
+		}
+		fmt.Fprintf(&summary, "%d of %d statements were skipped because their locks were not available; run apply again to complete the migration:", len(skipped), attempted)
+		writeFailures(&summary, skipped)
+	}
+	return errors.New(summary.String())
+}
+
+// writeFailures lists failed or skipped statements with their errors
+func writeFailures(summary *strings.Builder, failures []statementFailure) {
+	for _, failure := range failures {
+		fmt.Fprintf(summary, "\n  group %d, step %d: %s\n    %v", failure.groupNum, failure.stepNum, truncateSQL(failure.sql, 80), failure.err)
+	}
+}
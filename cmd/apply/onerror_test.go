@@ -0,0 +1,105 @@
+package apply
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/internal/plan"
+)
+
+// recordingExecutor records executed statements and fails those containing failOn
+type recordingExecutor struct {
+	failOn   string
+	executed []string
+}
+
+func (e *recordingExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	e.executed = append(e.executed, query)
+	if e.failOn != "" && strings.Contains(query, e.failOn) {
+		return nil, errors.New("relation \"missing\" does not exist")
+	}
+	return nil, nil
+}
+
+func (e *recordingExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	e.executed = append(e.executed, query)
+	return nil, errors.New("unexpected query")
+}
+
+func TestExecuteContinueOnError(t *testing.T) {
+	groups := []plan.ExecutionGroup{
+		transactionalGroup(
+			"CREATE TABLE a (id integer);",
+			"ALTER TABLE missing ADD COLUMN note text;",
+			"CREATE TABLE b (id integer);",
+		),
+		transactionalGroup("CREATE TABLE c (id integer);"),
+	}
+	conn := &recordingExecutor{failOn: "missing"}
+
+	failures, attempted := executeContinueOnError(context.Background(), conn, groups, lockWaitPolicy{}, true)
+
+	// Every statement is attempted on its own, including those after the failure
+	expected := []string{
+		"CREATE TABLE a (id integer);",
+		"ALTER TABLE missing ADD COLUMN note text;",
+		"CREATE TABLE b (id integer);",
+		"CREATE TABLE c (id integer);",
+	}
+	if !reflect.DeepEqual(conn.executed, expected) {
+		t.Errorf("executed = %v, want %v", conn.executed, expected)
+	}
+	if attempted != 4 || len(failures) != 1 {
+		t.Fatalf("expected 1 of 4 statements to fail, got %d of %d", len(failures), attempted)
+	}
+	if failures[0].groupNum != 1 || failures[0].stepNum != 2 {
+		t.Errorf("expected failure at group 1, step 2, got group %d, step %d", failures[0].groupNum, failures[0].stepNum)
+	}
+
+	err := failureSummaryError(failures, attempted)
+	if !strings.Contains(err.Error(), "1 of 4 statements failed") || !strings.Contains(err.Error(), "group 1, step 2: ALTER TABLE missing") {
+		t.Errorf("unexpected summary: %v", err)
+	}
+}
+
+func TestExecuteContinueOnErrorSkipsWaitOfFailedStatement(t *testing.T) {
+	groups := concurrentIndexGroups("missing", "idx_missing_id")
+	conn := &recordingExecutor{failOn: "missing"}
+
+	failures, attempted := executeContinueOnError(context.Background(), conn, groups, lockWaitPolicy{}, true)
+
+	// The wait directive is not run, since the index build never started
+	if len(conn.executed) != 1 || attempted != 1 || len(failures) != 1 {
+		t.Errorf("expected only the failing CREATE INDEX to run, executed %v", conn.executed)
+	}
+}
+
+func TestApplyCommandOnErrorValidation(t *testing.T) {
+	origDB, origUser, origFile, origPlan := applyDB, applyUser, applyFile, applyPlan
+	origOnError, origConcurrency := applyOnError, applyConcurrency
+	defer func() {
+		applyDB, applyUser, applyFile, applyPlan = origDB, origUser, origFile, origPlan
+		applyOnError, applyConcurrency = origOnError, origConcurrency
+	}()
+
+	applyDB = "testdb"
+	applyUser = "testuser"
+	applyFile = "schema.sql"
+	applyPlan = ""
+
+	applyOnError = "retry"
+	applyConcurrency = 1
+	if err := RunApply(ApplyCmd, []string{}); err == nil || !strings.Contains(err.Error(), "invalid --on-error value") {
+		t.Errorf("expected invalid --on-error error, got %v", err)
+	}
+
+	applyOnError = OnErrorContinue
+	applyConcurrency = 4
+	if err := RunApply(ApplyCmd, []string{}); err == nil || !strings.Contains(err.Error(), "cannot be combined with --concurrency") {
+		t.Errorf("expected --on-error=continue and --concurrency conflict, got %v", err)
+	}
+}
@@ -1,8 +1,14 @@
 package dump
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/pgplex/pgschema/cmd/util"
 	"github.com/pgplex/pgschema/internal/diff"
@@ -21,6 +27,22 @@ var (
 	multiFile  bool
 	file       string
 	noComments bool
+
+	noIndexes   bool
+	noTriggers  bool
+	noPolicies  bool
+	noFunctions bool
+
+	unusedSequences string
+
+	ignoreExtensionObjects bool
+
+	obfuscate bool
+	mapSchema []string
+
+	sslMode        string
+	connectTimeout time.Duration
+	maxConns       int
 )
 
 // DumpConfig holds configuration for dump execution
@@ -34,6 +56,29 @@ type DumpConfig struct {
 	MultiFile  bool
 	File       string
 	NoComments bool
+
+	// Object categories excluded from the dump
+	NoIndexes   bool
+	NoTriggers  bool
+	NoPolicies  bool
+	NoFunctions bool
+
+	// What to do with sequences no column or expression uses: "keep" (or empty), "warn", or "omit"
+	UnusedSequences string
+
+	// Keep the tables, views, sequences and types that are members of an extension
+	IncludeExtensionObjects bool
+
+	// Replace names with hashed aliases and strip comments and string literal defaults
+	Obfuscate bool
+
+	// Schema renames applied to the dump, as old=new pairs
+	MapSchemas []string
+
+	// Connection tuning (optional - defaults to sslmode=prefer, a 30s timeout, and an unlimited pool)
+	SSLMode        string
+	ConnectTimeout time.Duration
+	MaxConns       int
 }
 
 var DumpCmd = &cobra.Command{
@@ -52,56 +97,282 @@ func init() {
 	DumpCmd.Flags().StringVar(&user, "user", "", "Database user name (required) (env: PGUSER)")
 	DumpCmd.Flags().StringVar(&password, "password", "", "Database password (optional, can also use PGPASSWORD env var)")
 	DumpCmd.Flags().StringVar(&schema, "schema", "public", "Schema name to dump (default: public)")
+	DumpCmd.Flags().StringVar(&sslMode, "sslmode", util.DefaultSSLMode, "SSL mode for the database connection: disable, allow, prefer, require, verify-ca, verify-full (env: PGSSLMODE)")
+	DumpCmd.Flags().DurationVar(&connectTimeout, "connection-timeout", util.DefaultConnectTimeout, "Timeout for connecting to the database (env: PGCONNECT_TIMEOUT, in seconds)")
+	DumpCmd.Flags().IntVar(&maxConns, "max-conns", 0, "Maximum number of connections used for schema inspection (0 means unlimited)")
 	DumpCmd.Flags().BoolVar(&multiFile, "multi-file", false, "Output schema to multiple files organized by object type")
 	DumpCmd.Flags().StringVar(&file, "file", "", "Output file path (required when --multi-file is used)")
 	DumpCmd.Flags().BoolVar(&noComments, "no-comments", false, "Do not output object comment headers")
+	DumpCmd.Flags().BoolVar(&noIndexes, "no-indexes", false, "Do not dump indexes (constraint-backed indexes are kept)")
+	DumpCmd.Flags().BoolVar(&noTriggers, "no-triggers", false, "Do not dump triggers")
+	DumpCmd.Flags().BoolVar(&noPolicies, "no-policies", false, "Do not dump row-level security policies or RLS settings")
+	DumpCmd.Flags().BoolVar(&noFunctions, "no-functions", false, "Do not dump functions, along with the triggers and aggregates that depend on them")
+	DumpCmd.Flags().StringVar(&unusedSequences, "unused-sequences", "keep", "What to do with sequences that no column owns or uses and no expression references: keep, warn (list them on stderr), or omit (list them and leave them out of the dump)")
+	DumpCmd.Flags().BoolVar(&ignoreExtensionObjects, "ignore-extension-objects", true, "Leave out tables, views, sequences and types that are members of an extension (set to false to dump them)")
+	DumpCmd.Flags().BoolVar(&obfuscate, "obfuscate", false, "Replace object and column names with stable hashed aliases and strip comments and string literal defaults")
+	DumpCmd.Flags().StringArrayVar(&mapSchema, "map-schema", nil, "Rename a schema in the dump, including references to it, as old=new (repeatable)")
 }
 
-// ExecuteDump executes the dump operation with the given configuration
+// ExecuteDump executes the dump operation with the given configuration and returns the single-file
+// dump (empty in multi-file mode)
 func ExecuteDump(config *DumpConfig) (string, error) {
+	var output strings.Builder
+	if err := ExecuteDumpTo(config, &output); err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
+
+// ExecuteDumpTo is like ExecuteDump, but writes the single-file dump to w as it is formatted
+func ExecuteDumpTo(config *DumpConfig, w io.Writer) error {
 	// Validate flags
 	if config.MultiFile && config.File == "" {
 		// When --multi-file is used but no --file specified, emit warning and use single-file mode
 		fmt.Fprintf(os.Stderr, "Warning: --multi-file flag requires --file to be specified. Fallback to single-file mode.\n")
 		config.MultiFile = false
 	}
+	mapper, err := parseSchemaMappings(config.MapSchemas)
+	if err != nil {
+		return err
+	}
 
 	// Load ignore configuration
 	ignoreConfig, err := util.LoadIgnoreFileWithStructure()
 	if err != nil {
-		return "", fmt.Errorf("failed to load .pgschemaignore: %w", err)
+		return fmt.Errorf("failed to load .pgschemaignore: %w", err)
 	}
+	ignoreConfig = util.WithExtensionObjects(ignoreConfig, !config.IncludeExtensionObjects)
 
 	// Get IR from database using the shared utility
-	schemaIR, err := util.GetIRFromDatabase(config.Host, config.Port, config.DB, config.User, config.Password, config.Schema, "pgschema", ignoreConfig)
+	connConfig := &util.ConnectionConfig{
+		Host:            config.Host,
+		Port:            config.Port,
+		Database:        config.DB,
+		User:            config.User,
+		Password:        config.Password,
+		SSLMode:         config.SSLMode,
+		ApplicationName: "pgschema",
+		ConnectTimeout:  config.ConnectTimeout,
+		MaxConns:        config.MaxConns,
+	}
+	schemaIR, err := util.GetIRFromDatabaseWithConfig(connConfig, config.Schema, ignoreConfig)
 	if err != nil {
-		return "", fmt.Errorf("failed to get database schema: %w", err)
+		return fmt.Errorf("failed to get database schema: %w", err)
+	}
+
+	// Drop the object categories excluded by the --no-* flags
+	excludeObjects(schemaIR, config)
+
+	// Report sequences that look orphaned, and leave them out if requested
+	if config.UnusedSequences == "warn" || config.UnusedSequences == "omit" {
+		reportUnusedSequences(schemaIR, config.UnusedSequences == "omit")
+	}
+
+	if config.Obfuscate {
+		if err := obfuscateSchema(schemaIR); err != nil {
+			return err
+		}
 	}
 
+	// Rename the mapped schemas, including the dumped schema itself
+	mapSchemas(schemaIR, mapper)
+	targetSchema := mapper.schema(config.Schema)
+
 	// Create an empty schema for comparison to generate a dump diff
 	emptyIR := ir.NewIR()
 
 	// Generate diff between empty schema and target schema (this represents a complete dump)
-	diffs := diff.GenerateMigration(emptyIR, schemaIR, config.Schema)
+	diffs := diff.GenerateMigration(emptyIR, schemaIR, targetSchema)
 
 	// Create dump formatter
-	formatter := dump.NewDumpFormatter(schemaIR.Metadata.DatabaseVersion, config.Schema, config.NoComments)
+	formatter := dump.NewDumpFormatter(schemaIR.Metadata.DatabaseVersion, targetSchema, config.NoComments)
 
 	if config.MultiFile {
 		// Multi-file mode - output to files
 		err := formatter.FormatMultiFile(diffs, config.File)
 		if err != nil {
-			return "", fmt.Errorf("failed to create multi-file output: %w", err)
+			return fmt.Errorf("failed to create multi-file output: %w", err)
+		}
+		return nil
+	}
+
+	// Single file mode - stream the output statement by statement
+	if err := formatter.WriteSingleFile(w, diffs); err != nil {
+		return fmt.Errorf("failed to write dump: %w", err)
+	}
+	return nil
+}
+
+// excludeObjects removes the object categories excluded by the --no-* flags from the inspected IR.
+// Objects that cannot exist without an excluded one go with it: triggers and aggregates are built
+// on functions, and enabling RLS without its policies would deny all access to the table.
+func excludeObjects(schemaIR *ir.IR, config *DumpConfig) {
+	noTriggers := config.NoTriggers || config.NoFunctions
+
+	// Expressions that call a removed function are kept, so the dump cannot be applied without it
+	if config.NoFunctions {
+		for _, dependant := range functionDependants(schemaIR) {
+			fmt.Fprintf(os.Stderr, "Warning: %s; the function is omitted by --no-functions, so it must exist before the dump is applied\n", dependant)
+		}
+	}
+
+	for _, dbSchema := range schemaIR.Schemas {
+		for _, table := range dbSchema.Tables {
+			if config.NoIndexes {
+				table.Indexes = make(map[string]*ir.Index)
+			}
+			if noTriggers {
+				table.Triggers = make(map[string]*ir.Trigger)
+			}
+			if config.NoPolicies {
+				table.Policies = make(map[string]*ir.RLSPolicy)
+				table.RLSEnabled = false
+				table.RLSForced = false
+			}
+		}
+		for _, view := range dbSchema.Views {
+			if config.NoIndexes {
+				view.Indexes = nil
+			}
+			if noTriggers {
+				view.Triggers = nil
+			}
+		}
+		if config.NoFunctions {
+			dbSchema.Functions = make(map[string]*ir.Function)
+			dbSchema.Aggregates = make(map[string]*ir.Aggregate)
+
+			// Grants on the removed functions would reference missing objects
+			var privileges []*ir.Privilege
+			for _, p := range dbSchema.Privileges {
+				if p.ObjectType != ir.PrivilegeObjectTypeFunction {
+					privileges = append(privileges, p)
+				}
+			}
+			dbSchema.Privileges = privileges
+		}
+	}
+}
+
+// functionDependants describes each column default, generated column, constraint, index, policy,
+// view, procedure, and domain that calls one of the functions in the IR, sorted. Triggers and
+// aggregates are left out, since they are removed along with the functions.
+func functionDependants(schemaIR *ir.IR) []string {
+	var calls []*regexp.Regexp
+	var names []string
+	for _, dbSchema := range schemaIR.Schemas {
+		for _, function := range dbSchema.Functions {
+			calls = append(calls, functionCallPattern(function.Name))
+			names = append(names, function.Schema+"."+function.Name)
+		}
+	}
+	if len(calls) == 0 {
+		return nil
+	}
+
+	var dependants []string
+	check := func(object string, exprs ...string) {
+		for i, call := range calls {
+			for _, expr := range exprs {
+				if expr != "" && call.MatchString(expr) {
+					dependants = append(dependants, fmt.Sprintf("%s calls function %s", object, names[i]))
+					break
+				}
+			}
+		}
+	}
+	for _, dbSchema := range schemaIR.Schemas {
+		for _, table := range dbSchema.Tables {
+			for _, column := range table.Columns {
+				path := fmt.Sprintf("%s.%s.%s", table.Schema, table.Name, column.Name)
+				if column.DefaultValue != nil {
+					check("column default "+path, *column.DefaultValue)
+				}
+				if column.GeneratedExpr != nil {
+					check("generated column "+path, *column.GeneratedExpr)
+				}
+			}
+			for _, constraint := range table.Constraints {
+				check(fmt.Sprintf("constraint %s.%s.%s", table.Schema, table.Name, constraint.Name), constraint.CheckClause, constraint.ExclusionDefinition)
+			}
+			for _, index := range table.Indexes {
+				exprs := []string{index.Where}
+				for _, column := range index.Columns {
+					exprs = append(exprs, column.Name)
+				}
+				check(fmt.Sprintf("index %s.%s", index.Schema, index.Name), exprs...)
+			}
+			for _, policy := range table.Policies {
+				check(fmt.Sprintf("policy %s.%s.%s", table.Schema, table.Name, policy.Name), policy.Using, policy.WithCheck)
+			}
+		}
+		for _, view := range dbSchema.Views {
+			kind := "view"
+			if view.Materialized {
+				kind = "materialized view"
+			}
+			check(fmt.Sprintf("%s %s.%s", kind, view.Schema, view.Name), view.Definition)
+		}
+		for _, procedure := range dbSchema.Procedures {
+			check(fmt.Sprintf("procedure %s.%s", procedure.Schema, procedure.Name), procedure.Definition)
+		}
+		for _, typ := range dbSchema.Types {
+			exprs := []string{typ.Default}
+			for _, constraint := range typ.Constraints {
+				exprs = append(exprs, constraint.Definition)
+			}
+			check(fmt.Sprintf("domain %s.%s", typ.Schema, typ.Name), exprs...)
+		}
+	}
+	sort.Strings(dependants)
+	return dependants
+}
+
+// functionCallPattern matches a call of the named function, optionally schema-qualified or quoted.
+// Lowercase names match case-insensitively, like unquoted identifiers.
+func functionCallPattern(name string) *regexp.Regexp {
+	pattern := `(^|[^A-Za-z0-9_$])"?` + regexp.QuoteMeta(name) + `"?\s*\(`
+	if name == strings.ToLower(name) {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.MustCompile(pattern)
+}
+
+// reportUnusedSequences lists the sequences that nothing uses on stderr, and with omit removes them
+// and the grants on them from the IR, so that applying the dump drops them
+func reportUnusedSequences(schemaIR *ir.IR, omit bool) {
+	for _, seq := range schemaIR.UnusedSequences() {
+		if !omit {
+			fmt.Fprintf(os.Stderr, "Warning: sequence %s.%s is not used by any column or expression\n", seq.Schema, seq.Name)
+			continue
 		}
-		return "", nil
-	} else {
-		// Single file mode - return output as string
-		output := formatter.FormatSingleFile(diffs)
-		return output, nil
+		fmt.Fprintf(os.Stderr, "Warning: sequence %s.%s is not used by any column or expression; omitted from the dump\n", seq.Schema, seq.Name)
+
+		dbSchema := schemaIR.Schemas[seq.Schema]
+		delete(dbSchema.Sequences, seq.Name)
+		var privileges []*ir.Privilege
+		for _, p := range dbSchema.Privileges {
+			if p.ObjectType != ir.PrivilegeObjectTypeSequence || p.ObjectName != seq.Name {
+				privileges = append(privileges, p)
+			}
+		}
+		dbSchema.Privileges = privileges
 	}
 }
 
 func runDump(cmd *cobra.Command, args []string) error {
+	// Apply environment variables to connection tuning flags and validate them
+	util.ApplyConnectionEnvVars(cmd, &sslMode, &connectTimeout)
+	if err := util.ValidateConnectionFlags(sslMode, connectTimeout, maxConns); err != nil {
+		return err
+	}
+
+	switch unusedSequences {
+	case "keep", "warn", "omit":
+	default:
+		return fmt.Errorf("invalid --unused-sequences value %q (must be keep, warn, or omit)", unusedSequences)
+	}
+
 	// Derive final password: use flag if provided, otherwise check environment variable
 	finalPassword := password
 	if finalPassword == "" {
@@ -121,18 +392,28 @@ func runDump(cmd *cobra.Command, args []string) error {
 		MultiFile:  multiFile,
 		File:       file,
 		NoComments: noComments,
-	}
 
-	// Execute dump
-	output, err := ExecuteDump(config)
-	if err != nil {
-		return err
-	}
+		NoIndexes:   noIndexes,
+		NoTriggers:  noTriggers,
+		NoPolicies:  noPolicies,
+		NoFunctions: noFunctions,
+
+		UnusedSequences: unusedSequences,
+
+		IncludeExtensionObjects: !ignoreExtensionObjects,
 
-	// Print output to stdout (only in single-file mode)
-	if output != "" {
-		fmt.Print(output)
+		Obfuscate:  obfuscate,
+		MapSchemas: mapSchema,
+
+		SSLMode:        sslMode,
+		ConnectTimeout: connectTimeout,
+		MaxConns:       maxConns,
 	}
 
-	return nil
+	// Execute dump, streaming the output to stdout (only written in single-file mode)
+	output := bufio.NewWriter(os.Stdout)
+	if err := ExecuteDumpTo(config, output); err != nil {
+		return err
+	}
+	return output.Flush()
 }
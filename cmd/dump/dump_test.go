@@ -2,6 +2,7 @@ package dump
 
 import (
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -390,3 +391,622 @@ func TestNoComments_SingleFile(t *testing.T) {
 		}
 	})
 }
+
+func TestDumpCommand_ExclusionFlags(t *testing.T) {
+	flags := DumpCmd.Flags()
+	for _, name := range []string{"no-indexes", "no-triggers", "no-policies", "no-functions"} {
+		flag := flags.Lookup(name)
+		if flag == nil {
+			t.Errorf("Expected --%s flag to be defined", name)
+			continue
+		}
+		if flag.DefValue != "false" {
+			t.Errorf("Expected --%s default to be 'false', got '%s'", name, flag.DefValue)
+		}
+	}
+}
+
+// newExclusionTestIR builds a schema with a table that has an index, a trigger calling a function,
+// and an RLS policy, plus a grant on the function
+func newExclusionTestIR() *ir.IR {
+	orders := &ir.Table{
+		Schema: "public",
+		Name:   "orders",
+		Type:   ir.TableTypeBase,
+		Columns: []*ir.Column{
+			{Name: "id", Position: 1, DataType: "integer", IsNullable: false},
+			{Name: "owner", Position: 2, DataType: "text", IsNullable: true},
+		},
+		Constraints: map[string]*ir.Constraint{},
+		Indexes: map[string]*ir.Index{
+			"orders_owner_idx": {
+				Schema:  "public",
+				Table:   "orders",
+				Name:    "orders_owner_idx",
+				Type:    ir.IndexTypeRegular,
+				Method:  "btree",
+				Columns: []*ir.IndexColumn{{Name: "owner", Position: 1}},
+			},
+		},
+		Triggers: map[string]*ir.Trigger{
+			"orders_audit": {
+				Schema:   "public",
+				Table:    "orders",
+				Name:     "orders_audit",
+				Timing:   ir.TriggerTimingAfter,
+				Events:   []ir.TriggerEvent{ir.TriggerEventInsert},
+				Level:    ir.TriggerLevelRow,
+				Function: "audit()",
+			},
+		},
+		RLSEnabled: true,
+		Policies: map[string]*ir.RLSPolicy{
+			"orders_owner": {
+				Schema:     "public",
+				Table:      "orders",
+				Name:       "orders_owner",
+				Command:    ir.PolicyCommandAll,
+				Permissive: true,
+				Using:      "(owner = CURRENT_USER)",
+			},
+		},
+	}
+
+	return &ir.IR{
+		Schemas: map[string]*ir.Schema{
+			"public": {
+				Name:   "public",
+				Tables: map[string]*ir.Table{"orders": orders},
+				Views:  map[string]*ir.View{},
+				Functions: map[string]*ir.Function{
+					"audit()": {
+						Schema:     "public",
+						Name:       "audit",
+						Definition: "BEGIN RETURN NULL; END;",
+						ReturnType: "trigger",
+						Language:   "plpgsql",
+					},
+				},
+				Procedures: map[string]*ir.Procedure{},
+				Aggregates: map[string]*ir.Aggregate{},
+				Sequences:  map[string]*ir.Sequence{},
+				Types:      map[string]*ir.Type{},
+				Privileges: []*ir.Privilege{
+					{ObjectType: ir.PrivilegeObjectTypeFunction, ObjectName: "audit()", Grantee: "app", Privileges: []string{"EXECUTE"}},
+				},
+			},
+		},
+	}
+}
+
+// dumpExcluding returns the single-file dump of the exclusion test IR after applying config
+func dumpExcluding(config *DumpConfig) string {
+	schemaIR := newExclusionTestIR()
+	excludeObjects(schemaIR, config)
+	diffs := diff.GenerateMigration(ir.NewIR(), schemaIR, "public")
+	return dump.NewDumpFormatter("PostgreSQL 17.0", "public", true).FormatSingleFile(diffs)
+}
+
+func TestExcludeObjects(t *testing.T) {
+	full := dumpExcluding(&DumpConfig{})
+	for _, expected := range []string{"CREATE INDEX", "CREATE OR REPLACE TRIGGER", "CREATE POLICY", "ENABLE ROW LEVEL SECURITY", "CREATE OR REPLACE FUNCTION", "GRANT EXECUTE"} {
+		if !strings.Contains(full, expected) {
+			t.Fatalf("Expected full dump to contain %q, got:\n%s", expected, full)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		config   *DumpConfig
+		excluded []string
+		kept     []string
+	}{
+		{
+			name:     "NoIndexes",
+			config:   &DumpConfig{NoIndexes: true},
+			excluded: []string{"CREATE INDEX"},
+			kept:     []string{"CREATE TABLE", "CREATE OR REPLACE TRIGGER", "CREATE POLICY"},
+		},
+		{
+			name:     "NoTriggers",
+			config:   &DumpConfig{NoTriggers: true},
+			excluded: []string{"CREATE OR REPLACE TRIGGER"},
+			kept:     []string{"CREATE OR REPLACE FUNCTION", "CREATE INDEX"},
+		},
+		{
+			name:     "NoPolicies",
+			config:   &DumpConfig{NoPolicies: true},
+			excluded: []string{"CREATE POLICY", "ROW LEVEL SECURITY"},
+			kept:     []string{"CREATE TABLE", "CREATE INDEX"},
+		},
+		{
+			// Triggers calling the removed function are dropped with it
+			name:     "NoFunctions",
+			config:   &DumpConfig{NoFunctions: true},
+			excluded: []string{"CREATE OR REPLACE FUNCTION", "CREATE OR REPLACE TRIGGER", "GRANT EXECUTE"},
+			kept:     []string{"CREATE TABLE", "CREATE POLICY"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := dumpExcluding(tt.config)
+			for _, excluded := range tt.excluded {
+				if strings.Contains(output, excluded) {
+					t.Errorf("Expected dump to not contain %q, got:\n%s", excluded, output)
+				}
+			}
+			for _, kept := range tt.kept {
+				if !strings.Contains(output, kept) {
+					t.Errorf("Expected dump to contain %q, got:\n%s", kept, output)
+				}
+			}
+		})
+	}
+}
+
+func TestFunctionDependants(t *testing.T) {
+	schemaIR := newExclusionTestIR()
+	dbSchema := schemaIR.Schemas["public"]
+	dbSchema.Functions["next_code()"] = &ir.Function{
+		Schema:     "public",
+		Name:       "next_code",
+		Definition: "SELECT 'A'",
+		ReturnType: "text",
+		Language:   "sql",
+	}
+	orders := dbSchema.Tables["orders"]
+	orders.Columns[1].DefaultValue = ptrString("next_code()")
+	orders.Constraints["orders_owner_check"] = &ir.Constraint{
+		Schema:      "public",
+		Table:       "orders",
+		Name:        "orders_owner_check",
+		Type:        ir.ConstraintTypeCheck,
+		CheckClause: "CHECK (owner <> public.NEXT_CODE ())",
+	}
+	dbSchema.Views["order_codes"] = &ir.View{
+		Schema:     "public",
+		Name:       "order_codes",
+		Definition: "SELECT id, next_code_suffix(owner) AS code FROM orders",
+	}
+
+	expected := []string{
+		"column default public.orders.owner calls function public.next_code",
+		"constraint public.orders.orders_owner_check calls function public.next_code",
+	}
+	if got := functionDependants(schemaIR); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected dependants %v, got %v", expected, got)
+	}
+}
+
+// newObfuscationTestIR returns a schema with cross-object references: a foreign key, an index,
+// a check constraint, a sequence default, a comment, and a string literal default
+func newObfuscationTestIR() *ir.IR {
+	schemaIR := newExclusionTestIR()
+	dbSchema := schemaIR.Schemas["public"]
+	orders := dbSchema.Tables["orders"]
+	orders.Comment = "Customer orders"
+	orders.Columns[0].DefaultValue = ptrString("nextval('orders_id_seq'::regclass)")
+	orders.Columns[1].DefaultValue = ptrString("'nobody'::text")
+	orders.Columns[1].Comment = "Owner of the order"
+	orders.Constraints["orders_pkey"] = &ir.Constraint{
+		Schema:  "public",
+		Table:   "orders",
+		Name:    "orders_pkey",
+		Type:    ir.ConstraintTypePrimaryKey,
+		Columns: []*ir.ConstraintColumn{{Name: "id", Position: 1}},
+	}
+	orders.Constraints["orders_owner_check"] = &ir.Constraint{
+		Schema:      "public",
+		Table:       "orders",
+		Name:        "orders_owner_check",
+		Type:        ir.ConstraintTypeCheck,
+		CheckClause: "CHECK ((length(owner) > 0))",
+	}
+
+	dbSchema.Tables["order_items"] = &ir.Table{
+		Schema: "public",
+		Name:   "order_items",
+		Type:   ir.TableTypeBase,
+		Columns: []*ir.Column{
+			{Name: "order_id", Position: 1, DataType: "integer", IsNullable: false},
+		},
+		Constraints: map[string]*ir.Constraint{
+			"order_items_order_id_fkey": {
+				Schema:            "public",
+				Table:             "order_items",
+				Name:              "order_items_order_id_fkey",
+				Type:              ir.ConstraintTypeForeignKey,
+				Columns:           []*ir.ConstraintColumn{{Name: "order_id", Position: 1}},
+				ReferencedSchema:  "public",
+				ReferencedTable:   "orders",
+				ReferencedColumns: []*ir.ConstraintColumn{{Name: "id", Position: 1}},
+			},
+		},
+		Indexes:  map[string]*ir.Index{},
+		Triggers: map[string]*ir.Trigger{},
+		Policies: map[string]*ir.RLSPolicy{},
+	}
+	dbSchema.Sequences["orders_id_seq"] = &ir.Sequence{
+		Schema:        "public",
+		Name:          "orders_id_seq",
+		DataType:      "integer",
+		StartValue:    1,
+		Increment:     1,
+		OwnedByTable:  "orders",
+		OwnedByColumn: "id",
+	}
+	return schemaIR
+}
+
+func ptrString(s string) *string {
+	return &s
+}
+
+func TestObfuscateSchema(t *testing.T) {
+	original := newObfuscationTestIR()
+	obfuscated := newObfuscationTestIR()
+	o := newObfuscator([]byte("salt"))
+	o.obfuscate(obfuscated)
+
+	alias := o.hashAlias
+	ordersAlias := alias("t", "orders")
+	idAlias := alias("c", "id")
+	ownerAlias := alias("c", "owner")
+
+	// Structure is preserved: the same number of objects of each kind
+	origSchema, obfSchema := original.Schemas["public"], obfuscated.Schemas["public"]
+	if len(obfSchema.Tables) != len(origSchema.Tables) || len(obfSchema.Functions) != len(origSchema.Functions) || len(obfSchema.Sequences) != len(origSchema.Sequences) {
+		t.Fatalf("expected the same objects after obfuscation, got %d tables, %d functions, %d sequences",
+			len(obfSchema.Tables), len(obfSchema.Functions), len(obfSchema.Sequences))
+	}
+	for name, origTable := range origSchema.Tables {
+		table, ok := obfSchema.Tables[alias("t", name)]
+		if !ok {
+			t.Fatalf("expected table %q to be renamed to %q", name, alias("t", name))
+		}
+		if len(table.Columns) != len(origTable.Columns) || len(table.Constraints) != len(origTable.Constraints) ||
+			len(table.Indexes) != len(origTable.Indexes) || len(table.Triggers) != len(origTable.Triggers) ||
+			len(table.Policies) != len(origTable.Policies) {
+			t.Errorf("expected table %q to keep its structure", name)
+		}
+		if table.Comment != "" {
+			t.Errorf("expected the comment of table %q to be stripped", name)
+		}
+	}
+
+	// References are aliased consistently with the objects they point to
+	fk := obfSchema.Tables[alias("t", "order_items")].Constraints[alias("k", "order_items_order_id_fkey")]
+	if fk == nil || fk.ReferencedTable != ordersAlias || fk.ReferencedColumns[0].Name != idAlias {
+		t.Errorf("expected the foreign key to reference %s(%s), got %+v", ordersAlias, idAlias, fk)
+	}
+	orders := obfSchema.Tables[ordersAlias]
+	index := orders.Indexes[alias("i", "orders_owner_idx")]
+	if index == nil || index.Table != ordersAlias || index.Columns[0].Name != ownerAlias {
+		t.Errorf("expected the index to be on %s(%s), got %+v", ordersAlias, ownerAlias, index)
+	}
+	if check := orders.Constraints[alias("k", "orders_owner_check")]; check == nil || check.CheckClause != "CHECK ((length("+ownerAlias+") > 0))" {
+		t.Errorf("expected the check clause to reference the aliased column, got %+v", check)
+	}
+	if policy := orders.Policies[alias("pol", "orders_owner")]; policy == nil || policy.Using != "("+ownerAlias+" = CURRENT_USER)" {
+		t.Errorf("expected the policy to reference the aliased column, got %+v", policy)
+	}
+	if trigger := orders.Triggers[alias("tg", "orders_audit")]; trigger == nil || trigger.Function != alias("f", "audit")+"()" {
+		t.Errorf("expected the trigger to call the aliased function, got %+v", trigger)
+	}
+	sequence := obfSchema.Sequences[alias("s", "orders_id_seq")]
+	if sequence == nil || sequence.OwnedByTable != ordersAlias || sequence.OwnedByColumn != idAlias {
+		t.Errorf("expected the sequence to be owned by %s.%s, got %+v", ordersAlias, idAlias, sequence)
+	}
+	if def := orders.Columns[0].DefaultValue; def == nil || *def != "nextval('"+alias("s", "orders_id_seq")+"'::regclass)" {
+		t.Errorf("expected the sequence default to use the aliased sequence, got %v", def)
+	}
+
+	// Comments and string literal defaults are stripped
+	if orders.Columns[1].DefaultValue != nil || orders.Columns[1].Comment != "" {
+		t.Errorf("expected the string literal default and column comment to be stripped, got %+v", orders.Columns[1])
+	}
+
+	// Aliases depend on the salt, which obfuscateSchema picks at random on every run
+	again := newObfuscationTestIR()
+	newObfuscator([]byte("salt")).obfuscate(again)
+	if _, ok := again.Schemas["public"].Tables[ordersAlias]; !ok {
+		t.Error("expected obfuscation with the same salt to produce the same aliases")
+	}
+	salted := newObfuscationTestIR()
+	if err := obfuscateSchema(salted); err != nil {
+		t.Fatalf("obfuscateSchema() error = %v", err)
+	}
+	if _, ok := salted.Schemas["public"].Tables[ordersAlias]; ok {
+		t.Error("expected obfuscation with a random salt to produce different aliases")
+	}
+
+	output := dump.NewDumpFormatter("PostgreSQL 17.0", "public", true).FormatSingleFile(diff.GenerateMigration(ir.NewIR(), obfuscated, "public"))
+	for _, leaked := range []string{"orders", "owner", "audit", "nobody", "Customer orders"} {
+		if strings.Contains(output, leaked) {
+			t.Errorf("expected obfuscated dump to not contain %q, got:\n%s", leaked, output)
+		}
+	}
+}
+
+func TestObfuscateSchemaColumnNamedAfterType(t *testing.T) {
+	schemaIR := ir.NewIR()
+	schemaIR.Schemas["public"] = &ir.Schema{
+		Name: "public",
+		Tables: map[string]*ir.Table{
+			"events": {
+				Schema: "public",
+				Name:   "events",
+				Type:   ir.TableTypeBase,
+				Columns: []*ir.Column{
+					{Name: "date", Position: 1, DataType: "date", IsNullable: true},
+					{Name: "text", Position: 2, DataType: "text", IsNullable: true},
+					{Name: "status", Position: 3, DataType: "status", IsNullable: true},
+				},
+			},
+		},
+		Types: map[string]*ir.Type{
+			"status": {Schema: "public", Name: "status", Kind: ir.TypeKindEnum, EnumValues: []string{"open"}},
+		},
+	}
+	o := newObfuscator([]byte("salt"))
+	o.obfuscate(schemaIR)
+
+	events := schemaIR.Schemas["public"].Tables[o.hashAlias("t", "events")]
+	if events == nil {
+		t.Fatalf("expected table events to be renamed to %q", o.hashAlias("t", "events"))
+	}
+	expected := []struct{ name, dataType string }{
+		{o.hashAlias("c", "date"), "date"},
+		{o.hashAlias("c", "text"), "text"},
+		{o.hashAlias("c", "status"), o.hashAlias("ty", "status")},
+	}
+	for i, want := range expected {
+		if column := events.Columns[i]; column.Name != want.name || column.DataType != want.dataType {
+			t.Errorf("column %d: expected %s %s, got %s %s", i, want.name, want.dataType, column.Name, column.DataType)
+		}
+	}
+}
+
+// TestObfuscateSchemaColumnNamedAfterTable checks that a name used for both a table and a column
+// is aliased as the table where a relation is expected, and as the column elsewhere
+func TestObfuscateSchemaColumnNamedAfterTable(t *testing.T) {
+	schemaIR := ir.NewIR()
+	schemaIR.Schemas["public"] = &ir.Schema{
+		Name: "public",
+		Tables: map[string]*ir.Table{
+			"customer": {
+				Schema:  "public",
+				Name:    "customer",
+				Type:    ir.TableTypeBase,
+				Columns: []*ir.Column{{Name: "id", Position: 1, DataType: "integer"}},
+			},
+			"orders": {
+				Schema: "public",
+				Name:   "orders",
+				Type:   ir.TableTypeBase,
+				Columns: []*ir.Column{
+					{Name: "id", Position: 1, DataType: "integer"},
+					{Name: "customer", Position: 2, DataType: "integer"},
+				},
+			},
+		},
+		Views: map[string]*ir.View{
+			"order_customers": {
+				Schema:     "public",
+				Name:       "order_customers",
+				Definition: " SELECT orders.customer,\n    customer.id\n   FROM orders,\n    public.customer\n     JOIN customer c ON c.id = customer.id;",
+			},
+		},
+	}
+	o := newObfuscator([]byte("salt"))
+	o.obfuscate(schemaIR)
+
+	table, column := o.hashAlias("t", "customer"), o.hashAlias("c", "customer")
+	orders, id := o.hashAlias("t", "orders"), o.hashAlias("c", "id")
+	expected := " SELECT " + orders + "." + column + ",\n    " + table + "." + id + "\n   FROM " + orders + ",\n    public." + table +
+		"\n     JOIN " + table + " c ON c." + id + " = " + table + "." + id + ";"
+	view := schemaIR.Schemas["public"].Views[o.hashAlias("v", "order_customers")]
+	if view == nil || view.Definition != expected {
+		t.Errorf("expected view definition:\n%s\ngot:\n%+v", expected, view)
+	}
+}
+
+func TestParseSchemaMappings(t *testing.T) {
+	mapper, err := parseSchemaMappings([]string{"dev_app=app", "dev_shared=shared"})
+	if err != nil {
+		t.Fatalf("parseSchemaMappings() error = %v", err)
+	}
+	if mapper.schema("dev_app") != "app" || mapper.schema("dev_shared") != "shared" || mapper.schema("public") != "public" {
+		t.Errorf("unexpected mappings %v", mapper)
+	}
+
+	for _, values := range [][]string{{"dev_app"}, {"=app"}, {"dev_app="}, {"a=b", "a=c"}} {
+		if _, err := parseSchemaMappings(values); err == nil {
+			t.Errorf("expected an error for %q", values)
+		}
+	}
+}
+
+func TestMapSchemas(t *testing.T) {
+	schemaIR := ir.NewIR()
+	dbSchema := schemaIR.GetOrCreateSchema("dev_app")
+	dbSchema.Tables["orders"] = &ir.Table{
+		Schema: "dev_app",
+		Name:   "orders",
+		Type:   ir.TableTypeBase,
+		Columns: []*ir.Column{
+			{Name: "id", Position: 1, DataType: "bigint", DefaultValue: ptrString("nextval('dev_shared.order_seq'::regclass)")},
+			{Name: "customer_id", Position: 2, DataType: "integer"},
+			{Name: "status", Position: 3, DataType: "dev_shared.order_status", DefaultValue: ptrString("'new'::dev_shared.order_status")},
+			{Name: "note", Position: 4, DataType: "text", IsNullable: true},
+		},
+		Constraints: map[string]*ir.Constraint{
+			"orders_customer_id_fkey": {
+				Schema:            "dev_app",
+				Table:             "orders",
+				Name:              "orders_customer_id_fkey",
+				Type:              ir.ConstraintTypeForeignKey,
+				Columns:           []*ir.ConstraintColumn{{Name: "customer_id", Position: 1}},
+				ReferencedSchema:  "dev_shared",
+				ReferencedTable:   "customers",
+				ReferencedColumns: []*ir.ConstraintColumn{{Name: "id", Position: 1}},
+			},
+			"orders_note_check": {
+				Schema:      "dev_app",
+				Table:       "orders",
+				Name:        "orders_note_check",
+				Type:        ir.ConstraintTypeCheck,
+				Columns:     []*ir.ConstraintColumn{{Name: "note", Position: 1}},
+				CheckClause: "CHECK ((dev_shared.is_valid_note(note) AND (note <> 'dev_shared.x'::text)))",
+			},
+		},
+		Indexes:  map[string]*ir.Index{},
+		Triggers: map[string]*ir.Trigger{},
+		Policies: map[string]*ir.RLSPolicy{},
+	}
+	dbSchema.Functions["order_total(dev_shared.money_amount)"] = &ir.Function{
+		Schema:     "dev_app",
+		Name:       "order_total",
+		Definition: "SELECT dev_shared.round_amount($1)",
+		ReturnType: "dev_shared.money_amount",
+		Language:   "sql",
+		Parameters: []*ir.Parameter{{Name: "amount", DataType: "dev_shared.money_amount", Mode: "IN", Position: 1}},
+	}
+
+	mapper, err := parseSchemaMappings([]string{"dev_app=app", "dev_shared=shared"})
+	if err != nil {
+		t.Fatalf("parseSchemaMappings() error = %v", err)
+	}
+	mapSchemas(schemaIR, mapper)
+
+	appSchema, ok := schemaIR.Schemas["app"]
+	if !ok || appSchema.Name != "app" || len(schemaIR.Schemas) != 1 {
+		t.Fatalf("expected the dumped schema to be renamed to app, got %v", schemaIR.Schemas)
+	}
+	orders := appSchema.Tables["orders"]
+	if orders.Schema != "app" {
+		t.Errorf("expected the table to be in app, got %q", orders.Schema)
+	}
+	fk := orders.Constraints["orders_customer_id_fkey"]
+	if fk.Schema != "app" || fk.ReferencedSchema != "shared" {
+		t.Errorf("expected the foreign key to reference shared.customers, got %+v", fk)
+	}
+	if got := *orders.Columns[0].DefaultValue; got != "nextval('shared.order_seq'::regclass)" {
+		t.Errorf("expected the sequence default to be mapped, got %q", got)
+	}
+	if got := orders.Columns[2].DataType; got != "shared.order_status" {
+		t.Errorf("expected the column type to be mapped, got %q", got)
+	}
+	if got := *orders.Columns[2].DefaultValue; got != "'new'::shared.order_status" {
+		t.Errorf("expected the cast in the default to be mapped, got %q", got)
+	}
+	// String literals are data, not references
+	if got := orders.Constraints["orders_note_check"].CheckClause; got != "CHECK ((shared.is_valid_note(note) AND (note <> 'dev_shared.x'::text)))" {
+		t.Errorf("expected the function reference to be mapped and the literal kept, got %q", got)
+	}
+	function, ok := appSchema.Functions["order_total(shared.money_amount)"]
+	if !ok {
+		t.Fatalf("expected the function to be keyed by its mapped arguments, got %v", appSchema.Functions)
+	}
+	if function.ReturnType != "shared.money_amount" || function.Definition != "SELECT shared.round_amount($1)" {
+		t.Errorf("expected the function to be mapped, got %+v", function)
+	}
+
+	output := dump.NewDumpFormatter("PostgreSQL 17.0", "app", true).FormatSingleFile(diff.GenerateMigration(ir.NewIR(), schemaIR, "app"))
+	if !strings.Contains(output, "REFERENCES shared.customers") {
+		t.Errorf("expected the dump to reference shared.customers, got:\n%s", output)
+	}
+	if strings.Contains(strings.ReplaceAll(output, "'dev_shared.x'", ""), "dev_") {
+		t.Errorf("expected no unmapped schema names in the dump, got:\n%s", output)
+	}
+}
+
+func TestMapSchemasSwap(t *testing.T) {
+	schemaIR := ir.NewIR()
+	schemaIR.GetOrCreateSchema("public").Tables["t"] = &ir.Table{
+		Schema:      "public",
+		Name:        "t",
+		Type:        ir.TableTypeBase,
+		Columns:     []*ir.Column{{Name: "a", Position: 1, DataType: "blue.color"}, {Name: "b", Position: 2, DataType: `"Green".color`}},
+		Constraints: map[string]*ir.Constraint{},
+	}
+
+	mapSchemas(schemaIR, schemaMapper{"blue": "Green", "Green": "blue"})
+
+	columns := schemaIR.Schemas["public"].Tables["t"].Columns
+	if columns[0].DataType != `"Green".color` || columns[1].DataType != "blue.color" {
+		t.Errorf("expected the schemas to be swapped, got %q and %q", columns[0].DataType, columns[1].DataType)
+	}
+}
+
+func TestMapSchemasTableNamedLikeSchema(t *testing.T) {
+	schemaIR := ir.NewIR()
+	dbSchema := schemaIR.GetOrCreateSchema("public")
+	dbSchema.Tables["audit"] = &ir.Table{
+		Schema: "public",
+		Name:   "audit",
+		Type:   ir.TableTypeBase,
+		Columns: []*ir.Column{
+			{Name: "id", Position: 1, DataType: "integer"},
+			{Name: "level", Position: 2, DataType: "audit.level"},
+		},
+		Constraints: map[string]*ir.Constraint{},
+		Policies: map[string]*ir.RLSPolicy{
+			"audit_visible": {
+				Schema: "public",
+				Table:  "audit",
+				Name:   "audit_visible",
+				Using:  "(EXISTS ( SELECT 1 FROM audit.readers r WHERE (r.audit_id = audit.id)))",
+			},
+		},
+	}
+	dbSchema.Views["recent_audit"] = &ir.View{
+		Schema: "public",
+		Name:   "recent_audit",
+		Definition: " SELECT audit.id,\n    (audit.level)::audit.level AS level\n   FROM audit,\n    audit.events e\n" +
+			"  WHERE ((e.audit_id = audit.id) AND audit.is_recent(audit.id));",
+	}
+
+	mapSchemas(schemaIR, schemaMapper{"audit": "audit_prod"})
+
+	table := schemaIR.Schemas["public"].Tables["audit"]
+	if got := table.Columns[1].DataType; got != "audit_prod.level" {
+		t.Errorf("expected the column type to be mapped, got %q", got)
+	}
+	if got, want := table.Policies["audit_visible"].Using, "(EXISTS ( SELECT 1 FROM audit_prod.readers r WHERE (r.audit_id = audit.id)))"; got != want {
+		t.Errorf("expected only the relation to be mapped in the policy\ngot:  %s\nwant: %s", got, want)
+	}
+	want := " SELECT audit.id,\n    (audit.level)::audit_prod.level AS level\n   FROM audit,\n    audit_prod.events e\n" +
+		"  WHERE ((e.audit_id = audit.id) AND audit_prod.is_recent(audit.id));"
+	if got := schemaIR.Schemas["public"].Views["recent_audit"].Definition; got != want {
+		t.Errorf("expected the columns of table audit to be kept\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestReportUnusedSequences(t *testing.T) {
+	for _, omit := range []bool{false, true} {
+		schemaIR := newObfuscationTestIR()
+		dbSchema := schemaIR.Schemas["public"]
+		dbSchema.Sequences["legacy_orders_id_seq"] = &ir.Sequence{
+			Schema: "public", Name: "legacy_orders_id_seq", DataType: "integer", StartValue: 1, Increment: 1,
+		}
+		dbSchema.Privileges = []*ir.Privilege{
+			{ObjectType: ir.PrivilegeObjectTypeSequence, ObjectName: "legacy_orders_id_seq", Grantee: "app", Privileges: []string{"USAGE"}},
+			{ObjectType: ir.PrivilegeObjectTypeSequence, ObjectName: "orders_id_seq", Grantee: "app", Privileges: []string{"USAGE"}},
+		}
+
+		reportUnusedSequences(schemaIR, omit)
+
+		_, kept := dbSchema.Sequences["legacy_orders_id_seq"]
+		if kept == omit {
+			t.Errorf("omit=%v: expected the unused sequence to be kept only without omit", omit)
+		}
+		if _, ok := dbSchema.Sequences["orders_id_seq"]; !ok {
+			t.Errorf("omit=%v: expected the sequence owned by orders.id to be kept", omit)
+		}
+		if expected := map[bool]int{false: 2, true: 1}[omit]; len(dbSchema.Privileges) != expected {
+			t.Errorf("omit=%v: expected %d grants, got %d", omit, expected, len(dbSchema.Privileges))
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package dump
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+// schemaMapper renames schemas, e.g., dev_shared to shared, so that a dump taken from one
+// environment references the schema names of another
+type schemaMapper map[string]string
+
+// parseSchemaMappings parses --map-schema values of the form old=new
+func parseSchemaMappings(values []string) (schemaMapper, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	mapper := make(schemaMapper, len(values))
+	for _, value := range values {
+		from, to, ok := strings.Cut(value, "=")
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid --map-schema value %q: expected old=new", value)
+		}
+		if _, exists := mapper[from]; exists {
+			return nil, fmt.Errorf("invalid --map-schema value %q: schema %q is mapped more than once", value, from)
+		}
+		mapper[from] = to
+	}
+	return mapper, nil
+}
+
+// schema returns the mapped name of a schema, or the name itself if it is not mapped
+func (m schemaMapper) schema(name string) string {
+	if to, ok := m[name]; ok {
+		return to
+	}
+	return name
+}
+
+// mapSchemas renames the mapped schemas throughout the IR: the schemas themselves, the schema of
+// every object, and the schema qualifiers of cross-schema references such as foreign keys, column
+// types, defaults, and view and function bodies. All mappings apply at once, so a=b,b=a swaps
+// two schemas. Names inside string literals are not rewritten, except for regclass literals
+// such as nextval('dev_shared.seq'::regclass).
+func mapSchemas(schemaIR *ir.IR, m schemaMapper) {
+	if len(m) == 0 {
+		return
+	}
+
+	schemas := make(map[string]*ir.Schema, len(schemaIR.Schemas))
+	for name, dbSchema := range schemaIR.Schemas {
+		dbSchema.Name = m.schema(name)
+		m.mapSchemaObjects(dbSchema)
+		schemas[dbSchema.Name] = dbSchema
+	}
+	schemaIR.Schemas = schemas
+}
+
+// mapSchemaObjects rewrites the schema references of the objects in a schema
+func (m schemaMapper) mapSchemaObjects(dbSchema *ir.Schema) {
+	for _, table := range dbSchema.Tables {
+		m.mapTable(table)
+	}
+
+	for _, view := range dbSchema.Views {
+		view.Schema = m.schema(view.Schema)
+		view.Definition = m.sql(view.Definition)
+		for i, columnType := range view.ColumnTypes {
+			view.ColumnTypes[i] = m.names(columnType)
+		}
+		m.mapIndexes(view.Indexes)
+		m.mapTriggers(view.Triggers)
+	}
+
+	for _, sequence := range dbSchema.Sequences {
+		sequence.Schema = m.schema(sequence.Schema)
+	}
+
+	for _, typ := range dbSchema.Types {
+		typ.Schema = m.schema(typ.Schema)
+		typ.BaseType = m.names(typ.BaseType)
+		typ.Default = m.sql(typ.Default)
+		for _, column := range typ.Columns {
+			column.DataType = m.names(column.DataType)
+		}
+		for _, constraint := range typ.Constraints {
+			constraint.Definition = m.sql(constraint.Definition)
+		}
+	}
+
+	// Function keys are built from the argument types, which may be qualified
+	functions := make(map[string]*ir.Function, len(dbSchema.Functions))
+	for _, function := range dbSchema.Functions {
+		function.Schema = m.schema(function.Schema)
+		function.Definition = m.sql(function.Definition)
+		function.ReturnType = m.names(function.ReturnType)
+		m.mapParameters(function.Parameters)
+		for i, dependency := range function.Dependencies {
+			function.Dependencies[i] = m.names(dependency)
+		}
+		functions[function.Name+"("+function.GetArguments()+")"] = function
+	}
+	dbSchema.Functions = functions
+
+	procedures := make(map[string]*ir.Procedure, len(dbSchema.Procedures))
+	for _, procedure := range dbSchema.Procedures {
+		procedure.Schema = m.schema(procedure.Schema)
+		procedure.Definition = m.sql(procedure.Definition)
+		m.mapParameters(procedure.Parameters)
+		procedures[procedure.Name+"("+procedure.GetArguments()+")"] = procedure
+	}
+	dbSchema.Procedures = procedures
+
+	for _, aggregate := range dbSchema.Aggregates {
+		aggregate.Schema = m.schema(aggregate.Schema)
+		aggregate.ReturnType = m.names(aggregate.ReturnType)
+		aggregate.StateType = m.names(aggregate.StateType)
+		if aggregate.TransitionFunctionSchema != "" {
+			aggregate.TransitionFunctionSchema = m.schema(aggregate.TransitionFunctionSchema)
+		}
+		if aggregate.FinalFunctionSchema != "" {
+			aggregate.FinalFunctionSchema = m.schema(aggregate.FinalFunctionSchema)
+		}
+	}
+
+	for _, privilege := range dbSchema.Privileges {
+		privilege.ObjectName = m.names(privilege.ObjectName)
+	}
+	for _, privilege := range dbSchema.RevokedDefaultPrivileges {
+		privilege.ObjectName = m.names(privilege.ObjectName)
+	}
+}
+
+// mapTable rewrites the schema references of a table and its columns, constraints, indexes,
+// triggers, and policies
+func (m schemaMapper) mapTable(table *ir.Table) {
+	table.Schema = m.schema(table.Schema)
+	table.PartitionKey = m.sql(table.PartitionKey)
+	if table.PartitionOf != nil {
+		table.PartitionOf.ParentSchema = m.schema(table.PartitionOf.ParentSchema)
+	}
+	for i := range table.LikeClauses {
+		table.LikeClauses[i].SourceSchema = m.schema(table.LikeClauses[i].SourceSchema)
+	}
+	for i := range table.Dependencies {
+		table.Dependencies[i].Schema = m.schema(table.Dependencies[i].Schema)
+	}
+
+	for _, column := range table.Columns {
+		column.DataType = m.names(column.DataType)
+		if column.DefaultValue != nil {
+			value := m.sql(*column.DefaultValue)
+			column.DefaultValue = &value
+		}
+		if column.GeneratedExpr != nil {
+			expr := m.sql(*column.GeneratedExpr)
+			column.GeneratedExpr = &expr
+		}
+	}
+
+	for _, constraint := range table.Constraints {
+		constraint.Schema = m.schema(constraint.Schema)
+		if constraint.ReferencedSchema != "" {
+			constraint.ReferencedSchema = m.schema(constraint.ReferencedSchema)
+		}
+		constraint.CheckClause = m.sql(constraint.CheckClause)
+		constraint.ExclusionDefinition = m.sql(constraint.ExclusionDefinition)
+	}
+
+	m.mapIndexes(table.Indexes)
+	m.mapTriggers(table.Triggers)
+
+	for _, policy := range table.Policies {
+		policy.Schema = m.schema(policy.Schema)
+		policy.Using = m.sql(policy.Using)
+		policy.WithCheck = m.sql(policy.WithCheck)
+	}
+}
+
+// mapIndexes rewrites the schema references of the indexes of a table or materialized view
+func (m schemaMapper) mapIndexes(indexes map[string]*ir.Index) {
+	for _, index := range indexes {
+		index.Schema = m.schema(index.Schema)
+		index.Where = m.sql(index.Where)
+		for _, column := range index.Columns {
+			column.Name = m.sql(column.Name)
+		}
+	}
+}
+
+// mapTriggers rewrites the schema references of the triggers of a table or view
+func (m schemaMapper) mapTriggers(triggers map[string]*ir.Trigger) {
+	for _, trigger := range triggers {
+		trigger.Schema = m.schema(trigger.Schema)
+		trigger.Function = m.sql(trigger.Function)
+		trigger.Condition = m.sql(trigger.Condition)
+	}
+}
+
+// mapParameters rewrites the schema references of function or procedure parameters
+func (m schemaMapper) mapParameters(params []*ir.Parameter) {
+	for _, param := range params {
+		param.DataType = m.names(param.DataType)
+		if param.DefaultValue != nil {
+			value := m.sql(*param.DefaultValue)
+			param.DefaultValue = &value
+		}
+	}
+}
+
+// sql rewrites the schema qualifiers of a SQL expression or body. A qualified name is only
+// mapped where a schema can appear, so table-qualified column references such as audit.id are
+// kept even when a table is named like a mapped schema: after FROM, JOIN, and the other relation
+// keywords, in the relation list of a FROM clause, after a cast, AS, RETURNS, or SETOF, before
+// "(" as a function name, before %ROWTYPE, and as the first part of a three-part name. String
+// literals are left untouched unless they are cast to regclass.
+func (m schemaMapper) sql(text string) string {
+	return m.rewrite(text, false)
+}
+
+// names rewrites the schema qualifiers of a fragment made of type or object names only, such as a
+// column type or a function signature, where every qualifier is a schema
+func (m schemaMapper) names(text string) string {
+	return m.rewrite(text, true)
+}
+
+// rewrite rewrites the schema qualifiers of text; see sql and names
+func (m schemaMapper) rewrite(text string, names bool) string {
+	if text == "" {
+		return text
+	}
+
+	var result strings.Builder
+	afterDot := false
+	// prev is the previous token: a lowercased keyword or unquoted name, or a punctuation mark
+	prev := ""
+	fromClause := false
+	schemaPosition := func(rest string) bool {
+		if names || relationKeywords[prev] || (prev == "," && fromClause) || schemaKeywords[prev] {
+			return true
+		}
+		follower := strings.ToLower(strings.TrimLeft(rest[qualifiedPartLength(rest):], " \t\n"))
+		return strings.HasPrefix(follower, "(") || strings.HasPrefix(follower, ".") || strings.HasPrefix(follower, "%rowtype")
+	}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\'':
+			end := i + 1
+			for end < len(text) {
+				if text[end] == '\'' {
+					if end+1 < len(text) && text[end+1] == '\'' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			end = min(end+1, len(text))
+			literal := text[i:end]
+			if strings.HasPrefix(text[end:], "::regclass") && len(literal) >= 2 {
+				literal = "'" + m.names(literal[1:len(literal)-1]) + "'"
+			}
+			result.WriteString(literal)
+			i = end
+			afterDot = false
+			prev = "'"
+		case c == '"':
+			end := i + 1
+			for end < len(text) {
+				if text[end] == '"' {
+					if end+1 < len(text) && text[end+1] == '"' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			end = min(end+1, len(text))
+			name := strings.ReplaceAll(strings.Trim(text[i:end], `"`), `""`, `"`)
+			result.WriteString(m.qualifier(text[i:end], name, text[end:], afterDot, schemaPosition))
+			i = end
+			afterDot = false
+			prev = `"`
+		case isIdentifierStart(c):
+			end := i + 1
+			for end < len(text) && isIdentifierPart(text[end]) {
+				end++
+			}
+			word := text[i:end]
+			lower := strings.ToLower(word)
+			result.WriteString(m.qualifier(word, lower, text[end:], afterDot, schemaPosition))
+			i = end
+			afterDot = false
+			if lower == "from" {
+				fromClause = true
+			} else if fromClauseEnd[lower] {
+				fromClause = false
+			}
+			prev = lower
+		case c == ':' && i+1 < len(text) && text[i+1] == ':':
+			result.WriteString("::")
+			i += 2
+			afterDot = false
+			prev = "::"
+		default:
+			result.WriteByte(c)
+			if c != ' ' && c != '\n' && c != '\t' {
+				afterDot = c == '.'
+				if c == ';' {
+					fromClause = false
+				}
+				prev = string(c)
+			}
+			i++
+		}
+	}
+	return result.String()
+}
+
+// schemaKeywords are the keywords, besides the relation keywords, after which a name can be a
+// schema-qualified type
+var schemaKeywords = map[string]bool{
+	"::": true, "as": true, "returns": true, "setof": true,
+}
+
+// qualifiedPartLength returns the length of the ".name" part at the start of rest, where name is
+// an unquoted or quoted identifier, or 0 if rest does not start with one
+func qualifiedPartLength(rest string) int {
+	if !strings.HasPrefix(rest, ".") {
+		return 0
+	}
+	end := 1
+	if end < len(rest) && rest[end] == '"' {
+		end++
+		for end < len(rest) {
+			if rest[end] == '"' {
+				if end+1 < len(rest) && rest[end+1] == '"' {
+					end += 2
+					continue
+				}
+				break
+			}
+			end++
+		}
+		return min(end+1, len(rest))
+	}
+	for end < len(rest) && isIdentifierPart(rest[end]) {
+		end++
+	}
+	return end
+}
+
+// qualifier returns the mapped schema name for an identifier token that qualifies a name in a
+// schema position, or the token itself otherwise
+func (m schemaMapper) qualifier(token, name, rest string, afterDot bool, schemaPosition func(rest string) bool) string {
+	if afterDot || !strings.HasPrefix(rest, ".") {
+		return token
+	}
+	to, ok := m[name]
+	if !ok || !schemaPosition(rest) {
+		return token
+	}
+	return ir.QuoteIdentifier(to)
+}
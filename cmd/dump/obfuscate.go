@@ -0,0 +1,633 @@
+package dump
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+// nextvalDefaultRegex matches a sequence-backed column default, whose sequence name is
+// aliased in place rather than dropped like other string literals
+var nextvalDefaultRegex = regexp.MustCompile(`^nextval\('([^']*)'::regclass\)$`)
+
+// obfuscator renames the objects of an IR to hashed aliases. The same name always maps to the
+// same alias within a namespace, so references between objects stay consistent. Namespaces are
+// kept apart so that, e.g., a column named after a built-in type such as "date" is aliased
+// without touching the type of any column.
+type obfuscator struct {
+	salt      []byte            // mixed into every hash, so aliases cannot be reversed with a dictionary of common names
+	schemas   map[string]bool   // schema names, which are kept and may qualify a relation
+	relations map[string]string // tables, views, and sequences
+	columns   map[string]string // table, view, and composite type columns, and routine parameters
+	types     map[string]string // user-defined types
+	callables map[string]string // functions, procedures, and aggregates
+	names     map[string]string // constraints, indexes, triggers, and policies
+}
+
+// newObfuscator returns an obfuscator that hashes names with salt
+func newObfuscator(salt []byte) *obfuscator {
+	return &obfuscator{
+		salt:      salt,
+		schemas:   map[string]bool{"public": true, "pg_catalog": true},
+		relations: make(map[string]string),
+		columns:   make(map[string]string),
+		types:     make(map[string]string),
+		callables: make(map[string]string),
+		names:     make(map[string]string),
+	}
+}
+
+// obfuscateSchema replaces object, column, and parameter names with hashed aliases, strips
+// comments, and drops column and parameter defaults that contain string literals, so a schema
+// can be shared without exposing its naming or data. Schema names, roles, and enum values are
+// kept, and names inside string literals (e.g., dynamic SQL in function bodies) are not rewritten.
+// Names are hashed with a random salt, so aliases differ from one run to the next.
+func obfuscateSchema(schemaIR *ir.IR) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate obfuscation salt: %w", err)
+	}
+	newObfuscator(salt).obfuscate(schemaIR)
+	return nil
+}
+
+// obfuscate renames the objects of every schema in the IR
+func (o *obfuscator) obfuscate(schemaIR *ir.IR) {
+	schemaNames := make([]string, 0, len(schemaIR.Schemas))
+	for name := range schemaIR.Schemas {
+		schemaNames = append(schemaNames, name)
+		o.schemas[name] = true
+	}
+	sort.Strings(schemaNames)
+
+	// Register every name before rewriting anything, so that expressions referencing
+	// objects defined later in the schema are rewritten as well
+	for _, name := range schemaNames {
+		o.register(schemaIR.Schemas[name])
+	}
+	for _, name := range schemaNames {
+		o.rewrite(schemaIR.Schemas[name])
+	}
+}
+
+// hashAlias returns the alias of name built from prefix and a salted hash of the name
+func (o *obfuscator) hashAlias(prefix, name string) string {
+	sum := sha256.Sum256(append(append([]byte{}, o.salt...), name...))
+	return prefix + "_" + hex.EncodeToString(sum[:])[:8]
+}
+
+// add registers name in a namespace with an alias built from prefix. A name that is already
+// registered in the namespace keeps its first alias.
+func (o *obfuscator) add(aliases map[string]string, prefix, name string) {
+	if name == "" {
+		return
+	}
+	if _, ok := aliases[name]; ok {
+		return
+	}
+	aliases[name] = o.hashAlias(prefix, name)
+}
+
+// alias returns the alias registered for name in a namespace, or name itself if there is none
+func alias(aliases map[string]string, name string) string {
+	if alias, ok := aliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// register collects the names of all objects in the schema in a deterministic order
+func (o *obfuscator) register(dbSchema *ir.Schema) {
+	for _, key := range sortedKeys(dbSchema.Tables) {
+		o.add(o.relations, "t", dbSchema.Tables[key].Name)
+	}
+	for _, key := range sortedKeys(dbSchema.Views) {
+		o.add(o.relations, "v", dbSchema.Views[key].Name)
+	}
+	for _, key := range sortedKeys(dbSchema.Sequences) {
+		o.add(o.relations, "s", dbSchema.Sequences[key].Name)
+	}
+	for _, key := range sortedKeys(dbSchema.Types) {
+		o.add(o.types, "ty", dbSchema.Types[key].Name)
+	}
+	for _, key := range sortedKeys(dbSchema.Functions) {
+		o.add(o.callables, "f", dbSchema.Functions[key].Name)
+	}
+	for _, key := range sortedKeys(dbSchema.Procedures) {
+		o.add(o.callables, "p", dbSchema.Procedures[key].Name)
+	}
+	for _, key := range sortedKeys(dbSchema.Aggregates) {
+		o.add(o.callables, "a", dbSchema.Aggregates[key].Name)
+	}
+
+	for _, key := range sortedKeys(dbSchema.Tables) {
+		table := dbSchema.Tables[key]
+		for _, column := range table.Columns {
+			o.add(o.columns, "c", column.Name)
+		}
+		for _, name := range sortedKeys(table.Constraints) {
+			o.add(o.names, "k", name)
+		}
+		for _, column := range table.Columns {
+			o.add(o.names, "k", column.NotNullConstraintName)
+		}
+		for _, name := range sortedKeys(table.Indexes) {
+			o.add(o.names, "i", name)
+		}
+		for _, name := range sortedKeys(table.Triggers) {
+			o.add(o.names, "tg", name)
+		}
+		for _, name := range sortedKeys(table.Policies) {
+			o.add(o.names, "pol", name)
+		}
+	}
+	for _, key := range sortedKeys(dbSchema.Views) {
+		view := dbSchema.Views[key]
+		for _, column := range view.Columns {
+			o.add(o.columns, "c", column)
+		}
+		for _, name := range sortedKeys(view.Indexes) {
+			o.add(o.names, "i", name)
+		}
+		for _, name := range sortedKeys(view.Triggers) {
+			o.add(o.names, "tg", name)
+		}
+	}
+	for _, key := range sortedKeys(dbSchema.Types) {
+		typ := dbSchema.Types[key]
+		for _, column := range typ.Columns {
+			o.add(o.columns, "c", column.Name)
+		}
+		for _, constraint := range typ.Constraints {
+			o.add(o.names, "k", constraint.Name)
+		}
+	}
+	for _, key := range sortedKeys(dbSchema.Functions) {
+		for _, param := range dbSchema.Functions[key].Parameters {
+			o.add(o.columns, "arg", param.Name)
+		}
+	}
+	for _, key := range sortedKeys(dbSchema.Procedures) {
+		for _, param := range dbSchema.Procedures[key].Parameters {
+			o.add(o.columns, "arg", param.Name)
+		}
+	}
+}
+
+// rewrite renames the objects of the schema and every reference to them
+func (o *obfuscator) rewrite(dbSchema *ir.Schema) {
+	tables := make(map[string]*ir.Table, len(dbSchema.Tables))
+	for _, table := range dbSchema.Tables {
+		o.rewriteTable(table)
+		tables[table.Name] = table
+	}
+	dbSchema.Tables = tables
+
+	views := make(map[string]*ir.View, len(dbSchema.Views))
+	for _, view := range dbSchema.Views {
+		view.Name = alias(o.relations, view.Name)
+		view.Definition = o.rewriteSQL(view.Definition)
+		view.Comment = ""
+		for i, column := range view.Columns {
+			view.Columns[i] = alias(o.columns, column)
+		}
+		for i, columnType := range view.ColumnTypes {
+			view.ColumnTypes[i] = o.rewriteType(columnType)
+		}
+		view.Indexes = o.rewriteIndexes(view.Indexes, view.Name)
+		view.Triggers = o.rewriteTriggers(view.Triggers, view.Name)
+		views[view.Name] = view
+	}
+	dbSchema.Views = views
+
+	sequences := make(map[string]*ir.Sequence, len(dbSchema.Sequences))
+	for _, sequence := range dbSchema.Sequences {
+		sequence.Name = alias(o.relations, sequence.Name)
+		sequence.OwnedByTable = alias(o.relations, sequence.OwnedByTable)
+		sequence.OwnedByColumn = alias(o.columns, sequence.OwnedByColumn)
+		sequence.Comment = ""
+		sequences[sequence.Name] = sequence
+	}
+	dbSchema.Sequences = sequences
+
+	types := make(map[string]*ir.Type, len(dbSchema.Types))
+	for _, typ := range dbSchema.Types {
+		typ.Name = alias(o.types, typ.Name)
+		typ.Comment = ""
+		typ.BaseType = o.rewriteType(typ.BaseType)
+		typ.Default = o.rewriteDefault(typ.Default)
+		for _, column := range typ.Columns {
+			column.Name = alias(o.columns, column.Name)
+			column.DataType = o.rewriteType(column.DataType)
+		}
+		for _, constraint := range typ.Constraints {
+			constraint.Name = alias(o.names, constraint.Name)
+			constraint.Definition = o.rewriteSQL(constraint.Definition)
+		}
+		types[typ.Name] = typ
+	}
+	dbSchema.Types = types
+
+	functions := make(map[string]*ir.Function, len(dbSchema.Functions))
+	for _, function := range dbSchema.Functions {
+		function.Name = alias(o.callables, function.Name)
+		function.Definition = o.rewriteSQL(function.Definition)
+		function.ReturnType = o.rewriteType(function.ReturnType)
+		function.Comment = ""
+		o.rewriteParameters(function.Parameters)
+		for i, dependency := range function.Dependencies {
+			function.Dependencies[i] = o.rewriteSignature(dependency)
+		}
+		functions[function.Name+"("+function.GetArguments()+")"] = function
+	}
+	dbSchema.Functions = functions
+
+	procedures := make(map[string]*ir.Procedure, len(dbSchema.Procedures))
+	for _, procedure := range dbSchema.Procedures {
+		procedure.Name = alias(o.callables, procedure.Name)
+		procedure.Definition = o.rewriteSQL(procedure.Definition)
+		procedure.Comment = ""
+		o.rewriteParameters(procedure.Parameters)
+		procedures[procedure.Name+"("+procedure.GetArguments()+")"] = procedure
+	}
+	dbSchema.Procedures = procedures
+
+	aggregates := make(map[string]*ir.Aggregate, len(dbSchema.Aggregates))
+	for _, aggregate := range dbSchema.Aggregates {
+		aggregate.Name = alias(o.callables, aggregate.Name)
+		aggregate.ReturnType = o.rewriteType(aggregate.ReturnType)
+		aggregate.StateType = o.rewriteType(aggregate.StateType)
+		aggregate.TransitionFunction = alias(o.callables, aggregate.TransitionFunction)
+		aggregate.FinalFunction = alias(o.callables, aggregate.FinalFunction)
+		aggregate.Comment = ""
+		aggregates[aggregate.Name] = aggregate
+	}
+	dbSchema.Aggregates = aggregates
+
+	for _, privilege := range dbSchema.Privileges {
+		privilege.ObjectName = o.rewriteObjectName(privilege.ObjectType, privilege.ObjectName)
+	}
+	for _, privilege := range dbSchema.ColumnPrivileges {
+		privilege.TableName = alias(o.relations, privilege.TableName)
+		for i, column := range privilege.Columns {
+			privilege.Columns[i] = alias(o.columns, column)
+		}
+	}
+	for _, privilege := range dbSchema.RevokedDefaultPrivileges {
+		privilege.ObjectName = o.rewriteObjectName(privilege.ObjectType, privilege.ObjectName)
+	}
+}
+
+// rewriteTable renames a table along with its columns, constraints, indexes, triggers, and policies
+func (o *obfuscator) rewriteTable(table *ir.Table) {
+	table.Name = alias(o.relations, table.Name)
+	table.Comment = ""
+	table.PartitionKey = o.rewriteSQL(table.PartitionKey)
+	if table.PartitionOf != nil {
+		table.PartitionOf.ParentTable = alias(o.relations, table.PartitionOf.ParentTable)
+	}
+	for i := range table.LikeClauses {
+		table.LikeClauses[i].SourceTable = alias(o.relations, table.LikeClauses[i].SourceTable)
+	}
+	for i := range table.Dependencies {
+		dependency := &table.Dependencies[i]
+		if dependency.Type == ir.DependencyTypeFunction {
+			dependency.Name = o.rewriteSignature(dependency.Name)
+		} else {
+			dependency.Name = alias(o.relations, dependency.Name)
+		}
+	}
+
+	for _, column := range table.Columns {
+		column.Name = alias(o.columns, column.Name)
+		column.DataType = o.rewriteType(column.DataType)
+		column.Comment = ""
+		column.NotNullConstraintName = alias(o.names, column.NotNullConstraintName)
+		if column.DefaultValue != nil {
+			if value := o.rewriteDefault(*column.DefaultValue); value != "" {
+				column.DefaultValue = &value
+			} else {
+				column.DefaultValue = nil
+			}
+		}
+		if column.GeneratedExpr != nil {
+			expr := o.rewriteSQL(*column.GeneratedExpr)
+			column.GeneratedExpr = &expr
+		}
+	}
+
+	constraints := make(map[string]*ir.Constraint, len(table.Constraints))
+	for _, constraint := range table.Constraints {
+		constraint.Name = alias(o.names, constraint.Name)
+		constraint.Table = table.Name
+		constraint.Comment = ""
+		for _, column := range constraint.Columns {
+			column.Name = alias(o.columns, column.Name)
+		}
+		for i, column := range constraint.IncludeColumns {
+			constraint.IncludeColumns[i] = alias(o.columns, column)
+		}
+		constraint.ReferencedTable = alias(o.relations, constraint.ReferencedTable)
+		for _, column := range constraint.ReferencedColumns {
+			column.Name = alias(o.columns, column.Name)
+		}
+		constraint.CheckClause = o.rewriteSQL(constraint.CheckClause)
+		constraint.ExclusionDefinition = o.rewriteSQL(constraint.ExclusionDefinition)
+		constraints[constraint.Name] = constraint
+	}
+	table.Constraints = constraints
+
+	table.Indexes = o.rewriteIndexes(table.Indexes, table.Name)
+	table.Triggers = o.rewriteTriggers(table.Triggers, table.Name)
+
+	policies := make(map[string]*ir.RLSPolicy, len(table.Policies))
+	for _, policy := range table.Policies {
+		policy.Name = alias(o.names, policy.Name)
+		policy.Table = table.Name
+		policy.Using = o.rewriteSQL(policy.Using)
+		policy.WithCheck = o.rewriteSQL(policy.WithCheck)
+		policy.Comment = ""
+		policies[policy.Name] = policy
+	}
+	table.Policies = policies
+}
+
+// rewriteIndexes renames the indexes of a table or materialized view
+func (o *obfuscator) rewriteIndexes(indexes map[string]*ir.Index, tableName string) map[string]*ir.Index {
+	if indexes == nil {
+		return nil
+	}
+	result := make(map[string]*ir.Index, len(indexes))
+	for _, index := range indexes {
+		index.Name = alias(o.names, index.Name)
+		index.Table = tableName
+		index.Where = o.rewriteSQL(index.Where)
+		index.Comment = ""
+		for _, column := range index.Columns {
+			if alias, ok := o.columns[column.Name]; ok {
+				column.Name = alias
+			} else {
+				column.Name = o.rewriteSQL(column.Name)
+			}
+		}
+		result[index.Name] = index
+	}
+	return result
+}
+
+// rewriteTriggers renames the triggers of a table or view
+func (o *obfuscator) rewriteTriggers(triggers map[string]*ir.Trigger, tableName string) map[string]*ir.Trigger {
+	if triggers == nil {
+		return nil
+	}
+	result := make(map[string]*ir.Trigger, len(triggers))
+	for _, trigger := range triggers {
+		trigger.Name = alias(o.names, trigger.Name)
+		trigger.Table = tableName
+		trigger.Function = o.rewriteSQL(trigger.Function)
+		trigger.Condition = o.rewriteSQL(trigger.Condition)
+		trigger.Comment = ""
+		result[trigger.Name] = trigger
+	}
+	return result
+}
+
+// rewriteParameters renames function or procedure parameters and drops defaults with string literals
+func (o *obfuscator) rewriteParameters(params []*ir.Parameter) {
+	for _, param := range params {
+		param.Name = alias(o.columns, param.Name)
+		param.DataType = o.rewriteType(param.DataType)
+		if param.DefaultValue != nil {
+			if value := o.rewriteDefault(*param.DefaultValue); value != "" {
+				param.DefaultValue = &value
+			} else {
+				param.DefaultValue = nil
+			}
+		}
+	}
+}
+
+// rewriteDefault rewrites a default expression, returning "" if it contains a string literal.
+// Sequence defaults are kept with the sequence name inside the literal aliased.
+func (o *obfuscator) rewriteDefault(value string) string {
+	if match := nextvalDefaultRegex.FindStringSubmatch(value); match != nil {
+		sequence := match[1]
+		if dot := strings.LastIndex(sequence, "."); dot >= 0 {
+			sequence = sequence[:dot+1] + alias(o.relations, sequence[dot+1:])
+		} else {
+			sequence = alias(o.relations, sequence)
+		}
+		return "nextval('" + sequence + "'::regclass)"
+	}
+	if strings.Contains(value, "'") {
+		return ""
+	}
+	return o.rewriteSQL(value)
+}
+
+// rewriteObjectName rewrites the object name of a privilege: a relation or type name, or a
+// function or procedure signature
+func (o *obfuscator) rewriteObjectName(objectType ir.PrivilegeObjectType, name string) string {
+	switch objectType {
+	case ir.PrivilegeObjectTypeFunction, ir.PrivilegeObjectTypeProcedure:
+		return o.rewriteSignature(name)
+	case ir.PrivilegeObjectTypeType:
+		return o.rewriteType(name)
+	default:
+		return alias(o.relations, name)
+	}
+}
+
+// rewriteSignature rewrites a routine signature such as "audit(integer, mood)", aliasing the
+// routine name and any user-defined argument types
+func (o *obfuscator) rewriteSignature(signature string) string {
+	name, args, found := strings.Cut(signature, "(")
+	if !found {
+		return alias(o.callables, signature)
+	}
+	return alias(o.callables, name) + "(" + o.rewriteType(args)
+}
+
+// rewriteType rewrites a data type, such as "mood[]" or "numeric(10,2)". Only user-defined
+// types are aliased, so built-in types are kept even when a column shares their name.
+func (o *obfuscator) rewriteType(text string) string {
+	return o.rewriteText(text, true)
+}
+
+// relationKeywords are the keywords after which a name can only be a table, view, or sequence
+var relationKeywords = map[string]bool{
+	"from": true, "join": true, "update": true, "into": true, "table": true, "only": true, "references": true,
+}
+
+// fromClauseEnd are the keywords that end the comma-separated relation list of a FROM clause
+var fromClauseEnd = map[string]bool{
+	"where": true, "group": true, "order": true, "having": true, "limit": true, "window": true, "union": true,
+	"except": true, "intersect": true, "on": true, "using": true, "select": true, "returning": true, "set": true,
+	"values": true,
+}
+
+// rewriteSQL replaces the object names referenced in a SQL fragment with their aliases.
+// String literals are left untouched. A name followed by "(" is only replaced if it is a
+// function, procedure, aggregate, or type, a name after "::" only if it is a type, and a
+// name followed by "." only if it is a table, view, or sequence. A name in a relation position,
+// i.e. after FROM, JOIN, or a schema qualifier, is looked up as a table, view, or sequence
+// first. Other names are looked up as columns and parameters first, then as tables, views,
+// and sequences, then as types.
+func (o *obfuscator) rewriteSQL(text string) string {
+	return o.rewriteText(text, false)
+}
+
+// rewriteText implements rewriteSQL and, when typesOnly is set, rewriteType
+func (o *obfuscator) rewriteText(text string, typesOnly bool) string {
+	if text == "" {
+		return text
+	}
+
+	var result strings.Builder
+	afterCast := false
+	// prev is the previous token: a lowercased keyword or unquoted name, or a punctuation mark.
+	// qualifier is the name before prev when prev is ".".
+	prev, qualifier, lastName := "", "", ""
+	fromClause := false
+	relationPosition := func() bool {
+		return relationKeywords[prev] || (prev == "," && fromClause) || (prev == "." && o.schemas[qualifier])
+	}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\'':
+			// Copy the string literal, including doubled quotes, unchanged
+			end := i + 1
+			for end < len(text) {
+				if text[end] == '\'' {
+					if end+1 < len(text) && text[end+1] == '\'' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			end = min(end+1, len(text))
+			result.WriteString(text[i:end])
+			i = end
+			afterCast = false
+			prev = "'"
+		case c == '"':
+			end := i + 1
+			for end < len(text) {
+				if text[end] == '"' {
+					if end+1 < len(text) && text[end+1] == '"' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			end = min(end+1, len(text))
+			name := strings.ReplaceAll(strings.Trim(text[i:end], `"`), `""`, `"`)
+			result.WriteString(o.replaceIdentifier(text[i:end], name, text[end:], afterCast || typesOnly, relationPosition()))
+			i = end
+			afterCast = false
+			prev, lastName = `"`, name
+		case isIdentifierStart(c):
+			end := i + 1
+			for end < len(text) && isIdentifierPart(text[end]) {
+				end++
+			}
+			word := text[i:end]
+			lower := strings.ToLower(word)
+			result.WriteString(o.replaceIdentifier(word, lower, text[end:], afterCast || typesOnly, relationPosition()))
+			i = end
+			afterCast = false
+			if lower == "from" {
+				fromClause = true
+			} else if fromClauseEnd[lower] {
+				fromClause = false
+			}
+			prev, lastName = lower, lower
+		case c == ':' && i+1 < len(text) && text[i+1] == ':':
+			result.WriteString("::")
+			i += 2
+			afterCast = true
+			prev = "::"
+		default:
+			result.WriteByte(c)
+			if c != ' ' && c != '\n' && c != '\t' {
+				afterCast = false
+				if c == '.' {
+					qualifier = lastName
+				}
+				if c == ';' {
+					fromClause = false
+				}
+				prev = string(c)
+			}
+			i++
+		}
+	}
+	return result.String()
+}
+
+// replaceIdentifier returns the alias of an identifier token, or the token itself if it must be
+// kept. isType marks positions that can only hold a type name, such as after "::", and
+// isRelation positions where a table, view, or sequence name is expected, such as after FROM.
+func (o *obfuscator) replaceIdentifier(token, name, rest string, isType, isRelation bool) string {
+	if isType {
+		if alias, ok := o.types[name]; ok {
+			return alias
+		}
+		return token
+	}
+	next := strings.TrimLeft(rest, " ")
+	if strings.HasPrefix(next, "(") {
+		if alias, ok := o.callables[name]; ok {
+			return alias
+		}
+		if alias, ok := o.types[name]; ok {
+			return alias
+		}
+		return token
+	}
+	if strings.HasPrefix(next, ".") {
+		if alias, ok := o.relations[name]; ok {
+			return alias
+		}
+		return token
+	}
+	namespaces := []map[string]string{o.columns, o.relations, o.types}
+	if isRelation {
+		namespaces = []map[string]string{o.relations, o.columns, o.types}
+	}
+	for _, aliases := range namespaces {
+		if alias, ok := aliases[name]; ok {
+			return alias
+		}
+	}
+	return token
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9') || c == '$'
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,176 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// dollarQuoteTag matches the opening tag of a dollar-quoted string, e.g. $$ or $body$
+var dollarQuoteTag = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// modeledObjectKinds are the object kinds whose CREATE and ALTER statements are captured by the
+// schema IR and so reappear, possibly rewritten, in the canonical form
+var modeledObjectKinds = map[string]bool{
+	"TABLE":        true,
+	"VIEW":         true,
+	"MATERIALIZED": true,
+	"FUNCTION":     true,
+	"PROCEDURE":    true,
+	"AGGREGATE":    true,
+	"TYPE":         true,
+	"DOMAIN":       true,
+	"SEQUENCE":     true,
+	"INDEX":        true,
+	"TRIGGER":      true,
+	"POLICY":       true,
+	"DEFAULT":      true, // ALTER DEFAULT PRIVILEGES
+}
+
+// objectKindModifiers are the words that may precede the object kind in a CREATE statement
+var objectKindModifiers = map[string]bool{
+	"OR":         true,
+	"REPLACE":    true,
+	"UNIQUE":     true,
+	"UNLOGGED":   true,
+	"CONSTRAINT": true,
+	"RECURSIVE":  true,
+	"TEMP":       true,
+	"TEMPORARY":  true,
+}
+
+// droppedContent returns a description of each part of a schema file that its canonical form
+// does not keep: comments that do not appear in the output, which includes "-- pgschema:using"
+// directives, and statements the schema IR does not model, such as INSERT, SET or CREATE EXTENSION.
+// Include directives are skipped; the included files are formatted as part of the schema.
+func droppedContent(content, output string) []string {
+	var dropped []string
+
+	line := 1
+	stmtLine := 0
+	var stmt strings.Builder
+	endStatement := func() {
+		if kind, ok := unmodeledStatement(stmt.String()); ok {
+			dropped = append(dropped, fmt.Sprintf("line %d: %s statement", stmtLine, kind))
+		}
+		stmt.Reset()
+		stmtLine = 0
+	}
+
+	for i := 0; i < len(content); {
+		rest := content[i:]
+		end := len(rest)
+
+		switch {
+		case strings.HasPrefix(rest, "--"):
+			if n := strings.IndexByte(rest, '\n'); n >= 0 {
+				end = n
+			}
+			if comment := strings.TrimSpace(rest[:end]); !strings.Contains(output, comment) {
+				dropped = append(dropped, fmt.Sprintf("line %d: comment %q", line, comment))
+			}
+		case strings.HasPrefix(rest, "/*"):
+			if n := strings.Index(rest[2:], "*/"); n >= 0 {
+				end = n + 4
+			}
+			if comment := rest[:end]; !strings.Contains(output, comment) {
+				dropped = append(dropped, fmt.Sprintf("line %d: comment %q", line, firstLine(comment)))
+			}
+			// Keep statement text around the comment separated
+			stmt.WriteByte(' ')
+		case rest[0] == '\\' && stmtLine == 0:
+			// psql meta-command such as \i, which runs until the end of the line
+			if n := strings.IndexByte(rest, '\n'); n >= 0 {
+				end = n
+			}
+		case rest[0] == '\'' || rest[0] == '"':
+			end = quotedLength(rest, rest[:1])
+		case rest[0] == '$' && dollarQuoteTag.MatchString(rest):
+			tag := dollarQuoteTag.FindString(rest)
+			end = quotedLength(rest, tag)
+		default:
+			end = 1
+		}
+
+		if isStatementText(rest) {
+			if stmtLine == 0 && strings.TrimSpace(rest[:end]) != "" {
+				stmtLine = line
+			}
+			stmt.WriteString(rest[:end])
+		}
+		line += strings.Count(rest[:end], "\n")
+		i += end
+
+		if rest[0] == ';' {
+			if inAtomicBody(stmt.String()) {
+				// SQL-standard function bodies contain semicolons up to their closing END
+				stmt.WriteByte(';')
+			} else {
+				endStatement()
+			}
+		}
+	}
+	endStatement()
+
+	return dropped
+}
+
+// isStatementText reports whether rest starts with statement text rather than a comment or a meta-command
+func isStatementText(rest string) bool {
+	return !strings.HasPrefix(rest, "--") && !strings.HasPrefix(rest, "/*") && rest[0] != '\\' && rest[0] != ';'
+}
+
+// inAtomicBody reports whether stmt has an unclosed BEGIN ATOMIC function body
+func inAtomicBody(stmt string) bool {
+	words := strings.Fields(strings.ToUpper(stmt))
+	for i := 0; i+1 < len(words); i++ {
+		if words[i] == "BEGIN" && words[i+1] == "ATOMIC" {
+			return words[len(words)-1] != "END"
+		}
+	}
+	return false
+}
+
+// quotedLength returns the length of the quoted text at the start of s, including the opening and
+// closing quotes. A doubled quote inside single- or double-quoted text ends and restarts it, which
+// yields the same length. Unterminated text runs to the end of s.
+func quotedLength(s, quote string) int {
+	if n := strings.Index(s[len(quote):], quote); n >= 0 {
+		return len(quote) + n + len(quote)
+	}
+	return len(s)
+}
+
+// unmodeledStatement returns the kind of a statement, e.g. "INSERT" or "CREATE EXTENSION", and true
+// when the statement is not captured by the schema IR
+func unmodeledStatement(stmt string) (string, bool) {
+	words := strings.Fields(strings.ToUpper(stmt))
+	if len(words) == 0 {
+		return "", false
+	}
+
+	verb := words[0]
+	switch verb {
+	case "COMMENT", "GRANT", "REVOKE", "DROP":
+		return verb, false
+	case "CREATE", "ALTER":
+		for _, word := range words[1:] {
+			if objectKindModifiers[word] {
+				continue
+			}
+			kind := verb + " " + word
+			return kind, !modeledObjectKinds[word]
+		}
+		return verb, true
+	default:
+		return verb, true
+	}
+}
+
+// firstLine returns the first line of s, followed by "..." if s spans several lines
+func firstLine(s string) string {
+	if n := strings.IndexByte(s, '\n'); n >= 0 {
+		return s[:n] + "..."
+	}
+	return s
+}
@@ -0,0 +1,214 @@
+package format
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	planCmd "github.com/pgplex/pgschema/cmd/plan"
+	"github.com/pgplex/pgschema/cmd/util"
+	"github.com/pgplex/pgschema/internal/diff"
+	"github.com/pgplex/pgschema/internal/dump"
+	"github.com/pgplex/pgschema/internal/include"
+	"github.com/pgplex/pgschema/internal/postgres"
+	"github.com/pgplex/pgschema/ir"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fmtSchema    string
+	fmtWrite     bool
+	fmtCheck     bool
+	fmtPGVersion int
+
+	// Plan database flags (optional - if not provided, uses embedded postgres)
+	fmtPlanDBHost     string
+	fmtPlanDBPort     int
+	fmtPlanDBDatabase string
+	fmtPlanDBUser     string
+	fmtPlanDBPassword string
+)
+
+// FmtConfig holds configuration for fmt execution
+type FmtConfig struct {
+	File   string
+	Schema string
+}
+
+var FmtCmd = &cobra.Command{
+	Use:   "fmt [file]",
+	Short: "Canonicalize a schema file",
+	Long: "Rewrite a desired state schema file in the canonical form produced by dump. " +
+		"The file is applied to a temporary schema and dumped back, so the output is independent of how the file was written. " +
+		"Prints the result to stdout by default; use --write to update the file in place or --check to verify it is already canonical.",
+	Args:         cobra.ExactArgs(1),
+	RunE:         runFmt,
+	SilenceUsage: true,
+}
+
+func init() {
+	FmtCmd.Flags().StringVar(&fmtSchema, "schema", "public", "Schema name the file targets")
+	FmtCmd.Flags().BoolVar(&fmtWrite, "write", false, "Rewrite the file in place instead of printing to stdout")
+	FmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "Exit with an error if the file is not already canonical")
+	FmtCmd.Flags().IntVar(&fmtPGVersion, "pg-version", 17, "PostgreSQL major version of the embedded instance (14-18)")
+
+	// Plan database connection flags (optional - for using external database instead of embedded postgres)
+	FmtCmd.Flags().StringVar(&fmtPlanDBHost, "plan-host", "", "Plan database host (env: PGSCHEMA_PLAN_HOST). If provided, uses external database instead of embedded postgres")
+	FmtCmd.Flags().IntVar(&fmtPlanDBPort, "plan-port", 5432, "Plan database port (env: PGSCHEMA_PLAN_PORT)")
+	FmtCmd.Flags().StringVar(&fmtPlanDBDatabase, "plan-db", "", "Plan database name (env: PGSCHEMA_PLAN_DB)")
+	FmtCmd.Flags().StringVar(&fmtPlanDBUser, "plan-user", "", "Plan database user (env: PGSCHEMA_PLAN_USER)")
+	FmtCmd.Flags().StringVar(&fmtPlanDBPassword, "plan-password", "", "Plan database password (env: PGSCHEMA_PLAN_PASSWORD)")
+
+	FmtCmd.MarkFlagsMutuallyExclusive("write", "check")
+}
+
+func runFmt(cmd *cobra.Command, args []string) error {
+	file := args[0]
+
+	// Apply environment variables to plan database flags
+	util.ApplyPlanDBEnvVars(cmd, &fmtPlanDBHost, &fmtPlanDBDatabase, &fmtPlanDBUser, &fmtPlanDBPassword, &fmtPlanDBPort)
+
+	// Validate plan database flags if plan-host is provided
+	if err := util.ValidatePlanDBFlags(fmtPlanDBHost, fmtPlanDBDatabase, fmtPlanDBUser); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	if fmtWrite {
+		// Writing the expanded schema back would inline every included file
+		expanded, err := include.NewProcessor(filepath.Dir(file)).ProcessFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to process schema file: %w", err)
+		}
+		if expanded != string(content) {
+			return fmt.Errorf("%s contains include directives; --write is not supported for such files", file)
+		}
+	}
+
+	provider, err := createProvider()
+	if err != nil {
+		return err
+	}
+	defer provider.Stop()
+
+	config := &FmtConfig{
+		File:   file,
+		Schema: fmtSchema,
+	}
+	output, err := FormatFile(config, provider)
+	if err != nil {
+		return err
+	}
+
+	// The canonical form is a dump of the schema, so anything the schema IR does not capture is lost
+	dropped := droppedContent(string(content), output)
+
+	switch {
+	case fmtCheck:
+		if len(dropped) > 0 {
+			return fmt.Errorf("%s is not canonically formatted and contains content fmt would drop:\n  %s", file, strings.Join(dropped, "\n  "))
+		}
+		if output != string(content) {
+			return fmt.Errorf("%s is not canonically formatted", file)
+		}
+		return nil
+	case fmtWrite:
+		if len(dropped) > 0 {
+			return fmt.Errorf("%s was not rewritten because fmt would drop content the canonical form does not keep:\n  %s", file, strings.Join(dropped, "\n  "))
+		}
+		if output == string(content) {
+			return nil
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("failed to stat schema file: %w", err)
+		}
+		if err := os.WriteFile(file, []byte(output), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write schema file: %w", err)
+		}
+		return nil
+	default:
+		for _, d := range dropped {
+			fmt.Fprintf(os.Stderr, "Warning: %s %s is not kept in the canonical form\n", file, d)
+		}
+		fmt.Print(output)
+		return nil
+	}
+}
+
+// createProvider creates either an embedded PostgreSQL instance or connects to an external database
+// for applying the schema file. The caller is responsible for calling Stop() on the returned provider.
+func createProvider() (postgres.DesiredStateProvider, error) {
+	if fmtPlanDBHost != "" {
+		// Derive final plan database password
+		finalPlanPassword := fmtPlanDBPassword
+		if finalPlanPassword == "" {
+			if envPassword := os.Getenv("PGSCHEMA_PLAN_PASSWORD"); envPassword != "" {
+				finalPlanPassword = envPassword
+			}
+		}
+
+		externalConfig := &postgres.ExternalDatabaseConfig{
+			Host:     fmtPlanDBHost,
+			Port:     fmtPlanDBPort,
+			Database: fmtPlanDBDatabase,
+			Username: fmtPlanDBUser,
+			Password: finalPlanPassword,
+		}
+
+		// There is no target database to match, so use the plan database's own version
+		pgVersion, err := postgres.DetectPostgresVersionFromDB(externalConfig.ConnectionConfig())
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect PostgreSQL version: %w", err)
+		}
+		if _, err := fmt.Sscanf(string(pgVersion), "%d.", &externalConfig.TargetMajorVersion); err != nil {
+			return nil, fmt.Errorf("failed to parse PostgreSQL version %s: %w", pgVersion, err)
+		}
+
+		return postgres.NewExternalDatabase(externalConfig)
+	}
+
+	pgVersion, err := postgres.EmbeddedVersionForMajor(fmtPGVersion)
+	if err != nil {
+		return nil, err
+	}
+	embeddedPG, err := postgres.StartEmbeddedPostgres(&postgres.EmbeddedPostgresConfig{
+		Version:  pgVersion,
+		Database: "pgschema_temp",
+		Username: "pgschema",
+		Password: "pgschema",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start embedded PostgreSQL: %w", err)
+	}
+	return embeddedPG, nil
+}
+
+// FormatFile returns the canonical form of a schema file.
+// The file is applied to the provider and the resulting schema is dumped without the
+// version-dependent dump header, so formatting the output again yields the same text.
+// The caller is responsible for managing the provider lifecycle (creation and cleanup).
+func FormatFile(config *FmtConfig, provider postgres.DesiredStateProvider) (string, error) {
+	// Process include directives so split schemas can be applied as a whole
+	processor := include.NewProcessor(filepath.Dir(config.File))
+	desiredState, err := processor.ProcessFile(config.File)
+	if err != nil {
+		return "", fmt.Errorf("failed to process schema file: %w", err)
+	}
+
+	desiredIR, err := planCmd.InspectDesiredState(provider, config.Schema, desiredState, "pgschema", nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Generate diff between empty schema and desired schema (this represents a complete dump)
+	diffs := diff.GenerateMigration(ir.NewIR(), desiredIR, config.Schema)
+
+	formatter := dump.NewDumpFormatter(desiredIR.Metadata.DatabaseVersion, config.Schema, false)
+	return formatter.FormatStatements(diffs), nil
+}
@@ -0,0 +1,64 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/testutil"
+)
+
+func TestFormatFile_Idempotent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	embeddedPG := testutil.SetupPostgres(t)
+	defer embeddedPG.Stop()
+
+	// Deliberately messy input: lower-case keywords, odd spacing, out-of-order objects
+	input := `create view active_users as select id, email from users where deleted_at is null;
+create   table users(
+  id serial primary key,
+  email varchar(255) not null unique,
+  deleted_at timestamptz
+);
+create index idx_users_deleted_at on users(deleted_at);
+create type status as enum ('active','inactive');
+comment on table users is 'Registered users';
+`
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "schema.sql")
+	if err := os.WriteFile(file, []byte(input), 0644); err != nil {
+		t.Fatalf("Failed to write schema file: %v", err)
+	}
+
+	config := &FmtConfig{File: file, Schema: "public"}
+	first, err := FormatFile(config, embeddedPG)
+	if err != nil {
+		t.Fatalf("Failed to format schema file: %v", err)
+	}
+
+	for _, want := range []string{"CREATE TABLE IF NOT EXISTS users", "CREATE OR REPLACE VIEW active_users", "CREATE TYPE status AS ENUM"} {
+		if !strings.Contains(first, want) {
+			t.Errorf("Expected formatted output to contain %q, got:\n%s", want, first)
+		}
+	}
+	if strings.Contains(first, "-- pgschema database dump") {
+		t.Errorf("Expected formatted output to omit the dump header, got:\n%s", first)
+	}
+
+	// fmt of fmt must be a no-op
+	if err := os.WriteFile(file, []byte(first), 0644); err != nil {
+		t.Fatalf("Failed to write formatted schema file: %v", err)
+	}
+	second, err := FormatFile(config, embeddedPG)
+	if err != nil {
+		t.Fatalf("Failed to format already formatted schema file: %v", err)
+	}
+	if first != second {
+		t.Errorf("Formatting is not idempotent.\nFirst:\n%s\nSecond:\n%s", first, second)
+	}
+}
@@ -0,0 +1,95 @@
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFmtCommand(t *testing.T) {
+	// Test that the command is properly configured
+	if FmtCmd.Use != "fmt [file]" {
+		t.Errorf("Expected Use to be 'fmt [file]', got '%s'", FmtCmd.Use)
+	}
+
+	if FmtCmd.Short == "" {
+		t.Error("Expected Short description to be set")
+	}
+
+	flags := FmtCmd.Flags()
+
+	for _, name := range []string{"schema", "write", "check", "pg-version", "plan-host", "plan-port", "plan-db", "plan-user", "plan-password"} {
+		if flags.Lookup(name) == nil {
+			t.Errorf("Expected --%s flag to be defined", name)
+		}
+	}
+
+	if flag := flags.Lookup("schema"); flag != nil && flag.DefValue != "public" {
+		t.Errorf("Expected default schema to be 'public', got '%s'", flag.DefValue)
+	}
+	if flag := flags.Lookup("pg-version"); flag != nil && flag.DefValue != "17" {
+		t.Errorf("Expected default pg-version to be '17', got '%s'", flag.DefValue)
+	}
+}
+
+func TestFmtCommand_ArgsValidation(t *testing.T) {
+	if err := FmtCmd.Args(FmtCmd, []string{}); err == nil {
+		t.Error("Expected error when no file is given")
+	}
+	if err := FmtCmd.Args(FmtCmd, []string{"a.sql", "b.sql"}); err == nil {
+		t.Error("Expected error when more than one file is given")
+	}
+	if err := FmtCmd.Args(FmtCmd, []string{"schema.sql"}); err != nil {
+		t.Errorf("Expected no error for a single file, got: %v", err)
+	}
+}
+
+func TestDroppedContent(t *testing.T) {
+	output := "--\n-- Name: users; Type: TABLE; Schema: -; Owner: -\n--\n\nCREATE TABLE IF NOT EXISTS users (\n    id integer\n);\n"
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "canonical file",
+			content: output,
+		},
+		{
+			name:    "modeled statements",
+			content: "create unique index users_id on users (id);\nALTER TABLE users ADD COLUMN name text;\nCOMMENT ON TABLE users IS 'People -- with accounts; all of them';\nCREATE OR REPLACE FUNCTION f() RETURNS int AS $$ INSERT INTO t VALUES (1); SELECT 1 $$ LANGUAGE sql;\n",
+		},
+		{
+			name:    "comments",
+			content: "-- Users of the app\nCREATE TABLE users (id integer); /* ids\nare integers */\n",
+			want:    []string{`line 1: comment "-- Users of the app"`, `line 2: comment "/* ids..."`},
+		},
+		{
+			name:    "using directive",
+			content: "-- pgschema:using users.id = id::integer\nCREATE TABLE users (id integer);\n",
+			want:    []string{`line 1: comment "-- pgschema:using users.id = id::integer"`},
+		},
+		{
+			name:    "unmodeled statements",
+			content: "CREATE EXTENSION IF NOT EXISTS pgcrypto;\nCREATE TABLE users (id integer);\n\nINSERT INTO users\nVALUES (1);\nSET check_function_bodies = false;\n",
+			want:    []string{"line 1: CREATE EXTENSION statement", "line 4: INSERT statement", "line 6: SET statement"},
+		},
+		{
+			name:    "atomic function body",
+			content: "CREATE FUNCTION f() RETURNS int LANGUAGE sql BEGIN ATOMIC SELECT 1; END;\n",
+		},
+		{
+			name:    "include directive",
+			content: "\\i tables/users.sql\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := droppedContent(tt.content, output)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("droppedContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
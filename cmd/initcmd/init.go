@@ -0,0 +1,238 @@
+package initcmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pgplex/pgschema/cmd/dump"
+	planCmd "github.com/pgplex/pgschema/cmd/plan"
+	"github.com/pgplex/pgschema/cmd/util"
+	"github.com/pgplex/pgschema/internal/postgres"
+	"github.com/spf13/cobra"
+)
+
+var (
+	initHost     string
+	initPort     int
+	initDB       string
+	initUser     string
+	initPassword string
+	initSchema   string
+	initFile     string
+	initForce    bool
+
+	initSSLMode        string
+	initConnectTimeout time.Duration
+	initMaxConns       int
+
+	// Plan database connection flags (optional - for using external database instead of embedded postgres)
+	initPlanDBHost     string
+	initPlanDBPort     int
+	initPlanDBDatabase string
+	initPlanDBUser     string
+	initPlanDBPassword string
+)
+
+// InitConfig holds configuration for baseline creation
+type InitConfig struct {
+	Host     string
+	Port     int
+	DB       string
+	User     string
+	Password string
+	Schema   string
+	File     string
+	Force    bool
+
+	// Connection tuning (optional - defaults to sslmode=prefer, a 30s timeout, and an unlimited pool)
+	SSLMode        string
+	ConnectTimeout time.Duration
+	MaxConns       int
+}
+
+var InitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Adopt an existing database by writing a baseline schema file",
+	Long: `Dump the current schema of an existing database into a baseline schema file and verify
+that planning against it produces no changes. The baseline becomes the starting point for
+declarative migrations; use 'pgschema plan --baseline <file>' to confirm the database still matches it.`,
+	RunE:         runInit,
+	SilenceUsage: true,
+	PreRunE:      util.PreRunEWithEnvVarsAndConnection(&initDB, &initUser, &initHost, &initPort),
+}
+
+func init() {
+	InitCmd.Flags().StringVar(&initHost, "host", "localhost", "Database server host (env: PGHOST)")
+	InitCmd.Flags().IntVar(&initPort, "port", 5432, "Database server port (env: PGPORT)")
+	InitCmd.Flags().StringVar(&initDB, "db", "", "Database name (required) (env: PGDATABASE)")
+	InitCmd.Flags().StringVar(&initUser, "user", "", "Database user name (required) (env: PGUSER)")
+	InitCmd.Flags().StringVar(&initPassword, "password", "", "Database password (optional, can also use PGPASSWORD env var)")
+	InitCmd.Flags().StringVar(&initSchema, "schema", "public", "Schema name to adopt (default: public)")
+	InitCmd.Flags().StringVar(&initSSLMode, "sslmode", util.DefaultSSLMode, "SSL mode for the database connection: disable, allow, prefer, require, verify-ca, verify-full (env: PGSSLMODE)")
+	InitCmd.Flags().DurationVar(&initConnectTimeout, "connection-timeout", util.DefaultConnectTimeout, "Timeout for connecting to the database (env: PGCONNECT_TIMEOUT, in seconds)")
+	InitCmd.Flags().IntVar(&initMaxConns, "max-conns", 0, "Maximum number of connections used for schema inspection (0 means unlimited)")
+	InitCmd.Flags().StringVar(&initFile, "file", "schema.sql", "Path to write the baseline schema file")
+	InitCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite the baseline file if it already exists")
+
+	// Plan database connection flags
+	InitCmd.Flags().StringVar(&initPlanDBHost, "plan-host", "", "Plan database host (env: PGSCHEMA_PLAN_HOST). If provided, uses external database instead of embedded postgres for verifying the baseline")
+	InitCmd.Flags().IntVar(&initPlanDBPort, "plan-port", 5432, "Plan database port (env: PGSCHEMA_PLAN_PORT)")
+	InitCmd.Flags().StringVar(&initPlanDBDatabase, "plan-db", "", "Plan database name (env: PGSCHEMA_PLAN_DB)")
+	InitCmd.Flags().StringVar(&initPlanDBUser, "plan-user", "", "Plan database user (env: PGSCHEMA_PLAN_USER)")
+	InitCmd.Flags().StringVar(&initPlanDBPassword, "plan-password", "", "Plan database password (env: PGSCHEMA_PLAN_PASSWORD)")
+}
+
+// InitBaseline dumps the target schema, verifies that planning against the dump produces no changes,
+// using the provider to apply the desired state, and only then writes it to the baseline file.
+func InitBaseline(config *InitConfig, provider postgres.DesiredStateProvider) error {
+	if !config.Force {
+		if _, err := os.Stat(config.File); err == nil {
+			return fmt.Errorf("baseline file %s already exists (use --force to overwrite)", config.File)
+		}
+	}
+
+	output, err := dump.ExecuteDump(&dump.DumpConfig{
+		Host:           config.Host,
+		Port:           config.Port,
+		DB:             config.DB,
+		User:           config.User,
+		Password:       config.Password,
+		Schema:         config.Schema,
+		SSLMode:        config.SSLMode,
+		ConnectTimeout: config.ConnectTimeout,
+		MaxConns:       config.MaxConns,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The dump is written next to the baseline file and only moved into place once it is verified,
+	// so a failed verification leaves any existing baseline untouched
+	tempFile, err := writeTempBaseline(config.File, output)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile)
+
+	// Plan against the freshly written baseline; any change means the dump does not round-trip
+	planConfig := config.planConfig()
+	planConfig.File = tempFile
+	migrationPlan, err := planCmd.GeneratePlan(planConfig, provider)
+	if err != nil {
+		return fmt.Errorf("failed to verify baseline: %w", err)
+	}
+	if err := planCmd.VerifyBaseline(migrationPlan, config.File); err != nil {
+		return fmt.Errorf("baseline verification failed, please report this as a bug: %w", err)
+	}
+
+	if err := os.Rename(tempFile, config.File); err != nil {
+		return fmt.Errorf("failed to write baseline file: %w", err)
+	}
+	return nil
+}
+
+// writeTempBaseline writes the baseline to a temporary file in the directory of file and returns its path
+func writeTempBaseline(file, content string) (string, error) {
+	f, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".*")
+	if err != nil {
+		return "", fmt.Errorf("failed to write baseline file: %w", err)
+	}
+	if _, err = f.WriteString(content); err == nil {
+		err = f.Chmod(0644)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write baseline file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// planConfig returns the plan configuration for verifying the baseline file against the target database
+func (c *InitConfig) planConfig() *planCmd.PlanConfig {
+	return &planCmd.PlanConfig{
+		Host:            c.Host,
+		Port:            c.Port,
+		DB:              c.DB,
+		User:            c.User,
+		Password:        c.Password,
+		Schema:          c.Schema,
+		File:            c.File,
+		ApplicationName: "pgschema",
+		SSLMode:         c.SSLMode,
+		ConnectTimeout:  c.ConnectTimeout,
+		MaxConns:        c.MaxConns,
+	}
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	// Apply environment variables to connection tuning flags and validate them
+	util.ApplyConnectionEnvVars(cmd, &initSSLMode, &initConnectTimeout)
+	if err := util.ValidateConnectionFlags(initSSLMode, initConnectTimeout, initMaxConns); err != nil {
+		return err
+	}
+
+	// Apply environment variables to plan database flags
+	util.ApplyPlanDBEnvVars(cmd, &initPlanDBHost, &initPlanDBDatabase, &initPlanDBUser, &initPlanDBPassword, &initPlanDBPort)
+
+	// Validate plan database flags if plan-host is provided
+	if err := util.ValidatePlanDBFlags(initPlanDBHost, initPlanDBDatabase, initPlanDBUser); err != nil {
+		return err
+	}
+
+	// Derive final password: use flag if provided, otherwise check environment variable
+	finalPassword := initPassword
+	if finalPassword == "" {
+		if envPassword := os.Getenv("PGPASSWORD"); envPassword != "" {
+			finalPassword = envPassword
+		}
+	}
+
+	// Derive final plan database password
+	finalPlanPassword := initPlanDBPassword
+	if finalPlanPassword == "" {
+		if envPassword := os.Getenv("PGSCHEMA_PLAN_PASSWORD"); envPassword != "" {
+			finalPlanPassword = envPassword
+		}
+	}
+
+	config := &InitConfig{
+		Host:     initHost,
+		Port:     initPort,
+		DB:       initDB,
+		User:     initUser,
+		Password: finalPassword,
+		Schema:   initSchema,
+		File:     initFile,
+		Force:    initForce,
+
+		SSLMode:        initSSLMode,
+		ConnectTimeout: initConnectTimeout,
+		MaxConns:       initMaxConns,
+	}
+
+	// Create desired state provider (embedded postgres or external database)
+	planConfig := config.planConfig()
+	planConfig.PlanDBHost = initPlanDBHost
+	planConfig.PlanDBPort = initPlanDBPort
+	planConfig.PlanDBDatabase = initPlanDBDatabase
+	planConfig.PlanDBUser = initPlanDBUser
+	planConfig.PlanDBPassword = finalPlanPassword
+	provider, err := planCmd.CreateDesiredStateProvider(planConfig)
+	if err != nil {
+		return err
+	}
+	defer provider.Stop()
+
+	if err := InitBaseline(config, provider); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote baseline for schema %q to %s\n", config.Schema, config.File)
+	fmt.Fprintf(os.Stderr, "Edit this file to declare changes, and run 'pgschema plan --baseline %s' to check for drift.\n", config.File)
+	return nil
+}
@@ -0,0 +1,116 @@
+package initcmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	planCmd "github.com/pgplex/pgschema/cmd/plan"
+	"github.com/pgplex/pgschema/testutil"
+)
+
+// TestInitBaseline verifies the adoption workflow: dump an existing database to a baseline
+// file, plan against that file, and assert the plan is empty.
+func TestInitBaseline(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	targetPG := testutil.SetupPostgres(t)
+	defer targetPG.Stop()
+	conn, host, port, dbname, user, password := testutil.ConnectToPostgres(t, targetPG)
+	defer conn.Close()
+
+	planPG := testutil.SetupPostgres(t)
+	defer planPG.Stop()
+
+	// Existing schema that was not created by pgschema
+	_, err := conn.Exec(`
+CREATE TYPE order_status AS ENUM ('pending', 'shipped');
+
+CREATE TABLE customers (
+    id SERIAL PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE orders (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    customer_id INTEGER NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    status order_status NOT NULL DEFAULT 'pending',
+    total NUMERIC(10, 2) CHECK (total >= 0)
+);
+
+CREATE INDEX idx_orders_customer ON orders (customer_id);
+
+CREATE VIEW pending_orders AS
+    SELECT id, customer_id, total FROM orders WHERE status = 'pending';
+
+COMMENT ON TABLE customers IS 'Registered customers';
+`)
+	if err != nil {
+		t.Fatalf("Failed to create existing schema: %v", err)
+	}
+
+	baselineFile := filepath.Join(t.TempDir(), "schema.sql")
+	config := &InitConfig{
+		Host:     host,
+		Port:     port,
+		DB:       dbname,
+		User:     user,
+		Password: password,
+		Schema:   "public",
+		File:     baselineFile,
+	}
+
+	// Dump → plan → assert empty
+	if err := InitBaseline(config, planPG); err != nil {
+		t.Fatalf("InitBaseline failed: %v", err)
+	}
+
+	content, err := os.ReadFile(baselineFile)
+	if err != nil {
+		t.Fatalf("Failed to read baseline file: %v", err)
+	}
+	if !strings.Contains(string(content), "CREATE TABLE IF NOT EXISTS orders") {
+		t.Errorf("Expected baseline to contain the orders table, got:\n%s", content)
+	}
+
+	// The verified dump is moved into place, leaving no temporary file behind
+	if entries, err := os.ReadDir(filepath.Dir(baselineFile)); err != nil || len(entries) != 1 {
+		t.Errorf("Expected only the baseline file in its directory, got %v (err: %v)", entries, err)
+	}
+
+	// Re-planning the baseline, as 'pgschema plan --baseline' does, reports no changes
+	migrationPlan, err := planCmd.GeneratePlan(config.planConfig(), planPG)
+	if err != nil {
+		t.Fatalf("Failed to generate plan: %v", err)
+	}
+	if err := planCmd.VerifyBaseline(migrationPlan, baselineFile); err != nil {
+		t.Errorf("Expected empty plan for baseline: %v", err)
+	}
+
+	// Refuses to overwrite an existing baseline without --force
+	if err := InitBaseline(config, planPG); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected error for existing baseline file, got: %v", err)
+	}
+	config.Force = true
+	if err := InitBaseline(config, planPG); err != nil {
+		t.Errorf("Expected --force to overwrite baseline, got: %v", err)
+	}
+
+	// Drift after adoption is reported by the baseline check
+	if _, err := conn.Exec(`ALTER TABLE customers ADD COLUMN nickname TEXT`); err != nil {
+		t.Fatalf("Failed to alter table: %v", err)
+	}
+	migrationPlan, err = planCmd.GeneratePlan(config.planConfig(), planPG)
+	if err != nil {
+		t.Fatalf("Failed to generate plan: %v", err)
+	}
+	if err := planCmd.VerifyBaseline(migrationPlan, baselineFile); err == nil {
+		t.Error("Expected baseline verification to fail after drift")
+	} else if !strings.Contains(err.Error(), "nickname") {
+		t.Errorf("Expected drift error to mention the dropped column, got: %v", err)
+	}
+}
@@ -250,6 +250,9 @@ func runPlanAndApplyTest(t *testing.T, ctx context.Context, container *struct {
 		}
 	}
 
+	// Plan flags of the test case from the optional options.json
+	options := testutil.LoadFixtureOptions(t, filepath.Dir(tc.oldFile))
+
 	// STEP 1: Apply old.sql to initialize database state
 	oldContent, err := os.ReadFile(tc.oldFile)
 	if err != nil {
@@ -266,17 +269,17 @@ func runPlanAndApplyTest(t *testing.T, ctx context.Context, container *struct {
 	// STEP 2: Test plan command with new.sql as target
 	// Note: setup.sql has already been executed to both databases in STEP 0,
 	// so we only need to use new.sql for plan and apply operations
-	testPlanOutputs(t, container, dbName, tc.newFile, tc.planSQLFile, tc.planJSONFile, tc.planTXTFile)
+	testPlanOutputs(t, container, dbName, tc.newFile, tc.planSQLFile, tc.planJSONFile, tc.planTXTFile, options)
 
 	if !*generate {
 		// STEP 3: Apply the migration using apply command
-		err = applySchemaChanges(containerHost, portMapped, dbName, container.User, container.Password, "public", tc.newFile)
+		err = applySchemaChanges(containerHost, portMapped, dbName, container.User, container.Password, "public", tc.newFile, options)
 		if err != nil {
 			t.Fatalf("Failed to apply schema changes using pgschema apply: %v", err)
 		}
 
 		// STEP 4: Test idempotency - plan should produce no changes
-		secondPlanOutput, err := generatePlanSQLFormatted(containerHost, portMapped, dbName, container.User, container.Password, "public", tc.newFile)
+		secondPlanOutput, err := generatePlanSQLFormatted(containerHost, portMapped, dbName, container.User, container.Password, "public", tc.newFile, options)
 		if err != nil {
 			t.Fatalf("Failed to generate plan SQL for idempotency check: %v", err)
 		}
@@ -295,7 +298,7 @@ func testPlanOutputs(t *testing.T, container *struct {
 	DBName   string
 	User     string
 	Password string
-}, dbName, schemaFile, planSQLFile, planJSONFile, planTXTFile string) {
+}, dbName, schemaFile, planSQLFile, planJSONFile, planTXTFile string, options testutil.FixtureOptions) {
 	containerHost := container.Host
 	portMapped := container.Port
 	// Set fixed timestamp for generate mode to ensure deterministic output
@@ -304,7 +307,7 @@ func testPlanOutputs(t *testing.T, container *struct {
 		defer os.Unsetenv("PGSCHEMA_TEST_TIME")
 	}
 	// Test SQL format
-	sqlFormattedOutput, err := generatePlanSQLFormatted(containerHost, portMapped, dbName, container.User, container.Password, "public", schemaFile)
+	sqlFormattedOutput, err := generatePlanSQLFormatted(containerHost, portMapped, dbName, container.User, container.Password, "public", schemaFile, options)
 	if err != nil {
 		t.Fatalf("Failed to generate plan SQL formatted output: %v", err)
 	}
@@ -337,7 +340,7 @@ func testPlanOutputs(t *testing.T, container *struct {
 	}
 
 	// Test human-readable format
-	humanOutput, err := generatePlanHuman(containerHost, portMapped, dbName, container.User, container.Password, "public", schemaFile)
+	humanOutput, err := generatePlanHuman(containerHost, portMapped, dbName, container.User, container.Password, "public", schemaFile, options)
 	if err != nil {
 		t.Fatalf("Failed to generate plan human output: %v", err)
 	}
@@ -370,7 +373,7 @@ func testPlanOutputs(t *testing.T, container *struct {
 	}
 
 	// Test JSON format
-	jsonOutput, err := generatePlanJSON(containerHost, portMapped, dbName, container.User, container.Password, "public", schemaFile)
+	jsonOutput, err := generatePlanJSON(containerHost, portMapped, dbName, container.User, container.Password, "public", schemaFile, options)
 	if err != nil {
 		t.Fatalf("Failed to generate plan JSON output: %v", err)
 	}
@@ -422,7 +425,7 @@ func testPlanOutputs(t *testing.T, container *struct {
 }
 
 // applySchemaChanges applies schema changes using the ApplyMigration API directly
-func applySchemaChanges(host string, port int, database, user, password, schema, schemaFile string) error {
+func applySchemaChanges(host string, port int, database, user, password, schema, schemaFile string, options testutil.FixtureOptions) error {
 	// Create apply configuration
 	config := &apply.ApplyConfig{
 		Host:            host,
@@ -437,6 +440,10 @@ func applySchemaChanges(host string, port int, database, user, password, schema,
 		Quiet:           true, // Suppress plan display and progress messages in tests
 		LockTimeout:     "",
 		ApplicationName: "pgschema",
+		// Plan flags of the test case
+		AllowUnsafeTypeChanges: options.AllowUnsafeTypeChanges,
+		CascadeDrops:           options.CascadeDrops,
+		DefaultTablespace:      options.DefaultTablespace,
 	}
 
 	// Call ApplyMigration API directly with shared embedded postgres
@@ -444,7 +451,7 @@ func applySchemaChanges(host string, port int, database, user, password, schema,
 }
 
 // generatePlanOutput generates plan output by calling GeneratePlan directly with shared embedded postgres
-func generatePlanOutput(host string, port int, database, user, password, schema, schemaFile, outputFlag string, extraArgs ...string) (string, error) {
+func generatePlanOutput(host string, port int, database, user, password, schema, schemaFile string, options testutil.FixtureOptions, outputFlag string, extraArgs ...string) (string, error) {
 	// Create plan configuration with shared embedded postgres for performance
 	config := &planCmd.PlanConfig{
 		Host:            host,
@@ -455,6 +462,13 @@ func generatePlanOutput(host string, port int, database, user, password, schema,
 		Schema:          schema,
 		File:            schemaFile,
 		ApplicationName: "pgschema",
+		// Plan flags of the test case
+		AllowUnsafeTypeChanges: options.AllowUnsafeTypeChanges,
+		CascadeDrops:           options.CascadeDrops,
+		DefaultTablespace:      options.DefaultTablespace,
+		Only:                   options.Only,
+		Filters:                options.Filters,
+		CommentOnly:            options.CommentOnly,
 	}
 
 	// Generate the plan (reuse shared embedded postgres for performance)
@@ -500,18 +514,18 @@ func generatePlanOutput(host string, port int, database, user, password, schema,
 }
 
 // generatePlanHuman generates plan human-readable output
-func generatePlanHuman(host string, port int, database, user, password, schema, schemaFile string) (string, error) {
-	return generatePlanOutput(host, port, database, user, password, schema, schemaFile, "--output-human", "--no-color")
+func generatePlanHuman(host string, port int, database, user, password, schema, schemaFile string, options testutil.FixtureOptions) (string, error) {
+	return generatePlanOutput(host, port, database, user, password, schema, schemaFile, options, "--output-human", "--no-color")
 }
 
 // generatePlanJSON generates plan JSON output
-func generatePlanJSON(host string, port int, database, user, password, schema, schemaFile string) (string, error) {
-	return generatePlanOutput(host, port, database, user, password, schema, schemaFile, "--output-json")
+func generatePlanJSON(host string, port int, database, user, password, schema, schemaFile string, options testutil.FixtureOptions) (string, error) {
+	return generatePlanOutput(host, port, database, user, password, schema, schemaFile, options, "--output-json")
 }
 
 // generatePlanSQLFormatted generates plan SQL output
-func generatePlanSQLFormatted(host string, port int, database, user, password, schema, schemaFile string) (string, error) {
-	return generatePlanOutput(host, port, database, user, password, schema, schemaFile, "--output-sql")
+func generatePlanSQLFormatted(host string, port int, database, user, password, schema, schemaFile string, options testutil.FixtureOptions) (string, error) {
+	return generatePlanOutput(host, port, database, user, password, schema, schemaFile, options, "--output-sql")
 }
 
 // matchesFilter checks if a relative path matches the given filter pattern
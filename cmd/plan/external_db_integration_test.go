@@ -2,10 +2,13 @@ package plan
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/pgplex/pgschema/cmd/util"
 	"github.com/pgplex/pgschema/internal/postgres"
 	"github.com/pgplex/pgschema/testutil"
 	"github.com/stretchr/testify/assert"
@@ -103,13 +106,13 @@ func TestExternalDatabase_VersionMismatch(t *testing.T) {
 	targetHost, targetPort, targetDatabase, targetUser, targetPassword := targetDB.GetConnectionDetails()
 
 	// Detect version from target database
-	pgVersion, err := postgres.DetectPostgresVersionFromDB(
-		targetHost,
-		targetPort,
-		targetDatabase,
-		targetUser,
-		targetPassword,
-	)
+	pgVersion, err := postgres.DetectPostgresVersionFromDB(&util.ConnectionConfig{
+		Host:     targetHost,
+		Port:     targetPort,
+		Database: targetDatabase,
+		User:     targetUser,
+		Password: targetPassword,
+	})
 	require.NoError(t, err, "should detect PostgreSQL version")
 	assert.NotEmpty(t, pgVersion, "version should not be empty")
 }
@@ -218,3 +221,131 @@ func TestExternalDatabase_SchemaIsolation(t *testing.T) {
 	schema2 := extDB2.GetSchemaName()
 	assert.NotEqual(t, schema1, schema2, "temporary schemas should have unique names")
 }
+
+// schemaExists reports whether the named schema exists in the database
+func schemaExists(t *testing.T, conn *sql.DB, schemaName string) bool {
+	t.Helper()
+
+	var exists bool
+	err := conn.QueryRowContext(context.Background(),
+		"SELECT EXISTS (SELECT 1 FROM pg_namespace WHERE nspname = $1)", schemaName).Scan(&exists)
+	require.NoError(t, err)
+	return exists
+}
+
+// TestExternalDatabase_TempSchemaDroppedAfterFailedApply tests that the temporary schema
+// does not leak when the desired state SQL fails to apply
+func TestExternalDatabase_TempSchemaDroppedAfterFailedApply(t *testing.T) {
+	// Skip in short mode
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	externalPlanDB := testutil.SetupPostgres(t)
+	defer externalPlanDB.Stop()
+
+	conn, planHost, planPort, planDatabase, planUser, planPassword := testutil.ConnectToPostgres(t, externalPlanDB)
+	defer conn.Close()
+
+	majorVersion, err := testutil.GetMajorVersion(conn)
+	require.NoError(t, err, "should detect PostgreSQL major version")
+
+	extDB, err := postgres.NewExternalDatabase(&postgres.ExternalDatabaseConfig{
+		Host:               planHost,
+		Port:               planPort,
+		Database:           planDatabase,
+		Username:           planUser,
+		Password:           planPassword,
+		TargetMajorVersion: majorVersion,
+	})
+	require.NoError(t, err)
+	defer extDB.Stop()
+
+	tempSchema := extDB.GetSchemaName()
+
+	// Reference a type that does not exist so the apply fails after the schema is created
+	err = extDB.ApplySchema(context.Background(), "public", "CREATE TABLE test (id missing_type);")
+	require.Error(t, err, "applying invalid SQL should fail")
+	assert.True(t, schemaExists(t, conn, tempSchema), "temporary schema should exist before Stop")
+
+	require.NoError(t, extDB.Stop())
+	assert.False(t, schemaExists(t, conn, tempSchema), "temporary schema should be dropped after a failed apply")
+
+	// A second Stop (e.g. from a deferred call) must be a no-op
+	assert.NoError(t, extDB.Stop())
+}
+
+// TestExternalDatabase_KeepTempSchema tests that the temporary schema is retained when requested
+func TestExternalDatabase_KeepTempSchema(t *testing.T) {
+	// Skip in short mode
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	externalPlanDB := testutil.SetupPostgres(t)
+	defer externalPlanDB.Stop()
+
+	conn, planHost, planPort, planDatabase, planUser, planPassword := testutil.ConnectToPostgres(t, externalPlanDB)
+	defer conn.Close()
+
+	majorVersion, err := testutil.GetMajorVersion(conn)
+	require.NoError(t, err, "should detect PostgreSQL major version")
+
+	extDB, err := postgres.NewExternalDatabase(&postgres.ExternalDatabaseConfig{
+		Host:               planHost,
+		Port:               planPort,
+		Database:           planDatabase,
+		Username:           planUser,
+		Password:           planPassword,
+		TargetMajorVersion: majorVersion,
+		KeepTempSchema:     true,
+	})
+	require.NoError(t, err)
+
+	tempSchema := extDB.GetSchemaName()
+
+	err = extDB.ApplySchema(context.Background(), "public", "CREATE TABLE test (id missing_type);")
+	require.Error(t, err, "applying invalid SQL should fail")
+
+	require.NoError(t, extDB.Stop())
+	assert.True(t, schemaExists(t, conn, tempSchema), "temporary schema should be kept with KeepTempSchema")
+}
+
+// TestExternalDatabase_DropsStaleTempSchemas tests that temporary schemas leaked by
+// crashed runs are swept when connecting, while recent ones are left alone
+func TestExternalDatabase_DropsStaleTempSchemas(t *testing.T) {
+	// Skip in short mode
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	externalPlanDB := testutil.SetupPostgres(t)
+	defer externalPlanDB.Stop()
+
+	conn, planHost, planPort, planDatabase, planUser, planPassword := testutil.ConnectToPostgres(t, externalPlanDB)
+	defer conn.Close()
+
+	majorVersion, err := testutil.GetMajorVersion(conn)
+	require.NoError(t, err, "should detect PostgreSQL major version")
+
+	staleSchema := "pgschema_tmp_20000101_000000_deadbeef"
+	recentSchema := postgres.GenerateTempSchemaName()
+	for _, name := range []string{staleSchema, recentSchema} {
+		_, err := conn.ExecContext(context.Background(), fmt.Sprintf("CREATE SCHEMA \"%s\"", name))
+		require.NoError(t, err)
+	}
+
+	extDB, err := postgres.NewExternalDatabase(&postgres.ExternalDatabaseConfig{
+		Host:               planHost,
+		Port:               planPort,
+		Database:           planDatabase,
+		Username:           planUser,
+		Password:           planPassword,
+		TargetMajorVersion: majorVersion,
+	})
+	require.NoError(t, err)
+	defer extDB.Stop()
+
+	assert.False(t, schemaExists(t, conn, staleSchema), "stale temporary schema should be dropped")
+	assert.True(t, schemaExists(t, conn, recentSchema), "recent temporary schema should be kept")
+}
@@ -5,6 +5,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/pgplex/pgschema/internal/color"
+	"github.com/pgplex/pgschema/internal/diff"
+	"github.com/pgplex/pgschema/internal/plan"
+	"github.com/pgplex/pgschema/ir"
 )
 
 func TestDetermineOutputs(t *testing.T) {
@@ -116,4 +121,64 @@ func TestProcessOutput_FileCreation(t *testing.T) {
 	if string(content) != "test content" {
 		t.Errorf("expected 'test content', got '%s'", string(content))
 	}
-}
\ No newline at end of file
+}
+func TestProcessOutput_Color(t *testing.T) {
+	origColor, origNoColor := planColor, planNoColor
+	origStdout := os.Stdout
+	defer func() {
+		planColor, planNoColor = origColor, origNoColor
+		os.Stdout = origStdout
+	}()
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("NO_COLOR", "")
+
+	migrationPlan := plan.NewPlan([]diff.Diff{{
+		Type:       diff.DiffTypeTable,
+		Operation:  diff.DiffOperationCreate,
+		Path:       "public.posts",
+		Source:     &ir.Table{Schema: "public", Name: "posts"},
+		Statements: []diff.SQLStatement{{SQL: "CREATE TABLE IF NOT EXISTS posts (id integer);", CanRunInTransaction: true}},
+	}})
+
+	// captureStdout returns the human output written to stdout redirected to a file
+	captureStdout := func() string {
+		redirected := filepath.Join(t.TempDir(), "stdout.txt")
+		file, err := os.Create(redirected)
+		if err != nil {
+			t.Fatalf("failed to create output file: %v", err)
+		}
+		os.Stdout = file
+		err = processOutput(migrationPlan, outputSpec{format: "human", target: "stdout"}, PlanCmd)
+		os.Stdout = origStdout
+		file.Close()
+		if err != nil {
+			t.Fatalf("processOutput failed: %v", err)
+		}
+		content, err := os.ReadFile(redirected)
+		if err != nil {
+			t.Fatalf("failed to read output: %v", err)
+		}
+		return string(content)
+	}
+
+	tests := []struct {
+		name       string
+		color      string
+		noColor    bool
+		wantEscape bool
+	}{
+		{name: "auto when redirected", color: color.ModeAuto},
+		{name: "never", color: color.ModeNever},
+		{name: "no-color overrides always", color: color.ModeAlways, noColor: true},
+		{name: "always", color: color.ModeAlways, wantEscape: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planColor, planNoColor = tt.color, tt.noColor
+			output := captureStdout()
+			if hasEscape := strings.Contains(output, "\033["); hasEscape != tt.wantEscape {
+				t.Errorf("escape codes present = %v, want %v; output:\n%q", hasEscape, tt.wantEscape, output)
+			}
+		})
+	}
+}
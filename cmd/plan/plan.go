@@ -2,13 +2,16 @@ package plan
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/pgplex/pgschema/cmd/util"
+	"github.com/pgplex/pgschema/internal/color"
 	"github.com/pgplex/pgschema/internal/diff"
 	"github.com/pgplex/pgschema/internal/fingerprint"
 	"github.com/pgplex/pgschema/internal/include"
@@ -19,17 +22,53 @@ import (
 )
 
 var (
-	planHost     string
-	planPort     int
-	planDB       string
-	planUser     string
-	planPassword string
-	planSchema   string
-	planFile     string
-	outputHuman  string
-	outputJSON   string
-	outputSQL    string
-	planNoColor  bool
+	planHost      string
+	planPort      int
+	planDB        string
+	planUser      string
+	planPassword  string
+	planSchema    string
+	planFile      string
+	planBaseline  string
+	planDrift     bool
+	planCurrent   string
+	planSince     string
+	planPGVersion int
+	outputHuman   string
+	outputJSON    string
+	outputSQL     string
+	planSeparator string
+	planNoColor   bool
+	planColor     string
+	planSummary   bool
+
+	planKeepTempSchema bool
+	planOnly           []string
+	planFilters        []string
+	planCommentOnly    bool
+	planLintNaming     string
+	planFailOnWarning  bool
+	planMaxStmtLength  int
+	planAllowUnsafe    bool
+	planSafeFK         bool
+	planSemanticBody   bool
+	planCascadeDrops   []string
+	planTablespace     string
+	planSearchPath     string
+	planReverse        bool
+	planExplainOrder   bool
+	planValidateOnly   bool
+
+	planIgnoreExtensionObjects bool
+
+	// Duration estimates for table scans and rewrites
+	planEstimateDuration      bool
+	planEstimateRowsPerSecond int64
+
+	// Target database connection tuning
+	planSSLMode        string
+	planConnectTimeout time.Duration
+	planMaxConns       int
 
 	// Plan database flags (optional - if not provided, uses embedded postgres)
 	planDBHost     string
@@ -45,7 +84,16 @@ var PlanCmd = &cobra.Command{
 	Long:         "Generate a migration plan to apply a desired schema state to a target database schema. Compares the desired state (from --file) with the current state of a specific schema (specified by --schema, defaults to 'public').",
 	RunE:         runPlan,
 	SilenceUsage: true,
-	PreRunE:      util.PreRunEWithEnvVarsAndConnection(&planDB, &planUser, &planHost, &planPort),
+	PreRunE:      preRunPlan,
+}
+
+// preRunPlan validates the target database connection flags, which are not needed when the
+// current state comes from --current-file or --since, or when only the desired state is validated
+func preRunPlan(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("current-file") || cmd.Flags().Changed("since") || cmd.Flags().Changed("validate-only") {
+		return nil
+	}
+	return util.PreRunEWithEnvVarsAndConnection(&planDB, &planUser, &planHost, &planPort)(cmd, args)
 }
 
 func init() {
@@ -56,9 +104,18 @@ func init() {
 	PlanCmd.Flags().StringVar(&planUser, "user", "", "Database user name (required) (env: PGUSER)")
 	PlanCmd.Flags().StringVar(&planPassword, "password", "", "Database password (optional, can also use PGPASSWORD env var)")
 	PlanCmd.Flags().StringVar(&planSchema, "schema", "public", "Schema name")
+	PlanCmd.Flags().StringVar(&planSSLMode, "sslmode", util.DefaultSSLMode, "SSL mode for the target database connection: disable, allow, prefer, require, verify-ca, verify-full (env: PGSSLMODE)")
+	PlanCmd.Flags().DurationVar(&planConnectTimeout, "connection-timeout", util.DefaultConnectTimeout, "Timeout for connecting to the target database (env: PGCONNECT_TIMEOUT, in seconds)")
+	PlanCmd.Flags().IntVar(&planMaxConns, "max-conns", 0, "Maximum number of connections to the target database used for schema inspection (0 means unlimited)")
 
 	// Desired state schema file flag
-	PlanCmd.Flags().StringVar(&planFile, "file", "", "Path to desired state SQL schema file (required)")
+	PlanCmd.Flags().StringVar(&planFile, "file", "", "Path to desired state SQL schema file (required unless --baseline is used)")
+	PlanCmd.Flags().StringVar(&planFile, "desired-file", "", "Path to desired state SQL schema file (alias for --file)")
+	PlanCmd.Flags().StringVar(&planCurrent, "current-file", "", "Path to a SQL file describing the current state (e.g., a committed dump); plans offline without connecting to the target database")
+	PlanCmd.Flags().StringVar(&planSince, "since", "", "Git ref whose version of --file is the current state (e.g., origin/main); plans only the changes made to the file since then, without connecting to the target database")
+	PlanCmd.Flags().IntVar(&planPGVersion, "pg-version", 17, "PostgreSQL major version of the embedded instance when using --current-file, --since, or --validate-only (14-18)")
+	PlanCmd.Flags().StringVar(&planBaseline, "baseline", "", "Path to a baseline schema file (e.g., from pgschema init); fails if the database does not match it exactly")
+	PlanCmd.Flags().BoolVar(&planDrift, "check-drift", false, "Print a one-line JSON drift report of the objects that differ from --file instead of the plan, and exit with status 2 if there are any (for scheduled drift monitoring)")
 
 	// Plan database connection flags (optional - for using external database instead of embedded postgres)
 	PlanCmd.Flags().StringVar(&planDBHost, "plan-host", "", "Plan database host (env: PGSCHEMA_PLAN_HOST). If provided, uses external database instead of embedded postgres")
@@ -71,9 +128,46 @@ func init() {
 	PlanCmd.Flags().StringVar(&outputHuman, "output-human", "", "Output human-readable format to stdout or file path")
 	PlanCmd.Flags().StringVar(&outputJSON, "output-json", "", "Output JSON format to stdout or file path")
 	PlanCmd.Flags().StringVar(&outputSQL, "output-sql", "", "Output SQL format to stdout or file path")
-	PlanCmd.Flags().BoolVar(&planNoColor, "no-color", false, "Disable colored output")
-
-	PlanCmd.MarkFlagRequired("file")
+	PlanCmd.Flags().StringVar(&planSeparator, "statement-separator", string(plan.SeparatorBlankLine), "How statements are separated in the SQL output: "+strings.Join(plan.StatementSeparators(), ", "))
+	PlanCmd.Flags().StringVar(&planColor, "color", color.ModeAuto, "Color human output: auto (only on a terminal), always, or never")
+	PlanCmd.Flags().BoolVar(&planNoColor, "no-color", false, "Disable colored output (same as --color=never)")
+	PlanCmd.Flags().BoolVar(&planSummary, "summary-only", false, "Only output the change counts and the changed objects in human format, without the DDL")
+	PlanCmd.Flags().BoolVar(&planValidateOnly, "validate-only", false, "Only check that the desired state applies cleanly to an empty schema on the plan database, without contacting the target database")
+	PlanCmd.Flags().BoolVar(&planKeepTempSchema, "keep-temp-schema", false, "Keep the temporary pgschema_tmp_* schema used to validate the desired state (for debugging)")
+	PlanCmd.Flags().StringSliceVar(&planOnly, "only", nil, "Only include changes to these object categories (comma-separated): "+strings.Join(diff.ObjectCategories(), ", "))
+	PlanCmd.Flags().StringArrayVar(&planFilters, "filter", nil, "Only include changes to objects whose name matches a category:pattern glob (e.g., tables:users*); repeat to combine")
+	PlanCmd.Flags().BoolVar(&planCommentOnly, "comment-only", false, "Only include COMMENT ON changes, ignoring structural changes (e.g., to check that documentation is current)")
+	PlanCmd.Flags().StringVar(&planLintNaming, "lint-naming", "off", "Check constraint and index names against the patterns in "+util.LintFileName+" (off, warn, error)")
+	PlanCmd.Flags().BoolVar(&planFailOnWarning, "fail-on-warning", false, "Fail if planning reports any warning, such as a naming convention violation or a change that drops data")
+	PlanCmd.Flags().IntVar(&planMaxStmtLength, "max-statement-length", 0, "Warn about generated statements longer than this many bytes, which some clients and proxies reject (0 disables the check)")
+	PlanCmd.Flags().BoolVar(&planAllowUnsafe, "allow-unsafe-type-changes", false, "Allow column type changes without an implicit cast (e.g., text to integer), using the \"-- pgschema:using\" expression or an explicit cast")
+
+	PlanCmd.Flags().BoolVar(&planSemanticBody, "semantic-body-compare", false, "Ignore whitespace and comment differences in SQL and PL/pgSQL function and procedure bodies (and keyword case in SQL bodies)")
+	PlanCmd.Flags().StringSliceVar(&planCascadeDrops, "cascade-drops", nil, "Drop objects of these categories with CASCADE instead of RESTRICT, also dropping the columns that use them (comma-separated): "+strings.Join(diff.CascadeDropCategories(), ", "))
+	PlanCmd.Flags().StringVar(&planTablespace, "default-tablespace", "", "Create new tables and indexes in this tablespace; tablespaces of existing objects are not compared")
+	PlanCmd.Flags().StringVar(&planSearchPath, "search-path", "", "search_path the migration runs with (e.g., \"app, extensions\"); references to schemas on it are compared unqualified in column defaults, policies, and CHECK constraints")
+	PlanCmd.Flags().BoolVar(&planReverse, "reverse", false, "Generate the rollback plan that reverts the migration, warning about dropped data it cannot restore")
+	PlanCmd.Flags().BoolVar(&planExplainOrder, "explain-ordering", false, "Annotate each statement the dependency sort placed after an object created earlier in the plan with that statement and the reason (e.g., after CREATE TABLE customers because foreign key orders_customer_id_fkey references it)")
+	PlanCmd.Flags().BoolVar(&planSafeFK, "safe-fk", false, "Add all foreign keys on existing tables as NOT VALID first, then validate each one in its own transaction at the end of the plan")
+	PlanCmd.Flags().BoolVar(&planIgnoreExtensionObjects, "ignore-extension-objects", true, "Leave out tables, views, sequences and types that are members of an extension (set to false to compare them)")
+	PlanCmd.Flags().BoolVar(&planEstimateDuration, "estimate-duration", false, "Annotate steps that scan or rewrite existing tables with estimated_rows and estimated_duration_ms in the JSON plan, based on the target database's row estimates")
+	PlanCmd.Flags().Int64Var(&planEstimateRowsPerSecond, "estimate-rows-per-second", plan.DefaultEstimateRowsPerSecond, "Rows processed per second assumed by --estimate-duration")
+
+	PlanCmd.MarkFlagsOneRequired("file", "desired-file", "baseline")
+	PlanCmd.MarkFlagsMutuallyExclusive("file", "desired-file")
+	PlanCmd.MarkFlagsMutuallyExclusive("file", "baseline")
+	PlanCmd.MarkFlagsMutuallyExclusive("desired-file", "baseline")
+	PlanCmd.MarkFlagsMutuallyExclusive("current-file", "baseline")
+	PlanCmd.MarkFlagsMutuallyExclusive("current-file", "estimate-duration")
+	PlanCmd.MarkFlagsMutuallyExclusive("since", "current-file")
+	PlanCmd.MarkFlagsMutuallyExclusive("since", "baseline")
+	PlanCmd.MarkFlagsMutuallyExclusive("since", "estimate-duration")
+	for _, flag := range []string{"baseline", "current-file", "since", "reverse", "output-human", "output-json", "output-sql"} {
+		PlanCmd.MarkFlagsMutuallyExclusive("check-drift", flag)
+		PlanCmd.MarkFlagsMutuallyExclusive("validate-only", flag)
+	}
+	PlanCmd.MarkFlagsMutuallyExclusive("validate-only", "check-drift")
+	PlanCmd.MarkFlagsMutuallyExclusive("validate-only", "estimate-duration")
 }
 
 func runPlan(cmd *cobra.Command, args []string) error {
@@ -85,6 +179,47 @@ func runPlan(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Apply environment variables to connection tuning flags and validate them
+	util.ApplyConnectionEnvVars(cmd, &planSSLMode, &planConnectTimeout)
+	if err := util.ValidateConnectionFlags(planSSLMode, planConnectTimeout, planMaxConns); err != nil {
+		return err
+	}
+
+	// Validate object categories before doing any database work
+	if err := diff.ValidateCategories(planOnly); err != nil {
+		return err
+	}
+	if err := diff.ValidateFilters(planFilters); err != nil {
+		return err
+	}
+	if err := diff.ValidateCascadeDrops(planCascadeDrops); err != nil {
+		return err
+	}
+
+	if err := color.ValidateMode(planColor); err != nil {
+		return err
+	}
+
+	switch plan.StatementSeparator(planSeparator) {
+	case plan.SeparatorBlankLine, plan.SeparatorSemicolon, plan.SeparatorGexec:
+	default:
+		return fmt.Errorf("invalid --statement-separator value %q (must be one of: %s)", planSeparator, strings.Join(plan.StatementSeparators(), ", "))
+	}
+
+	switch planLintNaming {
+	case "off", "warn", "error":
+	default:
+		return fmt.Errorf("invalid --lint-naming value %q (must be off, warn, or error)", planLintNaming)
+	}
+
+	if planMaxStmtLength < 0 {
+		return fmt.Errorf("--max-statement-length must not be negative, got %d", planMaxStmtLength)
+	}
+
+	if planEstimateRowsPerSecond <= 0 {
+		return fmt.Errorf("--estimate-rows-per-second must be positive, got %d", planEstimateRowsPerSecond)
+	}
+
 	// Derive final password: use provided password or check environment variable
 	finalPassword := planPassword
 	if finalPassword == "" {
@@ -101,6 +236,36 @@ func runPlan(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// With --since, the current state is the desired state file as it was at the git ref
+	currentFile := planCurrent
+	if planSince != "" {
+		path, cleanup, err := checkoutFileAtRef(planFile, planSince)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		currentFile = path
+	}
+
+	// The notice describes the planning mode rather than the plan, so it is not a warning counted by
+	// --fail-on-warning, which would otherwise fail every offline plan
+	if currentFile != "" {
+		source := planCurrent
+		if planSince != "" {
+			source = fmt.Sprintf("%s at %s", planFile, planSince)
+		}
+		fmt.Fprintf(os.Stderr, "Note: the current state is read from %s, not the target database. "+
+			"Changes made to the database since that file was written are not detected, and expressions "+
+			"(defaults, checks, view and policy definitions) are normalized by the plan database's "+
+			"PostgreSQL version, which may differ from the target's.\n", source)
+	}
+
+	// A baseline is planned like any desired state file, but must produce no changes
+	file := planFile
+	if planBaseline != "" {
+		file = planBaseline
+	}
+
 	// Create plan configuration
 	config := &PlanConfig{
 		Host:            planHost,
@@ -109,14 +274,42 @@ func runPlan(cmd *cobra.Command, args []string) error {
 		User:            planUser,
 		Password:        finalPassword,
 		Schema:          planSchema,
-		File:            planFile,
+		File:            file,
+		CurrentFile:     currentFile,
+		PGVersion:       planPGVersion,
 		ApplicationName: "pgschema",
+		SSLMode:         planSSLMode,
+		ConnectTimeout:  planConnectTimeout,
+		MaxConns:        planMaxConns,
 		// Plan database configuration
 		PlanDBHost:     planDBHost,
 		PlanDBPort:     planDBPort,
 		PlanDBDatabase: planDBDatabase,
 		PlanDBUser:     planDBUser,
 		PlanDBPassword: finalPlanPassword,
+		KeepTempSchema: planKeepTempSchema,
+		Only:           planOnly,
+		Filters:        planFilters,
+		CommentOnly:    planCommentOnly,
+		LintNaming:     planLintNaming,
+		FailOnWarning:  planFailOnWarning,
+		MaxStmtLength:  planMaxStmtLength,
+		Reverse:        planReverse,
+		// Type change handling
+		AllowUnsafeTypeChanges: planAllowUnsafe,
+		SafeFK:                 planSafeFK,
+		SemanticBodyCompare:    planSemanticBody,
+		CascadeDrops:           planCascadeDrops,
+		DefaultTablespace:      planTablespace,
+		SearchPath:             planSearchPath,
+		// Duration estimates
+		EstimateDuration:      planEstimateDuration,
+		EstimateRowsPerSecond: planEstimateRowsPerSecond,
+		// Ordering annotations
+		ExplainOrdering: planExplainOrder,
+		ValidateOnly:    planValidateOnly,
+		// Extension members
+		IncludeExtensionObjects: !planIgnoreExtensionObjects,
 	}
 
 	// Create desired state provider (embedded postgres or external database)
@@ -126,11 +319,24 @@ func runPlan(cmd *cobra.Command, args []string) error {
 	}
 	defer provider.Stop()
 
+	if planValidateOnly {
+		if err := ValidateDesiredState(config, provider); err != nil {
+			return err
+		}
+		fmt.Printf("%s applies cleanly to an empty schema\n", planFile)
+		return nil
+	}
+
 	// Generate plan
 	migrationPlan, err := GeneratePlan(config, provider)
 	if err != nil {
 		return err
 	}
+	PrintWarnings(migrationPlan)
+
+	if planDrift {
+		return reportDrift(migrationPlan, planSchema, planFile)
+	}
 
 	// Determine which outputs to generate
 	outputs, err := determineOutputs()
@@ -145,9 +351,45 @@ func runPlan(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if planBaseline != "" {
+		return VerifyBaseline(migrationPlan, planBaseline)
+	}
+
 	return nil
 }
 
+// VerifyBaseline returns an error if the plan generated against a baseline file contains any changes,
+// i.e. the database has drifted from the baseline or the baseline does not round-trip.
+func VerifyBaseline(migrationPlan *plan.Plan, baselineFile string) error {
+	if !migrationPlan.HasAnyChanges() {
+		return nil
+	}
+	return fmt.Errorf("database does not match baseline %s; the following changes would be applied:\n%s",
+		baselineFile, migrationPlan.ToSQL(plan.SQLFormatRaw))
+}
+
+// driftExitCode is the exit status of --check-drift when the database has drifted; errors exit with 1
+const driftExitCode = 2
+
+// reportDrift prints the drift report of a plan generated against the committed schema file, and returns
+// an error with driftExitCode if any objects differ from it
+func reportDrift(migrationPlan *plan.Plan, schema, schemaFile string) error {
+	report := migrationPlan.DriftReport(schema)
+	content, err := report.ToJSON()
+	if err != nil {
+		return err
+	}
+	fmt.Println(content)
+
+	if !report.Drift {
+		return nil
+	}
+	return &util.ExitCodeError{
+		Code: driftExitCode,
+		Err:  fmt.Errorf("schema %s has drifted from %s: %d objects differ", schema, schemaFile, len(report.Changes)),
+	}
+}
+
 // PlanConfig holds configuration for plan generation
 type PlanConfig struct {
 	Host            string
@@ -158,27 +400,94 @@ type PlanConfig struct {
 	Schema          string
 	File            string
 	ApplicationName string
+	// CurrentFile is a SQL file describing the current state; if set, the target database is not contacted
+	CurrentFile string
+	// PGVersion is the embedded PostgreSQL major version used with CurrentFile (17 if zero)
+	PGVersion int
+	// Target database connection tuning (optional - defaults to sslmode=prefer, a 30s timeout, and an unlimited pool)
+	SSLMode        string
+	ConnectTimeout time.Duration
+	MaxConns       int
 	// Plan database configuration (optional - for external database)
 	PlanDBHost     string
 	PlanDBPort     int
 	PlanDBDatabase string
 	PlanDBUser     string
 	PlanDBPassword string
+	// KeepTempSchema skips dropping the temporary desired state schema (for debugging)
+	KeepTempSchema bool
+	// Only restricts the plan to changes in these object categories (e.g., "indexes"); empty means all
+	Only []string
+	// Filters restricts the plan to changes to objects matching "category:pattern" globs; empty means all
+	Filters []string
+	// CommentOnly restricts the plan to COMMENT ON changes across all object types
+	CommentOnly bool
+	// LintNaming checks desired state constraint and index names: "off" (or empty), "warn", or "error"
+	LintNaming string
+	// FailOnWarning fails the plan if any warning is reported, and reports changes that drop data as warnings
+	FailOnWarning bool
+	// MaxStmtLength warns about generated statements longer than this many bytes; zero disables the check
+	MaxStmtLength int
+	// Reverse generates the rollback plan, from the desired state back to the current state
+	Reverse bool
+	// ExplainOrdering annotates steps with the earlier statement the dependency sort placed them after
+	ExplainOrdering bool
+	// AllowUnsafeTypeChanges permits column type changes that need a USING clause
+	AllowUnsafeTypeChanges bool
+	// SemanticBodyCompare ignores whitespace and comment differences in function and procedure bodies
+	SemanticBodyCompare bool
+	// CascadeDrops lists the object categories dropped with CASCADE instead of RESTRICT (e.g., "types")
+	CascadeDrops []string
+	// DefaultTablespace is the tablespace new tables and indexes are created in
+	DefaultTablespace string
+	// SearchPath is the search_path the migration runs with; references to its schemas are compared unqualified
+	SearchPath string
+	// SafeFK batches NOT VALID foreign key adds before their validations, each validated in its own transaction
+	SafeFK bool
+	// EstimateDuration annotates table scans and rewrites with a duration estimate from the target's row counts
+	EstimateDuration bool
+	// EstimateRowsPerSecond is the processing rate used for estimates (plan.DefaultEstimateRowsPerSecond if zero)
+	EstimateRowsPerSecond int64
+	// ValidateOnly only applies the desired state to the plan database; the target is not contacted
+	ValidateOnly bool
+	// IncludeExtensionObjects compares the tables, views, sequences and types that are members of an extension
+	IncludeExtensionObjects bool
+}
+
+// TargetConnectionConfig returns the connection configuration for the target database
+func (c *PlanConfig) TargetConnectionConfig() *util.ConnectionConfig {
+	return &util.ConnectionConfig{
+		Host:            c.Host,
+		Port:            c.Port,
+		Database:        c.DB,
+		User:            c.User,
+		Password:        c.Password,
+		SSLMode:         c.SSLMode,
+		ApplicationName: c.ApplicationName,
+		ConnectTimeout:  c.ConnectTimeout,
+		MaxConns:        c.MaxConns,
+	}
+}
+
+// planDatabaseConfig returns the configuration of the external plan database given with --plan-host
+func (c *PlanConfig) planDatabaseConfig() *postgres.ExternalDatabaseConfig {
+	return &postgres.ExternalDatabaseConfig{
+		Host:           c.PlanDBHost,
+		Port:           c.PlanDBPort,
+		Database:       c.PlanDBDatabase,
+		Username:       c.PlanDBUser,
+		Password:       c.PlanDBPassword,
+		KeepTempSchema: c.KeepTempSchema,
+	}
 }
 
 // CreateDesiredStateProvider creates either an embedded PostgreSQL instance or connects to an external database
 // for validating the desired state schema. The caller is responsible for calling Stop() on the returned provider.
 func CreateDesiredStateProvider(config *PlanConfig) (postgres.DesiredStateProvider, error) {
 	// Detect target database PostgreSQL version (needed for both embedded and external)
-	pgVersion, err := postgres.DetectPostgresVersionFromDB(
-		config.Host,
-		config.Port,
-		config.DB,
-		config.User,
-		config.Password,
-	)
+	pgVersion, err := detectPlanPostgresVersion(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to detect PostgreSQL version: %w", err)
+		return nil, err
 	}
 
 	// Extract major version from the target database's version string (e.g., "16.9.0" -> 16).
@@ -191,14 +500,8 @@ func CreateDesiredStateProvider(config *PlanConfig) (postgres.DesiredStateProvid
 
 	// If plan-host is provided, use external database
 	if config.PlanDBHost != "" {
-		externalConfig := &postgres.ExternalDatabaseConfig{
-			Host:               config.PlanDBHost,
-			Port:               config.PlanDBPort,
-			Database:           config.PlanDBDatabase,
-			Username:           config.PlanDBUser,
-			Password:           config.PlanDBPassword,
-			TargetMajorVersion: targetMajorVersion,
-		}
+		externalConfig := config.planDatabaseConfig()
+		externalConfig.TargetMajorVersion = targetMajorVersion
 		return postgres.NewExternalDatabase(externalConfig)
 	}
 
@@ -206,15 +509,44 @@ func CreateDesiredStateProvider(config *PlanConfig) (postgres.DesiredStateProvid
 	return CreateEmbeddedPostgresForPlan(config, pgVersion)
 }
 
+// detectPlanPostgresVersion returns the PostgreSQL version the desired state is validated with.
+// It is the target database's version, unless the current state comes from a file or only the
+// desired state is validated: then the target is not contacted, and the plan database's version
+// (or the requested embedded version) is used.
+func detectPlanPostgresVersion(config *PlanConfig) (postgres.PostgresVersion, error) {
+	if config.CurrentFile == "" && !config.ValidateOnly {
+		pgVersion, err := postgres.DetectPostgresVersionFromDB(config.TargetConnectionConfig())
+		if err != nil {
+			return "", fmt.Errorf("failed to detect PostgreSQL version: %w", err)
+		}
+		return pgVersion, nil
+	}
+
+	if config.PlanDBHost != "" {
+		pgVersion, err := postgres.DetectPostgresVersionFromDB(config.planDatabaseConfig().ConnectionConfig())
+		if err != nil {
+			return "", fmt.Errorf("failed to detect plan database PostgreSQL version: %w", err)
+		}
+		return pgVersion, nil
+	}
+
+	majorVersion := config.PGVersion
+	if majorVersion == 0 {
+		majorVersion = 17
+	}
+	return postgres.EmbeddedVersionForMajor(majorVersion)
+}
+
 // CreateEmbeddedPostgresForPlan creates a temporary embedded PostgreSQL instance
 // for validating the desired state schema. The instance should be stopped by the caller.
 func CreateEmbeddedPostgresForPlan(config *PlanConfig, pgVersion postgres.PostgresVersion) (*postgres.EmbeddedPostgres, error) {
 	// Start embedded PostgreSQL with matching version
 	embeddedConfig := &postgres.EmbeddedPostgresConfig{
-		Version:  pgVersion,
-		Database: "pgschema_temp",
-		Username: "pgschema",
-		Password: "pgschema",
+		Version:        pgVersion,
+		Database:       "pgschema_temp",
+		Username:       "pgschema",
+		Password:       "pgschema",
+		KeepTempSchema: config.KeepTempSchema,
 	}
 	embeddedPG, err := postgres.StartEmbeddedPostgres(embeddedConfig)
 	if err != nil {
@@ -233,6 +565,7 @@ func GeneratePlan(config *PlanConfig, provider postgres.DesiredStateProvider) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to load .pgschemaignore: %w", err)
 	}
+	ignoreConfig = util.WithExtensionObjects(ignoreConfig, !config.IncludeExtensionObjects)
 
 	// Process desired state file with include directives
 	processor := include.NewProcessor(filepath.Dir(config.File))
@@ -241,10 +574,10 @@ func GeneratePlan(config *PlanConfig, provider postgres.DesiredStateProvider) (*
 		return nil, fmt.Errorf("failed to process desired state schema file: %w", err)
 	}
 
-	// Get current state from target database
-	currentStateIR, err := util.GetIRFromDatabase(config.Host, config.Port, config.DB, config.User, config.Password, config.Schema, config.ApplicationName, ignoreConfig)
+	// Get current state from the current state file or the target database
+	currentStateIR, err := inspectCurrentState(config, provider, ignoreConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current state from database: %w", err)
+		return nil, err
 	}
 
 	// Compute fingerprint of current database state
@@ -253,10 +586,238 @@ func GeneratePlan(config *PlanConfig, provider postgres.DesiredStateProvider) (*
 		return nil, fmt.Errorf("failed to compute source fingerprint: %w", err)
 	}
 
+	// Apply the desired state to the provider and inspect it
+	desiredStateIR, err := InspectDesiredState(provider, config.Schema, desiredState, config.ApplicationName, ignoreConfig)
+	if err != nil {
+		return nil, locateApplyError(err, processor)
+	}
+
+	// References to schemas on the search path resolve without a qualifier, so both states drop them
+	if searchPath := ir.ParseSearchPath(config.SearchPath); len(searchPath) > 0 {
+		ir.NormalizeSearchPath(currentStateIR, searchPath)
+		ir.NormalizeSearchPath(desiredStateIR, searchPath)
+	}
+
+	// Every warning is recorded through warn, so that the plan carries them all for the command to
+	// print and --fail-on-warning counts exactly those
+	var warnings []string
+	warn := func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+
+	// Check the desired state against the configured naming conventions
+	if config.LintNaming != "" && config.LintNaming != "off" {
+		violations, err := lintNaming(desiredStateIR)
+		if err != nil {
+			return nil, err
+		}
+		var descriptions []string
+		for _, violation := range violations {
+			descriptions = append(descriptions, violation.String())
+		}
+		if config.LintNaming == "error" && len(descriptions) > 0 {
+			return nil, fmt.Errorf("found %d naming convention violation(s):\n  %s", len(descriptions), strings.Join(descriptions, "\n  "))
+		}
+		for _, description := range descriptions {
+			warn("%s", description)
+		}
+	}
+
+	// USING expressions for column type changes come from "-- pgschema:using" directives
+	usingExpressions, err := parseUsingDirectives(desiredState, config.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process desired state schema file: %w", err)
+	}
+
+	// Generate diff (current -> desired) using IR directly
+	diffs, err := generateDiffs(config, currentStateIR, desiredStateIR, usingExpressions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Strict pipelines are also warned about changes that discard data
+	if config.FailOnWarning && !config.Reverse {
+		for _, d := range diff.FilterDataLoss(diffs) {
+			warn("the migration drops %s %s and its data", strings.TrimPrefix(d.Type.String(), "table."), d.Path)
+		}
+	}
+
+	// The rollback plan is the diff with desired and current swapped. USING expressions only apply
+	// forward. It runs against the migrated database, whose state is not known until the migration
+	// is applied, so the plan carries no fingerprint and apply does not check it for drift.
+	if config.Reverse {
+		for _, d := range diff.FilterDataLoss(diffs) {
+			warn("the migration drops %s %s; the rollback recreates it without its data",
+				strings.TrimPrefix(d.Type.String(), "table."), d.Path)
+		}
+		diffs, err = generateDiffs(config, desiredStateIR, currentStateIR, nil)
+		if err != nil {
+			return nil, err
+		}
+		sourceFingerprint = nil
+	}
+
+	// Create plan from diffs with fingerprint
+	migrationPlan := plan.NewPlanWithOptions(diffs, plan.Options{SafeForeignKeys: config.SafeFK})
+	migrationPlan.SourceFingerprint = sourceFingerprint
+
+	if config.ExplainOrdering {
+		migrationPlan.ExplainOrdering()
+	}
+
+	// Estimate how long table scans and rewrites will take from the target's planner statistics
+	if config.EstimateDuration {
+		rowCounts, err := util.GetTableRowEstimates(config.TargetConnectionConfig(), config.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate statement durations: %w", err)
+		}
+		migrationPlan.EstimateDurations(rowCounts, config.EstimateRowsPerSecond)
+	}
+
+	// Long statements cannot be split safely, so they are only reported
+	for _, step := range migrationPlan.LongStatements(config.MaxStmtLength) {
+		warn("the statement for %s %s is %d bytes, longer than --max-statement-length=%d; "+
+			"consider shortening it in the desired state, e.g. by moving a long IN list into a lookup table",
+			step.Type, step.Path, len(step.SQL), config.MaxStmtLength)
+	}
+	for _, groupNum := range migrationPlan.LongGroups(config.MaxStmtLength) {
+		group := migrationPlan.Groups[groupNum-1]
+		warn("the %d statements of group %d are sent as a single call of %d bytes, longer than --max-statement-length=%d",
+			len(group.Steps), groupNum, len(group.ConcatenatedSQL()), config.MaxStmtLength)
+	}
+
+	if config.FailOnWarning && len(warnings) > 0 {
+		return nil, fmt.Errorf("planning reported %d warning(s) and --fail-on-warning is set:\n  %s", len(warnings), strings.Join(warnings, "\n  "))
+	}
+	migrationPlan.Warnings = warnings
+	migrationPlan.SearchPath = config.SearchPath
+
+	return migrationPlan, nil
+}
+
+// generateDiffs diffs oldIR to newIR with the plan's options and narrows the result to the
+// requested categories, name filters, and comment-only changes
+func generateDiffs(config *PlanConfig, oldIR, newIR *ir.IR, usingExpressions map[string]string) ([]diff.Diff, error) {
+	// Structural changes are discarded in comment-only mode, so type changes never need manual intervention
+	diffs, err := diff.GenerateMigrationWithOptions(oldIR, newIR, config.Schema, diff.MigrationOptions{
+		AllowUnsafeTypeChanges: config.AllowUnsafeTypeChanges || config.CommentOnly,
+		UsingExpressions:       usingExpressions,
+		SemanticBodyCompare:    config.SemanticBodyCompare,
+		CascadeDrops:           config.CascadeDrops,
+		DefaultTablespace:      config.DefaultTablespace,
+	})
+	if err != nil {
+		var unsafeErr *diff.UnsafeTypeChangeError
+		if errors.As(err, &unsafeErr) {
+			return nil, fmt.Errorf("%w; add a \"-- pgschema:using table.column = expression\" directive to the schema file if needed and rerun with --allow-unsafe-type-changes", err)
+		}
+		return nil, err
+	}
+
+	// Restrict the plan to the requested object categories so migrations can be staged
+	if len(config.Only) > 0 {
+		diffs, err = diff.FilterByCategory(diffs, config.Only)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Focus the plan on the objects matching the name filters
+	if len(config.Filters) > 0 {
+		diffs, err = diff.FilterByPattern(diffs, config.Filters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Drop structural changes so only documentation drift is reported
+	if config.CommentOnly {
+		diffs = diff.FilterComments(diffs)
+	}
+
+	return diffs, nil
+}
+
+// inspectCurrentState returns the IR of the current state. With a current state file, the file is
+// applied to the provider and inspected the same way as the desired state; otherwise the target
+// database is inspected.
+func inspectCurrentState(config *PlanConfig, provider postgres.DesiredStateProvider, ignoreConfig *ir.IgnoreConfig) (*ir.IR, error) {
+	if config.CurrentFile == "" {
+		currentStateIR, err := util.GetIRFromDatabaseWithConfig(config.TargetConnectionConfig(), config.Schema, ignoreConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current state from database: %w", err)
+		}
+		return currentStateIR, nil
+	}
+
+	processor := include.NewProcessor(filepath.Dir(config.CurrentFile))
+	currentState, err := processor.ProcessFile(config.CurrentFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process current state file: %w", err)
+	}
+	currentStateIR, err := InspectDesiredState(provider, config.Schema, currentState, config.ApplicationName, ignoreConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current state from %s: %w", config.CurrentFile, err)
+	}
+	return currentStateIR, nil
+}
+
+// PrintWarnings prints the warnings reported while generating a plan on stderr
+func PrintWarnings(migrationPlan *plan.Plan) {
+	for _, warning := range migrationPlan.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+}
+
+// lintNaming returns the constraint and index names that don't match the patterns in the lint file
+func lintNaming(desiredStateIR *ir.IR) ([]ir.NamingViolation, error) {
+	namingConfig, err := util.LoadNamingConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", util.LintFileName, err)
+	}
+	if namingConfig == nil {
+		return nil, fmt.Errorf("--lint-naming requires naming patterns in %s", util.LintFileName)
+	}
+	return namingConfig.Lint(desiredStateIR)
+}
+
+// ValidateDesiredState applies the desired state file to an empty temporary schema on the provider
+// and inspects the result, returning the first error with the location of the failing statement.
+// It is a check that the file creates cleanly from scratch, independent of any target database.
+func ValidateDesiredState(config *PlanConfig, provider postgres.DesiredStateProvider) error {
+	ignoreConfig, err := util.LoadIgnoreFileWithStructure()
+	if err != nil {
+		return fmt.Errorf("failed to load .pgschemaignore: %w", err)
+	}
+	ignoreConfig = util.WithExtensionObjects(ignoreConfig, !config.IncludeExtensionObjects)
+
+	processor := include.NewProcessor(filepath.Dir(config.File))
+	desiredState, err := processor.ProcessFile(config.File)
+	if err != nil {
+		return fmt.Errorf("failed to process desired state schema file: %w", err)
+	}
+
+	_, err = InspectDesiredState(provider, config.Schema, desiredState, config.ApplicationName, ignoreConfig)
+	return locateApplyError(err, processor)
+}
+
+// locateApplyError reports the lines of an error from applying the desired state as lines of the
+// files they were read from, since \i includes were expanded before the SQL was applied
+func locateApplyError(err error, processor *include.Processor) error {
+	var applyErr *postgres.ApplyError
+	if errors.As(err, &applyErr) {
+		applyErr.Locate = processor.Location
+	}
+	return err
+}
+
+// InspectDesiredState applies the desired state SQL to the provider's temporary schema,
+// inspects it, and returns the resulting IR with schema names mapped back to targetSchema.
+func InspectDesiredState(provider postgres.DesiredStateProvider, targetSchema, desiredState, applicationName string, ignoreConfig *ir.IgnoreConfig) (*ir.IR, error) {
 	ctx := context.Background()
 
 	// Apply desired state SQL to the provider (embedded postgres or external database)
-	if err := provider.ApplySchema(ctx, config.Schema, desiredState); err != nil {
+	if err := provider.ApplySchema(ctx, targetSchema, desiredState); err != nil {
 		return nil, fmt.Errorf("failed to apply desired state: %w", err)
 	}
 
@@ -268,10 +829,10 @@ func GeneratePlan(config *PlanConfig, provider postgres.DesiredStateProvider) (*
 	// (e.g., pgschema_tmp_20251030_154501_123456789) to ensure isolation and prevent conflicts.
 	schemaToInspect := provider.GetSchemaName()
 	if schemaToInspect == "" {
-		schemaToInspect = config.Schema
+		schemaToInspect = targetSchema
 	}
 
-	desiredStateIR, err := util.GetIRFromDatabase(providerHost, providerPort, providerDB, providerUsername, providerPassword, schemaToInspect, config.ApplicationName, ignoreConfig)
+	desiredStateIR, err := util.GetIRFromDatabase(providerHost, providerPort, providerDB, providerUsername, providerPassword, schemaToInspect, applicationName, ignoreConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get desired state: %w", err)
 	}
@@ -281,17 +842,11 @@ func GeneratePlan(config *PlanConfig, provider postgres.DesiredStateProvider) (*
 	// because that's where objects were created. We need to replace these with the target
 	// schema name (e.g., "public") so that generated DDL references the correct schema.
 	// Without this normalization, DDL would reference non-existent temporary schemas and fail.
-	if schemaToInspect != config.Schema {
-		normalizeSchemaNames(desiredStateIR, schemaToInspect, config.Schema)
+	if schemaToInspect != targetSchema {
+		normalizeSchemaNames(desiredStateIR, schemaToInspect, targetSchema)
 	}
 
-	// Generate diff (current -> desired) using IR directly
-	diffs := diff.GenerateMigration(currentStateIR, desiredStateIR, config.Schema)
-
-	// Create plan from diffs with fingerprint
-	migrationPlan := plan.NewPlanWithFingerprint(diffs, sourceFingerprint)
-
-	return migrationPlan, nil
+	return desiredStateIR, nil
 }
 
 // outputSpec represents a single output specification
@@ -348,9 +903,17 @@ func processOutput(migrationPlan *plan.Plan, output outputSpec, cmd *cobra.Comma
 	// Generate content based on format
 	switch output.format {
 	case "human":
-		// For human format, use colored output when writing to stdout, unless explicitly disabled
-		useColor := output.target == "stdout" && !planNoColor
-		content = migrationPlan.HumanColored(useColor)
+		// For human format, use colored output when writing to stdout, unless disabled or redirected
+		colorMode := planColor
+		if planNoColor {
+			colorMode = color.ModeNever
+		}
+		useColor := output.target == "stdout" && color.Enabled(colorMode, os.Stdout)
+		if planSummary {
+			content = migrationPlan.SummaryColored(useColor)
+		} else {
+			content = migrationPlan.HumanColored(useColor)
+		}
 	case "json":
 		// Check if debug flag is set on the root command
 		debug, _ := cmd.Root().PersistentFlags().GetBool("debug")
@@ -360,7 +923,7 @@ func processOutput(migrationPlan *plan.Plan, output outputSpec, cmd *cobra.Comma
 		}
 		content += "\n"
 	case "sql":
-		content = migrationPlan.ToSQL(plan.SQLFormatRaw)
+		content = migrationPlan.ToSQLWithSeparator(plan.SQLFormatRaw, plan.StatementSeparator(planSeparator))
 	default:
 		return fmt.Errorf("unknown output format: %s", output.format)
 	}
@@ -433,6 +996,7 @@ func normalizeSchemaNames(irData *ir.IR, fromSchema, toSchema string) {
 					constraint.ReferencedSchema = toSchema
 				}
 				constraint.CheckClause = stripQualifiers(replaceString(constraint.CheckClause))
+				constraint.ExclusionDefinition = stripQualifiers(replaceString(constraint.ExclusionDefinition))
 			}
 
 			// Normalize schema references in table dependencies
@@ -449,6 +1013,11 @@ func normalizeSchemaNames(irData *ir.IR, fromSchema, toSchema string) {
 				}
 			}
 
+			// Normalize the parent reference of partitions
+			if table.PartitionOf != nil && table.PartitionOf.ParentSchema == fromSchema {
+				table.PartitionOf.ParentSchema = toSchema
+			}
+
 			// Normalize column data types and expressions
 			for _, column := range table.Columns {
 				column.DataType = replaceString(column.DataType)
@@ -466,6 +1035,9 @@ func normalizeSchemaNames(irData *ir.IR, fromSchema, toSchema string) {
 					index.Schema = toSchema
 				}
 				index.Where = replaceString(index.Where)
+				for _, column := range index.Columns {
+					column.Name = stripQualifiers(replaceString(column.Name))
+				}
 			}
 
 			// Normalize schema names in triggers
@@ -498,6 +1070,9 @@ func normalizeSchemaNames(irData *ir.IR, fromSchema, toSchema string) {
 					index.Schema = toSchema
 				}
 				index.Where = replaceString(index.Where)
+				for _, column := range index.Columns {
+					column.Name = stripQualifiers(replaceString(column.Name))
+				}
 			}
 
 			// Normalize schema names in view triggers (e.g., INSTEAD OF triggers)
@@ -673,11 +1248,40 @@ func ResetFlags() {
 	planUser = ""
 	planPassword = ""
 	planSchema = "public"
+	planSSLMode = util.DefaultSSLMode
+	planConnectTimeout = util.DefaultConnectTimeout
+	planMaxConns = 0
 	planFile = ""
+	planBaseline = ""
+	planDrift = false
+	planCurrent = ""
+	planSince = ""
+	planPGVersion = 17
 	outputHuman = ""
 	outputJSON = ""
 	outputSQL = ""
+	planSeparator = string(plan.SeparatorBlankLine)
 	planNoColor = false
+	planColor = color.ModeAuto
+	planSummary = false
+	planOnly = nil
+	planFilters = nil
+	planCommentOnly = false
+	planLintNaming = "off"
+	planFailOnWarning = false
+	planMaxStmtLength = 0
+	planAllowUnsafe = false
+	planSafeFK = false
+	planSemanticBody = false
+	planCascadeDrops = nil
+	planTablespace = ""
+	planSearchPath = ""
+	planReverse = false
+	planExplainOrder = false
+	planValidateOnly = false
+	planIgnoreExtensionObjects = true
+	planEstimateDuration = false
+	planEstimateRowsPerSecond = plan.DefaultEstimateRowsPerSecond
 	planDBHost = ""
 	planDBPort = 5432
 	planDBDatabase = ""
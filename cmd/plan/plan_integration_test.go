@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/pgplex/pgschema/internal/plan"
 	"github.com/pgplex/pgschema/testutil"
 	"github.com/spf13/cobra"
 )
@@ -437,3 +439,222 @@ func TestPlanCommand_EmptyDatabase(t *testing.T) {
 
 	t.Log("Plan command executed successfully on empty database")
 }
+
+// TestPlanCommand_CurrentFile plans offline: the current state comes from a dump file,
+// and the only difference from the desired state is one extra index.
+func TestPlanCommand_CurrentFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	embeddedPG := testutil.SetupPostgres(t)
+	defer embeddedPG.Stop()
+
+	baselineSQL := `
+CREATE TABLE users (
+    id integer PRIMARY KEY,
+    email text NOT NULL,
+    created_at timestamptz DEFAULT now()
+);
+
+CREATE INDEX idx_users_email ON users (email);
+`
+	desiredSQL := baselineSQL + `
+CREATE INDEX idx_users_created_at ON users (created_at);
+`
+
+	tmpDir := t.TempDir()
+	currentFile := filepath.Join(tmpDir, "prod_dump.sql")
+	desiredFile := filepath.Join(tmpDir, "schema.sql")
+	if err := os.WriteFile(currentFile, []byte(baselineSQL), 0644); err != nil {
+		t.Fatalf("Failed to write current state file: %v", err)
+	}
+	if err := os.WriteFile(desiredFile, []byte(desiredSQL), 0644); err != nil {
+		t.Fatalf("Failed to write desired state file: %v", err)
+	}
+
+	// No target connection details: the target database must not be contacted
+	config := &PlanConfig{
+		Schema:          "public",
+		File:            desiredFile,
+		CurrentFile:     currentFile,
+		ApplicationName: "pgschema-test",
+	}
+
+	migrationPlan, err := GeneratePlan(config, embeddedPG)
+	if err != nil {
+		t.Fatalf("Failed to generate plan from current state file: %v", err)
+	}
+
+	expected := "CREATE INDEX IF NOT EXISTS idx_users_created_at ON users (created_at);"
+	if sql := strings.TrimSpace(migrationPlan.ToSQL(plan.SQLFormatRaw)); sql != expected {
+		t.Errorf("Expected only the missing index to be created, got:\n%s", sql)
+	}
+
+	// Planning the baseline against itself produces no changes
+	config.File = currentFile
+	migrationPlan, err = GeneratePlan(config, embeddedPG)
+	if err != nil {
+		t.Fatalf("Failed to generate plan: %v", err)
+	}
+	if migrationPlan.HasAnyChanges() {
+		t.Errorf("Expected no changes when current and desired files match, got:\n%s", migrationPlan.ToSQL(plan.SQLFormatRaw))
+	}
+}
+
+// TestPlanCommand_Reverse checks that the rollback plan of adding a column and an index drops them again.
+func TestPlanCommand_Reverse(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	embeddedPG := testutil.SetupPostgres(t)
+	defer embeddedPG.Stop()
+
+	currentSQL := `
+CREATE TABLE users (
+    id integer PRIMARY KEY
+);
+`
+	desiredSQL := `
+CREATE TABLE users (
+    id integer PRIMARY KEY,
+    email text
+);
+
+CREATE INDEX idx_users_email ON users (email);
+`
+
+	tmpDir := t.TempDir()
+	currentFile := filepath.Join(tmpDir, "current.sql")
+	desiredFile := filepath.Join(tmpDir, "schema.sql")
+	if err := os.WriteFile(currentFile, []byte(currentSQL), 0644); err != nil {
+		t.Fatalf("Failed to write current state file: %v", err)
+	}
+	if err := os.WriteFile(desiredFile, []byte(desiredSQL), 0644); err != nil {
+		t.Fatalf("Failed to write desired state file: %v", err)
+	}
+
+	config := &PlanConfig{
+		Schema:          "public",
+		File:            desiredFile,
+		CurrentFile:     currentFile,
+		ApplicationName: "pgschema-test",
+		Reverse:         true,
+	}
+
+	migrationPlan, err := GeneratePlan(config, embeddedPG)
+	if err != nil {
+		t.Fatalf("Failed to generate rollback plan: %v", err)
+	}
+
+	sql := migrationPlan.ToSQL(plan.SQLFormatRaw)
+	if !strings.Contains(sql, "ALTER TABLE users DROP COLUMN email;") {
+		t.Errorf("Expected the rollback to drop the added column, got:\n%s", sql)
+	}
+	if !strings.Contains(sql, "DROP INDEX IF EXISTS idx_users_email;") {
+		t.Errorf("Expected the rollback to drop the added index, got:\n%s", sql)
+	}
+	if strings.Contains(sql, "ADD COLUMN") || strings.Contains(sql, "CREATE INDEX") {
+		t.Errorf("Expected no forward changes in the rollback plan, got:\n%s", sql)
+	}
+	if migrationPlan.SourceFingerprint != nil {
+		t.Error("Expected the rollback plan to carry no source fingerprint")
+	}
+}
+
+// TestPlanCommand_FailOnWarning checks that a plan dropping a column fails with FailOnWarning and
+// succeeds without it.
+func TestPlanCommand_FailOnWarning(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	embeddedPG := testutil.SetupPostgres(t)
+	defer embeddedPG.Stop()
+
+	tmpDir := t.TempDir()
+	currentFile := filepath.Join(tmpDir, "current.sql")
+	desiredFile := filepath.Join(tmpDir, "schema.sql")
+	if err := os.WriteFile(currentFile, []byte("CREATE TABLE users (id integer PRIMARY KEY, legacy_code text);\n"), 0644); err != nil {
+		t.Fatalf("Failed to write current state file: %v", err)
+	}
+	if err := os.WriteFile(desiredFile, []byte("CREATE TABLE users (id integer PRIMARY KEY);\n"), 0644); err != nil {
+		t.Fatalf("Failed to write desired state file: %v", err)
+	}
+
+	config := &PlanConfig{
+		Schema:          "public",
+		File:            desiredFile,
+		CurrentFile:     currentFile,
+		ApplicationName: "pgschema-test",
+	}
+	if _, err := GeneratePlan(config, embeddedPG); err != nil {
+		t.Fatalf("Expected the plan to succeed without --fail-on-warning, got: %v", err)
+	}
+
+	config.FailOnWarning = true
+	_, err := GeneratePlan(config, embeddedPG)
+	if err == nil || !strings.Contains(err.Error(), "1 warning(s)") {
+		t.Errorf("Expected dropping a column to fail the plan with --fail-on-warning, got: %v", err)
+	}
+
+	// A plan without warnings still succeeds
+	config.CurrentFile = desiredFile
+	if _, err := GeneratePlan(config, embeddedPG); err != nil {
+		t.Errorf("Expected a plan without warnings to succeed, got: %v", err)
+	}
+}
+
+// TestPlanCommand_ValidateOnly checks that --validate-only reports a type from a missing extension
+// with the line and statement that use it, and accepts a file that applies cleanly.
+func TestPlanCommand_ValidateOnly(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	embeddedPG := testutil.SetupPostgres(t)
+	defer embeddedPG.Stop()
+
+	tmpDir := t.TempDir()
+	desiredFile := filepath.Join(tmpDir, "schema.sql")
+	desiredSQL := `CREATE TABLE teams (id integer PRIMARY KEY);
+
+CREATE TABLE users (
+    id integer PRIMARY KEY,
+    email citext NOT NULL
+);
+`
+	if err := os.WriteFile(desiredFile, []byte(desiredSQL), 0644); err != nil {
+		t.Fatalf("Failed to write desired state file: %v", err)
+	}
+
+	// No target connection details: only the plan database is used
+	config := &PlanConfig{
+		Schema:          "public",
+		File:            desiredFile,
+		ApplicationName: "pgschema-test",
+		ValidateOnly:    true,
+	}
+
+	err := ValidateDesiredState(config, embeddedPG)
+	if err == nil {
+		t.Fatal("Expected the missing citext type to fail validation")
+	}
+	for _, expected := range []string{
+		`type "citext" does not exist`,
+		"at line 5: email citext NOT NULL",
+		"in statement at line 3: CREATE TABLE users (",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain %q, got:\n%v", expected, err)
+		}
+	}
+
+	if err := os.WriteFile(desiredFile, []byte(strings.ReplaceAll(desiredSQL, "citext", "text")), 0644); err != nil {
+		t.Fatalf("Failed to write desired state file: %v", err)
+	}
+	if err := ValidateDesiredState(config, embeddedPG); err != nil {
+		t.Errorf("Expected the desired state to validate, got: %v", err)
+	}
+}
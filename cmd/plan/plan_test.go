@@ -87,6 +87,120 @@ func TestPlanCommand(t *testing.T) {
 	if outputSQLFlag == nil {
 		t.Error("Expected --output-sql flag to be defined")
 	}
+
+	keepTempSchemaFlag := flags.Lookup("keep-temp-schema")
+	if keepTempSchemaFlag == nil {
+		t.Error("Expected --keep-temp-schema flag to be defined")
+	} else if keepTempSchemaFlag.DefValue != "false" {
+		t.Errorf("Expected default keep-temp-schema to be 'false', got '%s'", keepTempSchemaFlag.DefValue)
+	}
+
+	onlyFlag := flags.Lookup("only")
+	if onlyFlag == nil {
+		t.Error("Expected --only flag to be defined")
+	} else if onlyFlag.DefValue != "[]" {
+		t.Errorf("Expected default only to be '[]', got '%s'", onlyFlag.DefValue)
+	}
+
+	commentOnlyFlag := flags.Lookup("comment-only")
+	if commentOnlyFlag == nil {
+		t.Error("Expected --comment-only flag to be defined")
+	} else if commentOnlyFlag.DefValue != "false" {
+		t.Errorf("Expected default comment-only to be 'false', got '%s'", commentOnlyFlag.DefValue)
+	}
+
+	lintNamingFlag := flags.Lookup("lint-naming")
+	if lintNamingFlag == nil {
+		t.Error("Expected --lint-naming flag to be defined")
+	} else {
+		if lintNamingFlag.DefValue != "off" {
+			t.Errorf("Expected default lint-naming to be 'off', got '%s'", lintNamingFlag.DefValue)
+		}
+		if lintNamingFlag.NoOptDefVal != "" {
+			t.Errorf("Expected --lint-naming to require a value, got optional default '%s'", lintNamingFlag.NoOptDefVal)
+		}
+	}
+
+	allowUnsafeFlag := flags.Lookup("allow-unsafe-type-changes")
+	if allowUnsafeFlag == nil {
+		t.Error("Expected --allow-unsafe-type-changes flag to be defined")
+	} else if allowUnsafeFlag.DefValue != "false" {
+		t.Errorf("Expected default allow-unsafe-type-changes to be 'false', got '%s'", allowUnsafeFlag.DefValue)
+	}
+
+	currentFileFlag := flags.Lookup("current-file")
+	if currentFileFlag == nil {
+		t.Error("Expected --current-file flag to be defined")
+	}
+
+	desiredFileFlag := flags.Lookup("desired-file")
+	if desiredFileFlag == nil {
+		t.Error("Expected --desired-file flag to be defined")
+	}
+
+	pgVersionFlag := flags.Lookup("pg-version")
+	if pgVersionFlag == nil {
+		t.Error("Expected --pg-version flag to be defined")
+	} else if pgVersionFlag.DefValue != "17" {
+		t.Errorf("Expected default pg-version to be '17', got '%s'", pgVersionFlag.DefValue)
+	}
+
+	semanticBodyFlag := flags.Lookup("semantic-body-compare")
+	if semanticBodyFlag == nil {
+		t.Error("Expected --semantic-body-compare flag to be defined")
+	} else if semanticBodyFlag.DefValue != "false" {
+		t.Errorf("Expected default semantic-body-compare to be 'false', got '%s'", semanticBodyFlag.DefValue)
+	}
+
+	// Test connection tuning flags
+	sslModeFlag := flags.Lookup("sslmode")
+	if sslModeFlag == nil {
+		t.Error("Expected --sslmode flag to be defined")
+	} else if sslModeFlag.DefValue != "prefer" {
+		t.Errorf("Expected default sslmode to be 'prefer', got '%s'", sslModeFlag.DefValue)
+	}
+
+	connectionTimeoutFlag := flags.Lookup("connection-timeout")
+	if connectionTimeoutFlag == nil {
+		t.Error("Expected --connection-timeout flag to be defined")
+	} else if connectionTimeoutFlag.DefValue != "30s" {
+		t.Errorf("Expected default connection-timeout to be '30s', got '%s'", connectionTimeoutFlag.DefValue)
+	}
+
+	maxConnsFlag := flags.Lookup("max-conns")
+	if maxConnsFlag == nil {
+		t.Error("Expected --max-conns flag to be defined")
+	} else if maxConnsFlag.DefValue != "0" {
+		t.Errorf("Expected default max-conns to be '0', got '%s'", maxConnsFlag.DefValue)
+	}
+
+	safeFKFlag := flags.Lookup("safe-fk")
+	if safeFKFlag == nil {
+		t.Error("Expected --safe-fk flag to be defined")
+	} else if safeFKFlag.DefValue != "false" {
+		t.Errorf("Expected default safe-fk to be 'false', got '%s'", safeFKFlag.DefValue)
+	}
+
+	estimateDurationFlag := flags.Lookup("estimate-duration")
+	if estimateDurationFlag == nil {
+		t.Error("Expected --estimate-duration flag to be defined")
+	} else if estimateDurationFlag.DefValue != "false" {
+		t.Errorf("Expected default estimate-duration to be 'false', got '%s'", estimateDurationFlag.DefValue)
+	}
+
+	ignoreExtensionFlag := flags.Lookup("ignore-extension-objects")
+	if ignoreExtensionFlag == nil {
+		t.Error("Expected --ignore-extension-objects flag to be defined")
+	} else if ignoreExtensionFlag.DefValue != "true" {
+		t.Errorf("Expected default ignore-extension-objects to be 'true', got '%s'", ignoreExtensionFlag.DefValue)
+	}
+
+	estimateRateFlag := flags.Lookup("estimate-rows-per-second")
+	if estimateRateFlag == nil {
+		t.Error("Expected --estimate-rows-per-second flag to be defined")
+	} else if estimateRateFlag.DefValue != "100000" {
+		t.Errorf("Expected default estimate-rows-per-second to be '100000', got '%s'", estimateRateFlag.DefValue)
+	}
 }
 
 func TestPlanCommandRequiredFlags(t *testing.T) {
@@ -199,3 +313,52 @@ func TestPlanCommandFileError(t *testing.T) {
 		t.Error("Expected error when file doesn't exist, but got none")
 	}
 }
+
+func TestPreRunPlanCurrentFile(t *testing.T) {
+	planDB = ""
+	planUser = ""
+	t.Setenv("PGDATABASE", "")
+	t.Setenv("PGUSER", "")
+
+	testCmd := &cobra.Command{Use: "plan"}
+	testCmd.Flags().String("current-file", "", "")
+	testCmd.Flags().String("db", "", "")
+	testCmd.Flags().String("user", "", "")
+	testCmd.Flags().String("host", "", "")
+	testCmd.Flags().Int("port", 0, "")
+
+	// Without --current-file, the target database connection is required
+	if err := preRunPlan(testCmd, nil); err == nil {
+		t.Error("Expected error when --db is missing without --current-file")
+	}
+
+	// With --current-file, the target database is not contacted
+	if err := testCmd.Flags().Set("current-file", "prod_dump.sql"); err != nil {
+		t.Fatal(err)
+	}
+	if err := preRunPlan(testCmd, nil); err != nil {
+		t.Errorf("Expected no connection flags to be required with --current-file, got: %v", err)
+	}
+}
+
+func TestPreRunPlanValidateOnly(t *testing.T) {
+	planDB = ""
+	planUser = ""
+	t.Setenv("PGDATABASE", "")
+	t.Setenv("PGUSER", "")
+
+	testCmd := &cobra.Command{Use: "plan"}
+	testCmd.Flags().Bool("validate-only", false, "")
+	testCmd.Flags().String("db", "", "")
+	testCmd.Flags().String("user", "", "")
+	testCmd.Flags().String("host", "", "")
+	testCmd.Flags().Int("port", 0, "")
+
+	// With --validate-only, only the plan database is used
+	if err := testCmd.Flags().Set("validate-only", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := preRunPlan(testCmd, nil); err != nil {
+		t.Errorf("Expected no connection flags to be required with --validate-only, got: %v", err)
+	}
+}
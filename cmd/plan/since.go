@@ -0,0 +1,137 @@
+package plan
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// checkoutFileAtRef writes the schema file as it was at a git ref to a temporary directory, along
+// with the repository's other .sql files at their paths relative to the repository root, so that
+// its \i includes resolve as they do in the working tree. It returns the path of the file in the
+// temporary directory and a function that removes the directory.
+func checkoutFileAtRef(file, ref string) (string, func(), error) {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get absolute path for %s: %w", file, err)
+	}
+	fileDir := filepath.Dir(absFile)
+
+	// The file's directory relative to the repository root, e.g. "db/", or empty at the root
+	prefix, err := runGit(fileDir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find the git repository of %s: %w", file, err)
+	}
+	relFile := strings.TrimSpace(prefix) + filepath.Base(absFile)
+
+	// Paths are listed relative to the repository root, along with the blob each one is stored in
+	listing, err := runGit(fileDir, "ls-tree", "-r", "-z", "--full-tree", ref)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list files at %s: %w", ref, err)
+	}
+
+	var paths, objects []string
+	found := false
+	for _, entry := range strings.Split(listing, "\x00") {
+		// Each entry is "<mode> <type> <object>\t<path>"
+		meta, path, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		if !strings.HasSuffix(path, ".sql") && path != relFile {
+			continue
+		}
+		paths = append(paths, path)
+		objects = append(objects, fields[2])
+		if path == relFile {
+			found = true
+		}
+	}
+	if !found {
+		return "", nil, fmt.Errorf("%s does not exist at %s", file, ref)
+	}
+
+	// All blobs are read by a single git process
+	contents, err := readGitBlobs(fileDir, objects)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read files at %s: %w", ref, err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "pgschema-since-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tempDir) }
+
+	for i, path := range paths {
+		target := filepath.Join(tempDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(target, contents[i], 0o644); err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return filepath.Join(tempDir, filepath.FromSlash(relFile)), cleanup, nil
+}
+
+// readGitBlobs returns the contents of the given blobs, in order, read with git cat-file --batch
+func readGitBlobs(dir string, objects []string) ([][]byte, error) {
+	output, err := runGitWithInput(dir, strings.Join(objects, "\n")+"\n", "cat-file", "--batch")
+	if err != nil {
+		return nil, err
+	}
+
+	// Each blob is output as "<object> <type> <size>\n<contents>\n"
+	reader := bufio.NewReader(strings.NewReader(output))
+	contents := make([][]byte, 0, len(objects))
+	for _, object := range objects {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read object %s: %w", object, err)
+		}
+		var oid, typ string
+		var size int
+		if _, err := fmt.Sscanf(header, "%s %s %d", &oid, &typ, &size); err != nil {
+			return nil, fmt.Errorf("object %s is missing: %s", object, strings.TrimSpace(header))
+		}
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			return nil, fmt.Errorf("failed to read object %s: %w", object, err)
+		}
+		contents = append(contents, content[:size])
+	}
+	return contents, nil
+}
+
+// runGit runs a git command in dir and returns its standard output
+func runGit(dir string, args ...string) (string, error) {
+	return runGitWithInput(dir, "", args...)
+}
+
+// runGitWithInput runs a git command in dir with input on its standard input and returns its
+// standard output
+func runGitWithInput(dir, input string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
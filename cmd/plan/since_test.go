@@ -0,0 +1,81 @@
+package plan
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/internal/include"
+)
+
+func TestCheckoutFileAtRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		if _, err := runGit(repo, args...); err != nil {
+			t.Fatalf("git %s: %v", strings.Join(args, " "), err)
+		}
+	}
+	write := func(path, content string) {
+		t.Helper()
+		full := filepath.Join(repo, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldTables := "CREATE TABLE users (id integer PRIMARY KEY);\n"
+	newTables := "CREATE TABLE users (id integer PRIMARY KEY, email text);\n"
+	schemaFile := filepath.Join(repo, "db", "schema.sql")
+
+	git("init", "-q")
+	write("db/schema.sql", "\\i tables/users.sql\n")
+	write("db/tables/users.sql", oldTables)
+	write("shared/types.sql", "CREATE TYPE status AS ENUM ('active');\n")
+	git("add", "-A")
+	git("commit", "-q", "-m", "old")
+	write("db/tables/users.sql", newTables)
+	git("commit", "-q", "-am", "new")
+
+	// The file at the earlier revision resolves its includes from that revision too
+	path, cleanup, err := checkoutFileAtRef(schemaFile, "HEAD~1")
+	if err != nil {
+		t.Fatalf("checkoutFileAtRef() error = %v", err)
+	}
+	defer cleanup()
+
+	content, err := include.NewProcessor(filepath.Dir(path)).ProcessFile(path)
+	if err != nil {
+		t.Fatalf("failed to process the file at HEAD~1: %v", err)
+	}
+	if content != oldTables {
+		t.Errorf("content at HEAD~1 = %q, want %q", content, oldTables)
+	}
+
+	// .sql files outside the file's directory are checked out at their place in the repository
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "..", "shared", "types.sql")); err != nil {
+		t.Errorf("expected shared/types.sql to be checked out at the repository root: %v", err)
+	}
+
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the temporary directory to be removed, got %v", err)
+	}
+
+	if _, _, err := checkoutFileAtRef(filepath.Join(repo, "db", "missing.sql"), "HEAD"); err == nil || !strings.Contains(err.Error(), "does not exist at HEAD") {
+		t.Errorf("expected an error for a file missing at the ref, got %v", err)
+	}
+	if _, _, err := checkoutFileAtRef(schemaFile, "no-such-ref"); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+}
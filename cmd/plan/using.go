@@ -0,0 +1,54 @@
+package plan
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// usingDirectivePattern matches "-- pgschema:using table.column = expression" lines in the desired state
+var usingDirectivePattern = regexp.MustCompile(`^\s*--\s*pgschema:using\s+([^\s=]+)\s*=\s*(.+?)\s*;?\s*$`)
+
+// parseUsingDirectives extracts the USING expressions for column type changes from
+// "-- pgschema:using [schema.]table.column = expression" directives in the desired state SQL.
+// The result is keyed by "schema.table.column"; unqualified names use defaultSchema.
+func parseUsingDirectives(desiredState, defaultSchema string) (map[string]string, error) {
+	expressions := make(map[string]string)
+	for lineNum, line := range strings.Split(desiredState, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "--") || !strings.Contains(trimmed, "pgschema:using") {
+			continue
+		}
+		matches := usingDirectivePattern.FindStringSubmatch(line)
+		if matches == nil {
+			return nil, fmt.Errorf("line %d: invalid pgschema:using directive, expected \"-- pgschema:using table.column = expression\"", lineNum+1)
+		}
+
+		parts := strings.Split(matches[1], ".")
+		for i, part := range parts {
+			parts[i] = unquoteDirectiveIdentifier(part)
+		}
+		switch len(parts) {
+		case 2:
+			parts = append([]string{defaultSchema}, parts...)
+		case 3:
+		default:
+			return nil, fmt.Errorf("line %d: pgschema:using directive must name a column as table.column or schema.table.column, got %q", lineNum+1, matches[1])
+		}
+
+		key := strings.Join(parts, ".")
+		if _, exists := expressions[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate pgschema:using directive for %s", lineNum+1, key)
+		}
+		expressions[key] = matches[2]
+	}
+	return expressions, nil
+}
+
+// unquoteDirectiveIdentifier strips double quotes from a quoted identifier and folds unquoted ones to lower case
+func unquoteDirectiveIdentifier(identifier string) string {
+	if len(identifier) >= 2 && strings.HasPrefix(identifier, `"`) && strings.HasSuffix(identifier, `"`) {
+		return strings.ReplaceAll(identifier[1:len(identifier)-1], `""`, `"`)
+	}
+	return strings.ToLower(identifier)
+}
@@ -0,0 +1,68 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseUsingDirectives(t *testing.T) {
+	desiredState := `CREATE TABLE users (
+    id integer PRIMARY KEY,
+    -- pgschema:using users.age = NULLIF(age, '')::integer
+    age integer
+);
+
+--pgschema:using billing."Invoices"."Total" = "Total"::numeric(10,2);
+CREATE TABLE billing."Invoices" ("Total" numeric(10,2));
+`
+	expressions, err := parseUsingDirectives(desiredState, "public")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"public.users.age":       "NULLIF(age, '')::integer",
+		"billing.Invoices.Total": `"Total"::numeric(10,2)`,
+	}
+	if len(expressions) != len(expected) {
+		t.Fatalf("expected %d expressions, got %d: %v", len(expected), len(expressions), expressions)
+	}
+	for key, want := range expected {
+		if got := expressions[key]; got != want {
+			t.Errorf("expression for %s: expected %q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestParseUsingDirectivesErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		desiredState  string
+		expectedError string
+	}{
+		{
+			name:          "missing expression",
+			desiredState:  "-- pgschema:using users.age",
+			expectedError: "line 1: invalid pgschema:using directive",
+		},
+		{
+			name:          "column without table",
+			desiredState:  "CREATE TABLE users (age integer);\n-- pgschema:using age = age::integer",
+			expectedError: "line 2: pgschema:using directive must name a column",
+		},
+		{
+			name:          "duplicate directive",
+			desiredState:  "-- pgschema:using users.age = age::integer\n-- pgschema:using public.users.age = 0",
+			expectedError: "duplicate pgschema:using directive for public.users.age",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseUsingDirectives(tt.desiredState, "public")
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("expected error containing %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
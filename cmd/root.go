@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -8,7 +9,10 @@ import (
 
 	"github.com/pgplex/pgschema/cmd/apply"
 	"github.com/pgplex/pgschema/cmd/dump"
+	"github.com/pgplex/pgschema/cmd/format"
+	"github.com/pgplex/pgschema/cmd/initcmd"
 	"github.com/pgplex/pgschema/cmd/plan"
+	"github.com/pgplex/pgschema/cmd/util"
 	globallogger "github.com/pgplex/pgschema/internal/logger"
 	"github.com/pgplex/pgschema/internal/version"
 	"github.com/spf13/cobra"
@@ -31,9 +35,11 @@ var RootCmd = &cobra.Command{
 Version: %s@%s %s %s
 
 Commands:
+  init    Adopt an existing database as a baseline
   dump    Dump PostgreSQL schema
   plan    Generate migration plan
   apply   Apply schema migrations
+  fmt     Canonicalize a schema file
 
 Use "pgschema [command] --help" for more information about a command.`,
 		version.App(), GitCommit, platform(), BuildDate),
@@ -49,6 +55,8 @@ func init() {
 	RootCmd.AddCommand(dump.DumpCmd)
 	RootCmd.AddCommand(plan.PlanCmd)
 	RootCmd.AddCommand(apply.ApplyCmd)
+	RootCmd.AddCommand(format.FmtCmd)
+	RootCmd.AddCommand(initcmd.InitCmd)
 }
 
 func setupLogger() {
@@ -85,6 +93,10 @@ func platform() string {
 
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
+		var exitErr *util.ExitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -43,7 +43,7 @@ func TestRootCommandWithoutArgs(t *testing.T) {
 func TestRootCommandHasSubcommands(t *testing.T) {
 	commands := RootCmd.Commands()
 
-	expectedCommands := []string{"dump", "plan", "apply"}
+	expectedCommands := []string{"init", "dump", "plan", "apply"}
 	commandNames := make([]string, len(commands))
 	for i, cmd := range commands {
 		commandNames[i] = cmd.Name()
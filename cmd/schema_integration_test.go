@@ -63,6 +63,7 @@ func TestNonPublicSchemaOperations(t *testing.T) {
 			password,
 			"tenant", // Non-public schema
 			desiredStateFile,
+			testutil.FixtureOptions{},
 		)
 		if err != nil {
 			t.Fatalf("Failed to generate plan via CLI: %v", err)
@@ -84,6 +85,7 @@ func TestNonPublicSchemaOperations(t *testing.T) {
 			password,
 			"tenant", // Non-public schema
 			desiredStateFile,
+			testutil.FixtureOptions{},
 		)
 		if err != nil {
 			t.Fatalf("Failed to apply changes via CLI: %v", err)
@@ -171,6 +173,7 @@ func TestNonPublicSchemaOperations(t *testing.T) {
 			password,
 			"app_a", // Target only app_a
 			desiredStateFile,
+			testutil.FixtureOptions{},
 		)
 		if err != nil {
 			t.Fatalf("Failed to apply changes to app_a: %v", err)
@@ -252,6 +255,7 @@ func TestNonPublicSchemaOperations(t *testing.T) {
 			password,
 			"MyApp", // Mixed-case schema
 			desiredStateFile,
+			testutil.FixtureOptions{},
 		)
 		if err != nil {
 			t.Fatalf("Failed to generate plan for mixed-case schema: %v", err)
@@ -273,6 +277,7 @@ func TestNonPublicSchemaOperations(t *testing.T) {
 			password,
 			"MyApp", // Mixed-case schema
 			desiredStateFile,
+			testutil.FixtureOptions{},
 		)
 		if err != nil {
 			t.Fatalf("Failed to apply changes to mixed-case schema: %v", err)
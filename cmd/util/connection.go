@@ -12,6 +12,12 @@ import (
 	"github.com/pgplex/pgschema/ir"
 )
 
+// DefaultConnectTimeout is the connection timeout used when ConnectionConfig.ConnectTimeout is not set
+const DefaultConnectTimeout = 30 * time.Second
+
+// DefaultSSLMode is the SSL mode used when ConnectionConfig.SSLMode is not set
+const DefaultSSLMode = "prefer"
+
 // ConnectionConfig holds database connection parameters
 type ConnectionConfig struct {
 	Host            string
@@ -19,8 +25,26 @@ type ConnectionConfig struct {
 	Database        string
 	User            string
 	Password        string
-	SSLMode         string
+	SSLMode         string // SSL mode of the connection (DefaultSSLMode if empty)
 	ApplicationName string
+	ConnectTimeout  time.Duration // Timeout for establishing a connection (DefaultConnectTimeout if zero)
+	MaxConns        int           // Maximum open connections in the pool (0 means unlimited)
+}
+
+// connectTimeout returns the configured connection timeout or the default
+func (c *ConnectionConfig) connectTimeout() time.Duration {
+	if c.ConnectTimeout > 0 {
+		return c.ConnectTimeout
+	}
+	return DefaultConnectTimeout
+}
+
+// sslMode returns the configured SSL mode or the default
+func (c *ConnectionConfig) sslMode() string {
+	if c.SSLMode != "" {
+		return c.SSLMode
+	}
+	return DefaultSSLMode
 }
 
 // Connect establishes a database connection using the provided configuration
@@ -32,8 +56,10 @@ func Connect(config *ConnectionConfig) (*sql.DB, error) {
 		"port", config.Port,
 		"database", config.Database,
 		"user", config.User,
-		"sslmode", config.SSLMode,
+		"sslmode", config.sslMode(),
 		"application_name", config.ApplicationName,
+		"connect_timeout", config.connectTimeout(),
+		"max_conns", config.MaxConns,
 	)
 
 	dsn := buildDSN(config)
@@ -43,8 +69,13 @@ func Connect(config *ConnectionConfig) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Limit the pool for managed databases with connection limits; concurrent inspection queries wait for a free connection
+	if config.MaxConns > 0 {
+		conn.SetMaxOpenConns(config.MaxConns)
+	}
+
 	// Test the connection with a timeout to fail fast if the database is unreachable
-	pingCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	pingCtx, cancel := context.WithTimeout(context.Background(), config.connectTimeout())
 	defer cancel()
 	if err := conn.PingContext(pingCtx); err != nil {
 		log.Debug("Database ping failed", "error", err)
@@ -69,17 +100,16 @@ func buildDSN(config *ConnectionConfig) string {
 		parts = append(parts, fmt.Sprintf("password=%s", config.Password))
 	}
 
-	if config.SSLMode != "" {
-		parts = append(parts, fmt.Sprintf("sslmode=%s", config.SSLMode))
-	}
+	parts = append(parts, fmt.Sprintf("sslmode=%s", config.sslMode()))
 
 	if config.ApplicationName != "" {
 		parts = append(parts, fmt.Sprintf("application_name=%s", config.ApplicationName))
 	}
 
 	// Set connect_timeout to fail fast if the database is unreachable.
-	// This is a libpq parameter that pgx respects for TCP connection establishment.
-	parts = append(parts, "connect_timeout=30")
+	// This is a libpq parameter (whole seconds) that pgx respects for TCP connection establishment.
+	timeoutSeconds := int((config.connectTimeout() + time.Second - 1) / time.Second)
+	parts = append(parts, fmt.Sprintf("connect_timeout=%d", timeoutSeconds))
 
 	return strings.Join(parts, " ")
 }
@@ -93,10 +123,15 @@ func GetIRFromDatabase(host string, port int, db, user, password, schemaName, ap
 		Database:        db,
 		User:            user,
 		Password:        password,
-		SSLMode:         "prefer",
 		ApplicationName: applicationName,
 	}
 
+	return GetIRFromDatabaseWithConfig(config, schemaName, ignoreConfig)
+}
+
+// GetIRFromDatabaseWithConfig gets the IR from a database using the given connection configuration,
+// so callers can set the SSL mode, connection timeout, and pool size
+func GetIRFromDatabaseWithConfig(config *ConnectionConfig, schemaName string, ignoreConfig *ir.IgnoreConfig) (*ir.IR, error) {
 	conn, err := Connect(config)
 	if err != nil {
 		return nil, err
@@ -121,3 +156,39 @@ func GetIRFromDatabase(host string, port int, db, user, password, schemaName, ap
 
 	return schemaIR, nil
 }
+
+// GetTableRowEstimates returns the planner's row estimate (pg_class.reltuples) for each table in the schema,
+// keyed by "schema.table". Tables that have never been vacuumed or analyzed are omitted.
+func GetTableRowEstimates(config *ConnectionConfig, schemaName string) (map[string]int64, error) {
+	conn, err := Connect(config)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(context.Background(), `
+		SELECT n.nspname, c.relname, c.reltuples::bigint
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1
+		  AND c.relkind IN ('r', 'p')
+		  AND c.reltuples > 0`, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table row estimates: %w", err)
+	}
+	defer rows.Close()
+
+	estimates := make(map[string]int64)
+	for rows.Next() {
+		var schema, table string
+		var count int64
+		if err := rows.Scan(&schema, &table, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan table row estimate: %w", err)
+		}
+		estimates[schema+"."+table] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read table row estimates: %w", err)
+	}
+	return estimates, nil
+}
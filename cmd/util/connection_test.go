@@ -0,0 +1,61 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildDSNConnectTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    string
+	}{
+		{"default", 0, "connect_timeout=30"},
+		{"whole seconds", 5 * time.Second, "connect_timeout=5"},
+		{"rounds up", 1500 * time.Millisecond, "connect_timeout=2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsn := buildDSN(&ConnectionConfig{
+				Host:           "localhost",
+				Port:           5432,
+				Database:       "postgres",
+				User:           "postgres",
+				SSLMode:        "prefer",
+				ConnectTimeout: tt.timeout,
+			})
+			if !strings.Contains(dsn, tt.want) {
+				t.Errorf("Expected DSN to contain %q, got %q", tt.want, dsn)
+			}
+		})
+	}
+}
+
+func TestBuildDSNSSLMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		sslMode string
+		want    string
+	}{
+		{"default", "", "sslmode=prefer"},
+		{"explicit", "verify-full", "sslmode=verify-full"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsn := buildDSN(&ConnectionConfig{
+				Host:     "localhost",
+				Port:     5432,
+				Database: "postgres",
+				User:     "postgres",
+				SSLMode:  tt.sslMode,
+			})
+			if !strings.Contains(dsn, tt.want) {
+				t.Errorf("Expected DSN to contain %q, got %q", tt.want, dsn)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -98,6 +99,33 @@ func ApplyPlanDBEnvVars(cmd *cobra.Command, hostPtr, dbPtr, userPtr, passwordPtr
 	}
 }
 
+// ApplyConnectionEnvVars applies the libpq PGSSLMODE and PGCONNECT_TIMEOUT (seconds) environment variables
+// to the --sslmode and --connection-timeout flags if they were not explicitly set
+func ApplyConnectionEnvVars(cmd *cobra.Command, sslModePtr *string, timeoutPtr *time.Duration) {
+	if GetEnvWithDefault("PGSSLMODE", "") != "" && !cmd.Flags().Changed("sslmode") {
+		*sslModePtr = GetEnvWithDefault("PGSSLMODE", "")
+	}
+	if seconds := GetEnvIntWithDefault("PGCONNECT_TIMEOUT", 0); seconds > 0 && !cmd.Flags().Changed("connection-timeout") {
+		*timeoutPtr = time.Duration(seconds) * time.Second
+	}
+}
+
+// ValidateConnectionFlags validates the --sslmode, --connection-timeout, and --max-conns flags
+func ValidateConnectionFlags(sslMode string, timeout time.Duration, maxConns int) error {
+	switch sslMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		return fmt.Errorf("invalid --sslmode value %q (must be disable, allow, prefer, require, verify-ca, or verify-full)", sslMode)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("--connection-timeout must be positive, got %s", timeout)
+	}
+	if maxConns < 0 {
+		return fmt.Errorf("--max-conns must not be negative, got %d", maxConns)
+	}
+	return nil
+}
+
 // ValidatePlanDBFlags validates plan database flags when plan-host is provided
 // Ensures required flags are present for external database usage
 func ValidatePlanDBFlags(planHost, planDB, planUser string) error {
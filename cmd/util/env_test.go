@@ -3,6 +3,9 @@ package util
 import (
 	"os"
 	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
 )
 
 func TestGetEnvWithDefault(t *testing.T) {
@@ -111,4 +114,66 @@ func TestPreRunEWithEnvVars(t *testing.T) {
 	if preRunFunc == nil {
 		t.Error("PreRunEWithEnvVarsAndConnectionAndApp should return a non-nil function")
 	}
-}
\ No newline at end of file
+}
+
+func TestApplyConnectionEnvVars(t *testing.T) {
+	newCmd := func(sslMode *string, timeout *time.Duration) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().StringVar(sslMode, "sslmode", "prefer", "")
+		cmd.Flags().DurationVar(timeout, "connection-timeout", DefaultConnectTimeout, "")
+		return cmd
+	}
+
+	t.Setenv("PGSSLMODE", "require")
+	t.Setenv("PGCONNECT_TIMEOUT", "5")
+
+	// Environment variables apply when the flags were not set
+	var sslMode string
+	var timeout time.Duration
+	cmd := newCmd(&sslMode, &timeout)
+	ApplyConnectionEnvVars(cmd, &sslMode, &timeout)
+	if sslMode != "require" {
+		t.Errorf("Expected sslmode 'require' from PGSSLMODE, got '%s'", sslMode)
+	}
+	if timeout != 5*time.Second {
+		t.Errorf("Expected connection timeout 5s from PGCONNECT_TIMEOUT, got %s", timeout)
+	}
+
+	// Explicit flags take precedence over environment variables
+	cmd = newCmd(&sslMode, &timeout)
+	if err := cmd.Flags().Parse([]string{"--sslmode=disable", "--connection-timeout=2s"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	ApplyConnectionEnvVars(cmd, &sslMode, &timeout)
+	if sslMode != "disable" {
+		t.Errorf("Expected explicit sslmode 'disable', got '%s'", sslMode)
+	}
+	if timeout != 2*time.Second {
+		t.Errorf("Expected explicit connection timeout 2s, got %s", timeout)
+	}
+}
+
+func TestValidateConnectionFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		sslMode  string
+		timeout  time.Duration
+		maxConns int
+		wantErr  bool
+	}{
+		{"defaults", "prefer", DefaultConnectTimeout, 0, false},
+		{"verify-full with pool", "verify-full", 5 * time.Second, 4, false},
+		{"invalid sslmode", "strict", DefaultConnectTimeout, 0, true},
+		{"zero timeout", "prefer", 0, 0, true},
+		{"negative max conns", "prefer", DefaultConnectTimeout, -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConnectionFlags(tt.sslMode, tt.timeout, tt.maxConns)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConnectionFlags(%q, %s, %d) error = %v, wantErr %v", tt.sslMode, tt.timeout, tt.maxConns, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package util
+
+// ExitCodeError is an error that makes pgschema exit with a specific status instead of 1, so scripts
+// can tell an expected outcome (e.g., drift detected) apart from a failure
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
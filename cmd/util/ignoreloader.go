@@ -1,6 +1,7 @@
 package util
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/BurntSushi/toml"
@@ -99,5 +100,23 @@ func LoadIgnoreFileWithStructureFromPath(filePath string) (*ir.IgnoreConfig, err
 		Sequences:  tomlConfig.Sequences.Patterns,
 	}
 
+	// Compile patterns up front so an invalid regular expression is reported when loading
+	if err := config.Compile(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+
 	return config, nil
 }
+
+// WithExtensionObjects returns the ignore configuration set to keep or leave out the tables, views,
+// sequences and types that are members of an extension, creating an empty configuration if needed
+func WithExtensionObjects(config *ir.IgnoreConfig, ignore bool) *ir.IgnoreConfig {
+	if ignore && config == nil {
+		return nil
+	}
+	if config == nil {
+		config = &ir.IgnoreConfig{}
+	}
+	config.IncludeExtensionObjects = !ignore
+	return config
+}
@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/pgplex/pgschema/ir"
 )
 
 func TestLoadIgnoreFile_FileNotExists(t *testing.T) {
@@ -215,4 +217,41 @@ patterns = ["temp_*"]
 	if len(config.Functions) != 0 {
 		t.Errorf("Expected empty functions patterns, got %v", config.Functions)
 	}
-}
\ No newline at end of file
+}
+func TestLoadIgnoreFile_InvalidRegex(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "regex.pgschemaignore")
+
+	err := os.WriteFile(testFile, []byte("[tables]\npatterns = [\"/^tmp_(/\"]\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	config, err := LoadIgnoreFileFromPath(testFile)
+	if err == nil {
+		t.Error("LoadIgnoreFileFromPath() should return error for an invalid regex pattern")
+	}
+	if config != nil {
+		t.Error("LoadIgnoreFileFromPath() should return nil config for an invalid regex pattern")
+	}
+}
+
+func TestWithExtensionObjects(t *testing.T) {
+	if !WithExtensionObjects(nil, true).ShouldIgnoreExtensionObjects() {
+		t.Error("extension objects should be ignored by default")
+	}
+
+	config := WithExtensionObjects(nil, false)
+	if config == nil || config.ShouldIgnoreExtensionObjects() {
+		t.Error("WithExtensionObjects(nil, false) should return a config that keeps extension objects")
+	}
+
+	loaded := &ir.IgnoreConfig{Tables: []string{"temp_*"}}
+	config = WithExtensionObjects(loaded, false)
+	if config != loaded || config.ShouldIgnoreExtensionObjects() {
+		t.Error("WithExtensionObjects should keep the loaded patterns and include extension objects")
+	}
+	if !config.ShouldIgnoreTable("temp_orders") {
+		t.Error("loaded ignore patterns should still apply")
+	}
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pgplex/pgschema/ir"
+)
+
+const (
+	// LintFileName is the default name of the lint configuration file
+	LintFileName = ".pgschemalint"
+)
+
+// LintTomlConfig represents the TOML structure of the .pgschemalint file
+type LintTomlConfig struct {
+	Naming ir.NamingConfig `toml:"naming,omitempty"`
+}
+
+// LoadNamingConfig loads the naming patterns from the .pgschemalint file in the current directory
+// Returns nil if the file doesn't exist
+func LoadNamingConfig() (*ir.NamingConfig, error) {
+	return LoadNamingConfigFromPath(LintFileName)
+}
+
+// LoadNamingConfigFromPath loads the naming patterns from the specified lint file
+// Returns nil if the file doesn't exist
+func LoadNamingConfigFromPath(filePath string) (*ir.NamingConfig, error) {
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var tomlConfig LintTomlConfig
+	if _, err := toml.DecodeFile(filePath, &tomlConfig); err != nil {
+		return nil, err
+	}
+
+	config := tomlConfig.Naming
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
@@ -0,0 +1,56 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadNamingConfig_FileNotExists(t *testing.T) {
+	config, err := LoadNamingConfigFromPath(filepath.Join(t.TempDir(), LintFileName))
+	if err != nil {
+		t.Fatalf("LoadNamingConfigFromPath() should not error when file doesn't exist, got: %v", err)
+	}
+	if config != nil {
+		t.Error("LoadNamingConfigFromPath() should return nil config when file doesn't exist")
+	}
+}
+
+func TestLoadNamingConfig_ValidTOML(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), LintFileName)
+	tomlContent := `[naming]
+foreign_keys = "^fk_"
+indexes = "^idx_"
+`
+	if err := os.WriteFile(testFile, []byte(tomlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	config, err := LoadNamingConfigFromPath(testFile)
+	if err != nil {
+		t.Fatalf("LoadNamingConfigFromPath() error = %v", err)
+	}
+	if config == nil {
+		t.Fatal("LoadNamingConfigFromPath() returned nil config")
+	}
+	if config.ForeignKeys != "^fk_" {
+		t.Errorf("Expected foreign_keys pattern '^fk_', got %q", config.ForeignKeys)
+	}
+	if config.Indexes != "^idx_" {
+		t.Errorf("Expected indexes pattern '^idx_', got %q", config.Indexes)
+	}
+	if config.PrimaryKeys != "" {
+		t.Errorf("Expected empty primary_keys pattern, got %q", config.PrimaryKeys)
+	}
+}
+
+func TestLoadNamingConfig_InvalidPattern(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), LintFileName)
+	if err := os.WriteFile(testFile, []byte("[naming]\nindexes = \"idx_(\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	if _, err := LoadNamingConfigFromPath(testFile); err == nil {
+		t.Error("LoadNamingConfigFromPath() should error for an invalid regular expression")
+	}
+}
@@ -7,9 +7,15 @@ import (
 	"github.com/pgplex/pgschema/internal/logger"
 )
 
+// SQLExecutor is implemented by *sql.DB and *sql.Conn, so statements can run either on
+// the connection pool or on a dedicated connection that carries session settings.
+type SQLExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
 // ExecContextWithLogging executes SQL with debug logging if debug mode is enabled.
 // It logs the SQL statement before execution and the result/error after execution.
-func ExecContextWithLogging(ctx context.Context, db *sql.DB, sqlStmt string, description string) (sql.Result, error) {
+func ExecContextWithLogging(ctx context.Context, db SQLExecutor, sqlStmt string, description string) (sql.Result, error) {
 	isDebug := logger.IsDebug()
 	if isDebug {
 		logger.Get().Debug("Executing SQL", "description", description, "sql", sqlStmt)
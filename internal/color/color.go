@@ -19,6 +19,13 @@ const (
 	Bold    = "\033[1m"
 )
 
+// Values of the --color flag
+const (
+	ModeAuto   = "auto"   // Color output written to a terminal (default)
+	ModeAlways = "always" // Color output even when it is redirected
+	ModeNever  = "never"  // Never color output
+)
+
 // Color represents a colorizer that can be enabled or disabled
 type Color struct {
 	enabled bool
@@ -26,7 +33,30 @@ type Color struct {
 
 // New creates a new Color instance
 func New(enabled bool) *Color {
-	return &Color{enabled: enabled && shouldEnableColor()}
+	return &Color{enabled: enabled}
+}
+
+// ValidateMode returns an error if mode is not a valid --color value
+func ValidateMode(mode string) error {
+	switch mode {
+	case ModeAuto, ModeAlways, ModeNever:
+		return nil
+	default:
+		return fmt.Errorf("invalid --color value %q (must be auto, always, or never)", mode)
+	}
+}
+
+// Enabled reports whether output written to out should be colored in the given mode. In auto mode,
+// output is colored only when out is a terminal and the environment does not disable color.
+func Enabled(mode string, out *os.File) bool {
+	switch mode {
+	case ModeAlways:
+		return true
+	case ModeNever:
+		return false
+	default:
+		return shouldEnableColor() && isTerminal(out)
+	}
 }
 
 // shouldEnableColor determines if color should be enabled based on environment
@@ -38,14 +68,19 @@ func shouldEnableColor() bool {
 
 	// Check TERM environment variable
 	term := os.Getenv("TERM")
-	if term == "dumb" || term == "" {
+	return term != "dumb" && term != ""
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe
+func isTerminal(f *os.File) bool {
+	if f == nil {
 		return false
 	}
-
-	// Check if output is to a terminal
-	// This is a simplified check - in a real implementation you might use
-	// a package like github.com/mattn/go-isatty
-	return true
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
 }
 
 // Add colors a string to indicate additions (green, like Terraform)
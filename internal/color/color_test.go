@@ -0,0 +1,53 @@
+package color
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("NO_COLOR", "")
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "plan.txt"))
+	if err != nil {
+		t.Fatalf("failed to create output file: %v", err)
+	}
+	defer file.Close()
+
+	// Output redirected to a file is only colored when forced
+	if Enabled(ModeAuto, file) {
+		t.Error("expected auto mode to disable color for output redirected to a file")
+	}
+	if !Enabled(ModeAlways, file) {
+		t.Error("expected always mode to enable color for any output")
+	}
+	if Enabled(ModeNever, file) {
+		t.Error("expected never mode to disable color")
+	}
+}
+
+func TestNoEscapeCodesWhenDisabled(t *testing.T) {
+	disabled := New(false)
+	for _, text := range []string{disabled.Add("x"), disabled.Change("x"), disabled.Destroy("x"), disabled.FormatPlanHeader(1, 1, 1)} {
+		if strings.Contains(text, "\033[") {
+			t.Errorf("expected no escape codes when color is disabled, got %q", text)
+		}
+	}
+	if enabled := New(true); enabled.Add("x") != Green+"x"+Reset {
+		t.Errorf("expected additions to be green, got %q", enabled.Add("x"))
+	}
+}
+
+func TestValidateMode(t *testing.T) {
+	for _, mode := range []string{ModeAuto, ModeAlways, ModeNever} {
+		if err := ValidateMode(mode); err != nil {
+			t.Errorf("expected %q to be valid, got %v", mode, err)
+		}
+	}
+	if err := ValidateMode("sometimes"); err == nil || !strings.Contains(err.Error(), "invalid --color value") {
+		t.Errorf("expected an invalid --color error, got %v", err)
+	}
+}
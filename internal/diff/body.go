@@ -0,0 +1,179 @@
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// bodiesEqual compares two function or procedure bodies written in the given language.
+// With semantic comparison, SQL bodies are compared token by token (ignoring whitespace, comments,
+// and the case of unquoted keywords and identifiers) and PL/pgSQL bodies ignore whitespace and
+// comments. Bodies in other languages, or with semantic comparison disabled, must match exactly.
+func bodiesEqual(language, old, new string, semantic bool) bool {
+	if old == new {
+		return true
+	}
+	if !semantic {
+		return false
+	}
+	switch strings.ToLower(language) {
+	case "sql":
+		return normalizeBody(old, true) == normalizeBody(new, true)
+	case "plpgsql":
+		return normalizeBody(old, false) == normalizeBody(new, false)
+	default:
+		return false
+	}
+}
+
+// normalizeBody tokenizes a SQL or PL/pgSQL body and joins the tokens with single spaces, dropping
+// comments and insignificant whitespace. String literals, quoted identifiers, and dollar-quoted
+// strings are kept verbatim. If foldCase is set, unquoted words are lowercased as PostgreSQL would.
+func normalizeBody(body string, foldCase bool) string {
+	var tokens []string
+	i := 0
+	for i < len(body) {
+		c := body[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(body[i:], "--"):
+			end := strings.IndexByte(body[i:], '\n')
+			if end < 0 {
+				end = len(body) - i
+			}
+			i += end
+		case strings.HasPrefix(body[i:], "/*"):
+			i = skipBlockComment(body, i)
+		case c == '\'':
+			end := scanQuoted(body, i, '\'', false)
+			tokens = append(tokens, body[i:end])
+			i = end
+		case c == '"':
+			end := scanQuoted(body, i, '"', false)
+			tokens = append(tokens, body[i:end])
+			i = end
+		case c == '$' && i+1 < len(body) && body[i+1] >= '0' && body[i+1] <= '9':
+			// Positional parameter such as $1
+			end := i + 1
+			for end < len(body) && body[end] >= '0' && body[end] <= '9' {
+				end++
+			}
+			tokens = append(tokens, body[i:end])
+			i = end
+		case c == '$':
+			if end, ok := scanDollarQuoted(body, i); ok {
+				tokens = append(tokens, body[i:end])
+				i = end
+			} else {
+				tokens = append(tokens, "$")
+				i++
+			}
+		case isWordByte(c):
+			end := i
+			for end < len(body) && (isWordByte(body[end]) || body[end] == '$') {
+				end++
+			}
+			word := body[i:end]
+			// E'...' strings allow backslash escapes, so the closing quote must be found accordingly
+			if (word == "e" || word == "E") && end < len(body) && body[end] == '\'' {
+				stringEnd := scanQuoted(body, end, '\'', true)
+				tokens = append(tokens, word+body[end:stringEnd])
+				i = stringEnd
+				continue
+			}
+			if foldCase {
+				word = strings.ToLower(word)
+			}
+			tokens = append(tokens, word)
+			i = end
+		case isOperatorByte(c):
+			end := i
+			for end < len(body) && isOperatorByte(body[end]) &&
+				!strings.HasPrefix(body[end:], "--") && !strings.HasPrefix(body[end:], "/*") {
+				end++
+			}
+			tokens = append(tokens, body[i:end])
+			i = end
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// skipBlockComment returns the index just past the (possibly nested) block comment starting at i
+func skipBlockComment(body string, i int) int {
+	depth := 0
+	for i < len(body) {
+		switch {
+		case strings.HasPrefix(body[i:], "/*"):
+			depth++
+			i += 2
+		case strings.HasPrefix(body[i:], "*/"):
+			depth--
+			i += 2
+			if depth == 0 {
+				return i
+			}
+		default:
+			i++
+		}
+	}
+	return i
+}
+
+// scanQuoted returns the index just past the quoted string or identifier starting at i.
+// A doubled quote character is an escaped quote; backslash escapes are honored if requested.
+func scanQuoted(body string, i int, quote byte, backslashEscapes bool) int {
+	i++
+	for i < len(body) {
+		switch body[i] {
+		case '\\':
+			if backslashEscapes {
+				i += 2
+				continue
+			}
+		case quote:
+			if i+1 < len(body) && body[i+1] == quote {
+				i += 2
+				continue
+			}
+			return i + 1
+		}
+		i++
+	}
+	return i
+}
+
+// scanDollarQuoted returns the index just past the dollar-quoted string starting at i, or false if
+// the text at i is not a dollar-quote opening tag
+func scanDollarQuoted(body string, i int) (int, bool) {
+	tagEnd := i + 1
+	for tagEnd < len(body) && body[tagEnd] != '$' {
+		if !isWordByte(body[tagEnd]) {
+			return 0, false
+		}
+		tagEnd++
+	}
+	if tagEnd >= len(body) {
+		return 0, false
+	}
+	tag := body[i : tagEnd+1]
+	closing := strings.Index(body[tagEnd+1:], tag)
+	if closing < 0 {
+		return len(body), true
+	}
+	return tagEnd + 1 + closing + len(tag), true
+}
+
+// isWordByte reports whether c can appear in an unquoted identifier, keyword, or number
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+// isOperatorByte reports whether c is one of PostgreSQL's operator characters
+func isOperatorByte(c byte) bool {
+	return strings.IndexByte("+-*/<>=~!@#%^&|`?", c) >= 0
+}
@@ -0,0 +1,150 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestBodiesEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		old      string
+		new      string
+		semantic bool
+		expected bool
+	}{
+		{
+			name:     "reindented SQL body",
+			language: "sql",
+			old:      "\n    SELECT id, name FROM users WHERE active\n",
+			new:      "\nselect id,\n       name\n  from users\n where active -- only active users\n",
+			semantic: true,
+			expected: true,
+		},
+		{
+			name:     "block comments and operator spacing",
+			language: "sql",
+			old:      "SELECT a+b /* sum /* nested */ */ FROM t",
+			new:      "SELECT a + b FROM t",
+			semantic: true,
+			expected: true,
+		},
+		{
+			name:     "string literal contents are significant",
+			language: "sql",
+			old:      "SELECT 'a  b'",
+			new:      "SELECT 'a b'",
+			semantic: true,
+			expected: false,
+		},
+		{
+			name:     "comment markers inside strings are kept",
+			language: "sql",
+			old:      "SELECT '--not a comment', E'it\\'s /* here */'",
+			new:      "SELECT '--not a comment',E'it\\'s /* here */'",
+			semantic: true,
+			expected: true,
+		},
+		{
+			name:     "quoted identifiers keep their case",
+			language: "sql",
+			old:      `SELECT "Name" FROM users`,
+			new:      `SELECT "name" FROM users`,
+			semantic: true,
+			expected: false,
+		},
+		{
+			name:     "different SQL body",
+			language: "sql",
+			old:      "SELECT $1 + 1",
+			new:      "SELECT $1 + 2",
+			semantic: true,
+			expected: false,
+		},
+		{
+			name:     "reindented plpgsql body",
+			language: "plpgsql",
+			old:      "\nBEGIN\n    RETURN x * 2;\nEND;\n",
+			new:      "\nBEGIN\n  -- double it\n  RETURN x*2;\nEND;\n",
+			semantic: true,
+			expected: true,
+		},
+		{
+			name:     "plpgsql keeps keyword case",
+			language: "plpgsql",
+			old:      "BEGIN RETURN 1; END;",
+			new:      "begin return 1; end;",
+			semantic: true,
+			expected: false,
+		},
+		{
+			name:     "dollar-quoted strings are significant",
+			language: "plpgsql",
+			old:      "BEGIN EXECUTE $q$SELECT  1$q$; END;",
+			new:      "BEGIN EXECUTE $q$SELECT 1$q$; END;",
+			semantic: true,
+			expected: false,
+		},
+		{
+			name:     "other languages compare exactly",
+			language: "plpython3u",
+			old:      "return 1",
+			new:      "return  1",
+			semantic: true,
+			expected: false,
+		},
+		{
+			name:     "exact comparison",
+			language: "sql",
+			old:      "SELECT 1",
+			new:      "select 1",
+			semantic: false,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bodiesEqual(tt.language, tt.old, tt.new, tt.semantic); got != tt.expected {
+				t.Errorf("bodiesEqual(%q, %q) = %v, want %v", tt.old, tt.new, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateMigrationReformattedFunctionBody(t *testing.T) {
+	buildIR := func(definition string) *ir.IR {
+		state := ir.NewIR()
+		schema := state.GetOrCreateSchema("public")
+		schema.Functions["active_users_count()"] = &ir.Function{
+			Schema:     "public",
+			Name:       "active_users_count",
+			Definition: definition,
+			ReturnType: "bigint",
+			Language:   "sql",
+			Volatility: "STABLE",
+		}
+		return state
+	}
+	oldIR := buildIR("\n    SELECT count(*) FROM users WHERE active;\n")
+	newIR := buildIR("\n  select count(*)\n    from users\n   where active; -- soft-deleted users are inactive\n")
+
+	diffs, err := GenerateMigrationWithOptions(oldIR, newIR, "public", MigrationOptions{SemanticBodyCompare: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diffs) != 0 {
+		t.Errorf("expected no changes for a reformatted function body, got %s", buildSQLFromSteps(diffs))
+	}
+
+	diffs, err = GenerateMigrationWithOptions(oldIR, newIR, "public", MigrationOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diffs) != 1 || !strings.Contains(diffs[0].Statements[0].SQL, "CREATE OR REPLACE FUNCTION active_users_count()") {
+		t.Errorf("expected CREATE OR REPLACE with exact body comparison, got %s", buildSQLFromSteps(diffs))
+	}
+}
@@ -0,0 +1,140 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+// cascadeDropCategories maps the object categories accepted by MigrationOptions.CascadeDrops to the
+// diff types whose DROP statements use CASCADE when the category is listed. Regular views always drop with
+// CASCADE; types and domains drop with RESTRICT unless opted in here, since cascading from them
+// silently drops every table column that uses them.
+var cascadeDropCategories = map[string][]DiffType{
+	"types":   {DiffTypeType},
+	"domains": {DiffTypeDomain},
+}
+
+// CascadeDropCategories returns the sorted list of category names accepted by MigrationOptions.CascadeDrops
+func CascadeDropCategories() []string {
+	names := make([]string, 0, len(cascadeDropCategories))
+	for name := range cascadeDropCategories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateCascadeDrops returns an error if any of the given names is not a cascade drop category
+func ValidateCascadeDrops(categories []string) error {
+	_, err := cascadeDropTypes(categories)
+	return err
+}
+
+// cascadeDropTypes resolves cascade drop category names into the set of diff types they cover
+func cascadeDropTypes(categories []string) (map[DiffType]bool, error) {
+	cascade := make(map[DiffType]bool)
+	for _, category := range categories {
+		types, ok := cascadeDropCategories[strings.TrimSpace(category)]
+		if !ok {
+			return nil, fmt.Errorf("unknown cascade drop category %q (valid categories: %s)", category, strings.Join(CascadeDropCategories(), ", "))
+		}
+		for _, t := range types {
+			cascade[t] = true
+		}
+	}
+	return cascade, nil
+}
+
+// dropBehavior returns the CASCADE or RESTRICT clause for dropping an object of the given type
+func dropBehavior(diffType DiffType, cascade map[DiffType]bool) string {
+	if cascade[diffType] {
+		return "CASCADE"
+	}
+	return "RESTRICT"
+}
+
+// omitColumnsDroppedByCascade removes the changes for columns whose type is dropped with CASCADE.
+// Drops run before table modifications, so the cascade has already removed those columns: their
+// DROP COLUMN is omitted, and a column whose type changes away from the dropped type is added back
+// with its new definition instead of being altered.
+func (d *ddlDiff) omitColumnsDroppedByCascade() {
+	cascaded := make(map[string]bool)
+	for _, typeObj := range d.droppedTypes {
+		diffType := DiffTypeType
+		if typeObj.Kind == ir.TypeKindDomain {
+			diffType = DiffTypeDomain
+		}
+		if d.cascadeDrops[diffType] {
+			cascaded[typeObj.Schema+"."+typeObj.Name] = true
+		}
+	}
+	if len(cascaded) == 0 {
+		return
+	}
+
+	droppedByCascade := func(schema string, column *ir.Column) bool {
+		return cascaded[columnTypeKey(schema, column)]
+	}
+
+	for _, td := range d.modifiedTables {
+		kept := td.DroppedColumns[:0]
+		for _, column := range td.DroppedColumns {
+			if !droppedByCascade(td.Table.Schema, column) {
+				kept = append(kept, column)
+			}
+		}
+		td.DroppedColumns = kept
+
+		modified := td.ModifiedColumns[:0]
+		for _, columnDiff := range td.ModifiedColumns {
+			if droppedByCascade(td.Table.Schema, columnDiff.Old) {
+				td.AddedColumns = append(td.AddedColumns, columnDiff.New)
+			} else {
+				modified = append(modified, columnDiff)
+			}
+		}
+		td.ModifiedColumns = modified
+	}
+}
+
+// splitDroppedTypes separates the dropped types that a dropped or modified column of a kept table
+// still uses, and that are dropped with RESTRICT. PostgreSQL refuses to drop those until the column
+// is dropped or changed in the modify phase, so they are dropped after it; the others, including
+// the types dropped with CASCADE, are dropped with the other objects before it.
+func (d *ddlDiff) splitDroppedTypes() (beforeModify, afterModify []*ir.Type) {
+	used := make(map[string]bool)
+	for _, td := range d.modifiedTables {
+		for _, column := range td.DroppedColumns {
+			used[columnTypeKey(td.Table.Schema, column)] = true
+		}
+		for _, columnDiff := range td.ModifiedColumns {
+			used[columnTypeKey(td.Table.Schema, columnDiff.Old)] = true
+		}
+	}
+
+	for _, typeObj := range d.droppedTypes {
+		diffType := DiffTypeType
+		if typeObj.Kind == ir.TypeKindDomain {
+			diffType = DiffTypeDomain
+		}
+		if used[typeObj.Schema+"."+typeObj.Name] && !d.cascadeDrops[diffType] {
+			afterModify = append(afterModify, typeObj)
+		} else {
+			beforeModify = append(beforeModify, typeObj)
+		}
+	}
+	return beforeModify, afterModify
+}
+
+// columnTypeKey returns the "schema.name" of the type of a column of a table in schema,
+// without the array suffix
+func columnTypeKey(schema string, column *ir.Column) string {
+	dataType := strings.TrimSuffix(column.DataType, "[]")
+	if !strings.Contains(dataType, ".") {
+		dataType = schema + "." + dataType
+	}
+	return dataType
+}
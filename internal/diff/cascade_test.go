@@ -0,0 +1,107 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+// newCascadeTestIR builds a schema with a people table, optionally with a home column of the
+// composite type address and the type itself
+func newCascadeTestIR(withAddress bool) *ir.IR {
+	people := &ir.Table{
+		Schema:  "public",
+		Name:    "people",
+		Type:    ir.TableTypeBase,
+		Columns: []*ir.Column{{Name: "id", Position: 1, DataType: "integer"}},
+	}
+	schema := &ir.Schema{
+		Name:   "public",
+		Tables: map[string]*ir.Table{"people": people},
+		Types:  map[string]*ir.Type{},
+	}
+	if withAddress {
+		people.Columns = append(people.Columns, &ir.Column{Name: "home", Position: 2, DataType: "address", IsNullable: true})
+		schema.Types["address"] = &ir.Type{
+			Schema:  "public",
+			Name:    "address",
+			Kind:    ir.TypeKindComposite,
+			Columns: []*ir.TypeColumn{{Name: "street", DataType: "text", Position: 1}},
+		}
+	}
+	return &ir.IR{Schemas: map[string]*ir.Schema{"public": schema}}
+}
+
+func TestDropTypeCascadePolicy(t *testing.T) {
+	oldIR := newCascadeTestIR(true)
+	newIR := newCascadeTestIR(false)
+
+	restrictDiffs, err := GenerateMigrationWithOptions(oldIR, newIR, "public", MigrationOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	restrict := buildSQLFromSteps(restrictDiffs)
+	if !strings.Contains(restrict, "DROP TYPE IF EXISTS address RESTRICT;") || !strings.Contains(restrict, "DROP COLUMN home;") {
+		t.Errorf("expected RESTRICT drop and an explicit column drop without cascade permission, got:\n%s", restrict)
+	}
+
+	cascadeDiffs, err := GenerateMigrationWithOptions(oldIR, newIR, "public", MigrationOptions{CascadeDrops: []string{"types"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cascade := buildSQLFromSteps(cascadeDiffs)
+	if !strings.Contains(cascade, "DROP TYPE IF EXISTS address CASCADE;") {
+		t.Errorf("expected CASCADE drop with cascade permission, got:\n%s", cascade)
+	}
+	// The column is removed by the cascade, so dropping it again would fail
+	if strings.Contains(cascade, "DROP COLUMN home") {
+		t.Errorf("expected the cascaded column drop to be omitted, got:\n%s", cascade)
+	}
+
+	// Permission for domains does not extend to other types
+	domainDiffs, err := GenerateMigrationWithOptions(oldIR, newIR, "public", MigrationOptions{CascadeDrops: []string{"domains"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sql := buildSQLFromSteps(domainDiffs); !strings.Contains(sql, "DROP TYPE IF EXISTS address RESTRICT;") {
+		t.Errorf("expected RESTRICT drop when only domains cascade, got:\n%s", sql)
+	}
+}
+
+// TestDropTypeCascadeModifiedColumn checks that a column whose type changes away from a type
+// dropped with CASCADE is added back rather than altered, since the cascade removes it first.
+func TestDropTypeCascadeModifiedColumn(t *testing.T) {
+	oldIR := newCascadeTestIR(true)
+	newIR := newCascadeTestIR(false)
+	newIR.Schemas["public"].Tables["people"].Columns = append(newIR.Schemas["public"].Tables["people"].Columns,
+		&ir.Column{Name: "home", Position: 2, DataType: "text", IsNullable: true})
+
+	diffs, err := GenerateMigrationWithOptions(oldIR, newIR, "public", MigrationOptions{CascadeDrops: []string{"types"}, AllowUnsafeTypeChanges: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql := buildSQLFromSteps(diffs)
+	if !strings.Contains(sql, "DROP TYPE IF EXISTS address CASCADE;") || !strings.Contains(sql, "ADD COLUMN home text;") {
+		t.Errorf("expected a cascading type drop followed by the column added back, got:\n%s", sql)
+	}
+	if strings.Contains(sql, "ALTER COLUMN home") {
+		t.Errorf("expected no ALTER of the column removed by the cascade, got:\n%s", sql)
+	}
+	if strings.Index(sql, "DROP TYPE") > strings.Index(sql, "ADD COLUMN") {
+		t.Errorf("expected the type to be dropped before the column is added back, got:\n%s", sql)
+	}
+}
+
+func TestValidateCascadeDrops(t *testing.T) {
+	if err := ValidateCascadeDrops([]string{"types", " domains"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := ValidateCascadeDrops([]string{"views"})
+	if err == nil || !strings.Contains(err.Error(), `unknown cascade drop category "views"`) {
+		t.Errorf("expected unknown category error, got %v", err)
+	}
+	if _, err := GenerateMigrationWithOptions(ir.NewIR(), ir.NewIR(), "public", MigrationOptions{CascadeDrops: []string{"tables"}}); err == nil {
+		t.Error("expected GenerateMigrationWithOptions to reject an unknown cascade drop category")
+	}
+}
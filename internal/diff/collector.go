@@ -12,6 +12,8 @@ type diffContext struct {
 // diffCollector collects SQL statements with their context information
 type diffCollector struct {
 	diffs []Diff
+	// edges records why the topological sorts placed created objects where they are
+	edges orderingEdges
 }
 
 // newDiffCollector creates a new diffCollector
@@ -34,7 +36,7 @@ func (c *diffCollector) collect(context *diffContext, stmt string) {
 			Path:      context.Path,
 			Source:    context.Source,
 		}
-		c.diffs = append(c.diffs, step)
+		c.add(step)
 	}
 }
 
@@ -48,6 +50,16 @@ func (c *diffCollector) collectStatements(context *diffContext, statements []SQL
 			Path:       context.Path,
 			Source:     context.Source,
 		}
-		c.diffs = append(c.diffs, step)
+		c.add(step)
 	}
 }
+
+// add appends a diff, attaching the dependency it was placed after if it creates a sorted object
+func (c *diffCollector) add(step Diff) {
+	if key := createdObjectKey(step); key != "" {
+		if dep, ok := c.edges[key]; ok {
+			step.placedAfter = &dep
+		}
+	}
+	c.diffs = append(c.diffs, step)
+}
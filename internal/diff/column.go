@@ -2,6 +2,7 @@ package diff
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/pgplex/pgschema/ir"
@@ -20,10 +21,11 @@ func (cd *ColumnDiff) generateColumnSQL(tableSchema, tableName string, targetSch
 	// When a USING clause is needed, we must: DROP DEFAULT -> ALTER TYPE -> SET DEFAULT
 	// because PostgreSQL can't automatically cast default values during type changes with USING
 	hasTypeChange := oldType != newType
+	hasCollationChange := cd.Old.Collation != cd.New.Collation
 	oldDefault := cd.Old.DefaultValue
 	newDefault := cd.New.DefaultValue
 	hasOldDefault := oldDefault != nil && *oldDefault != ""
-	needsUsing := hasTypeChange && needsUsingClause(oldType, newType)
+	needsUsing := hasTypeChange && (cd.Using != "" || needsUsingClause(oldType, newType))
 
 	// If type is changing with USING clause and there's an existing default, drop the default first
 	if needsUsing && hasOldDefault {
@@ -32,18 +34,31 @@ func (cd *ColumnDiff) generateColumnSQL(tableSchema, tableName string, targetSch
 		statements = append(statements, sql)
 	}
 
-	// Handle data type changes
-	if hasTypeChange {
+	// Handle data type changes; a collation can only be changed through ALTER ... TYPE
+	if hasTypeChange || hasCollationChange {
+		// ALTER ... TYPE resets the collation to the default of the new type, so a non-default
+		// collation must be repeated whenever the type changes
+		collate := ""
+		if cd.New.Collation != "" {
+			collate = " COLLATE " + cd.New.Collation
+		} else if hasCollationChange {
+			collate = ` COLLATE "default"`
+		}
+
 		// Check if we need a USING clause for the type conversion
 		// This is required when converting from text-like types to custom types (like ENUMs)
 		// because PostgreSQL cannot implicitly cast these types
 		if needsUsing {
-			sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;",
-				qualifiedTableName, ir.QuoteIdentifier(cd.New.Name), newType, ir.QuoteIdentifier(cd.New.Name), newType)
+			using := cd.Using
+			if using == "" {
+				using = fmt.Sprintf("%s::%s", ir.QuoteIdentifier(cd.New.Name), newType)
+			}
+			sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s%s USING %s;",
+				qualifiedTableName, ir.QuoteIdentifier(cd.New.Name), newType, collate, using)
 			statements = append(statements, sql)
 		} else {
-			sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;",
-				qualifiedTableName, ir.QuoteIdentifier(cd.New.Name), newType)
+			sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s%s;",
+				qualifiedTableName, ir.QuoteIdentifier(cd.New.Name), newType, collate)
 			statements = append(statements, sql)
 		}
 	}
@@ -55,12 +70,24 @@ func (cd *ColumnDiff) generateColumnSQL(tableSchema, tableName string, targetSch
 			sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;",
 				qualifiedTableName, ir.QuoteIdentifier(cd.New.Name))
 			statements = append(statements, sql)
+		} else if cd.New.NotNullConstraintName != "" {
+			// ADD a named NOT NULL constraint (PostgreSQL 18+)
+			sql := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s NOT NULL %s;",
+				qualifiedTableName, ir.QuoteIdentifier(cd.New.NotNullConstraintName), ir.QuoteIdentifier(cd.New.Name))
+			statements = append(statements, sql)
 		} else {
 			// ADD NOT NULL - generate canonical SQL only
 			sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;",
 				qualifiedTableName, ir.QuoteIdentifier(cd.New.Name))
 			statements = append(statements, sql)
 		}
+	} else if !cd.New.IsNullable && cd.Old.NotNullConstraintName != cd.New.NotNullConstraintName {
+		// Rename the NOT NULL constraint, to or from its default name
+		sql := fmt.Sprintf("ALTER TABLE %s RENAME CONSTRAINT %s TO %s;",
+			qualifiedTableName,
+			ir.QuoteIdentifier(notNullConstraintName(cd.Old, tableName)),
+			ir.QuoteIdentifier(notNullConstraintName(cd.New, tableName)))
+		statements = append(statements, sql)
 	}
 
 	// Handle default value changes
@@ -96,9 +123,16 @@ func (cd *ColumnDiff) generateColumnSQL(tableSchema, tableName string, targetSch
 		statements = append(statements, sql)
 	}
 	if cd.New.Identity != nil && (cd.Old.Identity == nil || cd.Old.Identity.Generation != cd.New.Identity.Generation) {
-		sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s ADD GENERATED %s AS IDENTITY;",
-			qualifiedTableName, ir.QuoteIdentifier(cd.New.Name), cd.New.Identity.Generation)
+		sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s ADD GENERATED %s AS IDENTITY%s;",
+			qualifiedTableName, ir.QuoteIdentifier(cd.New.Name), cd.New.Identity.Generation, identityOptionsClause(cd.New))
 		statements = append(statements, sql)
+	} else if cd.Old.Identity != nil && cd.New.Identity != nil {
+		// Same generation: alter the options of the identity sequence in place
+		if options := alterIdentityOptions(cd.Old, cd.New); len(options) > 0 {
+			sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;",
+				qualifiedTableName, ir.QuoteIdentifier(cd.New.Name), strings.Join(options, " "))
+			statements = append(statements, sql)
+		}
 	}
 
 	return statements
@@ -138,6 +172,225 @@ func needsUsingClause(oldType, newType string) bool {
 	return false
 }
 
+// assignmentCastFamilies groups built-in types between which PostgreSQL has implicit or
+// assignment casts, so ALTER COLUMN ... TYPE converts existing values without a USING clause.
+var assignmentCastFamilies = map[string]string{
+	"smallint":                    "numeric",
+	"integer":                     "numeric",
+	"bigint":                      "numeric",
+	"int2":                        "numeric",
+	"int4":                        "numeric",
+	"int8":                        "numeric",
+	"numeric":                     "numeric",
+	"decimal":                     "numeric",
+	"real":                        "numeric",
+	"double precision":            "numeric",
+	"float4":                      "numeric",
+	"float8":                      "numeric",
+	"date":                        "timestamp",
+	"timestamp":                   "timestamp",
+	"timestamptz":                 "timestamp",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamp",
+	"time":                        "time",
+	"timetz":                      "time",
+	"time without time zone":      "time",
+	"time with time zone":         "time",
+	"json":                        "json",
+	"jsonb":                       "json",
+	"bit":                         "bit",
+	"varbit":                      "bit",
+	"bit varying":                 "bit",
+	"inet":                        "inet",
+	"cidr":                        "inet",
+}
+
+// hasAssignmentCast reports whether a built-in → built-in type change converts existing
+// values without a USING clause. Conversions to text-like types always do (via I/O
+// conversion), as do changes within one of assignmentCastFamilies and typmod-only changes
+// such as varchar(50) → varchar(100). Anything else, e.g. text → integer, fails on apply
+// unless a USING expression is given.
+func hasAssignmentCast(oldType, newType string) bool {
+	oldIsArray := strings.HasSuffix(oldType, "[]")
+	newIsArray := strings.HasSuffix(newType, "[]")
+	if oldIsArray != newIsArray {
+		return false
+	}
+
+	if ir.IsTextLikeType(newType) {
+		return true
+	}
+
+	oldBase := baseTypeName(oldType)
+	newBase := baseTypeName(newType)
+	if oldBase == newBase {
+		return true
+	}
+	oldFamily, oldOK := assignmentCastFamilies[oldBase]
+	newFamily, newOK := assignmentCastFamilies[newBase]
+	return oldOK && newOK && oldFamily == newFamily
+}
+
+// baseTypeName returns the lowercased type name without array suffix, typmod, or pg_catalog prefix
+func baseTypeName(typeName string) string {
+	t := strings.ToLower(strings.TrimSuffix(typeName, "[]"))
+	if idx := strings.Index(t, "("); idx != -1 {
+		t = strings.TrimSpace(t[:idx])
+	}
+	return strings.TrimPrefix(t, "pg_catalog.")
+}
+
+// notNullConstraintName returns the name of a column's NOT NULL constraint. PostgreSQL 18+
+// names it <table>_<column>_not_null unless the constraint is given an explicit name.
+func notNullConstraintName(column *ir.Column, tableName string) string {
+	if column.NotNullConstraintName != "" {
+		return column.NotNullConstraintName
+	}
+	return tableName + "_" + column.Name + "_not_null"
+}
+
+// checkVirtualGeneratedColumns returns an error if the desired state has VIRTUAL generated columns
+// but the current state was read from a PostgreSQL version before 18, which only has STORED ones
+func checkVirtualGeneratedColumns(oldIR, newIR *ir.IR) error {
+	major := oldIR.Metadata.MajorVersion()
+	if major == 0 || major >= 18 {
+		return nil
+	}
+
+	var columns []string
+	for _, schemaName := range sortedKeys(newIR.Schemas) {
+		dbSchema := newIR.Schemas[schemaName]
+		for _, tableName := range sortedKeys(dbSchema.Tables) {
+			for _, column := range dbSchema.Tables[tableName].Columns {
+				if column.IsGenerated && column.GeneratedVirtual {
+					columns = append(columns, schemaName+"."+tableName+"."+column.Name)
+				}
+			}
+		}
+	}
+	if len(columns) > 0 {
+		return fmt.Errorf("VIRTUAL generated columns require PostgreSQL 18 or later, but the target database is PostgreSQL %d: %s", major, strings.Join(columns, ", "))
+	}
+	return nil
+}
+
+// identityOptions holds the sequence options of an identity column, with defaults filled in
+type identityOptions struct {
+	start     int64
+	increment int64
+	minimum   int64
+	maximum   int64
+	cycle     bool
+}
+
+// resolveIdentityOptions returns the options of a column's identity sequence, filling in the
+// defaults PostgreSQL uses for options that are not set. The defaults depend on the column type
+// and on the direction of the increment.
+func resolveIdentityOptions(column *ir.Column) identityOptions {
+	identity := column.Identity
+	options := identityOptions{increment: 1, cycle: identity.Cycle}
+	if identity.Increment != nil {
+		options.increment = *identity.Increment
+	}
+
+	typeMin, typeMax := integerTypeBounds(column.DataType)
+	if options.increment > 0 {
+		options.minimum, options.maximum = 1, typeMax
+	} else {
+		options.minimum, options.maximum = typeMin, -1
+	}
+	if identity.Minimum != nil {
+		options.minimum = *identity.Minimum
+	}
+	if identity.Maximum != nil {
+		options.maximum = *identity.Maximum
+	}
+
+	options.start = defaultIdentityStart(options)
+	if identity.Start != nil {
+		options.start = *identity.Start
+	}
+	return options
+}
+
+// defaultIdentityStart returns the start value used when none is given: the minimum for
+// ascending sequences and the maximum for descending ones
+func defaultIdentityStart(options identityOptions) int64 {
+	if options.increment > 0 {
+		return options.minimum
+	}
+	return options.maximum
+}
+
+// integerTypeBounds returns the range of an integer column type
+func integerTypeBounds(dataType string) (int64, int64) {
+	switch baseTypeName(dataType) {
+	case "smallint", "int2":
+		return math.MinInt16, math.MaxInt16
+	case "integer", "int", "int4":
+		return math.MinInt32, math.MaxInt32
+	default:
+		return math.MinInt64, math.MaxInt64
+	}
+}
+
+// identityOptionsClause returns the parenthesized sequence options of an identity column that
+// differ from the defaults, or an empty string if all options are defaults
+func identityOptionsClause(column *ir.Column) string {
+	options := resolveIdentityOptions(column)
+	defaults := resolveIdentityOptions(&ir.Column{DataType: column.DataType, Identity: &ir.Identity{Increment: &options.increment}})
+
+	var parts []string
+	if options.start != defaultIdentityStart(options) {
+		parts = append(parts, fmt.Sprintf("START WITH %d", options.start))
+	}
+	if options.increment != 1 {
+		parts = append(parts, fmt.Sprintf("INCREMENT BY %d", options.increment))
+	}
+	if options.minimum != defaults.minimum {
+		parts = append(parts, fmt.Sprintf("MINVALUE %d", options.minimum))
+	}
+	if options.maximum != defaults.maximum {
+		parts = append(parts, fmt.Sprintf("MAXVALUE %d", options.maximum))
+	}
+	if options.cycle {
+		parts = append(parts, "CYCLE")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, " ") + ")"
+}
+
+// alterIdentityOptions returns the SET clauses of ALTER COLUMN that change the identity sequence
+// options of old to those of new. A changed start value only takes effect on a later RESTART, so
+// the current position of the sequence is left alone.
+func alterIdentityOptions(old, new *ir.Column) []string {
+	oldOptions, newOptions := resolveIdentityOptions(old), resolveIdentityOptions(new)
+
+	var clauses []string
+	if oldOptions.increment != newOptions.increment {
+		clauses = append(clauses, fmt.Sprintf("SET INCREMENT BY %d", newOptions.increment))
+	}
+	if oldOptions.minimum != newOptions.minimum {
+		clauses = append(clauses, fmt.Sprintf("SET MINVALUE %d", newOptions.minimum))
+	}
+	if oldOptions.maximum != newOptions.maximum {
+		clauses = append(clauses, fmt.Sprintf("SET MAXVALUE %d", newOptions.maximum))
+	}
+	if oldOptions.start != newOptions.start {
+		clauses = append(clauses, fmt.Sprintf("SET START WITH %d", newOptions.start))
+	}
+	if oldOptions.cycle != newOptions.cycle {
+		if newOptions.cycle {
+			clauses = append(clauses, "SET CYCLE")
+		} else {
+			clauses = append(clauses, "SET NO CYCLE")
+		}
+	}
+	return clauses
+}
+
 // columnsEqual compares two columns for equality
 // targetSchema is used to normalize type names before comparison
 func columnsEqual(old, new *ir.Column, targetSchema string) bool {
@@ -153,8 +406,17 @@ func columnsEqual(old, new *ir.Column, targetSchema string) bool {
 	if old.IsNullable != new.IsNullable {
 		return false
 	}
+	if old.Collation != new.Collation {
+		return false
+	}
+	if old.NotNullConstraintName != new.NotNullConstraintName {
+		return false
+	}
 
-	// Compare default values (already normalized by ir.normalizeColumn)
+	// Compare default values (already normalized by ir.normalizeColumn).
+	// DefaultValue only holds the column's own default (pg_attrdef), never the default inherited
+	// from a domain type, so a column relying on its domain's default compares as having none and
+	// domain default changes are handled by ALTER DOMAIN rather than by the column.
 	if (old.DefaultValue == nil) != (new.DefaultValue == nil) {
 		return false
 	}
@@ -178,6 +440,9 @@ func columnsEqual(old, new *ir.Column, targetSchema string) bool {
 		if old.Identity.Generation != new.Identity.Generation {
 			return false
 		}
+		if resolveIdentityOptions(old) != resolveIdentityOptions(new) {
+			return false
+		}
 	}
 
 	// Compare comments
@@ -31,8 +31,9 @@ func generateCreateColumnPrivilegesSQL(privileges []*ir.ColumnPrivilege, targetS
 	}
 }
 
-// generateDropColumnPrivilegesSQL generates REVOKE statements for removed column privileges
-func generateDropColumnPrivilegesSQL(privileges []*ir.ColumnPrivilege, targetSchema string, collector *diffCollector) {
+// generateDropColumnPrivilegesSQL generates REVOKE statements for removed column privileges.
+// retained holds the desired column privileges restored after a cascading REVOKE.
+func generateDropColumnPrivilegesSQL(privileges []*ir.ColumnPrivilege, retained []*ir.ColumnPrivilege, targetSchema string, collector *diffCollector) {
 	for _, cp := range privileges {
 		sql := generateRevokeColumnPrivilegeSQL(cp)
 
@@ -50,11 +51,16 @@ func generateDropColumnPrivilegesSQL(privileges []*ir.ColumnPrivilege, targetSch
 		}
 
 		collector.collect(context, sql)
+
+		if cp.WithGrantOption {
+			generateRegrantDependentColumnPrivilegesSQL(cp, cp.Privileges, retained, collector)
+		}
 	}
 }
 
-// generateModifyColumnPrivilegesSQL generates ALTER column privilege statements for modifications
-func generateModifyColumnPrivilegesSQL(diffs []*columnPrivilegeDiff, targetSchema string, collector *diffCollector) {
+// generateModifyColumnPrivilegesSQL generates ALTER column privilege statements for modifications.
+// retained holds the desired column privileges restored after a cascading REVOKE.
+func generateModifyColumnPrivilegesSQL(diffs []*columnPrivilegeDiff, retained []*ir.ColumnPrivilege, targetSchema string, collector *diffCollector) {
 	for _, diff := range diffs {
 		statements := diff.generateAlterColumnPrivilegeStatements()
 
@@ -74,7 +80,95 @@ func generateModifyColumnPrivilegesSQL(diffs []*columnPrivilegeDiff, targetSchem
 
 			collector.collect(context, stmt)
 		}
+
+		if cascaded := diff.cascadeRevokedPrivileges(); len(cascaded) > 0 {
+			generateRegrantDependentColumnPrivilegesSQL(diff.Old, cascaded, retained, collector)
+		}
+	}
+}
+
+// generateRegrantDependentColumnPrivilegesSQL restores the retained column privileges of other
+// grantees on the columns of revokedFrom after a REVOKE ... CASCADE of the given privilege types
+// (see generateRegrantDependentPrivilegesSQL).
+func generateRegrantDependentColumnPrivilegesSQL(revokedFrom *ir.ColumnPrivilege, revoked []string, retained []*ir.ColumnPrivilege, collector *diffCollector) {
+	revokedSet := make(map[string]bool)
+	for _, priv := range revoked {
+		revokedSet[priv] = true
+	}
+	revokedCols := make(map[string]bool)
+	for _, col := range revokedFrom.Columns {
+		revokedCols[col] = true
+	}
+
+	for _, cp := range retained {
+		if cp.TableName != revokedFrom.TableName || cp.Grantee == revokedFrom.Grantee {
+			continue
+		}
+		sharesColumn := false
+		for _, col := range cp.Columns {
+			if revokedCols[col] {
+				sharesColumn = true
+				break
+			}
+		}
+		if !sharesColumn {
+			continue
+		}
+		var privs []string
+		for _, priv := range cp.Privileges {
+			if revokedSet[priv] {
+				privs = append(privs, priv)
+			}
+		}
+		if len(privs) == 0 {
+			continue
+		}
+
+		sql := generateGrantColumnPrivilegeSQL(&ir.ColumnPrivilege{
+			TableName:       cp.TableName,
+			Columns:         cp.Columns,
+			Grantee:         cp.Grantee,
+			Privileges:      privs,
+			WithGrantOption: cp.WithGrantOption,
+		})
+
+		sortedCols := make([]string, len(cp.Columns))
+		copy(sortedCols, cp.Columns)
+		sort.Strings(sortedCols)
+		colKey := strings.Join(sortedCols, ",")
+
+		context := &diffContext{
+			Type:                DiffTypeColumnPrivilege,
+			Operation:           DiffOperationAlter,
+			Path:                fmt.Sprintf("column_privileges.TABLE.%s.%s.%s", cp.TableName, colKey, cp.Grantee),
+			Source:              cp,
+			CanRunInTransaction: true,
+		}
+
+		collector.collect(context, sql)
+	}
+}
+
+// cascadeRevokedPrivileges returns the privilege types whose grant option the modification
+// revokes with CASCADE (see privilegeDiff.cascadeRevokedPrivileges).
+func (d *columnPrivilegeDiff) cascadeRevokedPrivileges() []string {
+	if !d.Old.WithGrantOption {
+		return nil
+	}
+	if !d.New.WithGrantOption {
+		return d.Old.Privileges
+	}
+	newPrivSet := make(map[string]bool)
+	for _, p := range d.New.Privileges {
+		newPrivSet[p] = true
+	}
+	var revoked []string
+	for _, p := range d.Old.Privileges {
+		if !newPrivSet[p] {
+			revoked = append(revoked, p)
+		}
 	}
+	return revoked
 }
 
 // generateGrantColumnPrivilegeSQL generates a GRANT statement for column privileges
@@ -126,7 +220,7 @@ func generateRevokeColumnPrivilegeSQL(cp *ir.ColumnPrivilege) string {
 	grantee := formatGrantee(cp.Grantee)
 	tableName := ir.QuoteIdentifier(cp.TableName)
 
-	return fmt.Sprintf("REVOKE %s (%s) ON TABLE %s FROM %s;", privStr, colStr, tableName, grantee)
+	return fmt.Sprintf("REVOKE %s (%s) ON TABLE %s FROM %s%s;", privStr, colStr, tableName, grantee, revokeCascadeClause(cp.WithGrantOption))
 }
 
 // generateAlterColumnPrivilegeStatements generates statements for column privilege modifications
@@ -171,8 +265,8 @@ func (d *columnPrivilegeDiff) generateAlterColumnPrivilegeStatements() []string
 	// Generate REVOKE for removed privileges
 	if len(toRevoke) > 0 {
 		sort.Strings(toRevoke)
-		statements = append(statements, fmt.Sprintf("REVOKE %s (%s) ON TABLE %s FROM %s;",
-			strings.Join(toRevoke, ", "), colStr, tableName, grantee))
+		statements = append(statements, fmt.Sprintf("REVOKE %s (%s) ON TABLE %s FROM %s%s;",
+			strings.Join(toRevoke, ", "), colStr, tableName, grantee, revokeCascadeClause(d.Old.WithGrantOption)))
 	}
 
 	// Generate GRANT for added privileges
@@ -202,7 +296,7 @@ func (d *columnPrivilegeDiff) generateAlterColumnPrivilegeStatements() []string
 
 			if d.Old.WithGrantOption && !d.New.WithGrantOption {
 				// Revoke grant option only (keep the privilege)
-				statements = append(statements, fmt.Sprintf("REVOKE GRANT OPTION FOR %s (%s) ON TABLE %s FROM %s;",
+				statements = append(statements, fmt.Sprintf("REVOKE GRANT OPTION FOR %s (%s) ON TABLE %s FROM %s CASCADE;",
 					unchangedStr, colStr, tableName, grantee))
 			} else if !d.Old.WithGrantOption && d.New.WithGrantOption {
 				// Add grant option (re-grant with grant option)
@@ -0,0 +1,264 @@
+package diff
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+	"github.com/pgplex/pgschema/testutil"
+)
+
+func TestColumnsEqualDomainDefault(t *testing.T) {
+	override := "7"
+	domainColumn := func(defaultValue *string) *ir.Column {
+		return &ir.Column{Name: "rating", Position: 2, DataType: "user_rating", IsNullable: true, DefaultValue: defaultValue}
+	}
+
+	// A column relying on its domain's default has no column-level default on either side
+	if !columnsEqual(domainColumn(nil), domainColumn(nil), "public") {
+		t.Error("expected columns relying on the domain default to be equal")
+	}
+
+	// A column-level override is a real difference from relying on the domain default
+	if columnsEqual(domainColumn(nil), domainColumn(&override), "public") {
+		t.Error("expected a column-level default override to differ from the domain default")
+	}
+
+	if !columnsEqual(domainColumn(&override), domainColumn(&override), "public") {
+		t.Error("expected identical column-level overrides to be equal")
+	}
+}
+
+func TestGenerateMigrationRejectsUnsafeTypeChange(t *testing.T) {
+	buildIR := func(ageType string) *ir.IR {
+		state := ir.NewIR()
+		schema := state.GetOrCreateSchema("public")
+		schema.Tables["users"] = &ir.Table{
+			Schema: "public",
+			Name:   "users",
+			Type:   ir.TableTypeBase,
+			Columns: []*ir.Column{
+				{Name: "id", Position: 1, DataType: "integer", IsNullable: false},
+				{Name: "age", Position: 2, DataType: ageType, IsNullable: true},
+			},
+			Constraints: map[string]*ir.Constraint{},
+			Indexes:     map[string]*ir.Index{},
+			Triggers:    map[string]*ir.Trigger{},
+			Policies:    map[string]*ir.RLSPolicy{},
+		}
+		return state
+	}
+	oldIR, newIR := buildIR("text"), buildIR("integer")
+
+	_, err := GenerateMigrationWithOptions(oldIR, newIR, "public", MigrationOptions{})
+	var unsafeErr *UnsafeTypeChangeError
+	if !errors.As(err, &unsafeErr) {
+		t.Fatalf("expected UnsafeTypeChangeError, got %v", err)
+	}
+	if len(unsafeErr.Changes) != 1 || !strings.Contains(unsafeErr.Changes[0], "public.users.age") {
+		t.Errorf("expected the change to name public.users.age, got %v", unsafeErr.Changes)
+	}
+}
+
+func TestNamedNotNullConstraint(t *testing.T) {
+	unnamed := &ir.Column{Name: "email", Position: 2, DataType: "text"}
+	named := &ir.Column{Name: "email", Position: 2, DataType: "text", NotNullConstraintName: "email_required"}
+
+	accounts := &ir.Table{Schema: "public", Name: "accounts"}
+	if got := buildColumnClauses(accounts, named, false, "public"); got != " CONSTRAINT email_required NOT NULL" {
+		t.Errorf("expected named NOT NULL clause, got %q", got)
+	}
+	if got := buildColumnClauses(accounts, unnamed, false, "public"); got != " NOT NULL" {
+		t.Errorf("expected plain NOT NULL clause, got %q", got)
+	}
+
+	if columnsEqual(unnamed, named, "public") {
+		t.Error("expected a NOT NULL constraint name difference to be detected")
+	}
+
+	// Naming the constraint of an existing NOT NULL column renames it from its default name
+	rename := (&ColumnDiff{Old: unnamed, New: named}).generateColumnSQL("public", "accounts", "public")
+	expected := []string{"ALTER TABLE accounts RENAME CONSTRAINT accounts_email_not_null TO email_required;"}
+	if !reflect.DeepEqual(rename, expected) {
+		t.Errorf("expected %v, got %v", expected, rename)
+	}
+
+	// Adding a named NOT NULL to a nullable column creates the constraint with its name
+	nullable := &ir.Column{Name: "email", Position: 2, DataType: "text", IsNullable: true}
+	add := (&ColumnDiff{Old: nullable, New: named}).generateColumnSQL("public", "accounts", "public")
+	expected = []string{"ALTER TABLE accounts ADD CONSTRAINT email_required NOT NULL email;"}
+	if !reflect.DeepEqual(add, expected) {
+		t.Errorf("expected %v, got %v", expected, add)
+	}
+}
+
+// TestNamedNotNullConstraintRoundTrip checks that an explicitly named NOT NULL constraint is
+// inspected, dumped in the named form, and produces no differences once applied (PostgreSQL 18+).
+func TestNamedNotNullConstraintRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	conn, _, _, _, _, _ := testutil.ConnectToPostgres(t, sharedTestPostgres)
+	majorVersion, err := testutil.GetMajorVersion(conn)
+	conn.Close()
+	if err != nil {
+		t.Fatalf("failed to detect PostgreSQL version: %v", err)
+	}
+	if majorVersion < 18 {
+		t.Skipf("NOT NULL constraint names are recorded starting with PostgreSQL 18, got %d", majorVersion)
+	}
+
+	namedIR := testutil.ParseSQLToIR(t, sharedTestPostgres, `CREATE TABLE accounts (
+    id integer PRIMARY KEY,
+    email text CONSTRAINT accounts_email_required NOT NULL,
+    name text NOT NULL
+);`, "public")
+
+	columns := namedIR.Schemas["public"].Tables["accounts"].Columns
+	if columns[1].NotNullConstraintName != "accounts_email_required" {
+		t.Errorf("expected named NOT NULL constraint to be inspected, got %q", columns[1].NotNullConstraintName)
+	}
+	if columns[2].NotNullConstraintName != "" {
+		t.Errorf("expected the default NOT NULL constraint name to be omitted, got %q", columns[2].NotNullConstraintName)
+	}
+
+	dump := buildSQLFromSteps(GenerateMigration(ir.NewIR(), namedIR, "public"))
+	if !strings.Contains(dump, "email text CONSTRAINT accounts_email_required NOT NULL") {
+		t.Errorf("expected dump to name the NOT NULL constraint, got:\n%s", dump)
+	}
+
+	appliedIR := testutil.ParseSQLToIR(t, sharedTestPostgres, dump, "public")
+	if diffs := GenerateMigration(appliedIR, namedIR, "public"); len(diffs) != 0 {
+		t.Errorf("expected no differences after round-trip, got %s", buildSQLFromSteps(diffs))
+	}
+}
+
+func TestIdentityOptionsDiff(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+	identityColumn := func(identity *ir.Identity) *ir.Column {
+		return &ir.Column{Name: "id", Position: 1, DataType: "integer", Identity: identity}
+	}
+
+	// Inspected identities carry every option; defaults match an identity declared without options
+	inspected := identityColumn(&ir.Identity{Generation: "ALWAYS", Start: int64Ptr(1), Increment: int64Ptr(1), Minimum: int64Ptr(1), Maximum: int64Ptr(2147483647)})
+	declared := identityColumn(&ir.Identity{Generation: "ALWAYS"})
+	if !columnsEqual(inspected, declared, "public") {
+		t.Error("expected default identity options to be equal to omitted ones")
+	}
+	if clause := identityOptionsClause(inspected); clause != "" {
+		t.Errorf("expected no options clause for default identity options, got %q", clause)
+	}
+
+	incremented := identityColumn(&ir.Identity{Generation: "ALWAYS", Start: int64Ptr(1), Increment: int64Ptr(5), Minimum: int64Ptr(1), Maximum: int64Ptr(2147483647)})
+	if columnsEqual(inspected, incremented, "public") {
+		t.Error("expected an identity increment change to be detected")
+	}
+	alter := (&ColumnDiff{Old: inspected, New: incremented}).generateColumnSQL("public", "orders", "public")
+	expected := []string{"ALTER TABLE orders ALTER COLUMN id SET INCREMENT BY 5;"}
+	if !reflect.DeepEqual(alter, expected) {
+		t.Errorf("expected %v, got %v", expected, alter)
+	}
+
+	descending := identityColumn(&ir.Identity{Generation: "BY DEFAULT", Start: int64Ptr(100), Increment: int64Ptr(-1), Minimum: int64Ptr(-2147483648), Maximum: int64Ptr(100), Cycle: true})
+	if clause := identityOptionsClause(descending); clause != " (INCREMENT BY -1 MAXVALUE 100 CYCLE)" {
+		t.Errorf("unexpected options clause for a descending identity: %q", clause)
+	}
+	add := (&ColumnDiff{Old: identityColumn(nil), New: descending}).generateColumnSQL("public", "orders", "public")
+	expected = []string{"ALTER TABLE orders ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY -1 MAXVALUE 100 CYCLE);"}
+	if !reflect.DeepEqual(add, expected) {
+		t.Errorf("expected %v, got %v", expected, add)
+	}
+}
+
+func TestVirtualGeneratedColumn(t *testing.T) {
+	expr := "(price * quantity)"
+	stored := &ir.Column{Name: "total", Position: 3, DataType: "numeric", IsNullable: true, IsGenerated: true, GeneratedExpr: &expr}
+	virtual := &ir.Column{Name: "total", Position: 3, DataType: "numeric", IsNullable: true, IsGenerated: true, GeneratedExpr: &expr, GeneratedVirtual: true}
+	orders := &ir.Table{Schema: "public", Name: "orders"}
+
+	if got := buildColumnClauses(orders, stored, false, "public"); got != " GENERATED ALWAYS AS ((price * quantity)) STORED" {
+		t.Errorf("unexpected clauses for a stored generated column: %q", got)
+	}
+	if got := buildColumnClauses(orders, virtual, false, "public"); got != " GENERATED ALWAYS AS ((price * quantity)) VIRTUAL" {
+		t.Errorf("unexpected clauses for a virtual generated column: %q", got)
+	}
+
+	withColumn := func(column *ir.Column) *ir.IR {
+		state := ir.NewIR()
+		schema := state.GetOrCreateSchema("public")
+		schema.Tables["orders"] = &ir.Table{
+			Schema:      "public",
+			Name:        "orders",
+			Type:        ir.TableTypeBase,
+			Columns:     []*ir.Column{column},
+			Constraints: map[string]*ir.Constraint{},
+			Indexes:     map[string]*ir.Index{},
+			Triggers:    map[string]*ir.Trigger{},
+			Policies:    map[string]*ir.RLSPolicy{},
+		}
+		return state
+	}
+
+	// Virtual generated columns cannot be created before PostgreSQL 18
+	_, err := GenerateMigrationWithOptions(ir.NewIR(), withColumn(virtual), "public", MigrationOptions{})
+	if err != nil {
+		t.Errorf("expected no error for an unknown target version, got %v", err)
+	}
+	oldIR := ir.NewIR()
+	oldIR.Metadata.DatabaseVersion = "PostgreSQL 17.4"
+	_, err = GenerateMigrationWithOptions(oldIR, withColumn(virtual), "public", MigrationOptions{})
+	if err == nil || !strings.Contains(err.Error(), "PostgreSQL 18 or later") || !strings.Contains(err.Error(), "public.orders.total") {
+		t.Errorf("expected an error naming the virtual column, got %v", err)
+	}
+	if _, err = GenerateMigrationWithOptions(oldIR, withColumn(stored), "public", MigrationOptions{}); err != nil {
+		t.Errorf("expected stored generated columns to be allowed, got %v", err)
+	}
+	oldIR.Metadata.DatabaseVersion = "PostgreSQL 18.0"
+	if _, err = GenerateMigrationWithOptions(oldIR, withColumn(virtual), "public", MigrationOptions{}); err != nil {
+		t.Errorf("expected virtual generated columns to be allowed on PostgreSQL 18, got %v", err)
+	}
+}
+
+// TestVirtualGeneratedColumnRoundTrip checks that virtual and stored generated columns are told
+// apart when inspected and dumped, and produce no differences once applied (PostgreSQL 18+).
+func TestVirtualGeneratedColumnRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	conn, _, _, _, _, _ := testutil.ConnectToPostgres(t, sharedTestPostgres)
+	majorVersion, err := testutil.GetMajorVersion(conn)
+	conn.Close()
+	if err != nil {
+		t.Fatalf("failed to detect PostgreSQL version: %v", err)
+	}
+	if majorVersion < 18 {
+		t.Skipf("VIRTUAL generated columns are supported starting with PostgreSQL 18, got %d", majorVersion)
+	}
+
+	appliedIR := testutil.ParseSQLToIR(t, sharedTestPostgres, `CREATE TABLE orders (
+    price numeric NOT NULL,
+    quantity integer NOT NULL,
+    total numeric GENERATED ALWAYS AS (price * quantity) VIRTUAL,
+    total_stored numeric GENERATED ALWAYS AS (price * quantity) STORED
+);`, "public")
+
+	columns := appliedIR.Schemas["public"].Tables["orders"].Columns
+	if !columns[2].GeneratedVirtual || columns[3].GeneratedVirtual {
+		t.Fatalf("expected only total to be virtual, got %+v and %+v", columns[2], columns[3])
+	}
+
+	dumpSQL := buildSQLFromSteps(GenerateMigration(ir.NewIR(), appliedIR, "public"))
+	for _, expected := range []string{"VIRTUAL", "STORED"} {
+		if !strings.Contains(dumpSQL, expected) {
+			t.Errorf("expected dump to contain %q, got:\n%s", expected, dumpSQL)
+		}
+	}
+	dumpedIR := testutil.ParseSQLToIR(t, sharedTestPostgres, dumpSQL, "public")
+	if diffs := GenerateMigration(appliedIR, dumpedIR, "public"); len(diffs) != 0 {
+		t.Errorf("expected no differences after round-trip, got %s", buildSQLFromSteps(diffs))
+	}
+}
@@ -2,6 +2,7 @@ package diff
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/pgplex/pgschema/ir"
@@ -21,10 +22,10 @@ func generateConstraintSQL(constraint *ir.Constraint, targetSchema string) strin
 	switch constraint.Type {
 	case ir.ConstraintTypePrimaryKey:
 		// Always include CONSTRAINT name to be explicit and consistent
-		return fmt.Sprintf("CONSTRAINT %s PRIMARY KEY (%s)", ir.QuoteIdentifier(constraint.Name), strings.Join(getColumnNames(constraint.Columns), ", "))
+		return fmt.Sprintf("CONSTRAINT %s PRIMARY KEY (%s)%s%s", ir.QuoteIdentifier(constraint.Name), strings.Join(getColumnNames(constraint.Columns), ", "), generateIncludeClause(constraint), generateDeferrableClause(constraint))
 	case ir.ConstraintTypeUnique:
 		// Always include CONSTRAINT name to be explicit and consistent
-		return fmt.Sprintf("CONSTRAINT %s UNIQUE (%s)", ir.QuoteIdentifier(constraint.Name), strings.Join(getColumnNames(constraint.Columns), ", "))
+		return fmt.Sprintf("CONSTRAINT %s UNIQUE (%s)%s%s", ir.QuoteIdentifier(constraint.Name), strings.Join(getColumnNames(constraint.Columns), ", "), generateIncludeClause(constraint), generateDeferrableClause(constraint))
 	case ir.ConstraintTypeForeignKey:
 		// Always include CONSTRAINT name to preserve explicit FK names
 		// Use QualifyEntityNameWithQuotes to add schema qualifier when referencing tables in other schemas
@@ -41,13 +42,7 @@ func generateConstraintSQL(constraint *ir.Constraint, targetSchema string) strin
 			stmt += fmt.Sprintf(" ON DELETE %s", constraint.DeleteRule)
 		}
 		// Add deferrable clause
-		if constraint.Deferrable {
-			if constraint.InitiallyDeferred {
-				stmt += " DEFERRABLE INITIALLY DEFERRED"
-			} else {
-				stmt += " DEFERRABLE"
-			}
-		}
+		stmt += generateDeferrableClause(constraint)
 		// Add NOT VALID if needed
 		if !constraint.IsValid {
 			stmt += " NOT VALID"
@@ -70,6 +65,59 @@ func generateConstraintSQL(constraint *ir.Constraint, targetSchema string) strin
 	}
 }
 
+// generateIncludeClause returns the INCLUDE clause listing the non-key columns of a
+// primary key or unique constraint, or an empty string when there are none.
+func generateIncludeClause(constraint *ir.Constraint) string {
+	if len(constraint.IncludeColumns) == 0 {
+		return ""
+	}
+	var names []string
+	for _, name := range constraint.IncludeColumns {
+		names = append(names, ir.QuoteIdentifier(name))
+	}
+	return fmt.Sprintf(" INCLUDE (%s)", strings.Join(names, ", "))
+}
+
+// generateDeferrableClause returns the DEFERRABLE suffix for a constraint, or an empty
+// string when the constraint is not deferrable (the PostgreSQL default).
+func generateDeferrableClause(constraint *ir.Constraint) string {
+	if !constraint.Deferrable {
+		return ""
+	}
+	if constraint.InitiallyDeferred {
+		return " DEFERRABLE INITIALLY DEFERRED"
+	}
+	return " DEFERRABLE"
+}
+
+// deferrabilityOnlyChange reports whether a foreign key differs only in its DEFERRABLE or
+// INITIALLY setting, which ALTER CONSTRAINT can change in place without revalidating rows.
+func deferrabilityOnlyChange(old, new *ir.Constraint) bool {
+	if old.Type != ir.ConstraintTypeForeignKey || new.Type != ir.ConstraintTypeForeignKey {
+		return false
+	}
+	if old.Deferrable == new.Deferrable && old.InitiallyDeferred == new.InitiallyDeferred {
+		return false
+	}
+	adjusted := *old
+	adjusted.Deferrable = new.Deferrable
+	adjusted.InitiallyDeferred = new.InitiallyDeferred
+	return constraintsEqual(&adjusted, new)
+}
+
+// generateAlterDeferrabilitySQL returns the ALTER CONSTRAINT statement that sets a foreign key's
+// deferrability to match the desired constraint.
+func generateAlterDeferrabilitySQL(tableName string, constraint *ir.Constraint) string {
+	clause := "NOT DEFERRABLE"
+	if constraint.Deferrable {
+		clause = "DEFERRABLE INITIALLY IMMEDIATE"
+		if constraint.InitiallyDeferred {
+			clause = "DEFERRABLE INITIALLY DEFERRED"
+		}
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER CONSTRAINT %s %s;", tableName, ir.QuoteIdentifier(constraint.Name), clause)
+}
+
 // getInlineConstraintsForTable returns constraints in the correct order: PRIMARY KEY, UNIQUE, FOREIGN KEY
 func getInlineConstraintsForTable(table *ir.Table) []*ir.Constraint {
 	var inlineConstraints []*ir.Constraint
@@ -114,6 +162,57 @@ func getInlineConstraintsForTable(table *ir.Table) []*ir.Constraint {
 	return inlineConstraints
 }
 
+// detectRenamedConstraints pairs dropped constraints with added constraints that are identical
+// except for their name, so they can be renamed instead of dropped and recreated. Rebuilding a
+// foreign key or unique constraint rescans or reindexes the table, while a rename is catalog-only.
+// It returns the renames along with the remaining dropped and added constraints.
+func detectRenamedConstraints(dropped, added []*ir.Constraint) ([]*ConstraintDiff, []*ir.Constraint, []*ir.Constraint) {
+	if len(dropped) == 0 || len(added) == 0 {
+		return nil, dropped, added
+	}
+
+	// Sort both sides by name so pairing is deterministic
+	sortedDropped := append([]*ir.Constraint(nil), dropped...)
+	sort.Slice(sortedDropped, func(i, j int) bool { return sortedDropped[i].Name < sortedDropped[j].Name })
+	sortedAdded := append([]*ir.Constraint(nil), added...)
+	sort.Slice(sortedAdded, func(i, j int) bool { return sortedAdded[i].Name < sortedAdded[j].Name })
+
+	var renamed []*ConstraintDiff
+	var remainingDropped []*ir.Constraint
+	matched := make(map[*ir.Constraint]bool)
+	for _, old := range sortedDropped {
+		var match *ir.Constraint
+		for _, candidate := range sortedAdded {
+			if !matched[candidate] && constraintsEqualIgnoringName(old, candidate) {
+				match = candidate
+				break
+			}
+		}
+		if match == nil {
+			remainingDropped = append(remainingDropped, old)
+			continue
+		}
+		matched[match] = true
+		renamed = append(renamed, &ConstraintDiff{Old: old, New: match})
+	}
+
+	var remainingAdded []*ir.Constraint
+	for _, constraint := range added {
+		if !matched[constraint] {
+			remainingAdded = append(remainingAdded, constraint)
+		}
+	}
+
+	return renamed, remainingDropped, remainingAdded
+}
+
+// constraintsEqualIgnoringName reports whether two constraints differ only by name
+func constraintsEqualIgnoringName(old, new *ir.Constraint) bool {
+	renamed := *old
+	renamed.Name = new.Name
+	return constraintsEqual(&renamed, new)
+}
+
 // constraintsEqual compares two constraints for equality
 func constraintsEqual(old, new *ir.Constraint) bool {
 	// Basic properties
@@ -176,6 +275,11 @@ func constraintsEqual(old, new *ir.Constraint) bool {
 		}
 	}
 
+	// Compare non-key (INCLUDE) columns
+	if strings.Join(old.IncludeColumns, ",") != strings.Join(new.IncludeColumns, ",") {
+		return false
+	}
+
 	// Compare referenced columns
 	if len(old.ReferencedColumns) != len(new.ReferencedColumns) {
 		return false
@@ -0,0 +1,121 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestDetectRenamedConstraints(t *testing.T) {
+	check := func(name, clause string) *ir.Constraint {
+		return &ir.Constraint{
+			Schema:      "public",
+			Table:       "employees",
+			Name:        name,
+			Type:        ir.ConstraintTypeCheck,
+			CheckClause: clause,
+			IsValid:     true,
+		}
+	}
+	foreignKey := func(name, deleteRule string) *ir.Constraint {
+		return &ir.Constraint{
+			Schema:            "public",
+			Table:             "employees",
+			Name:              name,
+			Type:              ir.ConstraintTypeForeignKey,
+			Columns:           []*ir.ConstraintColumn{{Name: "department_id", Position: 1}},
+			ReferencedSchema:  "public",
+			ReferencedTable:   "departments",
+			ReferencedColumns: []*ir.ConstraintColumn{{Name: "id", Position: 1}},
+			DeleteRule:        deleteRule,
+			UpdateRule:        "NO ACTION",
+			IsValid:           true,
+		}
+	}
+
+	t.Run("renames check and foreign key", func(t *testing.T) {
+		dropped := []*ir.Constraint{check("salary_check", "CHECK (salary > 0)"), foreignKey("employees_department_id_fkey", "NO ACTION")}
+		added := []*ir.Constraint{foreignKey("fk_employees_department", "NO ACTION"), check("salary_positive", "CHECK (salary > 0)")}
+
+		renamed, remainingDropped, remainingAdded := detectRenamedConstraints(dropped, added)
+		if len(renamed) != 2 {
+			t.Fatalf("expected 2 renames, got %d", len(renamed))
+		}
+		if len(remainingDropped) != 0 || len(remainingAdded) != 0 {
+			t.Fatalf("expected no remaining drops or adds, got %d drops and %d adds", len(remainingDropped), len(remainingAdded))
+		}
+
+		got := map[string]string{}
+		for _, r := range renamed {
+			got[r.Old.Name] = r.New.Name
+		}
+		if got["salary_check"] != "salary_positive" {
+			t.Errorf("expected salary_check to be renamed to salary_positive, got %q", got["salary_check"])
+		}
+		if got["employees_department_id_fkey"] != "fk_employees_department" {
+			t.Errorf("expected employees_department_id_fkey to be renamed to fk_employees_department, got %q", got["employees_department_id_fkey"])
+		}
+	})
+
+	t.Run("structural change is not a rename", func(t *testing.T) {
+		dropped := []*ir.Constraint{check("salary_check", "CHECK (salary > 0)"), foreignKey("employees_department_id_fkey", "NO ACTION")}
+		added := []*ir.Constraint{check("salary_positive", "CHECK (salary >= 0)"), foreignKey("fk_employees_department", "CASCADE")}
+
+		renamed, remainingDropped, remainingAdded := detectRenamedConstraints(dropped, added)
+		if len(renamed) != 0 {
+			t.Fatalf("expected no renames, got %d", len(renamed))
+		}
+		if len(remainingDropped) != 2 || len(remainingAdded) != 2 {
+			t.Fatalf("expected 2 drops and 2 adds, got %d drops and %d adds", len(remainingDropped), len(remainingAdded))
+		}
+	})
+}
+
+func TestDeferrabilityOnlyChange(t *testing.T) {
+	foreignKey := func(deferrable, initiallyDeferred bool) *ir.Constraint {
+		return &ir.Constraint{
+			Schema:            "public",
+			Table:             "employees",
+			Name:              "employees_department_id_fkey",
+			Type:              ir.ConstraintTypeForeignKey,
+			Columns:           []*ir.ConstraintColumn{{Name: "department_id", Position: 1}},
+			ReferencedSchema:  "public",
+			ReferencedTable:   "departments",
+			ReferencedColumns: []*ir.ConstraintColumn{{Name: "id", Position: 1}},
+			DeleteRule:        "NO ACTION",
+			UpdateRule:        "NO ACTION",
+			Deferrable:        deferrable,
+			InitiallyDeferred: initiallyDeferred,
+			IsValid:           true,
+		}
+	}
+
+	immediate := foreignKey(false, false)
+	deferred := foreignKey(true, true)
+	if !deferrabilityOnlyChange(immediate, deferred) {
+		t.Fatal("expected a deferrability-only change to be detected")
+	}
+
+	tests := []struct {
+		constraint *ir.Constraint
+		expected   string
+	}{
+		{deferred, "ALTER TABLE employees ALTER CONSTRAINT employees_department_id_fkey DEFERRABLE INITIALLY DEFERRED;"},
+		{foreignKey(true, false), "ALTER TABLE employees ALTER CONSTRAINT employees_department_id_fkey DEFERRABLE INITIALLY IMMEDIATE;"},
+		{immediate, "ALTER TABLE employees ALTER CONSTRAINT employees_department_id_fkey NOT DEFERRABLE;"},
+	}
+	for _, tt := range tests {
+		if got := generateAlterDeferrabilitySQL("employees", tt.constraint); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+
+	cascading := foreignKey(true, true)
+	cascading.DeleteRule = "CASCADE"
+	if deferrabilityOnlyChange(immediate, cascading) {
+		t.Error("expected a delete rule change to require recreating the constraint")
+	}
+	if deferrabilityOnlyChange(immediate, foreignKey(false, false)) {
+		t.Error("expected identical constraints not to be a deferrability change")
+	}
+}
@@ -252,6 +252,10 @@ type Diff struct {
 	Operation  DiffOperation  `json:"operation"` // create, alter, drop, replace
 	Path       string         `json:"path"`
 	Source     DiffSource     `json:"-"` // interface; not JSON-serializable (see #305)
+	// NoRewrite keeps the statements as generated instead of rewriting them for online execution
+	NoRewrite bool `json:"-"`
+	// placedAfter is the dependency a topological sort placed the created object after (see ExplainOrdering)
+	placedAfter *orderingDependency
 }
 
 type ddlDiff struct {
@@ -285,12 +289,20 @@ type ddlDiff struct {
 	droppedPrivileges               []*ir.Privilege
 	modifiedPrivileges              []*privilegeDiff
 	revokedDefaultGrantsOnNewTables []*ir.Privilege // Privileges to revoke on newly created tables (issue #253)
+	retainedPrivileges              []*ir.Privilege // Desired privileges already granted, re-granted after a REVOKE ... CASCADE
 	addedRevokedDefaultPrivs        []*ir.RevokedDefaultPrivilege
 	droppedRevokedDefaultPrivs      []*ir.RevokedDefaultPrivilege
 	// Column-level privileges
 	addedColumnPrivileges    []*ir.ColumnPrivilege
 	droppedColumnPrivileges  []*ir.ColumnPrivilege
 	modifiedColumnPrivileges []*columnPrivilegeDiff
+	retainedColumnPrivileges []*ir.ColumnPrivilege // Desired column privileges already granted, re-granted after a REVOKE ... CASCADE
+	// semanticBody compares function and procedure bodies ignoring formatting (see bodiesEqual)
+	semanticBody bool
+	// cascadeDrops holds the diff types dropped with CASCADE instead of RESTRICT (see MigrationOptions.CascadeDrops)
+	cascadeDrops map[DiffType]bool
+	// orderingEdges records why the topological sorts placed created objects where they are
+	orderingEdges orderingEdges
 }
 
 // schemaDiff represents changes to a schema
@@ -354,13 +366,16 @@ type viewDiff struct {
 	CommentChanged   bool
 	OldComment       string
 	NewComment       string
-	AddedIndexes     []*ir.Index    // For materialized views
-	DroppedIndexes   []*ir.Index    // For materialized views
-	ModifiedIndexes  []*IndexDiff   // For materialized views
-	AddedTriggers    []*ir.Trigger  // For INSTEAD OF triggers on views
-	DroppedTriggers  []*ir.Trigger  // For INSTEAD OF triggers on views
-	ModifiedTriggers []*triggerDiff // For INSTEAD OF triggers on views
-	RequiresRecreate bool           // For materialized views with structural changes that require DROP + CREATE
+	OptionsChanged   bool               // WITH (...) options changed (regular views only)
+	ColumnsChanged   bool               // Column renames, comments, or storage changed (materialized views only)
+	RenamedColumns   []viewColumnRename // Columns renamed in place (materialized views only)
+	AddedIndexes     []*ir.Index        // For materialized views
+	DroppedIndexes   []*ir.Index        // For materialized views
+	ModifiedIndexes  []*IndexDiff       // For materialized views
+	AddedTriggers    []*ir.Trigger      // For INSTEAD OF triggers on views
+	DroppedTriggers  []*ir.Trigger      // For INSTEAD OF triggers on views
+	ModifiedTriggers []*triggerDiff     // For INSTEAD OF triggers on views
+	RequiresRecreate bool               // For materialized views with structural changes that require DROP + CREATE
 }
 
 // tableDiff represents changes to a table
@@ -372,6 +387,7 @@ type tableDiff struct {
 	AddedConstraints    []*ir.Constraint
 	DroppedConstraints  []*ir.Constraint
 	ModifiedConstraints []*ConstraintDiff
+	RenamedConstraints  []*ConstraintDiff // Structurally identical constraints with a new name
 	AddedIndexes        []*ir.Index
 	DroppedIndexes      []*ir.Index
 	ModifiedIndexes     []*IndexDiff
@@ -385,12 +401,18 @@ type tableDiff struct {
 	CommentChanged      bool
 	OldComment          string
 	NewComment          string
+	PersistenceChanged  bool               // LOGGED <-> UNLOGGED transition
+	StorageChanged      bool               // Storage parameters (WITH (...) / toast.*) changed
+	OldStorage          []string           // Storage parameters before the change
+	PartitionChanged    bool               // Parent or bound of the partition changed
+	OldPartitionOf      *ir.PartitionBound // Partition bound before the change
 }
 
 // ColumnDiff represents changes to a column
 type ColumnDiff struct {
-	Old *ir.Column
-	New *ir.Column
+	Old   *ir.Column
+	New   *ir.Column
+	Using string // USING expression for the type change; empty uses the default conversion
 }
 
 // ConstraintDiff represents changes to a constraint
@@ -418,8 +440,57 @@ type rlsChange struct {
 	Forced  *bool // nil = no change, true = force, false = no force
 }
 
-// GenerateMigration compares two IR schemas and returns the SQL differences
+// MigrationOptions controls how GenerateMigrationWithOptions handles changes that can fail on apply
+type MigrationOptions struct {
+	// AllowUnsafeTypeChanges permits column type changes without an implicit or assignment cast
+	// (e.g., text -> integer). They use the column's USING expression if one is given, and an
+	// explicit cast of the column otherwise.
+	AllowUnsafeTypeChanges bool
+	// UsingExpressions maps "schema.table.column" to the USING expression for that column's type change
+	UsingExpressions map[string]string
+	// SemanticBodyCompare treats SQL and PL/pgSQL function and procedure bodies that differ only in
+	// whitespace or comments as equal. By default, bodies must match character for character.
+	SemanticBodyCompare bool
+	// CascadeDrops lists the object categories (see CascadeDropCategories) whose DROP statements use
+	// CASCADE instead of RESTRICT, dropping the table columns and other objects that depend on them
+	CascadeDrops []string
+	// DefaultTablespace is the tablespace that created tables and indexes without one are placed in.
+	// Tablespaces of existing objects are never compared, so they are not moved.
+	DefaultTablespace string
+}
+
+// UnsafeTypeChangeError lists column type changes that need a USING clause but were not allowed
+type UnsafeTypeChangeError struct {
+	Changes []string // e.g., "public.users.age (text -> integer)"
+}
+
+// Error returns a message listing every disallowed type change
+func (e *UnsafeTypeChangeError) Error() string {
+	return fmt.Sprintf("column type changes without an implicit cast require manual intervention: %s", strings.Join(e.Changes, ", "))
+}
+
+// GenerateMigration compares two IR schemas and returns the SQL differences.
+// All column type changes are allowed; see GenerateMigrationWithOptions to restrict them.
 func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
+	diffs, _ := GenerateMigrationWithOptions(oldIR, newIR, targetSchema, MigrationOptions{AllowUnsafeTypeChanges: true})
+	return diffs
+}
+
+// GenerateMigrationWithOptions compares two IR schemas and returns the SQL differences.
+// It returns an *UnsafeTypeChangeError if a column type change needs a USING clause and
+// options.AllowUnsafeTypeChanges is not set.
+func GenerateMigrationWithOptions(oldIR, newIR *ir.IR, targetSchema string, options MigrationOptions) ([]Diff, error) {
+	cascadeDrops, err := cascadeDropTypes(options.CascadeDrops)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVirtualGeneratedColumns(oldIR, newIR); err != nil {
+		return nil, err
+	}
+	if err := checkDomainRecreations(oldIR, newIR); err != nil {
+		return nil, err
+	}
+
 	diff := &ddlDiff{
 		addedSchemas:               []*ir.Schema{},
 		droppedSchemas:             []*ir.Schema{},
@@ -453,6 +524,9 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 		addedColumnPrivileges:      []*ir.ColumnPrivilege{},
 		droppedColumnPrivileges:    []*ir.ColumnPrivilege{},
 		modifiedColumnPrivileges:   []*columnPrivilegeDiff{},
+		semanticBody:               options.SemanticBodyCompare,
+		cascadeDrops:               cascadeDrops,
+		orderingEdges:              orderingEdges{},
 	}
 
 	// Compare schemas first in deterministic order
@@ -558,7 +632,16 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 				}
 			} else {
 				if tableDiff := diffTables(oldTable, newTable, targetSchema); tableDiff != nil {
-					diff.modifiedTables = append(diff.modifiedTables, tableDiff)
+					// Partitions take column changes from their parent, which is in both states as well
+					if newTable.PartitionOf != nil {
+						parentKey := newTable.PartitionOf.ParentSchema + "." + newTable.PartitionOf.ParentTable
+						if oldParent, newParent := oldTables[parentKey], newTables[parentKey]; oldParent != nil && newParent != nil {
+							tableDiff.removeInheritedColumnChanges(oldParent, newParent, targetSchema)
+						}
+					}
+					if !tableDiff.isEmpty() {
+						diff.modifiedTables = append(diff.modifiedTables, tableDiff)
+					}
 				}
 			}
 		}
@@ -612,7 +695,7 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 	for _, key := range functionKeys {
 		newFunction := newFunctions[key]
 		if oldFunction, exists := oldFunctions[key]; exists {
-			if !functionsEqual(oldFunction, newFunction) {
+			if !functionsEqual(oldFunction, newFunction, diff.semanticBody) {
 				diff.modifiedFunctions = append(diff.modifiedFunctions, &functionDiff{
 					Old: oldFunction,
 					New: newFunction,
@@ -669,7 +752,7 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 	for _, key := range procedureKeys {
 		newProcedure := newProcedures[key]
 		if oldProcedure, exists := oldProcedures[key]; exists {
-			if !proceduresEqual(oldProcedure, newProcedure) {
+			if !proceduresEqual(oldProcedure, newProcedure, diff.semanticBody) {
 				diff.modifiedProcedures = append(diff.modifiedProcedures, &procedureDiff{
 					Old: oldProcedure,
 					New: newProcedure,
@@ -724,7 +807,10 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 	for _, key := range typeKeys {
 		newType := newTypes[key]
 		if oldType, exists := oldTypes[key]; exists {
-			if !typesEqual(oldType, newType) {
+			if domainNeedsRecreate(oldType, newType) {
+				diff.droppedTypes = append(diff.droppedTypes, oldType)
+				diff.addedTypes = append(diff.addedTypes, newType)
+			} else if !typesEqual(oldType, newType) {
 				diff.modifiedTypes = append(diff.modifiedTypes, &typeDiff{
 					Old: oldType,
 					New: newType,
@@ -781,6 +867,17 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 		if oldView, exists := oldViews[key]; exists {
 			structurallyDifferent := !viewsEqual(oldView, newView)
 			commentChanged := oldView.Comment != newView.Comment
+			optionsChanged := !relOptionsEqual(oldView.Options, newView.Options)
+
+			// A materialized view whose columns were only renamed is altered in place instead of recreated
+			var renamedColumns []viewColumnRename
+			if structurallyDifferent && oldView.Materialized && newView.Materialized {
+				if renames, ok := materializedViewColumnRenames(oldView, newView); ok {
+					renamedColumns = renames
+					structurallyDifferent = false
+				}
+			}
+			columnsChanged := newView.Materialized && (len(renamedColumns) > 0 || !viewColumnAttributesEqual(oldView, newView, renamedColumns))
 
 			// Check if indexes changed for materialized views
 			indexesChanged := false
@@ -811,7 +908,7 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 							if oldIndex, exists := oldView.Indexes[indexName]; exists {
 								structurallyEqual := indexesStructurallyEqual(oldIndex, newIndex)
 								commentChanged := oldIndex.Comment != newIndex.Comment
-								if !structurallyEqual || commentChanged {
+								if !structurallyEqual || commentChanged || !indexStatisticsEqual(oldIndex, newIndex) {
 									indexesChanged = true
 									break
 								}
@@ -825,7 +922,7 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 			addedTriggers, droppedTriggers, modifiedTriggers := diffViewTriggers(oldView, newView)
 			triggersChanged := len(addedTriggers) > 0 || len(droppedTriggers) > 0 || len(modifiedTriggers) > 0
 
-			if structurallyDifferent || commentChanged || indexesChanged || triggersChanged {
+			if structurallyDifferent || commentChanged || optionsChanged || indexesChanged || triggersChanged || columnsChanged {
 				// For materialized views with structural changes, mark for recreation
 				// For regular views with column changes incompatible with CREATE OR REPLACE VIEW,
 				// also mark for recreation (issue #308)
@@ -842,6 +939,9 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 					viewDiff := &viewDiff{
 						Old:              oldView,
 						New:              newView,
+						OptionsChanged:   optionsChanged,
+						ColumnsChanged:   columnsChanged,
+						RenamedColumns:   renamedColumns,
 						AddedTriggers:    addedTriggers,
 						DroppedTriggers:  droppedTriggers,
 						ModifiedTriggers: modifiedTriggers,
@@ -884,9 +984,10 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 							if oldIndex, exists := oldIndexes[indexName]; exists {
 								structurallyEqual := indexesStructurallyEqual(oldIndex, newIndex)
 								commentChanged := oldIndex.Comment != newIndex.Comment
+								statisticsChanged := !indexStatisticsEqual(oldIndex, newIndex)
 
-								// If either structure changed or comment changed, treat as modification
-								if !structurallyEqual || commentChanged {
+								// If structure, comment, or statistics targets changed, treat as modification
+								if !structurallyEqual || commentChanged || statisticsChanged {
 									viewDiff.ModifiedIndexes = append(viewDiff.ModifiedIndexes, &IndexDiff{
 										Old: oldIndex,
 										New: newIndex,
@@ -934,11 +1035,19 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 	for _, key := range seqKeys {
 		seq := newSequences[key]
 		if _, exists := oldSequences[key]; !exists {
-			// Skip sequences owned by table columns only if the column is also new
+			// Skip the implicit sequence of a SERIAL column only if the column is also new
 			// (created by SERIAL in CREATE TABLE). If the column already exists,
 			// we need to create the sequence explicitly for ALTER COLUMN to use.
 			if seq.OwnedByTable != "" && seq.OwnedByColumn != "" && !columnExistsInTables(oldTables, seq.Schema, seq.OwnedByTable, seq.OwnedByColumn) {
-				continue
+				if isSerialSequence(seq) {
+					continue
+				}
+				// Any other sequence is created ahead of the new table that owns it, so
+				// OWNED BY is left out; ownership follows from the column default
+				standalone := *seq
+				standalone.OwnedByTable = ""
+				standalone.OwnedByColumn = ""
+				seq = &standalone
 			}
 			diff.addedSequences = append(diff.addedSequences, seq)
 		}
@@ -961,9 +1070,8 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 	for _, key := range seqKeys {
 		newSeq := newSequences[key]
 		if oldSeq, exists := oldSequences[key]; exists {
-			// Skip sequences owned by table columns (created by SERIAL)
-			if (oldSeq.OwnedByTable != "" && oldSeq.OwnedByColumn != "") ||
-				(newSeq.OwnedByTable != "" && newSeq.OwnedByColumn != "") {
+			// Skip the implicit sequences of SERIAL columns
+			if isSerialSequence(oldSeq) || isSerialSequence(newSeq) {
 				continue
 			}
 			if !sequencesEqual(oldSeq, newSeq) {
@@ -1206,6 +1314,17 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 		return diff.modifiedPrivileges[i].New.GetObjectKey() < diff.modifiedPrivileges[j].New.GetObjectKey()
 	})
 
+	// Keep the desired privileges that already exist so they can be restored when a
+	// REVOKE ... CASCADE removes them as dependents of another grantee's grant option
+	for fullKey, p := range newPrivs {
+		if matchedNew[fullKey] {
+			diff.retainedPrivileges = append(diff.retainedPrivileges, p)
+		}
+	}
+	sort.Slice(diff.retainedPrivileges, func(i, j int) bool {
+		return diff.retainedPrivileges[i].GetObjectKey() < diff.retainedPrivileges[j].GetObjectKey()
+	})
+
 	// Compare revoked default privileges across all schemas
 	oldRevokedPrivs := make(map[string]*ir.RevokedDefaultPrivilege)
 	newRevokedPrivs := make(map[string]*ir.RevokedDefaultPrivilege)
@@ -1342,19 +1461,29 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 		return diff.modifiedColumnPrivileges[i].New.GetObjectKey() < diff.modifiedColumnPrivileges[j].New.GetObjectKey()
 	})
 
+	// Keep the desired column privileges that already exist (see retainedPrivileges)
+	for fullKey, cp := range newColPrivs {
+		if matchedNewColPrivs[fullKey] {
+			diff.retainedColumnPrivileges = append(diff.retainedColumnPrivileges, cp)
+		}
+	}
+	sort.Slice(diff.retainedColumnPrivileges, func(i, j int) bool {
+		return diff.retainedColumnPrivileges[i].GetFullKey() < diff.retainedColumnPrivileges[j].GetFullKey()
+	})
+
 	// Sort tables and views topologically for consistent ordering
 	// Pre-sort by name to ensure deterministic insertion order for cycle breaking
 	sort.Slice(diff.addedTables, func(i, j int) bool {
 		return diff.addedTables[i].Schema+"."+diff.addedTables[i].Name < diff.addedTables[j].Schema+"."+diff.addedTables[j].Name
 	})
-	diff.addedTables = topologicallySortTables(diff.addedTables)
+	diff.addedTables = topologicallySortTables(diff.addedTables, diff.orderingEdges)
 
 	sort.Slice(diff.droppedTables, func(i, j int) bool {
 		return diff.droppedTables[i].Schema+"."+diff.droppedTables[i].Name < diff.droppedTables[j].Schema+"."+diff.droppedTables[j].Name
 	})
-	diff.droppedTables = reverseSlice(topologicallySortTables(diff.droppedTables))
-	diff.addedViews = topologicallySortViews(diff.addedViews)
-	diff.droppedViews = reverseSlice(topologicallySortViews(diff.droppedViews))
+	diff.droppedTables = reverseSlice(topologicallySortTables(diff.droppedTables, nil))
+	diff.addedViews = topologicallySortViews(diff.addedViews, diff.orderingEdges)
+	diff.droppedViews = reverseSlice(topologicallySortViews(diff.droppedViews, nil))
 
 	// Sort ModifiedTables topologically based on constraint dependencies
 	// This ensures that UNIQUE/PK constraints are added before FKs that reference them
@@ -1365,10 +1494,78 @@ func GenerateMigration(oldIR, newIR *ir.IR, targetSchema string) []Diff {
 	// Sort individual table objects (indexes, triggers, policies, constraints) within each table
 	sortTableObjects(diff.modifiedTables)
 
+	// Decide how column type changes convert existing values
+	if err := diff.applyTypeChangeOptions(targetSchema, options); err != nil {
+		return nil, err
+	}
+
+	// Columns of types dropped with CASCADE are removed by the cascade itself
+	diff.omitColumnsDroppedByCascade()
+
+	// Place created tables and indexes without a tablespace in the default one
+	diff.applyDefaultTablespace(options.DefaultTablespace)
+
 	// Create a diffCollector and generate SQL
 	collector := newDiffCollector()
+	collector.edges = diff.orderingEdges
 	diff.collectMigrationSQL(targetSchema, collector)
-	return collector.diffs
+	keepPartitionedForeignKeys(collector.diffs, newIR, oldIR.Metadata.MajorVersion())
+	return collector.diffs, nil
+}
+
+// keepPartitionedForeignKeys marks foreign keys added to partitioned tables to be added as generated,
+// since PostgreSQL before 18 rejects NOT VALID foreign keys on them. The target is assumed to be
+// older if its version is not known.
+func keepPartitionedForeignKeys(diffs []Diff, newIR *ir.IR, major int) {
+	if major >= 18 {
+		return
+	}
+	for i := range diffs {
+		constraint, ok := diffs[i].Source.(*ir.Constraint)
+		if !ok || diffs[i].Type != DiffTypeTableConstraint || diffs[i].Operation != DiffOperationCreate ||
+			constraint.Type != ir.ConstraintTypeForeignKey {
+			continue
+		}
+		if dbSchema, ok := newIR.Schemas[constraint.Schema]; ok {
+			if table, ok := dbSchema.Tables[constraint.Table]; ok && table.IsPartitioned {
+				diffs[i].NoRewrite = true
+			}
+		}
+	}
+}
+
+// applyTypeChangeOptions sets the USING expression of modified columns whose type changes.
+// Built-in type changes without an assignment cast are only allowed with AllowUnsafeTypeChanges.
+func (d *ddlDiff) applyTypeChangeOptions(targetSchema string, options MigrationOptions) error {
+	var unsafeChanges []string
+	for _, td := range d.modifiedTables {
+		for _, cd := range td.ModifiedColumns {
+			oldType := stripSchemaPrefix(cd.Old.DataType, targetSchema)
+			newType := stripSchemaPrefix(cd.New.DataType, targetSchema)
+			if oldType == newType {
+				continue
+			}
+
+			key := fmt.Sprintf("%s.%s.%s", td.Table.Schema, td.Table.Name, cd.New.Name)
+			using := options.UsingExpressions[key]
+
+			// Changes involving custom types keep their existing cast handling (see needsUsingClause)
+			unsafe := ir.IsBuiltInType(oldType) && ir.IsBuiltInType(newType) && !hasAssignmentCast(oldType, newType)
+			if unsafe && !options.AllowUnsafeTypeChanges {
+				unsafeChanges = append(unsafeChanges, fmt.Sprintf("%s (%s -> %s)", key, oldType, newType))
+				continue
+			}
+			if unsafe && using == "" {
+				using = fmt.Sprintf("%s::%s", ir.QuoteIdentifier(cd.New.Name), newType)
+			}
+			cd.Using = using
+		}
+	}
+
+	if len(unsafeChanges) > 0 {
+		return &UnsafeTypeChangeError{Changes: unsafeChanges}
+	}
+	return nil
 }
 
 // collectMigrationSQL populates the collector with SQL statements for the diff
@@ -1601,7 +1798,7 @@ func (d *ddlDiff) generateCreateSQL(targetSchema string, collector *diffCollecto
 	// Revoke default grants on new tables that the user explicitly didn't include
 	// This must happen AFTER tables are created but BEFORE explicit grants
 	// See https://github.com/pgplex/pgschema/issues/253
-	generateDropPrivilegesSQL(d.revokedDefaultGrantsOnNewTables, targetSchema, collector)
+	generateDropPrivilegesSQL(d.revokedDefaultGrantsOnNewTables, nil, targetSchema, collector)
 
 	// Revoke default PUBLIC privileges (new revokes)
 	generateRevokeDefaultPrivilegesSQL(d.addedRevokedDefaultPrivs, targetSchema, collector)
@@ -1645,10 +1842,10 @@ func (d *ddlDiff) generateModifySQL(targetSchema string, collector *diffCollecto
 	generateModifyViewsSQL(d.modifiedViews, targetSchema, collector, preDroppedViews, dependentViewsCtx, recreatedViews)
 
 	// Modify functions
-	generateModifyFunctionsSQL(d.modifiedFunctions, targetSchema, collector)
+	generateModifyFunctionsSQL(d.modifiedFunctions, targetSchema, d.semanticBody, collector)
 
 	// Modify procedures
-	generateModifyProceduresSQL(d.modifiedProcedures, targetSchema, collector)
+	generateModifyProceduresSQL(d.modifiedProcedures, targetSchema, d.semanticBody, collector)
 
 	// Modify default privileges
 	generateModifyDefaultPrivilegesSQL(d.modifiedDefaultPrivileges, targetSchema, collector)
@@ -1658,10 +1855,14 @@ func (d *ddlDiff) generateModifySQL(targetSchema string, collector *diffCollecto
 	// Modifications (which contain REVOKEs) run before creates (which contain GRANTs)
 	// to prevent table-level REVOKEs from undoing column-level GRANTs.
 	// See https://github.com/pgplex/pgschema/issues/324
-	generateModifyPrivilegesSQL(d.modifiedPrivileges, targetSchema, collector)
-	generateModifyColumnPrivilegesSQL(d.modifiedColumnPrivileges, targetSchema, collector)
+	generateModifyPrivilegesSQL(d.modifiedPrivileges, d.retainedPrivileges, targetSchema, collector)
+	generateModifyColumnPrivilegesSQL(d.modifiedColumnPrivileges, d.retainedColumnPrivileges, targetSchema, collector)
 	generateCreatePrivilegesSQL(d.addedPrivileges, targetSchema, collector)
 	generateCreateColumnPrivilegesSQL(d.addedColumnPrivileges, targetSchema, collector)
+
+	// Drop the types that columns changed above no longer use
+	_, lateDropTypes := d.splitDroppedTypes()
+	generateDropTypesSQL(lateDropTypes, targetSchema, d.cascadeDrops, collector)
 }
 
 // generateDropSQL generates DROP statements in reverse dependency order
@@ -1670,8 +1871,8 @@ func (d *ddlDiff) generateDropSQL(targetSchema string, collector *diffCollector,
 
 	// REVOKE privileges BEFORE dropping objects (objects must exist for REVOKE to succeed)
 	generateRestoreDefaultPrivilegesSQL(d.droppedRevokedDefaultPrivs, targetSchema, collector)
-	generateDropColumnPrivilegesSQL(d.droppedColumnPrivileges, targetSchema, collector)
-	generateDropPrivilegesSQL(d.droppedPrivileges, targetSchema, collector)
+	generateDropColumnPrivilegesSQL(d.droppedColumnPrivileges, d.retainedColumnPrivileges, targetSchema, collector)
+	generateDropPrivilegesSQL(d.droppedPrivileges, d.retainedPrivileges, targetSchema, collector)
 	generateDropDefaultPrivilegesSQL(d.droppedDefaultPrivileges, targetSchema, collector)
 
 	// Drop triggers from modified tables and views first (triggers depend on functions)
@@ -1694,8 +1895,9 @@ func (d *ddlDiff) generateDropSQL(targetSchema string, collector *diffCollector,
 	// Drop sequences
 	generateDropSequencesSQL(d.droppedSequences, targetSchema, collector)
 
-	// Drop types
-	generateDropTypesSQL(d.droppedTypes, targetSchema, collector)
+	// Drop types, except those still used by columns that change in the modify phase
+	dropTypes, _ := d.splitDroppedTypes()
+	generateDropTypesSQL(dropTypes, targetSchema, d.cascadeDrops, collector)
 
 	// Drop schemas
 	// Note: Schema deletion is out of scope for schema-level comparisons
@@ -1969,7 +2171,7 @@ func typeMatchesLookup(typeName, defaultSchema string, lookup map[string]struct{
 var functionCallRegex = regexp.MustCompile(`(?i)([a-z_][a-z0-9_$]*(?:\.[a-z_][a-z0-9_$]*)*)\s*\(`)
 
 // tableReferencesNewFunction determines if a table references any newly added functions
-// in column defaults, generated columns, or CHECK constraints.
+// in column defaults, generated columns, CHECK and EXCLUDE constraints, or index expressions.
 func tableReferencesNewFunction(table *ir.Table, newFunctions map[string]struct{}) bool {
 	if len(newFunctions) == 0 || table == nil {
 		return false
@@ -1991,13 +2193,30 @@ func tableReferencesNewFunction(table *ir.Table, newFunctions map[string]struct{
 		}
 	}
 
-	// Check CHECK constraints
+	// Check CHECK and EXCLUDE constraints
 	for _, constraint := range table.Constraints {
 		if constraint.Type == ir.ConstraintTypeCheck && constraint.CheckClause != "" {
 			if referencesNewFunction(constraint.CheckClause, table.Schema, newFunctions) {
 				return true
 			}
 		}
+		if constraint.Type == ir.ConstraintTypeExclusion && constraint.ExclusionDefinition != "" {
+			if referencesNewFunction(constraint.ExclusionDefinition, table.Schema, newFunctions) {
+				return true
+			}
+		}
+	}
+
+	// Check index expressions and partial index predicates, since indexes are created with the table
+	for _, index := range table.Indexes {
+		for _, col := range index.Columns {
+			if referencesNewFunction(col.Name, table.Schema, newFunctions) {
+				return true
+			}
+		}
+		if index.IsPartial && referencesNewFunction(index.Where, table.Schema, newFunctions) {
+			return true
+		}
 	}
 
 	return false
@@ -183,8 +183,30 @@ func runFileBasedDiffTest(t *testing.T, oldFile, newFile, diffFile, testName str
 	oldIR := testutil.ParseSQLToIRWithSetup(t, sharedTestPostgres, string(oldDDL), "public", setupSQL)
 	newIR := testutil.ParseSQLToIRWithSetup(t, sharedTestPostgres, string(newDDL), "public", setupSQL)
 
-	// Run diff
-	diffs := GenerateMigration(oldIR, newIR, "public")
+	// Run diff with the plan flags from the optional options.json. Type changes are always
+	// allowed, like GenerateMigration, so diff.sql shows the cast used without a directive.
+	options := testutil.LoadFixtureOptions(t, filepath.Dir(oldFile))
+	diffs, err := GenerateMigrationWithOptions(oldIR, newIR, "public", MigrationOptions{
+		AllowUnsafeTypeChanges: true,
+		CascadeDrops:           options.CascadeDrops,
+		DefaultTablespace:      options.DefaultTablespace,
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate migration: %v", err)
+	}
+	if len(options.Only) > 0 {
+		if diffs, err = FilterByCategory(diffs, options.Only); err != nil {
+			t.Fatalf("Failed to apply only: %v", err)
+		}
+	}
+	if len(options.Filters) > 0 {
+		if diffs, err = FilterByPattern(diffs, options.Filters); err != nil {
+			t.Fatalf("Failed to apply filter: %v", err)
+		}
+	}
+	if options.CommentOnly {
+		diffs = FilterComments(diffs)
+	}
 
 	// Generate migration SQL
 	actualPlan := buildSQLFromSteps(diffs)
@@ -0,0 +1,287 @@
+package diff
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// objectCategories maps the category names accepted by FilterByCategory to the diff types they cover.
+// Sub-resources that are managed independently of their parent (indexes, triggers, policies)
+// get their own category so they can be staged separately from the table changes.
+var objectCategories = map[string][]DiffType{
+	"tables": {
+		DiffTypeTable,
+		DiffTypeTableColumn,
+		DiffTypeTableConstraint,
+		DiffTypeTableRLS,
+		DiffTypeTableComment,
+		DiffTypeTableColumnComment,
+	},
+	"indexes": {
+		DiffTypeTableIndex,
+		DiffTypeTableIndexComment,
+		DiffTypeMaterializedViewIndex,
+		DiffTypeMaterializedViewIndexComment,
+	},
+	"triggers": {
+		DiffTypeTableTrigger,
+		DiffTypeViewTrigger,
+	},
+	"policies": {
+		DiffTypeTablePolicy,
+	},
+	"views": {
+		DiffTypeView,
+		DiffTypeViewComment,
+	},
+	"materialized_views": {
+		DiffTypeMaterializedView,
+		DiffTypeMaterializedViewComment,
+	},
+	"functions":  {DiffTypeFunction},
+	"procedures": {DiffTypeProcedure},
+	"sequences":  {DiffTypeSequence},
+	"types":      {DiffTypeType},
+	"domains":    {DiffTypeDomain},
+	"privileges": {
+		DiffTypePrivilege,
+		DiffTypeColumnPrivilege,
+		DiffTypeDefaultPrivilege,
+		DiffTypeRevokedDefaultPrivilege,
+	},
+}
+
+// ObjectCategories returns the sorted list of category names accepted by FilterByCategory
+func ObjectCategories() []string {
+	names := make([]string, 0, len(objectCategories))
+	for name := range objectCategories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateCategories returns an error if any of the given names is not a known object category
+func ValidateCategories(categories []string) error {
+	_, err := allowedDiffTypes(categories)
+	return err
+}
+
+// relationTypes are the diff types of the tables and views that indexes, triggers, and policies belong to
+var relationTypes = map[DiffType]bool{
+	DiffTypeTable:            true,
+	DiffTypeView:             true,
+	DiffTypeMaterializedView: true,
+}
+
+// subResourceTypes are the diff types of objects created and dropped together with their table or view
+var subResourceTypes = map[DiffType]bool{
+	DiffTypeTableIndex:                   true,
+	DiffTypeTableIndexComment:            true,
+	DiffTypeTableTrigger:                 true,
+	DiffTypeTablePolicy:                  true,
+	DiffTypeMaterializedViewIndex:        true,
+	DiffTypeMaterializedViewIndexComment: true,
+	DiffTypeViewTrigger:                  true,
+}
+
+// FilterByCategory keeps only the diffs whose type belongs to one of the given categories.
+// The relative order of the remaining diffs is preserved, so dependency ordering within
+// the selected categories is unchanged. Indexes, triggers, and policies of a table or view
+// that is created, dropped, or recreated by a filtered-out change are filtered out as well,
+// since they cannot be applied without it.
+func FilterByCategory(diffs []Diff, categories []string) ([]Diff, error) {
+	allowed, err := allowedDiffTypes(categories)
+	if err != nil {
+		return nil, err
+	}
+
+	// Relations whose own creation or removal is not part of the filtered plan
+	excludedRelations := make(map[string]bool)
+	for _, d := range diffs {
+		if !allowed[d.Type] && relationTypes[d.Type] && d.Operation != DiffOperationAlter {
+			excludedRelations[d.Path] = true
+		}
+	}
+
+	filtered := make([]Diff, 0, len(diffs))
+	for _, d := range diffs {
+		if !allowed[d.Type] {
+			continue
+		}
+		if subResourceTypes[d.Type] && excludedRelations[relationPath(d.Path)] {
+			continue
+		}
+		filtered = append(filtered, d)
+	}
+	return filtered, nil
+}
+
+// relationPath returns the "schema.relation" prefix of a sub-resource path such as "schema.table.index"
+func relationPath(path string) string {
+	parts := strings.SplitN(path, ".", 3)
+	if len(parts) < 3 {
+		return path
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// nameFilter is a parsed "category:pattern" filter spec
+type nameFilter struct {
+	category string
+	pattern  string
+}
+
+// ValidateFilters returns an error if any of the given specs is not a valid "category:pattern" filter
+func ValidateFilters(specs []string) error {
+	_, err := parseFilters(specs)
+	return err
+}
+
+// FilterByPattern keeps only the diffs matching at least one "category:pattern" spec, e.g.
+// "tables:users*". The category is an object category accepted by FilterByCategory (a singular
+// name such as "table" also works), and the pattern is a glob (*, ?, [...]) matched against the
+// object name. Columns, constraints, and other table changes match on their table's name, while
+// indexes, triggers, and policies match on their own name. The relative order of the remaining
+// diffs is preserved.
+func FilterByPattern(diffs []Diff, specs []string) ([]Diff, error) {
+	filters, err := parseFilters(specs)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryOf := make(map[DiffType]string)
+	for category, types := range objectCategories {
+		for _, t := range types {
+			categoryOf[t] = category
+		}
+	}
+
+	filtered := make([]Diff, 0, len(diffs))
+	for _, d := range diffs {
+		category := categoryOf[d.Type]
+		name := filterTarget(d.Path, category)
+		for _, f := range filters {
+			if f.category != category {
+				continue
+			}
+			if matched, _ := filepath.Match(f.pattern, name); matched {
+				filtered = append(filtered, d)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// parseFilters splits "category:pattern" specs and resolves their category names
+func parseFilters(specs []string) ([]nameFilter, error) {
+	filters := make([]nameFilter, 0, len(specs))
+	for _, spec := range specs {
+		name, pattern, ok := strings.Cut(strings.TrimSpace(spec), ":")
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("invalid filter %q: expected category:pattern (e.g., tables:users*)", spec)
+		}
+		category, ok := lookupCategory(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid filter %q: unknown object category %q (valid categories: %s)", spec, name, strings.Join(ObjectCategories(), ", "))
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", spec, err)
+		}
+		filters = append(filters, nameFilter{category: category, pattern: pattern})
+	}
+	return filters, nil
+}
+
+// lookupCategory resolves a category name (case-insensitive), accepting singular forms such as
+// "table", "index", or "policy"
+func lookupCategory(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	candidates := []string{name, name + "s", name + "es"}
+	if strings.HasSuffix(name, "y") {
+		candidates = append(candidates, strings.TrimSuffix(name, "y")+"ies")
+	}
+	for _, candidate := range candidates {
+		if _, ok := objectCategories[candidate]; ok {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// filterTarget returns the object name a filter pattern is matched against, given a diff path
+// such as "schema.table", "schema.table.column", or "privileges.TABLE.users.grantee"
+func filterTarget(path, category string) string {
+	parts := strings.Split(path, ".")
+	switch {
+	case category == "indexes" || category == "triggers" || category == "policies":
+		// Sub-objects of tables and views are filtered by their own name
+		return parts[len(parts)-1]
+	case category == "privileges" && len(parts) > 2:
+		// Privilege paths start with the privilege kind and object type, then the object name
+		return parts[2]
+	case len(parts) > 1:
+		// Schema-qualified objects; table changes (columns, constraints) match on the table
+		return parts[1]
+	}
+	return path
+}
+
+// FilterComments keeps only COMMENT ON statements, dropping every structural change.
+// Comments are collected under the diff type of their object (e.g., function comments are
+// function diffs), so statements are matched by their SQL rather than by diff type.
+func FilterComments(diffs []Diff) []Diff {
+	filtered := make([]Diff, 0, len(diffs))
+	for _, d := range diffs {
+		var statements []SQLStatement
+		for _, stmt := range d.Statements {
+			if strings.HasPrefix(strings.TrimSpace(stmt.SQL), "COMMENT ON ") {
+				statements = append(statements, stmt)
+			}
+		}
+		if len(statements) == 0 {
+			continue
+		}
+		d.Statements = statements
+		filtered = append(filtered, d)
+	}
+	return filtered
+}
+
+// dataLossTypes are the diff types whose drop discards data that recreating the object cannot restore
+var dataLossTypes = map[DiffType]bool{
+	DiffTypeTable:       true,
+	DiffTypeTableColumn: true,
+	DiffTypeSequence:    true,
+}
+
+// FilterDataLoss keeps only the drops that discard data: dropped tables, columns, and sequences.
+// Reverting them recreates the objects empty, so they are not cleanly reversible.
+func FilterDataLoss(diffs []Diff) []Diff {
+	var filtered []Diff
+	for _, d := range diffs {
+		if d.Operation == DiffOperationDrop && dataLossTypes[d.Type] {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// allowedDiffTypes resolves category names (case-insensitive) to the set of diff types they cover
+func allowedDiffTypes(categories []string) (map[DiffType]bool, error) {
+	allowed := make(map[DiffType]bool)
+	for _, category := range categories {
+		name := strings.ToLower(strings.TrimSpace(category))
+		types, ok := objectCategories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown object category %q (valid categories: %s)", category, strings.Join(ObjectCategories(), ", "))
+		}
+		for _, t := range types {
+			allowed[t] = true
+		}
+	}
+	return allowed, nil
+}
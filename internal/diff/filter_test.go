@@ -0,0 +1,65 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestValidateCategories(t *testing.T) {
+	if err := ValidateCategories([]string{"tables", " Indexes "}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateCategories([]string{"widgets"}); err == nil || !strings.Contains(err.Error(), "widgets") {
+		t.Errorf("expected unknown category error, got %v", err)
+	}
+}
+
+func TestValidateFilters(t *testing.T) {
+	if err := ValidateFilters([]string{"table:users*", "functions:*", "index:idx_[a-z]*"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, spec := range []string{"users*", "widgets:users*", "tables:[users"} {
+		if err := ValidateFilters([]string{spec}); err == nil {
+			t.Errorf("expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestFilterDataLoss(t *testing.T) {
+	buildIR := func(withEmail bool) *ir.IR {
+		state := ir.NewIR()
+		schema := state.GetOrCreateSchema("public")
+		users := &ir.Table{
+			Schema:      "public",
+			Name:        "users",
+			Type:        ir.TableTypeBase,
+			Columns:     []*ir.Column{{Name: "id", Position: 1, DataType: "integer"}},
+			Constraints: map[string]*ir.Constraint{},
+			Indexes:     map[string]*ir.Index{},
+			Triggers:    map[string]*ir.Trigger{},
+			Policies:    map[string]*ir.RLSPolicy{},
+		}
+		if withEmail {
+			users.Columns = append(users.Columns, &ir.Column{Name: "email", Position: 2, DataType: "text", IsNullable: true})
+		}
+		schema.Tables["users"] = users
+		return state
+	}
+
+	// Adding a column loses nothing; its rollback drops the column and the data in it
+	forward := GenerateMigration(buildIR(false), buildIR(true), "public")
+	if lost := FilterDataLoss(forward); len(lost) != 0 {
+		t.Errorf("expected no data loss when adding a column, got %+v", lost)
+	}
+
+	reverse := GenerateMigration(buildIR(true), buildIR(false), "public")
+	if sql := buildSQLFromSteps(reverse); !strings.Contains(sql, "ALTER TABLE users DROP COLUMN email;") {
+		t.Errorf("expected the reverse of an added column to drop it, got %s", sql)
+	}
+	lost := FilterDataLoss(reverse)
+	if len(lost) != 1 || lost[0].Type != DiffTypeTableColumn || lost[0].Path != "public.users.email" {
+		t.Errorf("expected the dropped column to be flagged, got %+v", lost)
+	}
+}
@@ -13,7 +13,7 @@ func generateCreateFunctionsSQL(functions []*ir.Function, targetSchema string, c
 	buildFunctionBodyDependencies(functions)
 
 	// Sort functions by dependency order (topological sort)
-	sortedFunctions := topologicallySortFunctions(functions)
+	sortedFunctions := topologicallySortFunctions(functions, collector.edges)
 
 	for _, function := range sortedFunctions {
 		sql := generateFunctionSQL(function, targetSchema)
@@ -37,13 +37,13 @@ func generateCreateFunctionsSQL(functions []*ir.Function, targetSchema string, c
 }
 
 // generateModifyFunctionsSQL generates ALTER FUNCTION statements
-func generateModifyFunctionsSQL(diffs []*functionDiff, targetSchema string, collector *diffCollector) {
+func generateModifyFunctionsSQL(diffs []*functionDiff, targetSchema string, semanticBody bool, collector *diffCollector) {
 	for _, diff := range diffs {
 		oldFunc := diff.Old
 		newFunc := diff.New
 
 		// Check if only comment changed (no body/attribute changes)
-		onlyCommentChanged := functionsEqualExceptComment(oldFunc, newFunc) && oldFunc.Comment != newFunc.Comment
+		onlyCommentChanged := functionsEqualExceptComment(oldFunc, newFunc, semanticBody) && oldFunc.Comment != newFunc.Comment
 
 		if onlyCommentChanged {
 			// Only the comment changed - generate just COMMENT ON FUNCTION
@@ -52,7 +52,7 @@ func generateModifyFunctionsSQL(diffs []*functionDiff, targetSchema string, coll
 		}
 
 		// Check if only LEAKPROOF or PARALLEL attributes changed (not the function body/definition)
-		onlyAttributesChanged := functionsEqualExceptAttributes(oldFunc, newFunc)
+		onlyAttributesChanged := functionsEqualExceptAttributes(oldFunc, newFunc, semanticBody)
 
 		if onlyAttributesChanged {
 			// Generate ALTER FUNCTION statements for attribute-only changes
@@ -151,7 +151,7 @@ func generateModifyFunctionsSQL(diffs []*functionDiff, targetSchema string, coll
 // generateDropFunctionsSQL generates DROP FUNCTION statements
 func generateDropFunctionsSQL(functions []*ir.Function, targetSchema string, collector *diffCollector) {
 	// Sort functions by reverse dependency order (drop dependents before dependencies)
-	sortedFunctions := reverseSlice(topologicallySortFunctions(functions))
+	sortedFunctions := reverseSlice(topologicallySortFunctions(functions, nil))
 
 	for _, function := range sortedFunctions {
 		sql := generateDropFunctionSQL(function, targetSchema)
@@ -357,14 +357,14 @@ func formatFunctionParameter(param *ir.Parameter, includeDefault bool, targetSch
 
 // functionsEqualExceptAttributes compares two functions ignoring LEAKPROOF and PARALLEL attributes
 // Used to determine if ALTER FUNCTION can be used instead of CREATE OR REPLACE
-func functionsEqualExceptAttributes(old, new *ir.Function) bool {
+func functionsEqualExceptAttributes(old, new *ir.Function, semanticBody bool) bool {
 	if old.Schema != new.Schema {
 		return false
 	}
 	if old.Name != new.Name {
 		return false
 	}
-	if old.Definition != new.Definition {
+	if !bodiesEqual(new.Language, old.Definition, new.Definition, semanticBody) {
 		return false
 	}
 	if old.ReturnType != new.ReturnType {
@@ -395,14 +395,14 @@ func functionsEqualExceptAttributes(old, new *ir.Function) bool {
 }
 
 // functionsEqual compares two functions for equality
-func functionsEqual(old, new *ir.Function) bool {
+func functionsEqual(old, new *ir.Function, semanticBody bool) bool {
 	if old.Schema != new.Schema {
 		return false
 	}
 	if old.Name != new.Name {
 		return false
 	}
-	if old.Definition != new.Definition {
+	if !bodiesEqual(new.Language, old.Definition, new.Definition, semanticBody) {
 		return false
 	}
 	if old.ReturnType != new.ReturnType {
@@ -444,14 +444,14 @@ func functionsEqual(old, new *ir.Function) bool {
 
 // functionsEqualExceptComment compares two functions ignoring comment differences
 // Used to determine if only the comment changed (no body/attribute changes needed)
-func functionsEqualExceptComment(old, new *ir.Function) bool {
+func functionsEqualExceptComment(old, new *ir.Function, semanticBody bool) bool {
 	if old.Schema != new.Schema {
 		return false
 	}
 	if old.Name != new.Name {
 		return false
 	}
-	if old.Definition != new.Definition {
+	if !bodiesEqual(new.Language, old.Definition, new.Definition, semanticBody) {
 		return false
 	}
 	if old.ReturnType != new.ReturnType {
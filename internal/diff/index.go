@@ -39,7 +39,7 @@ func generateCreateIndexesSQLWithType(indexes []*ir.Index, targetSchema string,
 			CanRunInTransaction: true,
 		}
 
-		collector.collect(context, canonicalSQL)
+		collector.collectStatements(context, withIndexStatistics(canonicalSQL, index, targetSchema))
 
 		// Add index comment
 		if index.Comment != "" {
@@ -107,10 +107,10 @@ func generateIndexSQLWithName(index *ir.Index, indexName string, targetSchema st
 		// - Expressions: ((expression))
 		builder.WriteString(col.Name)
 
-		// Add operator class if specified (non-default operator class)
-		if col.Operator != "" {
+		// Add operator class if specified (non-default operator class or one with parameters)
+		if opclass := col.OperatorClass(); opclass != "" {
 			builder.WriteString(" ")
-			builder.WriteString(col.Operator)
+			builder.WriteString(opclass)
 		}
 
 		// Add direction if specified
@@ -120,6 +120,10 @@ func generateIndexSQLWithName(index *ir.Index, indexName string, targetSchema st
 		}
 	}
 	builder.WriteString(")")
+	if index.NullsNotDistinct {
+		builder.WriteString(" NULLS NOT DISTINCT")
+	}
+	builder.WriteString(tablespaceClause(index.Tablespace))
 
 	// WHERE clause for partial indexes
 	if index.IsPartial && index.Where != "" {
@@ -189,24 +193,33 @@ func generateIndexModifications(
 		structurallyEqual := indexesStructurallyEqual(indexDiff.Old, indexDiff.New)
 		commentChanged := indexDiff.Old.Comment != indexDiff.New.Comment
 
-		if structurallyEqual && commentChanged {
+		if structurallyEqual {
+			// Statistics targets can be changed in place without rebuilding the index
+			if statistics := generateIndexStatisticsSQL(indexDiff.Old, indexDiff.New, targetSchema); len(statistics) > 0 {
+				alterContext := &diffContext{
+					Type:                indexDiffType,
+					Operation:           DiffOperationAlter,
+					Path:                fmt.Sprintf("%s.%s.%s", indexDiff.New.Schema, indexDiff.New.Table, indexDiff.New.Name),
+					Source:              indexDiff,
+					CanRunInTransaction: true,
+				}
+				collector.collectStatements(alterContext, statistics)
+			}
 			// Only comment changed - generate COMMENT ON INDEX statement
-			generateIndexComment(indexDiff.New, targetSchema, commentDiffType, DiffOperationAlter, collector)
+			if commentChanged {
+				generateIndexComment(indexDiff.New, targetSchema, commentDiffType, DiffOperationAlter, collector)
+			}
 		} else {
 			// Structure changed - use online replacement approach
 			dropSQL := fmt.Sprintf("DROP INDEX IF EXISTS %s;", qualifyEntityName(indexDiff.Old.Schema, indexDiff.Old.Name, targetSchema))
 			canonicalSQL := generateIndexSQL(indexDiff.New, targetSchema, false)
 
-			statements := []SQLStatement{
+			statements := append([]SQLStatement{
 				{
 					SQL:                 dropSQL,
 					CanRunInTransaction: true,
 				},
-				{
-					SQL:                 canonicalSQL,
-					CanRunInTransaction: true,
-				},
-			}
+			}, withIndexStatistics(canonicalSQL, indexDiff.New, targetSchema)...)
 
 			alterContext := &diffContext{
 				Type:                indexDiffType,
@@ -235,16 +248,12 @@ func generateIndexModifications(
 			dropSQL := fmt.Sprintf("DROP INDEX IF EXISTS %s;", qualifyEntityName(newIndex.Schema, indexName, targetSchema))
 			canonicalSQL := generateIndexSQL(newIndex, targetSchema, false)
 
-			statements := []SQLStatement{
+			statements := append([]SQLStatement{
 				{
 					SQL:                 dropSQL,
 					CanRunInTransaction: true,
 				},
-				{
-					SQL:                 canonicalSQL,
-					CanRunInTransaction: true,
-				},
-			}
+			}, withIndexStatistics(canonicalSQL, newIndex, targetSchema)...)
 
 			alterContext := &diffContext{
 				Type:                indexDiffType,
@@ -274,7 +283,7 @@ func generateIndexModifications(
 			CanRunInTransaction: true,
 		}
 
-		collector.collect(context, canonicalSQL)
+		collector.collectStatements(context, withIndexStatistics(canonicalSQL, index, targetSchema))
 
 		// Add index comment if present
 		if index.Comment != "" {
@@ -283,6 +292,77 @@ func generateIndexModifications(
 	}
 }
 
+// withIndexStatistics returns the CREATE INDEX statement followed by the statistics targets of the new index
+func withIndexStatistics(createSQL string, index *ir.Index, targetSchema string) []SQLStatement {
+	statements := []SQLStatement{{SQL: createSQL, CanRunInTransaction: true}}
+	return append(statements, generateIndexStatisticsSQL(nil, index, targetSchema)...)
+}
+
+// generateIndexStatisticsSQL generates ALTER INDEX ... ALTER COLUMN n SET STATISTICS statements for
+// columns whose statistics target differs between oldIndex and newIndex (oldIndex is nil for a new index)
+func generateIndexStatisticsSQL(oldIndex, newIndex *ir.Index, targetSchema string) []SQLStatement {
+	oldTargets := indexStatisticsTargets(oldIndex)
+	newTargets := indexStatisticsTargets(newIndex)
+
+	var positions []int
+	for position := range newTargets {
+		positions = append(positions, position)
+	}
+	for position := range oldTargets {
+		if _, ok := newTargets[position]; !ok {
+			positions = append(positions, position)
+		}
+	}
+	sort.Ints(positions)
+
+	indexName := qualifyEntityName(newIndex.Schema, newIndex.Name, targetSchema)
+	var statements []SQLStatement
+	for _, position := range positions {
+		oldTarget, hadTarget := oldTargets[position]
+		newTarget, hasTarget := newTargets[position]
+		if hadTarget == hasTarget && oldTarget == newTarget {
+			continue
+		}
+		if !hasTarget {
+			newTarget = -1 // Reset to the default statistics target
+		}
+		statements = append(statements, SQLStatement{
+			SQL:                 fmt.Sprintf("ALTER INDEX %s ALTER COLUMN %d SET STATISTICS %d;", indexName, position, newTarget),
+			CanRunInTransaction: true,
+		})
+	}
+	return statements
+}
+
+// indexStatisticsEqual reports whether two indexes have the same per-column statistics targets
+func indexStatisticsEqual(oldIndex, newIndex *ir.Index) bool {
+	oldTargets := indexStatisticsTargets(oldIndex)
+	newTargets := indexStatisticsTargets(newIndex)
+	if len(oldTargets) != len(newTargets) {
+		return false
+	}
+	for position, target := range newTargets {
+		if oldTarget, ok := oldTargets[position]; !ok || oldTarget != target {
+			return false
+		}
+	}
+	return true
+}
+
+// indexStatisticsTargets maps index column positions to their explicit statistics targets
+func indexStatisticsTargets(index *ir.Index) map[int]int {
+	targets := make(map[int]int)
+	if index == nil {
+		return targets
+	}
+	for _, col := range index.Columns {
+		if col.StatisticsTarget != nil {
+			targets[col.Position] = *col.StatisticsTarget
+		}
+	}
+	return targets
+}
+
 // generateIndexComment generates COMMENT ON INDEX statement
 func generateIndexComment(
 	index *ir.Index,
@@ -0,0 +1,78 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+	"github.com/pgplex/pgschema/testutil"
+)
+
+func TestGenerateIndexSQLOperatorClassParams(t *testing.T) {
+	index := &ir.Index{
+		Schema: "public",
+		Table:  "documents",
+		Name:   "idx_documents_search",
+		Type:   ir.IndexTypeRegular,
+		Method: "gist",
+		Columns: []*ir.IndexColumn{
+			{Name: "search", Position: 1, Operator: "tsvector_ops", OperatorParams: "siglen=32"},
+		},
+	}
+
+	got := generateIndexSQL(index, "public", false)
+	expected := "CREATE INDEX IF NOT EXISTS idx_documents_search ON documents USING gist (search tsvector_ops (siglen=32));"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+// TestUniqueIndexNullsDistinctNoDiff checks that a unique index written with an explicit
+// NULLS DISTINCT matches the same index without it, and that NULLS NOT DISTINCT is kept and
+// recreates the index when it changes (PostgreSQL 15+).
+func TestUniqueIndexNullsDistinctNoDiff(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	conn, _, _, _, _, _ := testutil.ConnectToPostgres(t, sharedTestPostgres)
+	majorVersion, err := testutil.GetMajorVersion(conn)
+	conn.Close()
+	if err != nil {
+		t.Fatalf("failed to detect PostgreSQL version: %v", err)
+	}
+	if majorVersion < 15 {
+		t.Skipf("NULLS [NOT] DISTINCT requires PostgreSQL 15+, got %d", majorVersion)
+	}
+
+	tableSQL := "CREATE TABLE users (id integer PRIMARY KEY, email text);\n"
+	implicitIR := testutil.ParseSQLToIR(t, sharedTestPostgres, tableSQL+
+		"CREATE UNIQUE INDEX idx_users_email ON users (email);", "public")
+	explicitIR := testutil.ParseSQLToIR(t, sharedTestPostgres, tableSQL+
+		"CREATE UNIQUE INDEX idx_users_email ON users (email) NULLS DISTINCT;", "public")
+	notDistinctIR := testutil.ParseSQLToIR(t, sharedTestPostgres, tableSQL+
+		"CREATE UNIQUE INDEX idx_users_email ON users (email) NULLS NOT DISTINCT;", "public")
+
+	if diffs := GenerateMigration(implicitIR, explicitIR, "public"); len(diffs) != 0 {
+		t.Errorf("expected explicit NULLS DISTINCT to match the default, got %s", buildSQLFromSteps(diffs))
+	}
+
+	dump := buildSQLFromSteps(GenerateMigration(ir.NewIR(), explicitIR, "public"))
+	if strings.Contains(dump, "NULLS") {
+		t.Errorf("expected the default NULLS DISTINCT to be omitted, got:\n%s", dump)
+	}
+
+	dump = buildSQLFromSteps(GenerateMigration(ir.NewIR(), notDistinctIR, "public"))
+	if !strings.Contains(dump, "CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email) NULLS NOT DISTINCT;") {
+		t.Errorf("expected NULLS NOT DISTINCT to be dumped, got:\n%s", dump)
+	}
+	appliedIR := testutil.ParseSQLToIR(t, sharedTestPostgres, dump, "public")
+	if diffs := GenerateMigration(appliedIR, notDistinctIR, "public"); len(diffs) != 0 {
+		t.Errorf("expected no differences after round-trip, got %s", buildSQLFromSteps(diffs))
+	}
+
+	migration := buildSQLFromSteps(GenerateMigration(explicitIR, notDistinctIR, "public"))
+	if !strings.Contains(migration, "NULLS NOT DISTINCT") {
+		t.Errorf("expected switching to NULLS NOT DISTINCT to recreate the index, got:\n%s", migration)
+	}
+}
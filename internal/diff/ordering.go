@@ -0,0 +1,106 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// orderingDependency is an object that must exist before a diff's statements can run
+type orderingDependency struct {
+	key    string // "<kind>:<schema>.<name>", matching createdObjectKey
+	reason string // why the diff needs the object, e.g. "foreign key orders_customer_id_fkey references it"
+}
+
+// orderingEdges records, for each object placed by a topological sort, the dependency that placed it:
+// the last of its dependencies to be sorted before it. Keys match createdObjectKey.
+type orderingEdges map[string]orderingDependency
+
+// record records that the object with key to was placed after the object with key from
+func (e orderingEdges) record(to, from, reason string) {
+	if e != nil {
+		e[to] = orderingDependency{key: from, reason: reason}
+	}
+}
+
+// addEdgeReason keeps the reason of a graph edge, preferring the smallest one when several
+// dependencies connect the same two objects so that the explanation is deterministic
+func addEdgeReason(reasons map[[2]string]string, from, to, reason string) {
+	edge := [2]string{from, to}
+	if existing, ok := reasons[edge]; !ok || reason < existing {
+		reasons[edge] = reason
+	}
+}
+
+// ExplainOrdering explains the position of the diffs that a topological sort placed after an object
+// created earlier in the same migration. The result maps a diff's index to a note such as
+// "after CREATE TABLE customers because foreign key orders_customer_id_fkey references it"; diffs
+// without such a dependency are absent. The dependency reported is the edge the sort followed to
+// place the diff, i.e. the last of its dependencies to be created.
+func ExplainOrdering(diffs []Diff) map[int]string {
+	reasons := make(map[int]string)
+	created := make(map[string]int)
+
+	for i, d := range diffs {
+		if d.placedAfter != nil {
+			if j, ok := created[d.placedAfter.key]; ok {
+				reasons[i] = fmt.Sprintf("after %s because %s", describeCreate(diffs[j], pathSchema(d.Path)), d.placedAfter.reason)
+			}
+		}
+
+		if key := createdObjectKey(d); key != "" {
+			if _, exists := created[key]; !exists {
+				created[key] = i
+			}
+		}
+	}
+
+	return reasons
+}
+
+// createdObjectKey returns the dependency key of the object a diff creates, or "" if the diff does
+// not create an object other diffs can depend on
+func createdObjectKey(d Diff) string {
+	if d.Operation != DiffOperationCreate {
+		return ""
+	}
+	switch d.Type {
+	case DiffTypeTable:
+		return "table:" + d.Path
+	case DiffTypeView, DiffTypeMaterializedView:
+		return "view:" + d.Path
+	case DiffTypeType, DiffTypeDomain:
+		return "type:" + d.Path
+	case DiffTypeFunction, DiffTypeProcedure:
+		return "function:" + d.Path
+	}
+	return ""
+}
+
+// describeCreate describes the statement of a creating diff, e.g. "CREATE TYPE color".
+// The schema is omitted when it is the schema of the dependent object.
+func describeCreate(d Diff, schema string) string {
+	var keyword string
+	switch d.Type {
+	case DiffTypeTable:
+		keyword = "TABLE"
+	case DiffTypeView:
+		keyword = "VIEW"
+	case DiffTypeMaterializedView:
+		keyword = "MATERIALIZED VIEW"
+	case DiffTypeType:
+		keyword = "TYPE"
+	case DiffTypeDomain:
+		keyword = "DOMAIN"
+	case DiffTypeFunction:
+		keyword = "FUNCTION"
+	case DiffTypeProcedure:
+		keyword = "PROCEDURE"
+	}
+	return fmt.Sprintf("CREATE %s %s", keyword, strings.TrimPrefix(d.Path, schema+"."))
+}
+
+// pathSchema returns the schema of a diff path such as "public.users.email"
+func pathSchema(path string) string {
+	schema, _, _ := strings.Cut(path, ".")
+	return schema
+}
@@ -30,6 +30,10 @@ func generateCreatePoliciesSQL(policies []*ir.RLSPolicy, targetSchema string, co
 		}
 
 		collector.collect(context, sql)
+
+		if policy.Comment != "" {
+			generatePolicyComment(policy, targetSchema, DiffOperationCreate, collector)
+		}
 	}
 }
 
@@ -87,6 +91,11 @@ func generatePolicySQL(policy *ir.RLSPolicy, targetSchema string) string {
 
 	policyStmt := fmt.Sprintf("CREATE POLICY %s ON %s", ir.QuoteIdentifier(policy.Name), tableName)
 
+	// Policies are permissive by default; restrictive ones must say so
+	if !policy.Permissive {
+		policyStmt += " AS RESTRICTIVE"
+	}
+
 	// Add command type if specified
 	if policy.Command != ir.PolicyCommandAll {
 		policyStmt += fmt.Sprintf(" FOR %s", policy.Command)
@@ -122,7 +131,7 @@ func generateAlterPolicySQL(old, new *ir.RLSPolicy, targetSchema string) string
 	tableName := getTableNameWithSchema(new.Schema, new.Table, targetSchema)
 
 	// Check what aspects have changed
-	roleChange := !roleListsEqualCaseInsensitive(old.Roles, new.Roles)
+	roleChange := !policyRolesEqual(old.Roles, new.Roles)
 	usingChange := old.Using != new.Using
 	withCheckChange := old.WithCheck != new.WithCheck
 
@@ -132,7 +141,7 @@ func generateAlterPolicySQL(old, new *ir.RLSPolicy, targetSchema string) string
 	// Add TO clause if roles changed
 	if roleChange {
 		alterStmt += " TO "
-		for i, role := range new.Roles {
+		for i, role := range ir.NormalizePolicyRoles(new.Roles) {
 			if i > 0 {
 				alterStmt += ", "
 			}
@@ -158,6 +167,26 @@ func generateAlterPolicySQL(old, new *ir.RLSPolicy, targetSchema string) string
 	return alterStmt + ";"
 }
 
+// generatePolicyComment generates COMMENT ON POLICY statement
+func generatePolicyComment(policy *ir.RLSPolicy, targetSchema string, operation DiffOperation, collector *diffCollector) {
+	tableName := getTableNameWithSchema(policy.Schema, policy.Table, targetSchema)
+	var sql string
+	if policy.Comment == "" {
+		sql = fmt.Sprintf("COMMENT ON POLICY %s ON %s IS NULL;", ir.QuoteIdentifier(policy.Name), tableName)
+	} else {
+		sql = fmt.Sprintf("COMMENT ON POLICY %s ON %s IS %s;", ir.QuoteIdentifier(policy.Name), tableName, quoteString(policy.Comment))
+	}
+
+	context := &diffContext{
+		Type:                DiffTypeTablePolicy,
+		Operation:           operation,
+		Path:                fmt.Sprintf("%s.%s.%s", policy.Schema, policy.Table, policy.Name),
+		Source:              policy,
+		CanRunInTransaction: true,
+	}
+	collector.collect(context, sql)
+}
+
 // ensureParentheses wraps an expression in parentheses if not already wrapped.
 // PostgreSQL's CREATE POLICY and ALTER POLICY syntax requires USING (expr) and WITH CHECK (expr).
 // pg_get_expr may return expressions without outer parentheses (e.g., simple function calls).
@@ -172,14 +201,16 @@ func ensureParentheses(expr string) string {
 	return "(" + expr + ")"
 }
 
-// roleListsEqualCaseInsensitive compares two role lists for equality (case-insensitive)
-// PostgreSQL role names are case-insensitive by default
-func roleListsEqualCaseInsensitive(oldRoles, newRoles []string) bool {
+// policyRolesEqual compares two role lists after normalization, so an empty list,
+// "public", and PUBLIC are equal and ordering and case don't matter
+func policyRolesEqual(oldRoles, newRoles []string) bool {
+	oldRoles = ir.NormalizePolicyRoles(oldRoles)
+	newRoles = ir.NormalizePolicyRoles(newRoles)
 	if len(oldRoles) != len(newRoles) {
 		return false
 	}
 	for i, role := range oldRoles {
-		if !strings.EqualFold(newRoles[i], role) {
+		if newRoles[i] != role {
 			return false
 		}
 	}
@@ -209,15 +240,17 @@ func policiesEqual(old, new *ir.RLSPolicy) bool {
 	if old.WithCheck != new.WithCheck {
 		return false
 	}
-	if len(old.Roles) != len(new.Roles) {
+	if old.Comment != new.Comment {
 		return false
 	}
-	for i, role := range old.Roles {
-		if new.Roles[i] != role {
-			return false
-		}
-	}
-	return true
+	return policyRolesEqual(old.Roles, new.Roles)
+}
+
+// policiesEqualExceptComment compares two policies ignoring comment differences
+func policiesEqualExceptComment(old, new *ir.RLSPolicy) bool {
+	oldCopy := *old
+	oldCopy.Comment = new.Comment
+	return policiesEqual(&oldCopy, new)
 }
 
 // needsRecreate determines if a policy change requires DROP/CREATE instead of ALTER
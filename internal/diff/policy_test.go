@@ -0,0 +1,64 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestPoliciesEqualPublicRoles(t *testing.T) {
+	policy := func(roles ...string) *ir.RLSPolicy {
+		return &ir.RLSPolicy{
+			Schema:     "public",
+			Table:      "users",
+			Name:       "tenant_policy",
+			Command:    ir.PolicyCommandAll,
+			Permissive: true,
+			Roles:      roles,
+			Using:      "(tenant_id = 1)",
+		}
+	}
+
+	if !policiesEqual(policy(), policy("PUBLIC")) {
+		t.Error("expected implicit and explicit PUBLIC to be equal")
+	}
+	if !policiesEqual(policy("public"), policy("PUBLIC")) {
+		t.Error("expected catalog 'public' to equal PUBLIC")
+	}
+	if !policiesEqual(policy("reader", "Admin"), policy("admin", "reader")) {
+		t.Error("expected role lists to compare regardless of order and case")
+	}
+	if policiesEqual(policy("admin"), policy("PUBLIC")) {
+		t.Error("expected a named role to differ from PUBLIC")
+	}
+
+	sql := generateAlterPolicySQL(policy("admin"), policy(), "public")
+	if sql != "ALTER POLICY tenant_policy ON users TO PUBLIC;" {
+		t.Errorf("unexpected ALTER POLICY statement: %s", sql)
+	}
+}
+
+func TestRestrictivePolicy(t *testing.T) {
+	permissive := &ir.RLSPolicy{
+		Schema:     "public",
+		Table:      "documents",
+		Name:       "documents_tenant_guard",
+		Command:    ir.PolicyCommandSelect,
+		Permissive: true,
+		Roles:      []string{"PUBLIC"},
+		Using:      "(tenant_id = 1)",
+	}
+	restrictive := *permissive
+	restrictive.Permissive = false
+
+	expected := "CREATE POLICY documents_tenant_guard ON documents AS RESTRICTIVE FOR SELECT TO PUBLIC USING (tenant_id = 1);"
+	if sql := generatePolicySQL(&restrictive, "public"); sql != expected {
+		t.Errorf("expected %q, got %q", expected, sql)
+	}
+	if policiesEqual(permissive, &restrictive) {
+		t.Error("expected permissive and restrictive policies to differ")
+	}
+	if !needsRecreate(permissive, &restrictive) {
+		t.Error("expected a permissiveness change to require DROP and CREATE")
+	}
+}
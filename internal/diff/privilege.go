@@ -25,8 +25,9 @@ func generateCreatePrivilegesSQL(privileges []*ir.Privilege, targetSchema string
 	}
 }
 
-// generateDropPrivilegesSQL generates REVOKE statements for removed privileges
-func generateDropPrivilegesSQL(privileges []*ir.Privilege, targetSchema string, collector *diffCollector) {
+// generateDropPrivilegesSQL generates REVOKE statements for removed privileges.
+// retained holds the desired privileges restored after a cascading REVOKE.
+func generateDropPrivilegesSQL(privileges []*ir.Privilege, retained []*ir.Privilege, targetSchema string, collector *diffCollector) {
 	for _, p := range privileges {
 		sql := generateRevokePrivilegeSQL(p)
 
@@ -39,11 +40,16 @@ func generateDropPrivilegesSQL(privileges []*ir.Privilege, targetSchema string,
 		}
 
 		collector.collect(context, sql)
+
+		if p.WithGrantOption {
+			generateRegrantDependentPrivilegesSQL(p, p.Privileges, retained, collector)
+		}
 	}
 }
 
-// generateModifyPrivilegesSQL generates ALTER privilege statements for modifications
-func generateModifyPrivilegesSQL(diffs []*privilegeDiff, targetSchema string, collector *diffCollector) {
+// generateModifyPrivilegesSQL generates ALTER privilege statements for modifications.
+// retained holds the desired privileges restored after a cascading REVOKE.
+func generateModifyPrivilegesSQL(diffs []*privilegeDiff, retained []*ir.Privilege, targetSchema string, collector *diffCollector) {
 	for _, diff := range diffs {
 		statements := diff.generateAlterPrivilegeStatements()
 		for _, stmt := range statements {
@@ -57,6 +63,54 @@ func generateModifyPrivilegesSQL(diffs []*privilegeDiff, targetSchema string, co
 
 			collector.collect(context, stmt)
 		}
+
+		if cascaded := diff.cascadeRevokedPrivileges(); len(cascaded) > 0 {
+			generateRegrantDependentPrivilegesSQL(diff.Old, cascaded, retained, collector)
+		}
+	}
+}
+
+// generateRegrantDependentPrivilegesSQL restores the retained privileges of other grantees on the
+// object of revokedFrom after a REVOKE ... CASCADE of the given privilege types. Grantors are not
+// modeled, so any of those grants may have been made by revokedFrom and removed by the cascade;
+// granting again one that survived is a no-op.
+func generateRegrantDependentPrivilegesSQL(revokedFrom *ir.Privilege, revoked []string, retained []*ir.Privilege, collector *diffCollector) {
+	revokedSet := make(map[string]bool)
+	for _, priv := range revoked {
+		revokedSet[priv] = true
+	}
+
+	for _, p := range retained {
+		if p.ObjectType != revokedFrom.ObjectType || p.ObjectName != revokedFrom.ObjectName || p.Grantee == revokedFrom.Grantee {
+			continue
+		}
+		var privs []string
+		for _, priv := range p.Privileges {
+			if revokedSet[priv] {
+				privs = append(privs, priv)
+			}
+		}
+		if len(privs) == 0 {
+			continue
+		}
+
+		sql := generateGrantPrivilegeSQL(&ir.Privilege{
+			ObjectType:      p.ObjectType,
+			ObjectName:      p.ObjectName,
+			Grantee:         p.Grantee,
+			Privileges:      privs,
+			WithGrantOption: p.WithGrantOption,
+		})
+
+		context := &diffContext{
+			Type:                DiffTypePrivilege,
+			Operation:           DiffOperationAlter,
+			Path:                fmt.Sprintf("privileges.%s.%s.%s", p.ObjectType, p.ObjectName, p.Grantee),
+			Source:              p,
+			CanRunInTransaction: true,
+		}
+
+		collector.collect(context, sql)
 	}
 }
 
@@ -91,7 +145,40 @@ func generateRevokePrivilegeSQL(p *ir.Privilege) string {
 	grantee := formatGrantee(p.Grantee)
 	objectRef := formatObjectReference(p.ObjectType, p.ObjectName)
 
-	return fmt.Sprintf("REVOKE %s ON %s FROM %s;", privStr, objectRef, grantee)
+	return fmt.Sprintf("REVOKE %s ON %s FROM %s%s;", privStr, objectRef, grantee, revokeCascadeClause(p.WithGrantOption))
+}
+
+// revokeCascadeClause returns the CASCADE clause for revoking a privilege that was held with
+// GRANT OPTION, which also revokes the privileges the grantee granted to others. Without it,
+// PostgreSQL rejects the REVOKE when such dependent grants exist. The dependent grants that
+// are still desired are granted again afterwards (see generateRegrantDependentPrivilegesSQL).
+func revokeCascadeClause(withGrantOption bool) string {
+	if withGrantOption {
+		return " CASCADE"
+	}
+	return ""
+}
+
+// cascadeRevokedPrivileges returns the privilege types whose grant option the modification
+// revokes with CASCADE: the removed types, or all old types when the grant option is dropped.
+func (d *privilegeDiff) cascadeRevokedPrivileges() []string {
+	if !d.Old.WithGrantOption {
+		return nil
+	}
+	if !d.New.WithGrantOption {
+		return d.Old.Privileges
+	}
+	newPrivSet := make(map[string]bool)
+	for _, p := range d.New.Privileges {
+		newPrivSet[p] = true
+	}
+	var revoked []string
+	for _, p := range d.Old.Privileges {
+		if !newPrivSet[p] {
+			revoked = append(revoked, p)
+		}
+	}
+	return revoked
 }
 
 // generateAlterPrivilegeStatements generates statements for privilege modifications
@@ -128,8 +215,8 @@ func (d *privilegeDiff) generateAlterPrivilegeStatements() []string {
 	// Generate REVOKE for removed privileges
 	if len(toRevoke) > 0 {
 		sort.Strings(toRevoke)
-		statements = append(statements, fmt.Sprintf("REVOKE %s ON %s FROM %s;",
-			strings.Join(toRevoke, ", "), objectRef, grantee))
+		statements = append(statements, fmt.Sprintf("REVOKE %s ON %s FROM %s%s;",
+			strings.Join(toRevoke, ", "), objectRef, grantee, revokeCascadeClause(d.Old.WithGrantOption)))
 	}
 
 	// Generate GRANT for added privileges
@@ -158,7 +245,7 @@ func (d *privilegeDiff) generateAlterPrivilegeStatements() []string {
 
 			if d.Old.WithGrantOption && !d.New.WithGrantOption {
 				// Revoke grant option only (keep the privilege)
-				statements = append(statements, fmt.Sprintf("REVOKE GRANT OPTION FOR %s ON %s FROM %s;",
+				statements = append(statements, fmt.Sprintf("REVOKE GRANT OPTION FOR %s ON %s FROM %s CASCADE;",
 					unchangedStr, objectRef, grantee))
 			} else if !d.Old.WithGrantOption && d.New.WithGrantOption {
 				// Add grant option (re-grant with grant option)
@@ -39,13 +39,13 @@ func generateCreateProceduresSQL(procedures []*ir.Procedure, targetSchema string
 }
 
 // generateModifyProceduresSQL generates DROP and CREATE PROCEDURE statements for modified procedures
-func generateModifyProceduresSQL(diffs []*procedureDiff, targetSchema string, collector *diffCollector) {
+func generateModifyProceduresSQL(diffs []*procedureDiff, targetSchema string, semanticBody bool, collector *diffCollector) {
 	for _, diff := range diffs {
 		oldProc := diff.Old
 		newProc := diff.New
 
 		// Check if only comment changed (no body changes)
-		onlyCommentChanged := proceduresEqualExceptComment(oldProc, newProc) && oldProc.Comment != newProc.Comment
+		onlyCommentChanged := proceduresEqualExceptComment(oldProc, newProc, semanticBody) && oldProc.Comment != newProc.Comment
 
 		if onlyCommentChanged {
 			// Only the comment changed - generate just COMMENT ON PROCEDURE
@@ -253,14 +253,14 @@ func containsProcedureParameterReferences(body string) bool {
 }
 
 // proceduresEqual compares two procedures for equality
-func proceduresEqual(old, new *ir.Procedure) bool {
+func proceduresEqual(old, new *ir.Procedure, semanticBody bool) bool {
 	if old.Schema != new.Schema {
 		return false
 	}
 	if old.Name != new.Name {
 		return false
 	}
-	if old.Definition != new.Definition {
+	if !bodiesEqual(new.Language, old.Definition, new.Definition, semanticBody) {
 		return false
 	}
 	if old.Language != new.Language {
@@ -289,14 +289,14 @@ func proceduresEqual(old, new *ir.Procedure) bool {
 
 // proceduresEqualExceptComment compares two procedures ignoring comment differences
 // Used to determine if only the comment changed (no body changes needed)
-func proceduresEqualExceptComment(old, new *ir.Procedure) bool {
+func proceduresEqualExceptComment(old, new *ir.Procedure, semanticBody bool) bool {
 	if old.Schema != new.Schema {
 		return false
 	}
 	if old.Name != new.Name {
 		return false
 	}
-	if old.Definition != new.Definition {
+	if !bodiesEqual(new.Language, old.Definition, new.Definition, semanticBody) {
 		return false
 	}
 	if old.Language != new.Language {
@@ -14,8 +14,18 @@ const (
 	defaultSequenceMaxValue int64 = math.MaxInt64 // bigint max
 	smallintMaxValue        int64 = math.MaxInt16 // smallint max
 	integerMaxValue         int64 = math.MaxInt32 // integer max
+	defaultSequenceCache    int64 = 1
 )
 
+// sequenceCache returns the cache size of a sequence, treating an unset cache as the default.
+// An explicit CACHE 1 and an omitted CACHE are the same sequence and must never produce a diff.
+func sequenceCache(seq *ir.Sequence) int64 {
+	if seq.Cache == nil {
+		return defaultSequenceCache
+	}
+	return *seq.Cache
+}
+
 // generateCreateSequencesSQL generates CREATE SEQUENCE statements
 func generateCreateSequencesSQL(sequences []*ir.Sequence, targetSchema string, collector *diffCollector) {
 	for _, seq := range sequences {
@@ -105,8 +115,8 @@ func generateSequenceSQL(seq *ir.Sequence, targetSchema string) string {
 	}
 
 	// Add cache if it differs from default (1)
-	if seq.Cache != nil && *seq.Cache != 1 {
-		parts = append(parts, fmt.Sprintf("CACHE %d", *seq.Cache))
+	if cache := sequenceCache(seq); cache != defaultSequenceCache {
+		parts = append(parts, fmt.Sprintf("CACHE %d", cache))
 	}
 
 	if seq.CycleOption {
@@ -169,15 +179,7 @@ func (d *sequenceDiff) generateAlterSequenceStatements(targetSchema string) []st
 	}
 
 	// Handle Cache changes
-	oldCache := int64(1)
-	if d.Old.Cache != nil {
-		oldCache = *d.Old.Cache
-	}
-	newCache := int64(1)
-	if d.New.Cache != nil {
-		newCache = *d.New.Cache
-	}
-	if oldCache != newCache {
+	if newCache := sequenceCache(d.New); sequenceCache(d.Old) != newCache {
 		alterParts = append(alterParts, fmt.Sprintf("CACHE %d", newCache))
 	}
 
@@ -254,15 +256,7 @@ func sequencesEqual(old, new *ir.Sequence) bool {
 	}
 
 	// Handle Cache comparison with defaults
-	oldCache := int64(1)
-	if old.Cache != nil {
-		oldCache = *old.Cache
-	}
-	newCache := int64(1)
-	if new.Cache != nil {
-		newCache = *new.Cache
-	}
-	if oldCache != newCache {
+	if sequenceCache(old) != sequenceCache(new) {
 		return false
 	}
 
@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestSequencesEqualDefaultCache(t *testing.T) {
+	explicitDefault := int64(1)
+	explicit := &ir.Sequence{Schema: "public", Name: "order_seq", DataType: "bigint", StartValue: 1, Increment: 1, Cache: &explicitDefault}
+	omitted := &ir.Sequence{Schema: "public", Name: "order_seq", DataType: "bigint", StartValue: 1, Increment: 1}
+
+	if !sequencesEqual(explicit, omitted) {
+		t.Error("expected explicit CACHE 1 to equal an omitted cache")
+	}
+	if sql := generateSequenceSQL(explicit, "public"); sql != generateSequenceSQL(omitted, "public") {
+		t.Errorf("expected explicit CACHE 1 to be omitted from CREATE SEQUENCE, got %q", sql)
+	}
+
+	cached := int64(20)
+	omitted.Cache = &cached
+	if sequencesEqual(explicit, omitted) {
+		t.Error("expected a cache change to be detected")
+	}
+}
+
+func TestIsSerialColumn(t *testing.T) {
+	column := func(dataType, defaultValue string) *ir.Column {
+		return &ir.Column{Name: "id", Position: 1, DataType: dataType, DefaultValue: &defaultValue}
+	}
+
+	tests := []struct {
+		name     string
+		column   *ir.Column
+		expected bool
+	}{
+		{"implicit sequence", column("integer", "nextval('orders_id_seq'::regclass)"), true},
+		{"qualified implicit sequence", column("bigint", "nextval('public.orders_id_seq'::regclass)"), true},
+		{"same-named sequence in another schema", column("integer", "nextval('shared.orders_id_seq'::regclass)"), false},
+		{"implicit sequence with suffix", column("smallint", "nextval('orders_id_seq1'::regclass)"), true},
+		{"shared sequence", column("integer", "nextval('order_numbers'::regclass)"), false},
+		{"non-integer column", column("numeric", "nextval('orders_id_seq'::regclass)"), false},
+		{"nextval in expression", column("integer", "(nextval('orders_id_seq'::regclass) * 2)"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSerialColumn("public", "orders", tt.column); got != tt.expected {
+				t.Errorf("isSerialColumn() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	// Long names are truncated the way PostgreSQL truncates them
+	longTable := strings.Repeat("t", 60)
+	if !isSerialSequenceName(strings.Repeat("t", 56)+"_id_seq", longTable, "id") {
+		t.Error("expected the truncated implicit sequence name to be recognized")
+	}
+
+	// Truncation never splits a multibyte character: the 56-byte budget for "x" followed by thirty
+	// two-byte characters ends in the middle of the 28th, which is dropped
+	multibyteTable := "x" + strings.Repeat("é", 30)
+	if !isSerialSequenceName("x"+strings.Repeat("é", 27)+"_id_seq", multibyteTable, "id") {
+		t.Error("expected the truncated multibyte implicit sequence name to be recognized")
+	}
+}
+
+func TestStandaloneSequenceOwnedByNewTable(t *testing.T) {
+	defaultValue := "nextval('order_numbers'::regclass)"
+	newIR := ir.NewIR()
+	schema := newIR.GetOrCreateSchema("public")
+	schema.Tables["orders"] = &ir.Table{
+		Schema:      "public",
+		Name:        "orders",
+		Type:        ir.TableTypeBase,
+		Columns:     []*ir.Column{{Name: "number", Position: 1, DataType: "integer", DefaultValue: &defaultValue}},
+		Constraints: map[string]*ir.Constraint{},
+		Indexes:     map[string]*ir.Index{},
+		Triggers:    map[string]*ir.Trigger{},
+		Policies:    map[string]*ir.RLSPolicy{},
+	}
+	schema.Sequences["order_numbers"] = &ir.Sequence{
+		Schema: "public", Name: "order_numbers", DataType: "bigint", StartValue: 1, Increment: 1,
+		OwnedByTable: "orders", OwnedByColumn: "number",
+	}
+
+	sql := buildSQLFromSteps(GenerateMigration(ir.NewIR(), newIR, "public"))
+	for _, expected := range []string{"CREATE SEQUENCE IF NOT EXISTS order_numbers AS bigint;", "number integer DEFAULT nextval('order_numbers'::regclass)"} {
+		if !strings.Contains(sql, expected) {
+			t.Errorf("expected migration to contain %q, got:\n%s", expected, sql)
+		}
+	}
+}
@@ -2,8 +2,10 @@ package diff
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/pgplex/pgschema/ir"
 )
@@ -202,6 +204,10 @@ func diffTables(oldTable, newTable *ir.Table, targetSchema string) *tableDiff {
 		}
 	}
 
+	// Pair dropped and added constraints that only differ by name into renames
+	diff.RenamedConstraints, diff.DroppedConstraints, diff.AddedConstraints =
+		detectRenamedConstraints(diff.DroppedConstraints, diff.AddedConstraints)
+
 	// Find modified constraints
 	for name, newConstraint := range newConstraints {
 		if oldConstraint, exists := oldConstraints[name]; exists {
@@ -245,9 +251,10 @@ func diffTables(oldTable, newTable *ir.Table, targetSchema string) *tableDiff {
 		if oldIndex, exists := oldIndexes[name]; exists {
 			structurallyEqual := indexesStructurallyEqual(oldIndex, newIndex)
 			commentChanged := oldIndex.Comment != newIndex.Comment
+			statisticsChanged := !indexStatisticsEqual(oldIndex, newIndex)
 
-			// If only comments changed, treat as modification
-			if structurallyEqual && commentChanged {
+			// If only comments or statistics targets changed, treat as modification
+			if structurallyEqual && (commentChanged || statisticsChanged) {
 				diff.ModifiedIndexes = append(diff.ModifiedIndexes, &IndexDiff{
 					Old: oldIndex,
 					New: newIndex,
@@ -328,22 +335,108 @@ func diffTables(oldTable, newTable *ir.Table, targetSchema string) *tableDiff {
 		diff.NewComment = newTable.Comment
 	}
 
+	// Check for LOGGED/UNLOGGED changes
+	if oldTable.IsUnlogged != newTable.IsUnlogged {
+		diff.PersistenceChanged = true
+	}
+
+	// Check for storage parameter changes, including toast.* options. PostgreSQL rejects storage
+	// parameters on partitioned tables; each partition keeps its own, compared as a table of its own.
+	if !newTable.IsPartitioned && !relOptionsEqual(oldTable.StorageParameters, newTable.StorageParameters) {
+		diff.StorageChanged = true
+		diff.OldStorage = oldTable.StorageParameters
+	}
+
+	// Check for partition bound changes; PostgreSQL cannot alter a bound in place
+	if !partitionBoundsEqual(oldTable.PartitionOf, newTable.PartitionOf) {
+		diff.PartitionChanged = true
+		diff.OldPartitionOf = oldTable.PartitionOf
+	}
+
 	// Return nil if no changes
-	if len(diff.AddedColumns) == 0 && len(diff.DroppedColumns) == 0 &&
-		len(diff.ModifiedColumns) == 0 && len(diff.AddedConstraints) == 0 &&
-		len(diff.DroppedConstraints) == 0 && len(diff.ModifiedConstraints) == 0 &&
-		len(diff.AddedIndexes) == 0 && len(diff.DroppedIndexes) == 0 &&
-		len(diff.ModifiedIndexes) == 0 && len(diff.AddedTriggers) == 0 &&
-		len(diff.DroppedTriggers) == 0 && len(diff.ModifiedTriggers) == 0 &&
-		len(diff.AddedPolicies) == 0 && len(diff.DroppedPolicies) == 0 &&
-		len(diff.ModifiedPolicies) == 0 && len(diff.RLSChanges) == 0 &&
-		!diff.CommentChanged {
+	if diff.isEmpty() {
 		return nil
 	}
 
 	return diff
 }
 
+// partitionBoundsEqual reports whether two tables are partitions of the same parent with the same bound
+func partitionBoundsEqual(old, new *ir.PartitionBound) bool {
+	if old == nil || new == nil {
+		return old == nil && new == nil
+	}
+	return *old == *new
+}
+
+// isEmpty reports whether the table diff has no changes
+func (td *tableDiff) isEmpty() bool {
+	return len(td.AddedColumns) == 0 && len(td.DroppedColumns) == 0 &&
+		len(td.ModifiedColumns) == 0 && len(td.AddedConstraints) == 0 &&
+		len(td.DroppedConstraints) == 0 && len(td.ModifiedConstraints) == 0 &&
+		len(td.RenamedConstraints) == 0 && len(td.AddedIndexes) == 0 && len(td.DroppedIndexes) == 0 &&
+		len(td.ModifiedIndexes) == 0 && len(td.AddedTriggers) == 0 &&
+		len(td.DroppedTriggers) == 0 && len(td.ModifiedTriggers) == 0 &&
+		len(td.AddedPolicies) == 0 && len(td.DroppedPolicies) == 0 &&
+		len(td.ModifiedPolicies) == 0 && len(td.RLSChanges) == 0 &&
+		!td.CommentChanged && !td.PersistenceChanged && !td.StorageChanged && !td.PartitionChanged
+}
+
+// removeInheritedColumnChanges removes the column changes of a partition that PostgreSQL makes
+// itself when the same change is applied to the partitioned parent: columns added to or dropped
+// from the parent are added to or dropped from all partitions, and SET DEFAULT on the parent
+// also sets the default of every partition. Defaults a partition overrides are kept.
+func (td *tableDiff) removeInheritedColumnChanges(oldParent, newParent *ir.Table, targetSchema string) {
+	oldParentColumns := make(map[string]*ir.Column)
+	for _, column := range oldParent.Columns {
+		oldParentColumns[column.Name] = column
+	}
+	newParentColumns := make(map[string]*ir.Column)
+	for _, column := range newParent.Columns {
+		newParentColumns[column.Name] = column
+	}
+
+	var added []*ir.Column
+	for _, column := range td.AddedColumns {
+		if newParentColumns[column.Name] == nil || oldParentColumns[column.Name] != nil {
+			added = append(added, column)
+		}
+	}
+	td.AddedColumns = added
+
+	var dropped []*ir.Column
+	for _, column := range td.DroppedColumns {
+		if oldParentColumns[column.Name] == nil || newParentColumns[column.Name] != nil {
+			dropped = append(dropped, column)
+		}
+	}
+	td.DroppedColumns = dropped
+
+	var modified []*ColumnDiff
+	for _, columnDiff := range td.ModifiedColumns {
+		oldParentColumn := oldParentColumns[columnDiff.New.Name]
+		newParentColumn := newParentColumns[columnDiff.New.Name]
+		if oldParentColumn != nil && newParentColumn != nil &&
+			!stringPtrEqual(oldParentColumn.DefaultValue, newParentColumn.DefaultValue) &&
+			stringPtrEqual(columnDiff.New.DefaultValue, newParentColumn.DefaultValue) {
+			// The parent's SET DEFAULT already gives the partition its new default
+			remaining := *columnDiff.New
+			remaining.DefaultValue = columnDiff.Old.DefaultValue
+			if columnsEqual(columnDiff.Old, &remaining, targetSchema) {
+				continue
+			}
+			columnDiff = &ColumnDiff{Old: columnDiff.Old, New: &remaining, Using: columnDiff.Using}
+		}
+		modified = append(modified, columnDiff)
+	}
+	td.ModifiedColumns = modified
+}
+
+// stringPtrEqual reports whether two optional strings are both unset or hold the same value
+func stringPtrEqual(a, b *string) bool {
+	return (a == nil) == (b == nil) && (a == nil || *a == *b)
+}
+
 // diffExternalTable compares two external tables and returns only trigger differences
 // External tables are not managed by pgschema, so we only track triggers on them
 func diffExternalTable(oldTable, newTable *ir.Table) *tableDiff {
@@ -565,8 +658,17 @@ func generateTableSQL(table *ir.Table, targetSchema string, createdTables map[st
 	// Only include table name without schema if it's in the target schema
 	tableName := ir.QualifyEntityNameWithQuotes(table.Schema, table.Name, targetSchema)
 
+	// Partitions inherit their columns from the parent
+	if table.PartitionOf != nil {
+		return generatePartitionTableSQL(table, tableName, targetSchema, createdTables, existingTables)
+	}
+
 	var parts []string
-	parts = append(parts, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (", tableName))
+	if table.IsUnlogged {
+		parts = append(parts, fmt.Sprintf("CREATE UNLOGGED TABLE IF NOT EXISTS %s (", tableName))
+	} else {
+		parts = append(parts, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (", tableName))
+	}
 
 	// Add columns
 	var columnParts []string
@@ -608,15 +710,79 @@ func generateTableSQL(table *ir.Table, targetSchema string, createdTables map[st
 	parts = append(parts, strings.Join(columnParts, ",\n"))
 
 	// Add partition clause for partitioned tables
+	closing := ")"
 	if table.IsPartitioned && table.PartitionStrategy != "" && table.PartitionKey != "" {
-		parts = append(parts, fmt.Sprintf(") PARTITION BY %s (%s);", table.PartitionStrategy, table.PartitionKey))
-	} else {
-		parts = append(parts, ");")
+		closing += fmt.Sprintf(" PARTITION BY %s (%s)", table.PartitionStrategy, table.PartitionKey)
 	}
+	parts = append(parts, closing+storageParametersClause(table)+tablespaceClause(table.Tablespace)+";")
 
 	return strings.Join(parts, "\n"), deferred
 }
 
+// generatePartitionTableSQL generates CREATE TABLE ... PARTITION OF for a partition of a partitioned table.
+// Constraints defined on the partition itself are listed inline; constraints inherited from the parent
+// are created by PostgreSQL along with the partition.
+func generatePartitionTableSQL(table *ir.Table, tableName, targetSchema string, createdTables map[string]bool, existingTables map[string]bool) (string, []*deferredConstraint) {
+	parentName := ir.QualifyEntityNameWithQuotes(table.PartitionOf.ParentSchema, table.PartitionOf.ParentTable, targetSchema)
+
+	create := "CREATE TABLE"
+	if table.IsUnlogged {
+		create = "CREATE UNLOGGED TABLE"
+	}
+
+	var constraintParts []string
+	var deferred []*deferredConstraint
+	currentKey := fmt.Sprintf("%s.%s", table.Schema, table.Name)
+	for _, constraint := range getInlineConstraintsForTable(table) {
+		if shouldDeferConstraint(table, constraint, currentKey, createdTables, existingTables) {
+			deferred = append(deferred, &deferredConstraint{
+				table:      table,
+				constraint: constraint,
+			})
+			continue
+		}
+		if constraintDef := generateConstraintSQL(constraint, targetSchema); constraintDef != "" {
+			constraintParts = append(constraintParts, fmt.Sprintf("    %s", constraintDef))
+		}
+	}
+
+	var sql string
+	if len(constraintParts) > 0 {
+		sql = fmt.Sprintf("%s IF NOT EXISTS %s PARTITION OF %s (\n%s\n) %s", create, tableName, parentName,
+			strings.Join(constraintParts, ",\n"), partitionBoundSQL(table.PartitionOf))
+	} else {
+		sql = fmt.Sprintf("%s IF NOT EXISTS %s PARTITION OF %s\n    %s", create, tableName, parentName, partitionBoundSQL(table.PartitionOf))
+	}
+
+	// Partitions can be partitioned themselves
+	if table.IsPartitioned && table.PartitionStrategy != "" && table.PartitionKey != "" {
+		sql += fmt.Sprintf(" PARTITION BY %s (%s)", table.PartitionStrategy, table.PartitionKey)
+	}
+
+	return sql + storageParametersClause(table) + tablespaceClause(table.Tablespace) + ";", deferred
+}
+
+// storageParametersClause returns the " WITH (...)" clause for a table's storage parameters, or "" if it has
+// none. Partitioned tables cannot have storage parameters, so they never get the clause.
+func storageParametersClause(table *ir.Table) string {
+	if len(table.StorageParameters) == 0 || table.IsPartitioned {
+		return ""
+	}
+	return fmt.Sprintf(" WITH (%s)", strings.Join(table.StorageParameters, ", "))
+}
+
+// partitionBoundSQL returns the bound clause of a partition, e.g., "FOR VALUES WITH (MODULUS 4, REMAINDER 0)"
+func partitionBoundSQL(bound *ir.PartitionBound) string {
+	switch bound.Strategy {
+	case "DEFAULT":
+		return "DEFAULT"
+	case "HASH":
+		return fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", bound.Modulus, bound.Remainder)
+	default:
+		return "FOR VALUES " + bound.Values
+	}
+}
+
 func shouldDeferConstraint(table *ir.Table, constraint *ir.Constraint, currentKey string, createdTables map[string]bool, existingTables map[string]bool) bool {
 	if constraint == nil || constraint.Type != ir.ConstraintTypeForeignKey {
 		return false
@@ -650,6 +816,59 @@ func shouldDeferConstraint(table *ir.Table, constraint *ir.Constraint, currentKe
 // Note: DroppedTriggers are skipped here because they are already processed in the DROP phase
 // (see generateDropTriggersFromModifiedTables in trigger.go)
 func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector *diffCollector) {
+	// Handle LOGGED/UNLOGGED changes before any other modification, since the switch rewrites the table
+	if td.PersistenceChanged {
+		tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
+		persistence := "LOGGED"
+		if td.Table.IsUnlogged {
+			persistence = "UNLOGGED"
+		}
+		sql := fmt.Sprintf("ALTER TABLE %s SET %s;", tableName, persistence)
+
+		context := &diffContext{
+			Type:                DiffTypeTable,
+			Operation:           DiffOperationAlter,
+			Path:                fmt.Sprintf("%s.%s", td.Table.Schema, td.Table.Name),
+			Source:              td.Table,
+			CanRunInTransaction: true,
+		}
+		collector.collect(context, sql)
+	}
+
+	// Handle storage parameter changes (e.g., fillfactor, toast.autovacuum_enabled)
+	if td.StorageChanged {
+		tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
+		reset, set := relOptionsChanges(td.OldStorage, td.Table.StorageParameters)
+
+		context := &diffContext{
+			Type:                DiffTypeTable,
+			Operation:           DiffOperationAlter,
+			Path:                fmt.Sprintf("%s.%s", td.Table.Schema, td.Table.Name),
+			Source:              td.Table,
+			CanRunInTransaction: true,
+		}
+		if len(reset) > 0 {
+			collector.collect(context, fmt.Sprintf("ALTER TABLE %s RESET (%s);", tableName, strings.Join(reset, ", ")))
+		}
+		if len(set) > 0 {
+			collector.collect(context, fmt.Sprintf("ALTER TABLE %s SET (%s);", tableName, strings.Join(set, ", ")))
+		}
+	}
+
+	// Detach the partition from its old parent; it is attached again once its columns match the new one
+	if td.PartitionChanged && td.OldPartitionOf != nil {
+		parentName := getTableNameWithSchema(td.OldPartitionOf.ParentSchema, td.OldPartitionOf.ParentTable, targetSchema)
+		tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
+		context := &diffContext{
+			Type:                DiffTypeTable,
+			Operation:           DiffOperationAlter,
+			Path:                fmt.Sprintf("%s.%s", td.Table.Schema, td.Table.Name),
+			Source:              td.Table,
+			CanRunInTransaction: true,
+		}
+		collector.collect(context, fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s;", parentName, tableName))
+	}
+
 	// Drop constraints first (before dropping columns) - already sorted by the Diff operation
 	for _, constraint := range td.DroppedConstraints {
 		tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
@@ -665,6 +884,22 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 		collector.collect(context, sql)
 	}
 
+	// Rename constraints before any constraint is added, so new names cannot collide
+	for _, renamed := range td.RenamedConstraints {
+		tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
+		sql := fmt.Sprintf("ALTER TABLE %s RENAME CONSTRAINT %s TO %s;", tableName,
+			ir.QuoteIdentifier(renamed.Old.Name), ir.QuoteIdentifier(renamed.New.Name))
+
+		context := &diffContext{
+			Type:                DiffTypeTableConstraint,
+			Operation:           DiffOperationAlter,
+			Path:                fmt.Sprintf("%s.%s.%s", td.Table.Schema, td.Table.Name, renamed.New.Name),
+			Source:              renamed,
+			CanRunInTransaction: true,
+		}
+		collector.collect(context, sql)
+	}
+
 	// Drop columns - already sorted by the Diff operation
 	for _, column := range td.DroppedColumns {
 		tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
@@ -702,12 +937,12 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 		}
 
 		// Build column type and strip schema prefix if it matches target schema
-		columnType := formatColumnDataType(column)
+		columnType := formatColumnDataType(td.Table.Schema, td.Table.Name, column)
 		columnType = stripSchemaPrefix(columnType, targetSchema)
 		tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
 
 		// Build and append all column clauses
-		clauses := buildColumnClauses(column, isPartOfAnyPK, td.Table.Schema, targetSchema)
+		clauses := buildColumnClauses(td.Table, column, isPartOfAnyPK, targetSchema)
 
 		// Check for single-column constraints that can be added inline
 		var inlineConstraint string
@@ -716,9 +951,9 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 			if len(constraint.Columns) == 1 && constraint.Columns[0].Name == column.Name {
 				switch constraint.Type {
 				case ir.ConstraintTypePrimaryKey:
-					inlineConstraint = fmt.Sprintf(" CONSTRAINT %s PRIMARY KEY", ir.QuoteIdentifier(constraint.Name))
+					inlineConstraint = fmt.Sprintf(" CONSTRAINT %s PRIMARY KEY%s%s", ir.QuoteIdentifier(constraint.Name), generateIncludeClause(constraint), generateDeferrableClause(constraint))
 				case ir.ConstraintTypeUnique:
-					inlineConstraint = fmt.Sprintf(" CONSTRAINT %s UNIQUE", ir.QuoteIdentifier(constraint.Name))
+					inlineConstraint = fmt.Sprintf(" CONSTRAINT %s UNIQUE%s%s", ir.QuoteIdentifier(constraint.Name), generateIncludeClause(constraint), generateDeferrableClause(constraint))
 				case ir.ConstraintTypeForeignKey:
 					// For FK, use the generateForeignKeyClause with inline=true
 					fkClause := generateForeignKeyClause(constraint, targetSchema, true)
@@ -801,6 +1036,21 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 		}
 	}
 
+	// Attach the partition to its new parent with the new bound
+	if td.PartitionChanged && td.Table.PartitionOf != nil {
+		bound := td.Table.PartitionOf
+		parentName := getTableNameWithSchema(bound.ParentSchema, bound.ParentTable, targetSchema)
+		tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
+		context := &diffContext{
+			Type:                DiffTypeTable,
+			Operation:           DiffOperationAlter,
+			Path:                fmt.Sprintf("%s.%s", td.Table.Schema, td.Table.Name),
+			Source:              td.Table,
+			CanRunInTransaction: true,
+		}
+		collector.collect(context, fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s %s;", parentName, tableName, partitionBoundSQL(bound)))
+	}
+
 	// Add new constraints - already sorted by the Diff operation
 	for _, constraint := range td.AddedConstraints {
 		// Skip constraints that were already added inline with columns
@@ -817,8 +1067,8 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 				columnNames = append(columnNames, ir.QuoteIdentifier(col.Name))
 			}
 			tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
-			sql := fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s UNIQUE (%s);",
-				tableName, ir.QuoteIdentifier(constraint.Name), strings.Join(columnNames, ", "))
+			sql := fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s UNIQUE (%s)%s%s;",
+				tableName, ir.QuoteIdentifier(constraint.Name), strings.Join(columnNames, ", "), generateIncludeClause(constraint), generateDeferrableClause(constraint))
 
 			context := &diffContext{
 				Type:                DiffTypeTableConstraint,
@@ -876,8 +1126,8 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 				columnNames = append(columnNames, ir.QuoteIdentifier(col.Name))
 			}
 			tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
-			sql := fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s PRIMARY KEY (%s);",
-				tableName, ir.QuoteIdentifier(constraint.Name), strings.Join(columnNames, ", "))
+			sql := fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s PRIMARY KEY (%s)%s%s;",
+				tableName, ir.QuoteIdentifier(constraint.Name), strings.Join(columnNames, ", "), generateIncludeClause(constraint), generateDeferrableClause(constraint))
 
 			context := &diffContext{
 				Type:                DiffTypeTableConstraint,
@@ -909,6 +1159,19 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 		tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
 		constraint := ConstraintDiff.New
 
+		// A foreign key whose deferrability alone changed is altered in place
+		if deferrabilityOnlyChange(ConstraintDiff.Old, constraint) {
+			context := &diffContext{
+				Type:                DiffTypeTableConstraint,
+				Operation:           DiffOperationAlter,
+				Path:                fmt.Sprintf("%s.%s.%s", td.Table.Schema, td.Table.Name, constraint.Name),
+				Source:              ConstraintDiff,
+				CanRunInTransaction: true,
+			}
+			collector.collect(context, generateAlterDeferrabilitySQL(tableName, constraint))
+			continue
+		}
+
 		// Step 1: Drop the old constraint
 		dropSQL := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", tableName, ir.QuoteIdentifier(ConstraintDiff.Old.Name))
 		dropContext := &diffContext{
@@ -930,8 +1193,8 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 			for _, col := range columns {
 				columnNames = append(columnNames, ir.QuoteIdentifier(col.Name))
 			}
-			addSQL = fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s UNIQUE (%s);",
-				tableName, ir.QuoteIdentifier(constraint.Name), strings.Join(columnNames, ", "))
+			addSQL = fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s UNIQUE (%s)%s%s;",
+				tableName, ir.QuoteIdentifier(constraint.Name), strings.Join(columnNames, ", "), generateIncludeClause(constraint), generateDeferrableClause(constraint))
 
 		case ir.ConstraintTypeCheck:
 			// Add CHECK constraint with ensured outer parentheses
@@ -958,8 +1221,8 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 			for _, col := range columns {
 				columnNames = append(columnNames, ir.QuoteIdentifier(col.Name))
 			}
-			addSQL = fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s PRIMARY KEY (%s);",
-				tableName, ir.QuoteIdentifier(constraint.Name), strings.Join(columnNames, ", "))
+			addSQL = fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s PRIMARY KEY (%s)%s%s;",
+				tableName, ir.QuoteIdentifier(constraint.Name), strings.Join(columnNames, ", "), generateIncludeClause(constraint), generateDeferrableClause(constraint))
 
 		case ir.ConstraintTypeExclusion:
 			addSQL = fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s %s;",
@@ -1070,6 +1333,10 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 			CanRunInTransaction: true,
 		}
 		collector.collect(context, sql)
+
+		if policy.Comment != "" {
+			generatePolicyComment(policy, targetSchema, DiffOperationCreate, collector)
+		}
 	}
 
 	// Modify triggers - already sorted by the Diff operation
@@ -1116,6 +1383,12 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 
 	// Modify policies - already sorted by the Diff operation
 	for _, policyDiff := range td.ModifiedPolicies {
+		// Only the comment changed - generate just COMMENT ON POLICY
+		if policiesEqualExceptComment(policyDiff.Old, policyDiff.New) {
+			generatePolicyComment(policyDiff.New, targetSchema, DiffOperationAlter, collector)
+			continue
+		}
+
 		// Check if this policy needs to be recreated (DROP + CREATE)
 		if needsRecreate(policyDiff.Old, policyDiff.New) {
 			tableName := getTableNameWithSchema(td.Table.Schema, td.Table.Name, targetSchema)
@@ -1140,6 +1413,11 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 				CanRunInTransaction: true,
 			}
 			collector.collect(context, sql)
+
+			// The recreated policy loses its comment
+			if policyDiff.New.Comment != "" {
+				generatePolicyComment(policyDiff.New, targetSchema, DiffOperationCreate, collector)
+			}
 		} else {
 			// Use ALTER POLICY for simple changes
 			sql := generateAlterPolicySQL(policyDiff.Old, policyDiff.New, targetSchema)
@@ -1152,6 +1430,10 @@ func (td *tableDiff) generateAlterTableStatements(targetSchema string, collector
 				CanRunInTransaction: true,
 			}
 			collector.collect(context, sql)
+
+			if policyDiff.Old.Comment != policyDiff.New.Comment {
+				generatePolicyComment(policyDiff.New, targetSchema, DiffOperationAlter, collector)
+			}
 		}
 	}
 
@@ -1252,7 +1534,7 @@ func writeColumnDefinitionToBuilder(builder *strings.Builder, table *ir.Table, c
 	builder.WriteString(" ")
 
 	// Data type - handle array types and precision/scale for appropriate types
-	dataType := formatColumnDataTypeForCreate(column)
+	dataType := formatColumnDataTypeForCreate(table.Schema, table.Name, column)
 
 	// Strip schema prefix if it matches the target schema
 	dataType = stripSchemaPrefix(dataType, targetSchema)
@@ -1276,28 +1558,33 @@ func writeColumnDefinitionToBuilder(builder *strings.Builder, table *ir.Table, c
 	}
 
 	// Build and append all column clauses
-	clauses := buildColumnClauses(column, isPartOfAnyPK, table.Schema, targetSchema)
+	clauses := buildColumnClauses(table, column, isPartOfAnyPK, targetSchema)
 	builder.WriteString(clauses)
 }
 
 // buildColumnClauses builds the SQL clauses for a column definition (works for both CREATE TABLE and ALTER TABLE)
 // Returns the clauses as a string to be appended to the column name and type
 // Order follows PostgreSQL documentation: https://www.postgresql.org/docs/current/sql-altertable.html
-func buildColumnClauses(column *ir.Column, isPartOfAnyPK bool, tableSchema string, targetSchema string) string {
+func buildColumnClauses(table *ir.Table, column *ir.Column, isPartOfAnyPK bool, targetSchema string) string {
 	var parts []string
 
+	// 0. COLLATE belongs to the data type, so it precedes all constraints
+	if column.Collation != "" {
+		parts = append(parts, "COLLATE "+column.Collation)
+	}
+
 	// 1. Identity columns (must come early, before DEFAULT)
 	if column.Identity != nil {
 		switch column.Identity.Generation {
 		case "ALWAYS":
-			parts = append(parts, "GENERATED ALWAYS AS IDENTITY")
+			parts = append(parts, "GENERATED ALWAYS AS IDENTITY"+identityOptionsClause(column))
 		case "BY DEFAULT":
-			parts = append(parts, "GENERATED BY DEFAULT AS IDENTITY")
+			parts = append(parts, "GENERATED BY DEFAULT AS IDENTITY"+identityOptionsClause(column))
 		}
 	}
 
 	// 2. DEFAULT (skip for SERIAL, identity, or generated columns)
-	if column.DefaultValue != nil && column.Identity == nil && !column.IsGenerated && !isSerialColumn(column) {
+	if column.DefaultValue != nil && column.Identity == nil && !column.IsGenerated && !isSerialColumn(table.Schema, table.Name, column) {
 		// DefaultValue is already normalized by ir.normalizeColumn
 		// (schema qualifiers and sequence references are handled there)
 		parts = append(parts, fmt.Sprintf("DEFAULT %s", *column.DefaultValue))
@@ -1305,13 +1592,20 @@ func buildColumnClauses(column *ir.Column, isPartOfAnyPK bool, tableSchema strin
 
 	// 3. Generated column syntax (must come before constraints)
 	if column.IsGenerated && column.GeneratedExpr != nil {
-		// TODO: Add support for GENERATED ALWAYS AS (...) VIRTUAL when PostgreSQL 18 is supported
-		parts = append(parts, fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", *column.GeneratedExpr))
+		storage := "STORED"
+		if column.GeneratedVirtual {
+			storage = "VIRTUAL"
+		}
+		parts = append(parts, fmt.Sprintf("GENERATED ALWAYS AS (%s) %s", *column.GeneratedExpr, storage))
 	}
 
 	// 4. NOT NULL (skip for PK including multi-column PKs, identity, and SERIAL)
-	if !column.IsNullable && column.Identity == nil && !isSerialColumn(column) && !isPartOfAnyPK {
-		parts = append(parts, "NOT NULL")
+	if !column.IsNullable && column.Identity == nil && !isSerialColumn(table.Schema, table.Name, column) && !isPartOfAnyPK {
+		if column.NotNullConstraintName != "" {
+			parts = append(parts, fmt.Sprintf("CONSTRAINT %s NOT NULL", ir.QuoteIdentifier(column.NotNullConstraintName)))
+		} else {
+			parts = append(parts, "NOT NULL")
+		}
 	}
 
 	// Note: No inline constraints (PRIMARY KEY, UNIQUE, FOREIGN KEY) are added here
@@ -1326,10 +1620,19 @@ func buildColumnClauses(column *ir.Column, isPartOfAnyPK bool, tableSchema strin
 	return result
 }
 
-// isSerialColumn checks if a column is a SERIAL column (integer type with nextval default)
-func isSerialColumn(column *ir.Column) bool {
-	// Check if column has nextval default
-	if column.DefaultValue == nil || !strings.Contains(*column.DefaultValue, "nextval") {
+// isSerialColumn checks if a column is a SERIAL column: an integer column whose default takes
+// nextval() of the sequence SERIAL would have created for it. A column drawing from any other
+// sequence keeps its explicit DEFAULT, since SERIAL would create a different sequence. That
+// includes a sequence of the same name in another schema.
+func isSerialColumn(schema, tableName string, column *ir.Column) bool {
+	if column.DefaultValue == nil {
+		return false
+	}
+	match := serialDefaultRegex.FindStringSubmatch(*column.DefaultValue)
+	if match == nil || !isSerialSequenceName(match[2], tableName, column.Name) {
+		return false
+	}
+	if match[1] != "" && match[1] != schema && match[1] != ir.QuoteIdentifier(schema) {
 		return false
 	}
 
@@ -1342,12 +1645,62 @@ func isSerialColumn(column *ir.Column) bool {
 	}
 }
 
+// serialDefaultRegex matches a nextval() default, capturing the schema qualifier, if any, and the
+// unqualified sequence name
+var serialDefaultRegex = regexp.MustCompile(`^nextval\('(?:([^'.]+)\.)?([^'.]+)'::regclass\)$`)
+
+// isSerialSequence reports whether a sequence is the implicit sequence of the SERIAL column owning it.
+// Such sequences are created and dropped along with their column rather than on their own.
+func isSerialSequence(seq *ir.Sequence) bool {
+	return seq.OwnedByTable != "" && seq.OwnedByColumn != "" && isSerialSequenceName(seq.Name, seq.OwnedByTable, seq.OwnedByColumn)
+}
+
+// isSerialSequenceName reports whether seqName is the name PostgreSQL chooses for the sequence of
+// a SERIAL column: <table>_<column>_seq, with the longer of the two names truncated to fit in
+// 63 bytes and a numeric suffix added if the name was already taken.
+func isSerialSequenceName(seqName, tableName, columnName string) bool {
+	const maxIdentifierLength = 63
+	available := maxIdentifierLength - len("__seq")
+	tableBytes, columnBytes := len(tableName), len(columnName)
+	for tableBytes+columnBytes > available {
+		if tableBytes > columnBytes {
+			tableBytes--
+		} else {
+			columnBytes--
+		}
+	}
+	// Like PostgreSQL's makeObjectName, the byte budget is split first and each name is then
+	// clipped to a character boundary, so a multibyte character is never cut in half
+	base := clipToCharBoundary(tableName, tableBytes) + "_" + clipToCharBoundary(columnName, columnBytes) + "_seq"
+	suffix, ok := strings.CutPrefix(seqName, base)
+	if !ok {
+		return false
+	}
+	for _, c := range suffix {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// clipToCharBoundary shortens s to at most n bytes without splitting a multibyte UTF-8 character
+func clipToCharBoundary(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
 // formatColumnDataType formats a column's data type with appropriate modifiers for ALTER TABLE statements
-func formatColumnDataType(column *ir.Column) string {
+func formatColumnDataType(schema, tableName string, column *ir.Column) string {
 	dataType := column.DataType
 
 	// Handle SERIAL types
-	if isSerialColumn(column) {
+	if isSerialColumn(schema, tableName, column) {
 		switch column.DataType {
 		case "smallint", "int2":
 			return "smallserial"
@@ -1375,11 +1728,11 @@ func formatColumnDataType(column *ir.Column) string {
 }
 
 // formatColumnDataTypeForCreate formats a column's data type with appropriate modifiers for CREATE TABLE statements
-func formatColumnDataTypeForCreate(column *ir.Column) string {
+func formatColumnDataTypeForCreate(schema, tableName string, column *ir.Column) string {
 	dataType := column.DataType
 
 	// Handle SERIAL types (uppercase for CREATE TABLE)
-	if isSerialColumn(column) {
+	if isSerialColumn(schema, tableName, column) {
 		switch column.DataType {
 		case "smallint", "int2":
 			return "SMALLSERIAL"
@@ -1414,6 +1767,7 @@ func indexesStructurallyEqual(oldIndex, newIndex *ir.Index) bool {
 		oldIndex.Method != newIndex.Method ||
 		oldIndex.IsPartial != newIndex.IsPartial ||
 		oldIndex.IsExpression != newIndex.IsExpression ||
+		oldIndex.NullsNotDistinct != newIndex.NullsNotDistinct ||
 		oldIndex.Where != newIndex.Where {
 		return false
 	}
@@ -1429,7 +1783,8 @@ func indexesStructurallyEqual(oldIndex, newIndex *ir.Index) bool {
 		if oldCol.Name != newCol.Name ||
 			oldCol.Position != newCol.Position ||
 			oldCol.Direction != newCol.Direction ||
-			oldCol.Operator != newCol.Operator {
+			oldCol.Operator != newCol.Operator ||
+			oldCol.OperatorParams != newCol.OperatorParams {
 			return false
 		}
 	}
@@ -1474,13 +1829,7 @@ func generateForeignKeyClause(constraint *ir.Constraint, targetSchema string, in
 	}
 
 	// Add deferrable clause
-	if constraint.Deferrable {
-		if constraint.InitiallyDeferred {
-			clause += " DEFERRABLE INITIALLY DEFERRED"
-		} else {
-			clause += " DEFERRABLE"
-		}
-	}
+	clause += generateDeferrableClause(constraint)
 
 	return clause
 }
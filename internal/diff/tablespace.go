@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"fmt"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+// applyDefaultTablespace places the tables and indexes created by the migration that have no
+// tablespace in the given one. Only CREATE statements carry the tablespace; it is never compared,
+// so objects that already exist stay where they are. The objects are copied rather than changed in
+// place, so the desired state IR passed in by the caller is left as it was.
+func (d *ddlDiff) applyDefaultTablespace(tablespace string) {
+	if tablespace == "" {
+		return
+	}
+
+	for i, table := range d.addedTables {
+		placed := *table
+		if placed.Tablespace == "" {
+			placed.Tablespace = tablespace
+		}
+		placed.Indexes = indexMapWithTablespace(table.Indexes, tablespace)
+		d.addedTables[i] = &placed
+	}
+	for _, td := range d.modifiedTables {
+		td.AddedIndexes = indexesWithTablespace(td.AddedIndexes, tablespace)
+		for _, indexDiff := range td.ModifiedIndexes {
+			indexDiff.New = indexWithTablespace(indexDiff.New, tablespace)
+		}
+	}
+	for i, view := range d.addedViews {
+		placed := *view
+		placed.Indexes = indexMapWithTablespace(view.Indexes, tablespace)
+		d.addedViews[i] = &placed
+	}
+	for _, vd := range d.modifiedViews {
+		placed := *vd.New
+		placed.Indexes = indexMapWithTablespace(vd.New.Indexes, tablespace)
+		vd.New = &placed
+		vd.AddedIndexes = indexesWithTablespace(vd.AddedIndexes, tablespace)
+		for _, indexDiff := range vd.ModifiedIndexes {
+			indexDiff.New = indexWithTablespace(indexDiff.New, tablespace)
+		}
+	}
+}
+
+// indexWithTablespace returns the index, or a copy of it placed in tablespace if it has none
+func indexWithTablespace(index *ir.Index, tablespace string) *ir.Index {
+	if index == nil || index.Tablespace != "" {
+		return index
+	}
+	placed := *index
+	placed.Tablespace = tablespace
+	return &placed
+}
+
+// indexesWithTablespace applies indexWithTablespace to a list of indexes
+func indexesWithTablespace(indexes []*ir.Index, tablespace string) []*ir.Index {
+	if indexes == nil {
+		return nil
+	}
+	placed := make([]*ir.Index, len(indexes))
+	for i, index := range indexes {
+		placed[i] = indexWithTablespace(index, tablespace)
+	}
+	return placed
+}
+
+// indexMapWithTablespace applies indexWithTablespace to the indexes of a table or materialized view
+func indexMapWithTablespace(indexes map[string]*ir.Index, tablespace string) map[string]*ir.Index {
+	if indexes == nil {
+		return nil
+	}
+	placed := make(map[string]*ir.Index, len(indexes))
+	for name, index := range indexes {
+		placed[name] = indexWithTablespace(index, tablespace)
+	}
+	return placed
+}
+
+// tablespaceClause returns the " TABLESPACE name" clause for a CREATE statement, or "" if no tablespace is set
+func tablespaceClause(tablespace string) string {
+	if tablespace == "" {
+		return ""
+	}
+	return fmt.Sprintf(" TABLESPACE %s", ir.QuoteIdentifier(tablespace))
+}
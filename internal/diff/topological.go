@@ -8,8 +8,9 @@ import (
 )
 
 // topologicallySortTables sorts tables across all schemas in dependency order
-// Tables that are referenced by foreign keys will come before the tables that reference them
-func topologicallySortTables(tables []*ir.Table) []*ir.Table {
+// Tables that are referenced by foreign keys will come before the tables that reference them.
+// If edges is not nil, the dependency that placed each table is recorded in it.
+func topologicallySortTables(tables []*ir.Table, edges orderingEdges) []*ir.Table {
 	if len(tables) <= 1 {
 		return tables
 	}
@@ -26,6 +27,7 @@ func topologicallySortTables(tables []*ir.Table) []*ir.Table {
 	// Build dependency graph
 	inDegree := make(map[string]int)
 	adjList := make(map[string][]string)
+	edgeReasons := make(map[[2]string]string)
 
 	// Initialize
 	for key := range tableMap {
@@ -48,11 +50,25 @@ func topologicallySortTables(tables []*ir.Table) []*ir.Table {
 				if _, exists := tableMap[keyB]; exists && keyA != keyB {
 					adjList[keyB] = append(adjList[keyB], keyA)
 					inDegree[keyA]++
+					addEdgeReason(edgeReasons, keyB, keyA, "foreign key "+constraint.Name+" references it")
 				}
 			}
 		}
 	}
 
+	// Build edges: partitions are created after their partitioned parent
+	for keyA, tableA := range tableMap {
+		if tableA.PartitionOf == nil {
+			continue
+		}
+		keyB := tableA.PartitionOf.ParentSchema + "." + tableA.PartitionOf.ParentTable
+		if _, exists := tableMap[keyB]; exists && keyA != keyB {
+			adjList[keyB] = append(adjList[keyB], keyA)
+			inDegree[keyA]++
+			addEdgeReason(edgeReasons, keyB, keyA, "it is a partition of it")
+		}
+	}
+
 	// Kahn's algorithm with deterministic cycle breaking
 	var queue []string
 	var result []string
@@ -114,6 +130,7 @@ func topologicallySortTables(tables []*ir.Table) []*ir.Table {
 			if inDegree[neighbor] <= 0 && !processed[neighbor] {
 				queue = append(queue, neighbor)
 				sort.Strings(queue)
+				edges.record("table:"+neighbor, "table:"+current, edgeReasons[[2]string{current, neighbor}])
 			}
 		}
 	}
@@ -128,8 +145,9 @@ func topologicallySortTables(tables []*ir.Table) []*ir.Table {
 }
 
 // topologicallySortViews sorts views across all schemas in dependency order
-// Views that depend on other views will come after their dependencies
-func topologicallySortViews(views []*ir.View) []*ir.View {
+// Views that depend on other views will come after their dependencies.
+// If edges is not nil, the dependency that placed each view is recorded in it.
+func topologicallySortViews(views []*ir.View, edges orderingEdges) []*ir.View {
 	if len(views) <= 1 {
 		return views
 	}
@@ -162,6 +180,12 @@ func topologicallySortViews(views []*ir.View) []*ir.View {
 			}
 		}
 	}
+	viewReason := func(key string) string {
+		if viewMap[key].Materialized {
+			return "materialized view " + viewMap[key].Name + " selects from it"
+		}
+		return "view " + viewMap[key].Name + " selects from it"
+	}
 
 	// Kahn's algorithm with deterministic cycle breaking
 	var queue []string
@@ -209,6 +233,7 @@ func topologicallySortViews(views []*ir.View) []*ir.View {
 			if inDegree[neighbor] <= 0 && !processed[neighbor] {
 				queue = append(queue, neighbor)
 				sort.Strings(queue)
+				edges.record("view:"+neighbor, "view:"+current, viewReason(neighbor))
 			}
 		}
 	}
@@ -241,8 +266,9 @@ func nextInOrder(order []string, processed map[string]bool) string {
 }
 
 // topologicallySortTypes sorts types across all schemas in dependency order
-// Types that are referenced by composite types will come before the types that reference them
-func topologicallySortTypes(types []*ir.Type) []*ir.Type {
+// Types that are referenced by composite types will come before the types that reference them.
+// If edges is not nil, the dependency that placed each type is recorded in it.
+func topologicallySortTypes(types []*ir.Type, edges orderingEdges) []*ir.Type {
 	if len(types) <= 1 {
 		return types
 	}
@@ -260,6 +286,8 @@ func topologicallySortTypes(types []*ir.Type) []*ir.Type {
 	inDegree := make(map[string]int)
 	adjList := make(map[string][]string)
 
+	edgeReasons := make(map[[2]string]string)
+
 	// Initialize
 	for key := range typeMap {
 		inDegree[key] = 0
@@ -277,6 +305,7 @@ func topologicallySortTypes(types []*ir.Type) []*ir.Type {
 					if _, exists := typeMap[referencedType]; exists && keyA != referencedType {
 						adjList[referencedType] = append(adjList[referencedType], keyA)
 						inDegree[keyA]++
+						addEdgeReason(edgeReasons, referencedType, keyA, "attribute "+col.Name+" uses it")
 					}
 				}
 			}
@@ -287,6 +316,7 @@ func topologicallySortTypes(types []*ir.Type) []*ir.Type {
 				if _, exists := typeMap[referencedType]; exists && keyA != referencedType {
 					adjList[referencedType] = append(adjList[referencedType], keyA)
 					inDegree[keyA]++
+					addEdgeReason(edgeReasons, referencedType, keyA, "domain "+typeA.Name+" is based on it")
 				}
 			}
 		}
@@ -360,6 +390,7 @@ func topologicallySortTypes(types []*ir.Type) []*ir.Type {
 			if inDegree[neighbor] <= 0 && !processed[neighbor] {
 				queue = append(queue, neighbor)
 				sort.Strings(queue)
+				edges.record("type:"+neighbor, "type:"+current, edgeReasons[[2]string{current, neighbor}])
 			}
 		}
 	}
@@ -406,8 +437,9 @@ func findLastDot(s string) int {
 }
 
 // topologicallySortFunctions sorts functions across all schemas in dependency order
-// Functions that are referenced by other functions will come before the functions that reference them
-func topologicallySortFunctions(functions []*ir.Function) []*ir.Function {
+// Functions that are referenced by other functions will come before the functions that reference them.
+// If edges is not nil, the dependency that placed each function is recorded in it.
+func topologicallySortFunctions(functions []*ir.Function, edges orderingEdges) []*ir.Function {
 	if len(functions) <= 1 {
 		return functions
 	}
@@ -499,6 +531,9 @@ func topologicallySortFunctions(functions []*ir.Function) []*ir.Function {
 			if inDegree[neighbor] <= 0 && !processed[neighbor] {
 				queue = append(queue, neighbor)
 				sort.Strings(queue)
+				dependent := funcMap[neighbor]
+				edges.record("function:"+dependent.Schema+"."+dependent.Name,
+					"function:"+funcMap[current].Schema+"."+funcMap[current].Name, "function "+dependent.Name+" calls it")
 			}
 		}
 	}
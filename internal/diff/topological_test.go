@@ -17,7 +17,7 @@ func TestTopologicallySortTablesHandlesCycles(t *testing.T) {
 		newTestTable("z", "y"), // depends on the cycle
 	}
 
-	sorted := topologicallySortTables(tables)
+	sorted := topologicallySortTables(tables, nil)
 	if len(sorted) != len(tables) {
 		t.Fatalf("expected %d tables, got %d", len(tables), len(sorted))
 	}
@@ -73,7 +73,7 @@ func TestTopologicallySortTypesHandlesCycles(t *testing.T) {
 		newTestCompositeType("z", "y"),
 	}
 
-	sorted := topologicallySortTypes(types)
+	sorted := topologicallySortTypes(types, nil)
 	if len(sorted) != len(types) {
 		t.Fatalf("expected %d types, got %d", len(types), len(sorted))
 	}
@@ -109,7 +109,7 @@ func TestTopologicallySortTypesMultipleNoDependencies(t *testing.T) {
 		newTestEnumType("b"),
 	}
 
-	sorted := topologicallySortTypes(types)
+	sorted := topologicallySortTypes(types, nil)
 	if len(sorted) != len(types) {
 		t.Fatalf("expected %d types, got %d", len(types), len(sorted))
 	}
@@ -133,7 +133,7 @@ func TestTopologicallySortTypesDomainReferencingCustomType(t *testing.T) {
 		newTestCompositeType("person", "status_domain"),
 	}
 
-	sorted := topologicallySortTypes(types)
+	sorted := topologicallySortTypes(types, nil)
 	if len(sorted) != len(types) {
 		t.Fatalf("expected %d types, got %d", len(types), len(sorted))
 	}
@@ -163,7 +163,7 @@ func TestTopologicallySortTypesCompositeWithMultipleDependencies(t *testing.T) {
 		newTestCompositeType("project", "task"),
 	}
 
-	sorted := topologicallySortTypes(types)
+	sorted := topologicallySortTypes(types, nil)
 	if len(sorted) != len(types) {
 		t.Fatalf("expected %d types, got %d", len(types), len(sorted))
 	}
@@ -427,7 +427,7 @@ func TestBuildFunctionBodyDependenciesWithTopologicalSort(t *testing.T) {
 	}
 
 	// Now sort
-	sorted := topologicallySortFunctions(functions)
+	sorted := topologicallySortFunctions(functions, nil)
 
 	// a_helper should come before z_wrapper
 	order := make(map[string]int)
@@ -36,15 +36,10 @@ func triggersEqual(old, new *ir.Trigger) bool {
 		return false
 	}
 
-	// Compare events
-	if len(old.Events) != len(new.Events) {
+	// Compare events regardless of the order they were declared in
+	if !triggerEventsEqual(old.Events, new.Events) {
 		return false
 	}
-	for i, event := range old.Events {
-		if event != new.Events[i] {
-			return false
-		}
-	}
 
 	// Compare constraint trigger properties
 	if old.IsConstraint != new.IsConstraint {
@@ -207,6 +202,23 @@ func generateDropTriggersFromModifiedViews(views []*viewDiff, targetSchema strin
 	}
 }
 
+// triggerEventsEqual reports whether two triggers fire on the same set of events.
+// "AFTER DELETE OR INSERT" and "AFTER INSERT OR DELETE" are the same trigger.
+func triggerEventsEqual(old, new []ir.TriggerEvent) bool {
+	oldSet := make(map[ir.TriggerEvent]bool, len(old))
+	for _, event := range old {
+		oldSet[event] = true
+	}
+	newSet := make(map[ir.TriggerEvent]bool, len(new))
+	for _, event := range new {
+		if !oldSet[event] {
+			return false
+		}
+		newSet[event] = true
+	}
+	return len(oldSet) == len(newSet)
+}
+
 // generateTriggerSQLWithMode generates CREATE [OR REPLACE] TRIGGER or CREATE CONSTRAINT TRIGGER statement
 func generateTriggerSQLWithMode(trigger *ir.Trigger, targetSchema string) string {
 	// Build event list in standard order: INSERT, UPDATE, DELETE, TRUNCATE
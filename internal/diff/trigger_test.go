@@ -0,0 +1,40 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestTriggersEqualEventOrder(t *testing.T) {
+	newTrigger := func(events ...ir.TriggerEvent) *ir.Trigger {
+		return &ir.Trigger{
+			Schema:   "public",
+			Table:    "orders",
+			Name:     "orders_audit",
+			Timing:   ir.TriggerTimingAfter,
+			Events:   events,
+			Level:    ir.TriggerLevelRow,
+			Function: "audit()",
+		}
+	}
+
+	declared := newTrigger(ir.TriggerEventDelete, ir.TriggerEventInsert)
+	canonical := newTrigger(ir.TriggerEventInsert, ir.TriggerEventDelete)
+	if !triggersEqual(declared, canonical) {
+		t.Error("expected triggers with the same events in a different order to be equal")
+	}
+
+	if sql := generateTriggerSQLWithMode(declared, "public"); !strings.Contains(sql, "AFTER INSERT OR DELETE ON orders") {
+		t.Errorf("expected events in canonical order, got %q", sql)
+	}
+
+	withUpdate := newTrigger(ir.TriggerEventInsert, ir.TriggerEventUpdate)
+	if triggersEqual(canonical, withUpdate) {
+		t.Error("expected triggers with different events to differ")
+	}
+	if triggersEqual(canonical, newTrigger(ir.TriggerEventInsert)) {
+		t.Error("expected a removed event to be detected")
+	}
+}
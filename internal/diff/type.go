@@ -11,7 +11,7 @@ import (
 // generateCreateTypesSQL generates CREATE TYPE statements
 func generateCreateTypesSQL(types []*ir.Type, targetSchema string, collector *diffCollector) {
 	// Sort types topologically to handle dependencies (e.g., composite types referencing enum types)
-	sortedTypes := topologicallySortTypes(types)
+	sortedTypes := topologicallySortTypes(types, collector.edges)
 
 	for _, typeObj := range sortedTypes {
 		sql := generateTypeSQL(typeObj, targetSchema)
@@ -77,7 +77,7 @@ func generateModifyTypesSQL(diffs []*typeDiff, targetSchema string, collector *d
 }
 
 // generateDropTypesSQL generates DROP TYPE statements
-func generateDropTypesSQL(types []*ir.Type, targetSchema string, collector *diffCollector) {
+func generateDropTypesSQL(types []*ir.Type, targetSchema string, cascade map[DiffType]bool, collector *diffCollector) {
 	// Sort types by name for consistent ordering
 	sortedTypes := make([]*ir.Type, len(types))
 	copy(sortedTypes, types)
@@ -89,20 +89,16 @@ func generateDropTypesSQL(types []*ir.Type, targetSchema string, collector *diff
 		typeName := qualifyEntityName(typeObj.Schema, typeObj.Name, targetSchema)
 
 		var sql string
-		if typeObj.Kind == ir.TypeKindDomain {
-			sql = fmt.Sprintf("DROP DOMAIN IF EXISTS %s RESTRICT;", typeName)
-		} else {
-			sql = fmt.Sprintf("DROP TYPE IF EXISTS %s RESTRICT;", typeName)
-		}
-
-		// Create context for this statement
 		var diffType DiffType
 		if typeObj.Kind == ir.TypeKindDomain {
 			diffType = DiffTypeDomain
+			sql = fmt.Sprintf("DROP DOMAIN IF EXISTS %s %s;", typeName, dropBehavior(diffType, cascade))
 		} else {
 			diffType = DiffTypeType
+			sql = fmt.Sprintf("DROP TYPE IF EXISTS %s %s;", typeName, dropBehavior(diffType, cascade))
 		}
 
+		// Create context for this statement
 		context := &diffContext{
 			Type:                diffType,
 			Operation:           DiffOperationDrop,
@@ -223,6 +219,52 @@ func generateAlterDomainStatements(oldDomain, newDomain *ir.Type, targetSchema s
 	return statements
 }
 
+// domainNeedsRecreate reports whether a domain change cannot be made with ALTER DOMAIN.
+// PostgreSQL has no ALTER DOMAIN ... COLLATE, so a domain whose collation changes is recreated.
+func domainNeedsRecreate(oldType, newType *ir.Type) bool {
+	return oldType.Kind == ir.TypeKindDomain && newType.Kind == ir.TypeKindDomain && oldType.Collation != newType.Collation
+}
+
+// checkDomainRecreations returns an error if a domain that must be recreated is used by a table
+// column, since the domain cannot be dropped without dropping the column and its data
+func checkDomainRecreations(oldIR, newIR *ir.IR) error {
+	recreated := make(map[string]bool)
+	for _, schemaName := range sortedKeys(newIR.Schemas) {
+		oldSchema, ok := oldIR.Schemas[schemaName]
+		if !ok {
+			continue
+		}
+		for typeName, newType := range newIR.Schemas[schemaName].Types {
+			if oldType, ok := oldSchema.Types[typeName]; ok && domainNeedsRecreate(oldType, newType) {
+				recreated[schemaName+"."+typeName] = true
+			}
+		}
+	}
+	if len(recreated) == 0 {
+		return nil
+	}
+
+	var columns []string
+	for _, schemaName := range sortedKeys(oldIR.Schemas) {
+		dbSchema := oldIR.Schemas[schemaName]
+		for _, tableName := range sortedKeys(dbSchema.Tables) {
+			for _, column := range dbSchema.Tables[tableName].Columns {
+				dataType := strings.TrimSuffix(column.DataType, "[]")
+				if !strings.Contains(dataType, ".") {
+					dataType = schemaName + "." + dataType
+				}
+				if recreated[dataType] {
+					columns = append(columns, fmt.Sprintf("%s.%s.%s (%s)", schemaName, tableName, column.Name, dataType))
+				}
+			}
+		}
+	}
+	if len(columns) > 0 {
+		return fmt.Errorf("changing the collation of a domain requires recreating it, which is not supported while table columns use it: %s", strings.Join(columns, ", "))
+	}
+	return nil
+}
+
 // generateTypeSQL generates CREATE TYPE statement
 func generateTypeSQL(typeObj *ir.Type, targetSchema string) string {
 	// Only include type name without schema if it's in the target schema
@@ -257,15 +299,20 @@ func generateTypeSQL(typeObj *ir.Type, targetSchema string) string {
 		}
 		return fmt.Sprintf("CREATE TYPE %s AS (%s);", typeName, strings.Join(attributes, ", "))
 	case ir.TypeKindDomain:
+		domainType := typeObj.BaseType
+		if typeObj.Collation != "" {
+			domainType += " COLLATE " + typeObj.Collation
+		}
+
 		// Use multi-line format for better readability if there are constraints
 		hasConstraints := len(typeObj.Constraints) > 0 || typeObj.NotNull || typeObj.Default != ""
 
 		if !hasConstraints {
-			return fmt.Sprintf("CREATE DOMAIN %s AS %s;", typeName, typeObj.BaseType)
+			return fmt.Sprintf("CREATE DOMAIN %s AS %s;", typeName, domainType)
 		}
 
 		// Multi-line format
-		lines := []string{fmt.Sprintf("CREATE DOMAIN %s AS %s", typeName, typeObj.BaseType)}
+		lines := []string{fmt.Sprintf("CREATE DOMAIN %s AS %s", typeName, domainType)}
 
 		if typeObj.Default != "" {
 			lines = append(lines, fmt.Sprintf("  DEFAULT %s", typeObj.Default))
@@ -332,6 +379,9 @@ func typesEqual(old, new *ir.Type) bool {
 		if old.BaseType != new.BaseType {
 			return false
 		}
+		if old.Collation != new.Collation {
+			return false
+		}
 		if old.NotNull != new.NotNull {
 			return false
 		}
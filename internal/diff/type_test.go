@@ -0,0 +1,53 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestGenerateDomainSQLCollation(t *testing.T) {
+	domain := &ir.Type{Schema: "public", Name: "tags", Kind: ir.TypeKindDomain, BaseType: "text[]", Collation: `"C"`}
+	if got, expected := generateTypeSQL(domain, "public"), `CREATE DOMAIN tags AS text[] COLLATE "C";`; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	domain.NotNull = true
+	if got, expected := generateTypeSQL(domain, "public"), "CREATE DOMAIN tags AS text[] COLLATE \"C\"\n  NOT NULL;"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	other := &ir.Type{Schema: "public", Name: "tags", Kind: ir.TypeKindDomain, BaseType: "text[]", NotNull: true}
+	if typesEqual(domain, other) {
+		t.Error("expected a collation difference to be detected")
+	}
+}
+
+// TestDomainCollationChangeWithColumns checks that a domain collation change, which needs the
+// domain to be recreated, is rejected while a table column uses the domain.
+func TestDomainCollationChangeWithColumns(t *testing.T) {
+	newSchemaIR := func(collation string) *ir.IR {
+		schemaIR := ir.NewIR()
+		schemaIR.Schemas["public"] = &ir.Schema{
+			Name: "public",
+			Types: map[string]*ir.Type{
+				"code": {Schema: "public", Name: "code", Kind: ir.TypeKindDomain, BaseType: "text", Collation: collation},
+			},
+			Tables: map[string]*ir.Table{
+				"products": {
+					Schema:  "public",
+					Name:    "products",
+					Type:    ir.TableTypeBase,
+					Columns: []*ir.Column{{Name: "code", Position: 1, DataType: "code", IsNullable: true}},
+				},
+			},
+		}
+		return schemaIR
+	}
+
+	_, err := GenerateMigrationWithOptions(newSchemaIR(""), newSchemaIR(`"C"`), "public", MigrationOptions{AllowUnsafeTypeChanges: true})
+	if err == nil || !strings.Contains(err.Error(), "public.products.code (public.code)") {
+		t.Errorf("expected an error naming the column that uses the domain, got %v", err)
+	}
+}
@@ -68,6 +68,11 @@ func generateCreateViewsSQL(views []*ir.View, targetSchema string, collector *di
 			}
 		}
 
+		// Add materialized view column comments and storage
+		if view.Materialized {
+			generateViewColumnsSQL(nil, view, nil, targetSchema, collector)
+		}
+
 		// For materialized views, create indexes
 		if view.Materialized && view.Indexes != nil {
 			indexList := make([]*ir.Index, 0, len(view.Indexes))
@@ -230,6 +235,11 @@ func generateModifyViewsSQL(diffs []*viewDiff, targetSchema string, collector *d
 				collector.collect(commentContext, commentSQL)
 			}
 
+			// Recreate column comments and storage for materialized views
+			if diff.New.Materialized {
+				generateViewColumnsSQL(nil, diff.New, nil, targetSchema, collector)
+			}
+
 			// Recreate indexes for materialized views
 			if diff.New.Materialized && diff.New.Indexes != nil {
 				indexList := make([]*ir.Index, 0, len(diff.New.Indexes))
@@ -249,8 +259,9 @@ func generateModifyViewsSQL(diffs []*viewDiff, targetSchema string, collector *d
 		}
 
 		// Check if only the comment changed and definition is identical
-		// Both IRs come from pg_get_viewdef() at the same PostgreSQL version, so string comparison is sufficient
-		definitionsEqual := diff.Old.Definition == diff.New.Definition
+		// Both IRs come from pg_get_viewdef() at the same PostgreSQL version, so string comparison is sufficient.
+		// A materialized view whose columns are renamed in place keeps its definition.
+		definitionsEqual := diff.Old.Definition == diff.New.Definition || len(diff.RenamedColumns) > 0
 		commentOnlyChange := diff.CommentChanged && definitionsEqual && diff.Old.Materialized == diff.New.Materialized
 
 		// Check if only indexes changed (for materialized views)
@@ -261,8 +272,21 @@ func generateModifyViewsSQL(diffs []*viewDiff, targetSchema string, collector *d
 		hasTriggerChanges := len(diff.AddedTriggers) > 0 || len(diff.DroppedTriggers) > 0 || len(diff.ModifiedTriggers) > 0
 		triggerOnlyChange := hasTriggerChanges && definitionsEqual && !diff.CommentChanged && !hasIndexChanges
 
-		// Handle non-structural changes (comment-only, index-only, or trigger-only)
-		if commentOnlyChange || indexOnlyChange || triggerOnlyChange {
+		// Check if only WITH (...) options changed (ALTER VIEW ... SET/RESET instead of CREATE OR REPLACE)
+		optionsOnlyChange := diff.OptionsChanged && definitionsEqual && !diff.New.Materialized
+
+		// Check if only column renames, comments, or storage changed (for materialized views)
+		columnOnlyChange := diff.ColumnsChanged && definitionsEqual
+
+		// Handle non-structural changes (comment-only, index-only, trigger-only, options-only, or column-only)
+		if commentOnlyChange || indexOnlyChange || triggerOnlyChange || optionsOnlyChange || columnOnlyChange {
+			if diff.ColumnsChanged {
+				generateViewColumnsSQL(diff.Old, diff.New, diff.RenamedColumns, targetSchema, collector)
+			}
+			if diff.OptionsChanged && !diff.New.Materialized {
+				generateAlterViewOptionsSQL(diff, targetSchema, collector)
+			}
+
 			// Only generate COMMENT ON VIEW statement if comment actually changed
 			if diff.CommentChanged {
 				viewName := qualifyEntityName(diff.New.Schema, diff.New.Name, targetSchema)
@@ -413,7 +437,7 @@ func generateModifyViewsSQL(diffs []*viewDiff, targetSchema string, collector *d
 	// This is critical for views that depend on multiple mat views being recreated.
 	// Re-sort topologically to ensure correct order when views from different mat view
 	// dependency lists have cross-dependencies (e.g., V3 from mat_B depends on V1 from mat_A).
-	sortedDependentViews := topologicallySortViews(allDependentViewsToRecreate)
+	sortedDependentViews := topologicallySortViews(allDependentViewsToRecreate, nil)
 	for _, depView := range sortedDependentViews {
 		depViewKey := depView.Schema + "." + depView.Name
 
@@ -503,8 +527,80 @@ func generateViewSQL(view *ir.View, targetSchema string) string {
 		createClause = "CREATE OR REPLACE VIEW"
 	}
 
+	// WITH (...) options only apply to regular views; CREATE OR REPLACE VIEW replaces
+	// the existing options, so removed options are reset as well
+	withClause := ""
+	if !view.Materialized && len(view.Options) > 0 {
+		withClause = fmt.Sprintf(" WITH (%s)", strings.Join(view.Options, ", "))
+	}
+
 	// Use the view definition as-is - it has already been normalized
-	return fmt.Sprintf("%s %s AS\n%s;", createClause, viewName, view.Definition)
+	return fmt.Sprintf("%s %s%s AS\n%s;", createClause, viewName, withClause, view.Definition)
+}
+
+// generateAlterViewOptionsSQL generates ALTER VIEW ... RESET/SET statements for changed view options
+func generateAlterViewOptionsSQL(diff *viewDiff, targetSchema string, collector *diffCollector) {
+	viewName := qualifyEntityName(diff.New.Schema, diff.New.Name, targetSchema)
+
+	reset, set := relOptionsChanges(diff.Old.Options, diff.New.Options)
+
+	context := &diffContext{
+		Type:                DiffTypeView,
+		Operation:           DiffOperationAlter,
+		Path:                fmt.Sprintf("%s.%s", diff.New.Schema, diff.New.Name),
+		Source:              diff,
+		CanRunInTransaction: true,
+	}
+	if len(reset) > 0 {
+		collector.collect(context, fmt.Sprintf("ALTER VIEW %s RESET (%s);", viewName, strings.Join(reset, ", ")))
+	}
+	if len(set) > 0 {
+		collector.collect(context, fmt.Sprintf("ALTER VIEW %s SET (%s);", viewName, strings.Join(set, ", ")))
+	}
+}
+
+// relOptionsChanges returns the option names to RESET and the "key=value" options to SET
+// to turn the old normalized reloptions (view options or table storage parameters) into the new ones
+func relOptionsChanges(oldOptions, newOptions []string) (reset []string, set []string) {
+	oldValues := relOptionsMap(oldOptions)
+	newValues := relOptionsMap(newOptions)
+
+	for _, option := range oldOptions {
+		key, _, _ := strings.Cut(option, "=")
+		if _, exists := newValues[key]; !exists {
+			reset = append(reset, key)
+		}
+	}
+	for _, option := range newOptions {
+		key, value, _ := strings.Cut(option, "=")
+		if oldValue, exists := oldValues[key]; !exists || oldValue != value {
+			set = append(set, option)
+		}
+	}
+	return reset, set
+}
+
+// relOptionsMap splits normalized "key=value" reloptions into a map
+func relOptionsMap(options []string) map[string]string {
+	result := make(map[string]string, len(options))
+	for _, option := range options {
+		key, value, _ := strings.Cut(option, "=")
+		result[key] = value
+	}
+	return result
+}
+
+// relOptionsEqual compares normalized (sorted) reloptions
+func relOptionsEqual(old, new []string) bool {
+	if len(old) != len(new) {
+		return false
+	}
+	for i := range old {
+		if old[i] != new[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // diffViewTriggers computes added, dropped, and modified triggers between two views
@@ -579,7 +675,7 @@ func viewsEqual(old, new *ir.View) bool {
 // viewColumnsRequireRecreate checks whether the view's column set has changed
 // in a way that requires DROP + CREATE instead of CREATE OR REPLACE.
 // PostgreSQL's CREATE OR REPLACE VIEW only allows adding new columns at the end;
-// it rejects any changes to existing column names or positions.
+// it rejects any changes to existing column names, positions, or types.
 func viewColumnsRequireRecreate(old, new *ir.View) bool {
 	oldCols := old.Columns
 	newCols := new.Columns
@@ -595,7 +691,7 @@ func viewColumnsRequireRecreate(old, new *ir.View) bool {
 	if len(newCols) >= len(oldCols) {
 		prefixMatch := true
 		for i, col := range oldCols {
-			if newCols[i] != col {
+			if newCols[i] != col || viewColumnTypeChanged(old, new, i) {
 				prefixMatch = false
 				break
 			}
@@ -609,14 +705,359 @@ func viewColumnsRequireRecreate(old, new *ir.View) bool {
 	return true
 }
 
+// viewColumnTypeChanged reports whether the type of the view column at idx differs.
+// Column types unknown on either side are treated as unchanged.
+func viewColumnTypeChanged(old, new *ir.View, idx int) bool {
+	if idx >= len(old.ColumnTypes) || idx >= len(new.ColumnTypes) {
+		return false
+	}
+	return old.ColumnTypes[idx] != new.ColumnTypes[idx]
+}
+
+// viewColumnRename is a materialized view column renamed with ALTER MATERIALIZED VIEW ... RENAME COLUMN
+type viewColumnRename struct {
+	Old string
+	New string
+}
+
+// materializedViewColumnRenames reports whether the new materialized view only renames columns of
+// the old one, so it can be altered in place instead of recreated. This is the case when the column
+// types are unchanged and renaming the columns in the old definition gives the new definition.
+func materializedViewColumnRenames(old, new *ir.View) ([]viewColumnRename, bool) {
+	if len(old.Columns) == 0 || len(old.Columns) != len(new.Columns) {
+		return nil, false
+	}
+
+	renames := make(map[int]viewColumnRename)
+	var ordered []viewColumnRename
+	for idx, name := range old.Columns {
+		if viewColumnTypeChanged(old, new, idx) {
+			return nil, false
+		}
+		if new.Columns[idx] != name {
+			rename := viewColumnRename{Old: name, New: new.Columns[idx]}
+			renames[idx] = rename
+			ordered = append(ordered, rename)
+		}
+	}
+	if len(ordered) == 0 {
+		return nil, false
+	}
+
+	renamed, ok := renameSelectListColumns(old.Definition, renames)
+	if !ok || renamed != new.Definition {
+		return nil, false
+	}
+	return ordered, true
+}
+
+// selectListEndRegex matches the clause that ends the select list of a pg_get_viewdef definition
+var selectListEndRegex = regexp.MustCompile(`^\s+(FROM|WHERE|GROUP|HAVING|WINDOW|ORDER|LIMIT|OFFSET|UNION|INTERSECT|EXCEPT)\b`)
+
+// identifierChainRegex matches a possibly qualified column reference, capturing its last name
+var identifierChainRegex = regexp.MustCompile(`^(?:(?:[A-Za-z_][A-Za-z0-9_$]*|"(?:[^"]|"")+")\.)*([A-Za-z_][A-Za-z0-9_$]*|"(?:[^"]|"")+")$`)
+
+// renameSelectListColumns renames the output columns at the given positions of a view definition
+// the way pg_get_viewdef shows them after ALTER MATERIALIZED VIEW ... RENAME COLUMN: the item gets
+// an "AS new_name" alias, which is omitted when it is a column reference of that name.
+// It returns false for definitions whose select list it cannot split (e.g., SELECT DISTINCT).
+func renameSelectListColumns(definition string, renames map[int]viewColumnRename) (string, bool) {
+	start := strings.Index(definition, "SELECT ")
+	if start < 0 || (start > 0 && strings.TrimSpace(definition[:start]) != "") {
+		return "", false
+	}
+	start += len("SELECT ")
+	if hasKeywordPrefix(definition[start:], "DISTINCT") || hasKeywordPrefix(definition[start:], "ALL") {
+		return "", false
+	}
+
+	// Split the select list at top-level commas
+	var items [][2]int
+	itemStart, depth := start, 0
+	var quote byte
+	end := len(definition)
+	for i := start; i < len(definition); i++ {
+		c := definition[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, [2]int{itemStart, i})
+				itemStart = i + 1
+			}
+		}
+		if depth == 0 && selectListEndRegex.MatchString(definition[i+1:]) {
+			end = i + 1
+			break
+		}
+	}
+	items = append(items, [2]int{itemStart, end})
+
+	var result strings.Builder
+	last, applied := 0, 0
+	for idx, bounds := range items {
+		rename, ok := renames[idx]
+		if !ok {
+			continue
+		}
+		raw := definition[bounds[0]:bounds[1]]
+		item := strings.TrimSpace(raw)
+		leading := raw[:strings.Index(raw, item)]
+
+		// The item is named by its alias, or by the column it references
+		expr := strings.TrimSuffix(item, " AS "+ir.QuoteIdentifier(rename.Old))
+		if expr == item {
+			match := identifierChainRegex.FindStringSubmatch(item)
+			if match == nil || match[1] != ir.QuoteIdentifier(rename.Old) {
+				return "", false
+			}
+		}
+
+		renamed := expr + " AS " + ir.QuoteIdentifier(rename.New)
+		if match := identifierChainRegex.FindStringSubmatch(expr); match != nil && match[1] == ir.QuoteIdentifier(rename.New) {
+			renamed = expr
+		}
+
+		result.WriteString(definition[last:bounds[0]])
+		result.WriteString(leading)
+		result.WriteString(renamed)
+		last = bounds[0] + len(leading) + len(item)
+		applied++
+	}
+	if applied != len(renames) {
+		return "", false
+	}
+	result.WriteString(definition[last:])
+	return result.String(), true
+}
+
+// viewColumnAttributesEqual reports whether the column comments and storage of two materialized views
+// are the same, matching renamed columns by their old name
+func viewColumnAttributesEqual(old, new *ir.View, renames []viewColumnRename) bool {
+	oldNames := viewColumnOldNames(renames)
+	for _, name := range new.Columns {
+		oldName := oldNames(name)
+		if old.ColumnComments[oldName] != new.ColumnComments[name] ||
+			viewColumnStorage(old, oldName) != viewColumnStorage(new, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// viewColumnOldNames returns a function that maps a column name of the new view to its old name
+func viewColumnOldNames(renames []viewColumnRename) func(string) string {
+	oldNames := make(map[string]string, len(renames))
+	for _, rename := range renames {
+		oldNames[rename.New] = rename.Old
+	}
+	return func(name string) string {
+		if oldName, ok := oldNames[name]; ok {
+			return oldName
+		}
+		return name
+	}
+}
+
+// viewColumnStorage returns the storage set on a view column, or "" if it has its type's storage
+func viewColumnStorage(view *ir.View, column string) string {
+	if storage := view.ColumnStorage[column]; storage != nil {
+		return storage.Storage
+	}
+	return ""
+}
+
+// generateViewColumnsSQL generates the column renames, comments, and storage changes of a materialized
+// view. When old is nil the view was just created, so only the new view's settings are emitted.
+func generateViewColumnsSQL(old, new *ir.View, renames []viewColumnRename, targetSchema string, collector *diffCollector) {
+	viewName := qualifyEntityName(new.Schema, new.Name, targetSchema)
+	operation := DiffOperationAlter
+	if old == nil {
+		operation = DiffOperationCreate
+		old = &ir.View{}
+	}
+
+	for _, rename := range renames {
+		context := &diffContext{
+			Type:                DiffTypeMaterializedView,
+			Operation:           DiffOperationAlter,
+			Path:                fmt.Sprintf("%s.%s", new.Schema, new.Name),
+			Source:              new,
+			CanRunInTransaction: true,
+		}
+		collector.collect(context, fmt.Sprintf("ALTER MATERIALIZED VIEW %s RENAME COLUMN %s TO %s;",
+			viewName, ir.QuoteIdentifier(rename.Old), ir.QuoteIdentifier(rename.New)))
+	}
+
+	oldNames := viewColumnOldNames(renames)
+	for _, name := range new.Columns {
+		oldName := oldNames(name)
+
+		if comment := new.ColumnComments[name]; comment != old.ColumnComments[oldName] {
+			sql := fmt.Sprintf("COMMENT ON COLUMN %s.%s IS NULL;", viewName, ir.QuoteIdentifier(name))
+			if comment != "" {
+				sql = fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", viewName, ir.QuoteIdentifier(name), quoteString(comment))
+			}
+			context := &diffContext{
+				Type:                DiffTypeMaterializedViewComment,
+				Operation:           operation,
+				Path:                fmt.Sprintf("%s.%s.%s", new.Schema, new.Name, name),
+				Source:              new,
+				CanRunInTransaction: true,
+			}
+			collector.collect(context, sql)
+		}
+
+		if storage := viewColumnStorage(new, name); storage != viewColumnStorage(old, oldName) {
+			if storage == "" {
+				storage = old.ColumnStorage[oldName].TypeStorage
+			}
+			context := &diffContext{
+				Type:                DiffTypeMaterializedView,
+				Operation:           operation,
+				Path:                fmt.Sprintf("%s.%s", new.Schema, new.Name),
+				Source:              new,
+				CanRunInTransaction: true,
+			}
+			collector.collect(context, fmt.Sprintf("ALTER MATERIALIZED VIEW %s ALTER COLUMN %s SET STORAGE %s;",
+				viewName, ir.QuoteIdentifier(name), storage))
+		}
+	}
+}
+
 // viewDependsOnView checks if viewA depends on viewB
 func viewDependsOnView(viewA *ir.View, viewBName string) bool {
 	if viewA == nil || viewA.Definition == "" {
 		return false
 	}
+	// An unqualified name that matches one of viewA's CTEs (e.g. the self-referencing
+	// name in a WITH RECURSIVE query) refers to the CTE, not to a view of the same name
+	if !strings.Contains(viewBName, ".") && extractCTENames(viewA.Definition)[strings.ToLower(viewBName)] {
+		return false
+	}
 	return containsIdentifier(viewA.Definition, viewBName)
 }
 
+// extractCTENames returns the lowercased names of the common table expressions declared
+// in the leading WITH [RECURSIVE] clause of a view definition as formatted by pg_get_viewdef.
+// Returns nil if the definition does not start with a WITH clause.
+func extractCTENames(definition string) map[string]bool {
+	s := strings.TrimSpace(definition)
+	if !hasKeywordPrefix(s, "WITH") {
+		return nil
+	}
+	s = strings.TrimSpace(s[len("WITH"):])
+	if hasKeywordPrefix(s, "RECURSIVE") {
+		s = strings.TrimSpace(s[len("RECURSIVE"):])
+	}
+
+	names := make(map[string]bool)
+	for {
+		// CTE name, optionally double-quoted
+		var name string
+		if strings.HasPrefix(s, "\"") {
+			end := strings.Index(s[1:], "\"")
+			if end < 0 {
+				return names
+			}
+			name = s[1 : end+1]
+			s = s[end+2:]
+		} else {
+			end := 0
+			for end < len(s) && isIdentifierChar(s[end]) {
+				end++
+			}
+			if end == 0 {
+				return names
+			}
+			name = s[:end]
+			s = s[end:]
+		}
+		names[strings.ToLower(name)] = true
+		s = strings.TrimSpace(s)
+
+		// Optional column alias list: name(col1, col2)
+		if strings.HasPrefix(s, "(") {
+			s = strings.TrimSpace(skipParenthesized(s))
+		}
+
+		// AS [NOT] [MATERIALIZED] ( query )
+		if !hasKeywordPrefix(s, "AS") {
+			return names
+		}
+		s = strings.TrimSpace(s[len("AS"):])
+		if hasKeywordPrefix(s, "NOT") {
+			s = strings.TrimSpace(s[len("NOT"):])
+		}
+		if hasKeywordPrefix(s, "MATERIALIZED") {
+			s = strings.TrimSpace(s[len("MATERIALIZED"):])
+		}
+		if !strings.HasPrefix(s, "(") {
+			return names
+		}
+		s = strings.TrimSpace(skipParenthesized(s))
+
+		if !strings.HasPrefix(s, ",") {
+			return names
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+}
+
+// hasKeywordPrefix reports whether s starts with the given keyword (case-insensitive)
+// followed by a non-identifier character or the end of the string
+func hasKeywordPrefix(s, keyword string) bool {
+	if len(s) < len(keyword) || !strings.EqualFold(s[:len(keyword)], keyword) {
+		return false
+	}
+	return len(s) == len(keyword) || !isIdentifierChar(s[len(keyword)])
+}
+
+// isIdentifierChar reports whether c can appear in an unquoted SQL identifier
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// skipParenthesized skips a balanced parenthesized group at the start of s and returns the remainder.
+// Parentheses inside string literals and quoted identifiers are ignored.
+// Returns an empty string if the group is not closed.
+func skipParenthesized(s string) string {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[i+1:]
+			}
+		}
+	}
+	return ""
+}
+
 // containsIdentifier checks if the given SQL text contains the identifier as a whole word.
 // This uses word boundary matching to avoid false positives (e.g., "user" matching "users").
 func containsIdentifier(sqlText, identifier string) bool {
@@ -738,7 +1179,7 @@ func findDependentViewsForRecreatedViews(allViews map[string]*ir.View, modifiedV
 		allDependents := findTransitiveDependents(directDependents, allViews, addedViewKeys)
 
 		// Topologically sort the dependents so they can be dropped/recreated in correct order
-		sortedDependents := topologicallySortViews(allDependents)
+		sortedDependents := topologicallySortViews(allDependents, nil)
 
 		ctx.dependents[recreatedViewKey] = sortedDependents
 	}
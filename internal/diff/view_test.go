@@ -0,0 +1,183 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestExtractCTENames(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		expected   map[string]bool
+	}{
+		{
+			name:       "no WITH clause",
+			definition: " SELECT id,\n    name\n   FROM employees",
+			expected:   nil,
+		},
+		{
+			name: "recursive CTE",
+			definition: ` WITH RECURSIVE org_chart AS (
+         SELECT e.id,
+            e.manager_id
+           FROM employees e
+          WHERE e.manager_id IS NULL
+        UNION ALL
+         SELECT e.id,
+            e.manager_id
+           FROM employees e
+             JOIN org_chart oc ON e.manager_id = oc.id
+        )
+ SELECT id,
+    manager_id
+   FROM org_chart`,
+			expected: map[string]bool{"org_chart": true},
+		},
+		{
+			name: "multiple CTEs with column list and materialization",
+			definition: ` WITH totals(department, total) AS MATERIALIZED (
+         SELECT employees.department,
+            sum(employees.salary) AS sum
+           FROM employees
+          WHERE employees.name <> ')'::text
+          GROUP BY employees.department
+        ), "Ranked" AS NOT MATERIALIZED (
+         SELECT totals.department,
+            rank() OVER (ORDER BY totals.total DESC) AS rank
+           FROM totals
+        )
+ SELECT department,
+    rank
+   FROM "Ranked"`,
+			expected: map[string]bool{"totals": true, "ranked": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractCTENames(tt.definition)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("extractCTENames() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestViewDependsOnViewIgnoresCTENames(t *testing.T) {
+	view := &ir.View{
+		Schema: "public",
+		Name:   "employee_hierarchy",
+		Definition: ` WITH RECURSIVE org_chart AS (
+         SELECT employees.id,
+            employees.manager_id
+           FROM employees
+        UNION ALL
+         SELECT e.id,
+            e.manager_id
+           FROM employees e
+             JOIN org_chart oc ON e.manager_id = oc.id
+        )
+ SELECT org_chart.id,
+    d.name
+   FROM org_chart
+     JOIN department_names d ON d.id = org_chart.id`,
+	}
+
+	if viewDependsOnView(view, "org_chart") {
+		t.Error("expected CTE name org_chart not to be treated as a view dependency")
+	}
+	if !viewDependsOnView(view, "department_names") {
+		t.Error("expected department_names to be detected as a view dependency")
+	}
+}
+
+func TestViewColumnsRequireRecreate(t *testing.T) {
+	view := func(columns, types []string) *ir.View {
+		return &ir.View{Schema: "public", Name: "active_orders", Columns: columns, ColumnTypes: types}
+	}
+	base := view([]string{"id", "total"}, []string{"integer", "numeric"})
+
+	tests := []struct {
+		name     string
+		new      *ir.View
+		expected bool
+	}{
+		{"unchanged", view([]string{"id", "total"}, []string{"integer", "numeric"}), false},
+		{"column appended", view([]string{"id", "total", "status"}, []string{"integer", "numeric", "text"}), false},
+		{"column removed", view([]string{"id"}, []string{"integer"}), true},
+		{"column renamed", view([]string{"id", "amount"}, []string{"integer", "numeric"}), true},
+		{"column type changed", view([]string{"id", "total"}, []string{"integer", "bigint"}), true},
+		{"column types unknown", view([]string{"id", "total"}, nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := viewColumnsRequireRecreate(base, tt.new); got != tt.expected {
+				t.Errorf("viewColumnsRequireRecreate() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenameSelectListColumns(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		renames    map[int]viewColumnRename
+		expected   string
+		ok         bool
+	}{
+		{
+			name:       "column reference gets an alias",
+			definition: " SELECT id,\n    total\n   FROM orders",
+			renames:    map[int]viewColumnRename{1: {Old: "total", New: "amount"}},
+			expected:   " SELECT id,\n    total AS amount\n   FROM orders",
+			ok:         true,
+		},
+		{
+			name:       "alias is replaced",
+			definition: " SELECT id,\n    sum(total) AS sum\n   FROM orders\n  GROUP BY id",
+			renames:    map[int]viewColumnRename{1: {Old: "sum", New: "Total Amount"}},
+			expected:   " SELECT id,\n    sum(total) AS \"Total Amount\"\n   FROM orders\n  GROUP BY id",
+			ok:         true,
+		},
+		{
+			name:       "alias matching the column name is dropped",
+			definition: " SELECT o.id,\n    o.total AS amount\n   FROM orders o",
+			renames:    map[int]viewColumnRename{1: {Old: "amount", New: "total"}},
+			expected:   " SELECT o.id,\n    o.total\n   FROM orders o",
+			ok:         true,
+		},
+		{
+			name:       "commas inside expressions",
+			definition: " SELECT coalesce(a, b) AS c,\n    d\n   FROM t",
+			renames:    map[int]viewColumnRename{0: {Old: "c", New: "e"}},
+			expected:   " SELECT coalesce(a, b) AS e,\n    d\n   FROM t",
+			ok:         true,
+		},
+		{
+			name:       "DISTINCT is not handled",
+			definition: " SELECT DISTINCT id\n   FROM orders",
+			renames:    map[int]viewColumnRename{0: {Old: "id", New: "order_id"}},
+			ok:         false,
+		},
+		{
+			name:       "name from an expression without alias",
+			definition: " SELECT 1 + 1\n   FROM orders",
+			renames:    map[int]viewColumnRename{0: {Old: "?column?", New: "two"}},
+			ok:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := renameSelectListColumns(tt.definition, tt.renames)
+			if ok != tt.ok || (ok && got != tt.expected) {
+				t.Errorf("renameSelectListColumns() = %q, %v, want %q, %v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
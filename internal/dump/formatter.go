@@ -2,6 +2,7 @@ package dump
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -31,10 +32,32 @@ func NewDumpFormatter(dbVersion string, targetSchema string, noComments bool) *D
 // FormatSingleFile formats SQL output for single-file dump with pg_dump-style headers
 func (f *DumpFormatter) FormatSingleFile(diffs []diff.Diff) string {
 	var output strings.Builder
+	_ = f.WriteSingleFile(&output, diffs) // writing to a strings.Builder cannot fail
+	return output.String()
+}
 
-	// Generate and write header
-	header := f.generateDumpHeader()
-	output.WriteString(header)
+// WriteSingleFile writes a single-file dump to w one statement at a time, so that large dumps are
+// not built up in memory first. The output is identical to FormatSingleFile.
+func (f *DumpFormatter) WriteSingleFile(w io.Writer, diffs []diff.Diff) error {
+	if _, err := io.WriteString(w, f.generateDumpHeader()); err != nil {
+		return err
+	}
+	return f.WriteStatements(w, diffs)
+}
+
+// FormatStatements formats the SQL statements of a single-file dump without the dump header.
+// The output does not depend on the database or pgschema version, which makes it suitable
+// for canonicalizing schema files.
+func (f *DumpFormatter) FormatStatements(diffs []diff.Diff) string {
+	var output strings.Builder
+	_ = f.WriteStatements(&output, diffs) // writing to a strings.Builder cannot fail
+	return output.String()
+}
+
+// WriteStatements writes the SQL statements of a single-file dump to w, like FormatStatements.
+// It returns the first error from w; nothing more is written after it.
+func (f *DumpFormatter) WriteStatements(w io.Writer, diffs []diff.Diff) error {
+	output := &errWriter{w: w}
 
 	// Format SQL with pg_dump-style formatting
 	for i, step := range diffs {
@@ -70,7 +93,20 @@ func (f *DumpFormatter) FormatSingleFile(diffs []diff.Diff) string {
 
 	// Add trailing newline (Unix convention)
 	output.WriteString("\n")
-	return output.String()
+	return output.err
+}
+
+// errWriter remembers the first write error and skips all writes after it
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) WriteString(s string) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = io.WriteString(e.w, s)
 }
 
 // FormatMultiFile creates multiple SQL files organized by object type
@@ -0,0 +1,109 @@
+package dump
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/pgplex/pgschema/internal/diff"
+	"github.com/pgplex/pgschema/ir"
+)
+
+// largeSchemaDiffs returns the dump diffs of a schema with the given number of commented tables,
+// each with an index
+func largeSchemaDiffs(tables int) []diff.Diff {
+	schemaIR := ir.NewIR()
+	schema := schemaIR.GetOrCreateSchema("public")
+	for i := 0; i < tables; i++ {
+		name := fmt.Sprintf("table_%05d", i)
+		schema.Tables[name] = &ir.Table{
+			Schema:  "public",
+			Name:    name,
+			Type:    ir.TableTypeBase,
+			Comment: "Table " + name,
+			Columns: []*ir.Column{
+				{Name: "id", Position: 1, DataType: "integer"},
+				{Name: "name", Position: 2, DataType: "text", IsNullable: true, Comment: "Display name"},
+				{Name: "created_at", Position: 3, DataType: "timestamptz", IsNullable: true},
+			},
+			Constraints: map[string]*ir.Constraint{},
+			Indexes: map[string]*ir.Index{
+				"idx_" + name + "_name": {
+					Schema:  "public",
+					Table:   name,
+					Name:    "idx_" + name + "_name",
+					Type:    ir.IndexTypeRegular,
+					Method:  "btree",
+					Columns: []*ir.IndexColumn{{Name: "name", Position: 1}},
+				},
+			},
+			Triggers: map[string]*ir.Trigger{},
+			Policies: map[string]*ir.RLSPolicy{},
+		}
+	}
+	return diff.GenerateMigration(ir.NewIR(), schemaIR, "public")
+}
+
+func TestWriteSingleFileMatchesFormatSingleFile(t *testing.T) {
+	diffs := largeSchemaDiffs(50)
+	for _, noComments := range []bool{false, true} {
+		formatter := NewDumpFormatter("PostgreSQL 17.0", "public", noComments)
+
+		var streamed bytes.Buffer
+		if err := formatter.WriteSingleFile(&streamed, diffs); err != nil {
+			t.Fatalf("WriteSingleFile() error = %v", err)
+		}
+		if buffered := formatter.FormatSingleFile(diffs); streamed.String() != buffered {
+			t.Errorf("streamed dump (noComments=%v) differs from the buffered dump", noComments)
+		}
+	}
+}
+
+// failingWriter fails every write after the first limit bytes
+type failingWriter struct {
+	limit   int
+	written int
+}
+
+var errWriteFailed = errors.New("disk full")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.written+len(p) > w.limit {
+		return 0, errWriteFailed
+	}
+	w.written += len(p)
+	return len(p), nil
+}
+
+func TestWriteSingleFileReturnsWriteError(t *testing.T) {
+	formatter := NewDumpFormatter("PostgreSQL 17.0", "public", false)
+	if err := formatter.WriteSingleFile(&failingWriter{limit: 1000}, largeSchemaDiffs(10)); !errors.Is(err, errWriteFailed) {
+		t.Errorf("expected the write error, got %v", err)
+	}
+}
+
+// BenchmarkDumpOutput compares the memory allocated to format a large dump as a string with
+// streaming it to a writer; run with -benchmem
+func BenchmarkDumpOutput(b *testing.B) {
+	diffs := largeSchemaDiffs(5000)
+	formatter := NewDumpFormatter("PostgreSQL 17.0", "public", false)
+
+	b.Run("buffered", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := io.WriteString(io.Discard, formatter.FormatSingleFile(diffs)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := formatter.WriteSingleFile(io.Discard, diffs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
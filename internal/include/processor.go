@@ -13,6 +13,19 @@ import (
 type Processor struct {
 	baseDir string
 	visited map[string]bool
+	sources []source // file and line each line of the last processed output was read from
+}
+
+// source is the file and line number a line of processed SQL was read from
+type source struct {
+	file string
+	line int
+}
+
+// sourceLine is a line of processed SQL along with where it was read from
+type sourceLine struct {
+	text   string
+	source source
 }
 
 // NewProcessor creates a new include processor for the given base directory
@@ -27,6 +40,7 @@ func NewProcessor(baseDir string) *Processor {
 func (p *Processor) ProcessFile(filename string) (string, error) {
 	// Reset visited map for each top-level file processing
 	p.visited = make(map[string]bool)
+	p.sources = nil
 	
 	// Get absolute path to ensure consistent path handling
 	absPath, err := filepath.Abs(filename)
@@ -37,14 +51,39 @@ func (p *Processor) ProcessFile(filename string) (string, error) {
 	// Update base directory based on the input file's directory
 	p.baseDir = filepath.Dir(absPath)
 	
-	return p.processFileRecursive(absPath)
+	lines, err := p.processFileRecursive(absPath)
+	if err != nil {
+		return "", err
+	}
+
+	texts := make([]string, len(lines))
+	p.sources = make([]source, len(lines))
+	for i, line := range lines {
+		texts[i] = line.text
+		p.sources[i] = line.source
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// Location describes where a line of the last processed output was read from: "line N" for a line
+// of the processed file itself, and "line N of path" for a line of an included file, with the path
+// relative to the processed file's directory
+func (p *Processor) Location(line int) string {
+	if line < 1 || line > len(p.sources) {
+		return fmt.Sprintf("line %d", line)
+	}
+	src := p.sources[line-1]
+	if src.file == "" {
+		return fmt.Sprintf("line %d", src.line)
+	}
+	return fmt.Sprintf("line %d of %s", src.line, src.file)
 }
 
 // processFileRecursive recursively processes a file and its includes
-func (p *Processor) processFileRecursive(filename string) (string, error) {
+func (p *Processor) processFileRecursive(filename string) ([]sourceLine, error) {
 	// Check for circular dependencies
 	if p.visited[filename] {
-		return "", fmt.Errorf("circular dependency detected: %s", filename)
+		return nil, fmt.Errorf("circular dependency detected: %s", filename)
 	}
 	
 	// Mark file as visited
@@ -57,29 +96,38 @@ func (p *Processor) processFileRecursive(filename string) (string, error) {
 	// Read the file content
 	content, err := os.ReadFile(filename)
 	if err != nil {
-		return "", fmt.Errorf("failed to read file %s: %w", filename, err)
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
 	}
 	
+	// Lines of the top-level file are reported without a file name
+	relPath := ""
+	if len(p.visited) > 1 {
+		relPath, err = filepath.Rel(p.baseDir, filename)
+		if err != nil {
+			relPath = filename
+		}
+	}
+
 	// Process includes in the current file
 	currentDir := filepath.Dir(filename)
-	processedContent, err := p.processIncludes(string(content), currentDir)
+	processedLines, err := p.processIncludes(string(content), currentDir, relPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to process includes in %s: %w", filename, err)
+		return nil, fmt.Errorf("failed to process includes in %s: %w", filename, err)
 	}
 	
-	return processedContent, nil
+	return processedLines, nil
 }
 
-// processIncludes processes \i directives in the given content
-func (p *Processor) processIncludes(content string, currentDir string) (string, error) {
+// processIncludes processes \i directives in the given content of the file at relPath
+func (p *Processor) processIncludes(content string, currentDir string, relPath string) ([]sourceLine, error) {
 	// Regex to match \i directives
 	// Matches: \i filename or \i filename; (with optional semicolon)
 	includeRegex := regexp.MustCompile(`^\s*\\i\s+([^\s;]+)\s*;?\s*$`)
 	
 	lines := strings.Split(content, "\n")
-	var resultLines []string
+	var resultLines []sourceLine
 	
-	for _, line := range lines {
+	for i, line := range lines {
 		matches := includeRegex.FindStringSubmatch(line)
 		if matches != nil {
 			// Found an include directive
@@ -88,38 +136,36 @@ func (p *Processor) processIncludes(content string, currentDir string) (string,
 			// Resolve the include path
 			resolvedPath, isFolder, err := p.resolveIncludePath(includePath, currentDir)
 			if err != nil {
-				return "", fmt.Errorf("failed to resolve include path %s: %w", includePath, err)
+				return nil, fmt.Errorf("failed to resolve include path %s: %w", includePath, err)
 			}
 
-			var includedContent string
+			var includedLines []sourceLine
 			if isFolder {
 				// Process the folder recursively
-				includedContent, err = p.processFolderRecursive(resolvedPath)
+				includedLines, err = p.processFolderRecursive(resolvedPath)
 				if err != nil {
-					return "", fmt.Errorf("failed to process included folder %s: %w", resolvedPath, err)
+					return nil, fmt.Errorf("failed to process included folder %s: %w", resolvedPath, err)
 				}
 			} else {
 				// Process the included file recursively
-				includedContent, err = p.processFileRecursive(resolvedPath)
+				includedLines, err = p.processFileRecursive(resolvedPath)
 				if err != nil {
-					return "", fmt.Errorf("failed to process included file %s: %w", resolvedPath, err)
+					return nil, fmt.Errorf("failed to process included file %s: %w", resolvedPath, err)
+				}
+				// Remove the last empty line if the content ends with \n
+				if len(includedLines) > 0 && includedLines[len(includedLines)-1].text == "" {
+					includedLines = includedLines[:len(includedLines)-1]
 				}
 			}
 
-			// Split included content into lines and add them
-			includedLines := strings.Split(includedContent, "\n")
-			// Remove the last empty line if the content ends with \n
-			if len(includedLines) > 0 && includedLines[len(includedLines)-1] == "" {
-				includedLines = includedLines[:len(includedLines)-1]
-			}
 			resultLines = append(resultLines, includedLines...)
 		} else {
 			// Regular line, add as-is
-			resultLines = append(resultLines, line)
+			resultLines = append(resultLines, sourceLine{text: line, source: source{file: relPath, line: i + 1}})
 		}
 	}
 	
-	return strings.Join(resultLines, "\n"), nil
+	return resultLines, nil
 }
 
 // resolveIncludePath resolves an include path relative to the current directory
@@ -182,12 +228,13 @@ func (p *Processor) resolveIncludePath(includePath string, currentDir string) (s
 	return absPath, isFolder, nil
 }
 
-// processFolderRecursive processes all .sql files in a folder using DFS
-func (p *Processor) processFolderRecursive(folderPath string) (string, error) {
+// processFolderRecursive processes all .sql files in a folder using DFS. Each file's lines are
+// followed by the next file's, as if every file ended with a newline.
+func (p *Processor) processFolderRecursive(folderPath string) ([]sourceLine, error) {
 	// Read directory contents
 	entries, err := os.ReadDir(folderPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to read directory %s: %w", folderPath, err)
+		return nil, fmt.Errorf("failed to read directory %s: %w", folderPath, err)
 	}
 
 	// Sort entries alphabetically (natural filename order)
@@ -195,7 +242,7 @@ func (p *Processor) processFolderRecursive(folderPath string) (string, error) {
 		return entries[i].Name() < entries[j].Name()
 	})
 
-	var resultParts []string
+	var resultLines []sourceLine
 
 	// Process each entry in alphabetical order
 	for _, entry := range entries {
@@ -203,29 +250,25 @@ func (p *Processor) processFolderRecursive(folderPath string) (string, error) {
 
 		if entry.IsDir() {
 			// Recursively process subdirectory (DFS)
-			subFolderContent, err := p.processFolderRecursive(entryPath)
+			subFolderLines, err := p.processFolderRecursive(entryPath)
 			if err != nil {
-				return "", fmt.Errorf("failed to process subdirectory %s: %w", entryPath, err)
-			}
-			if subFolderContent != "" {
-				resultParts = append(resultParts, subFolderContent)
+				return nil, fmt.Errorf("failed to process subdirectory %s: %w", entryPath, err)
 			}
+			resultLines = append(resultLines, subFolderLines...)
 		} else if strings.HasSuffix(entry.Name(), ".sql") {
 			// Process .sql file
-			fileContent, err := p.processFileRecursive(entryPath)
+			fileLines, err := p.processFileRecursive(entryPath)
 			if err != nil {
-				return "", fmt.Errorf("failed to process file %s: %w", entryPath, err)
+				return nil, fmt.Errorf("failed to process file %s: %w", entryPath, err)
 			}
-			if fileContent != "" {
-				// Ensure the file content ends with a newline for proper concatenation
-				if !strings.HasSuffix(fileContent, "\n") {
-					fileContent += "\n"
-				}
-				resultParts = append(resultParts, fileContent)
+			// A trailing newline ends the last line rather than starting an empty one
+			if len(fileLines) > 0 && fileLines[len(fileLines)-1].text == "" {
+				fileLines = fileLines[:len(fileLines)-1]
 			}
+			resultLines = append(resultLines, fileLines...)
 		}
 		// Ignore non-.sql files
 	}
 
-	return strings.Join(resultParts, ""), nil
-}
\ No newline at end of file
+	return resultLines, nil
+}
@@ -667,3 +667,53 @@ func TestProcessFile_MatchesPreGeneratedSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestProcessFile_Location(t *testing.T) {
+	tempDir := t.TempDir()
+
+	mainFile := filepath.Join(tempDir, "main.sql")
+	mainContent := "-- Main file\n\\i types/\nCREATE TABLE users (id integer);\n"
+	if err := os.WriteFile(mainFile, []byte(mainContent), 0644); err != nil {
+		t.Fatalf("Failed to write main file: %v", err)
+	}
+
+	typesDir := filepath.Join(tempDir, "types")
+	if err := os.MkdirAll(typesDir, 0755); err != nil {
+		t.Fatalf("Failed to create types dir: %v", err)
+	}
+	files := map[string]string{
+		"a_mood.sql":   "CREATE TYPE mood AS ENUM ('ok');\n",
+		"b_status.sql": "-- Status\nCREATE TYPE status AS ENUM ('open');",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(typesDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	processor := NewProcessor(tempDir)
+	result, err := processor.ProcessFile(mainFile)
+	if err != nil {
+		t.Fatalf("ProcessFile failed: %v", err)
+	}
+
+	lines := strings.Split(result, "\n")
+	expected := []struct {
+		text     string
+		location string
+	}{
+		{"-- Main file", "line 1"},
+		{"CREATE TYPE mood AS ENUM ('ok');", "line 1 of " + filepath.Join("types", "a_mood.sql")},
+		{"-- Status", "line 1 of " + filepath.Join("types", "b_status.sql")},
+		{"CREATE TYPE status AS ENUM ('open');", "line 2 of " + filepath.Join("types", "b_status.sql")},
+		{"CREATE TABLE users (id integer);", "line 3"},
+	}
+	for i, want := range expected {
+		if lines[i] != want.text {
+			t.Errorf("line %d: expected %q, got %q", i+1, want.text, lines[i])
+		}
+		if got := processor.Location(i + 1); got != want.location {
+			t.Errorf("line %d: expected location %q, got %q", i+1, want.location, got)
+		}
+	}
+}
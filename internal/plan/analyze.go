@@ -0,0 +1,53 @@
+package plan
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgschema/ir"
+)
+
+// analyzeStepTypes are the step types whose changes affect a table's planner statistics
+var analyzeStepTypes = map[string]bool{
+	"table":            true,
+	"table.column":     true,
+	"table.index":      true,
+	"table.constraint": true,
+}
+
+// AnalyzeStatements returns an ANALYZE statement for each table touched by the plan, sorted by table.
+// Tables dropped by the plan are skipped. The statements are meant to run after the migration,
+// outside of any DDL transaction.
+func (p *Plan) AnalyzeStatements() []string {
+	touched := make(map[string]bool)
+	dropped := make(map[string]bool)
+
+	for _, group := range p.Groups {
+		for _, step := range group.Steps {
+			if step.Directive != nil || !analyzeStepTypes[step.Type] || step.Path == "" {
+				continue
+			}
+			tablePath := stepTablePath(step.Path)
+			if step.Type == "table" && step.Operation == "drop" {
+				dropped[tablePath] = true
+				continue
+			}
+			touched[tablePath] = true
+		}
+	}
+
+	var tables []string
+	for tablePath := range touched {
+		if !dropped[tablePath] {
+			tables = append(tables, tablePath)
+		}
+	}
+	sort.Strings(tables)
+
+	statements := make([]string, 0, len(tables))
+	for _, tablePath := range tables {
+		schema, table, _ := strings.Cut(tablePath, ".")
+		statements = append(statements, "ANALYZE "+ir.QuoteIdentifier(schema)+"."+ir.QuoteIdentifier(table)+";")
+	}
+	return statements
+}
@@ -0,0 +1,53 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pgplex/pgschema/internal/diff"
+)
+
+func TestAnalyzeStatements(t *testing.T) {
+	p := NewPlan([]diff.Diff{
+		{
+			Type:       diff.DiffTypeTableIndex,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.users.idx_users_email",
+			Statements: []diff.SQLStatement{{SQL: "CREATE INDEX IF NOT EXISTS idx_users_email ON users (email);"}},
+		},
+		{
+			Type:       diff.DiffTypeTableColumn,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.Orders.note",
+			Statements: []diff.SQLStatement{{SQL: `ALTER TABLE "Orders" ADD COLUMN note text;`}},
+		},
+		{
+			Type:       diff.DiffTypeTableComment,
+			Operation:  diff.DiffOperationAlter,
+			Path:       "public.customers",
+			Statements: []diff.SQLStatement{{SQL: "COMMENT ON TABLE customers IS 'Customers';"}},
+		},
+		{
+			Type:       diff.DiffTypeTable,
+			Operation:  diff.DiffOperationDrop,
+			Path:       "public.legacy",
+			Statements: []diff.SQLStatement{{SQL: "DROP TABLE IF EXISTS legacy CASCADE;"}},
+		},
+		{
+			Type:       diff.DiffTypeFunction,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.touch",
+			Statements: []diff.SQLStatement{{SQL: "CREATE OR REPLACE FUNCTION touch() RETURNS void LANGUAGE sql AS $$ SELECT 1 $$;"}},
+		},
+	})
+
+	// The new index on users (run CONCURRENTLY in its own group) and the new column on "Orders" are
+	// analyzed; comment-only changes, dropped tables, and non-table objects are not
+	expected := []string{
+		`ANALYZE public."Orders";`,
+		"ANALYZE public.users;",
+	}
+	if got := p.AnalyzeStatements(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
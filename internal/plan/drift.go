@@ -0,0 +1,62 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DriftReport is a compact, machine-readable summary of a plan between a database and its committed
+// schema file, for drift monitors that only need to know which objects differ
+type DriftReport struct {
+	CheckedAt time.Time     `json:"checked_at"`
+	Schema    string        `json:"schema"`
+	Drift     bool          `json:"drift"`
+	Changes   []DriftChange `json:"changes"`
+}
+
+// DriftChange is an object that differs from the schema file, with the operation that would bring it
+// back in line (e.g. "create" for an object missing from the database)
+type DriftChange struct {
+	Object    string `json:"object"`    // e.g., "public.users.email"
+	Type      string `json:"type"`      // e.g., "table.column"
+	Operation string `json:"operation"` // e.g., "create", "alter", "drop"
+}
+
+// DriftReport summarizes the plan as a drift report for the given schema. Each changed object is listed
+// once, in plan order, even when it takes several steps.
+func (p *Plan) DriftReport(schema string) *DriftReport {
+	report := &DriftReport{
+		CheckedAt: p.CreatedAt,
+		Schema:    schema,
+		Changes:   []DriftChange{},
+	}
+
+	seen := make(map[string]bool)
+	for _, g := range p.Groups {
+		for _, step := range g.Steps {
+			key := stepKey(step.Type, step.Operation, step.Path)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			report.Changes = append(report.Changes, DriftChange{
+				Object:    step.Path,
+				Type:      step.Type,
+				Operation: step.Operation,
+			})
+		}
+	}
+	report.Drift = len(report.Changes) > 0
+
+	return report
+}
+
+// ToJSON returns the report as a single line of JSON, suitable for log-based alerting
+func (r *DriftReport) ToJSON() (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal drift report: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,83 @@
+package plan
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/internal/diff"
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestDriftReport(t *testing.T) {
+	index := &ir.Index{
+		Schema:  "public",
+		Table:   "users",
+		Name:    "idx_users_email",
+		Type:    ir.IndexTypeRegular,
+		Method:  "btree",
+		Columns: []*ir.IndexColumn{{Name: "email", Position: 1}},
+	}
+	p := NewPlan([]diff.Diff{
+		{
+			Type:       diff.DiffTypeTableColumn,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.users.email",
+			Statements: []diff.SQLStatement{{SQL: "ALTER TABLE users ADD COLUMN email text;"}},
+		},
+		{
+			Type:       diff.DiffTypeTableIndex,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.users.idx_users_email",
+			Source:     index,
+			Statements: []diff.SQLStatement{{SQL: "CREATE INDEX IF NOT EXISTS idx_users_email ON users (email);"}},
+		},
+		{
+			Type:       diff.DiffTypeTable,
+			Operation:  diff.DiffOperationDrop,
+			Path:       "public.legacy",
+			Statements: []diff.SQLStatement{{SQL: "DROP TABLE IF EXISTS legacy;"}},
+		},
+	})
+
+	report := p.DriftReport("public")
+	if !report.Drift || report.Schema != "public" || !report.CheckedAt.Equal(p.CreatedAt) {
+		t.Errorf("unexpected report header: %+v", report)
+	}
+	// The index is built concurrently and then waited on, but is reported once
+	expected := []DriftChange{
+		{Object: "public.users.email", Type: "table.column", Operation: "create"},
+		{Object: "public.users.idx_users_email", Type: "table.index", Operation: "create"},
+		{Object: "public.legacy", Type: "table", Operation: "drop"},
+	}
+	if !reflect.DeepEqual(report.Changes, expected) {
+		t.Errorf("expected changes %+v, got %+v", expected, report.Changes)
+	}
+
+	output, err := report.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	if strings.Contains(output, "\n") {
+		t.Errorf("expected a single line of JSON, got %q", output)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("failed to decode %q: %v", output, err)
+	}
+	for _, field := range []string{"checked_at", "schema", "drift", "changes"} {
+		if _, ok := decoded[field]; !ok {
+			t.Errorf("expected field %q in %s", field, output)
+		}
+	}
+
+	// Without drift, changes is an empty list rather than null
+	output, err = NewPlan(nil).DriftReport("public").ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	if !strings.Contains(output, `"drift":false`) || !strings.Contains(output, `"changes":[]`) {
+		t.Errorf("expected an empty report without drift, got %s", output)
+	}
+}
@@ -0,0 +1,73 @@
+package plan
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultEstimateRowsPerSecond is the default processing rate used to estimate statement durations
+const DefaultEstimateRowsPerSecond = 100000
+
+var (
+	// alterColumnTypePattern matches column type changes, which rewrite the whole table
+	alterColumnTypePattern = regexp.MustCompile(`(?is)\bALTER\s+COLUMN\s+.+\s+TYPE\s`)
+	// createIndexPattern matches index builds, which scan the whole table
+	createIndexPattern = regexp.MustCompile(`(?is)^CREATE\s+(UNIQUE\s+)?INDEX\b`)
+	// addConstraintPattern matches constraints that are validated against existing rows when added
+	addConstraintPattern = regexp.MustCompile(`(?is)\bADD\s+CONSTRAINT\s+.+\b(CHECK|FOREIGN\s+KEY|PRIMARY\s+KEY|UNIQUE)\b`)
+)
+
+// EstimateDurations annotates steps that scan or rewrite an existing table with the table's estimated
+// row count and a rough duration at rowsPerSecond. rowCounts is keyed by "schema.table"; tables that are
+// missing (e.g. created by this plan) or empty are left unannotated.
+func (p *Plan) EstimateDurations(rowCounts map[string]int64, rowsPerSecond int64) {
+	if rowsPerSecond <= 0 {
+		rowsPerSecond = DefaultEstimateRowsPerSecond
+	}
+
+	for gi := range p.Groups {
+		for si := range p.Groups[gi].Steps {
+			step := &p.Groups[gi].Steps[si]
+			if !isFullTableStep(step) {
+				continue
+			}
+			rows := rowCounts[stepTablePath(step.Path)]
+			if rows <= 0 {
+				continue
+			}
+			step.EstimatedRows = rows
+			// Round up so that any non-empty table reports at least 1ms
+			step.EstimatedDurationMs = (rows*1000 + rowsPerSecond - 1) / rowsPerSecond
+		}
+	}
+}
+
+// isFullTableStep reports whether a step reads or rewrites every row of its table
+func isFullTableStep(step *Step) bool {
+	if !strings.HasPrefix(step.Type, "table.") {
+		return false
+	}
+	sql := strings.TrimSpace(step.SQL)
+	upper := strings.ToUpper(sql)
+	switch {
+	case createIndexPattern.MatchString(sql):
+		return true
+	case strings.Contains(upper, "VALIDATE CONSTRAINT"):
+		return true
+	case alterColumnTypePattern.MatchString(sql):
+		return true
+	case addConstraintPattern.MatchString(sql):
+		// NOT VALID skips the check of existing rows
+		return !strings.HasSuffix(strings.TrimSuffix(upper, ";"), "NOT VALID")
+	}
+	return false
+}
+
+// stepTablePath returns the "schema.table" prefix of a table sub-resource path
+func stepTablePath(path string) string {
+	parts := strings.SplitN(path, ".", 3)
+	if len(parts) < 2 {
+		return path
+	}
+	return parts[0] + "." + parts[1]
+}
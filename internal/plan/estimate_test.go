@@ -0,0 +1,113 @@
+package plan
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/internal/diff"
+)
+
+func estimateTestPlan() *Plan {
+	return NewPlan([]diff.Diff{
+		{
+			Type:       diff.DiffTypeTableColumn,
+			Operation:  diff.DiffOperationAlter,
+			Path:       "public.orders.amount",
+			Statements: []diff.SQLStatement{{SQL: "ALTER TABLE orders ALTER COLUMN amount TYPE bigint;"}},
+		},
+		{
+			Type:       diff.DiffTypeTableConstraint,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.orders.orders_amount_check",
+			Statements: []diff.SQLStatement{{SQL: "ALTER TABLE orders\nADD CONSTRAINT orders_amount_check CHECK (amount > 0) NOT VALID;"}},
+		},
+		{
+			Type:       diff.DiffTypeTableColumn,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.orders.note",
+			Statements: []diff.SQLStatement{{SQL: "ALTER TABLE orders ADD COLUMN note text;"}},
+		},
+		{
+			Type:       diff.DiffTypeTableColumn,
+			Operation:  diff.DiffOperationAlter,
+			Path:       "public.customers.name",
+			Statements: []diff.SQLStatement{{SQL: "ALTER TABLE customers ALTER COLUMN name TYPE varchar(200);"}},
+		},
+	})
+}
+
+func TestEstimateDurations(t *testing.T) {
+	p := estimateTestPlan()
+	p.EstimateDurations(map[string]int64{"public.orders": 1000000, "public.customers": 2000000}, 100000)
+
+	steps := p.Groups[0].Steps
+	if len(steps) != 4 {
+		t.Fatalf("expected 4 steps, got %d", len(steps))
+	}
+
+	// Column type change on orders rewrites the table: 1M rows at 100k rows/s
+	if steps[0].EstimatedRows != 1000000 || steps[0].EstimatedDurationMs != 10000 {
+		t.Errorf("expected type change on orders to estimate 1000000 rows / 10000ms, got %d rows / %dms", steps[0].EstimatedRows, steps[0].EstimatedDurationMs)
+	}
+
+	// NOT VALID constraints and plain ADD COLUMN don't touch existing rows
+	for _, step := range steps[1:3] {
+		if step.EstimatedRows != 0 || step.EstimatedDurationMs != 0 {
+			t.Errorf("expected no estimate for %q, got %d rows / %dms", step.SQL, step.EstimatedRows, step.EstimatedDurationMs)
+		}
+	}
+
+	// Estimates scale with the row count
+	if steps[3].EstimatedDurationMs != 2*steps[0].EstimatedDurationMs {
+		t.Errorf("expected customers estimate (%dms) to be twice the orders estimate (%dms)", steps[3].EstimatedDurationMs, steps[0].EstimatedDurationMs)
+	}
+
+	jsonOutput, err := p.ToJSON()
+	if err != nil {
+		t.Fatalf("failed to serialize plan: %v", err)
+	}
+	if !strings.Contains(jsonOutput, `"estimated_duration_ms": 10000`) || !strings.Contains(jsonOutput, `"estimated_rows": 1000000`) {
+		t.Errorf("expected estimate fields in JSON plan, got:\n%s", jsonOutput)
+	}
+}
+
+func TestEstimateDurationsOmittedByDefault(t *testing.T) {
+	p := estimateTestPlan()
+
+	// Tables without row estimates (e.g. created by the plan) are never annotated
+	p.EstimateDurations(map[string]int64{}, 100000)
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("failed to serialize plan: %v", err)
+	}
+	if strings.Contains(string(data), "estimated_") {
+		t.Errorf("expected no estimate fields in JSON plan, got:\n%s", data)
+	}
+}
+
+func TestIsFullTableStep(t *testing.T) {
+	tests := []struct {
+		name string
+		step Step
+		want bool
+	}{
+		{"create index", Step{Type: "table.index", SQL: "CREATE INDEX IF NOT EXISTS idx_orders_amount ON orders (amount);"}, true},
+		{"create unique index concurrently", Step{Type: "table.index", SQL: "CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS orders_key ON orders (id);"}, true},
+		{"validate constraint", Step{Type: "table.constraint", SQL: "ALTER TABLE orders VALIDATE CONSTRAINT orders_customer_fkey;"}, true},
+		{"add foreign key", Step{Type: "table.constraint", SQL: "ALTER TABLE orders\nADD CONSTRAINT orders_customer_fkey FOREIGN KEY (customer_id) REFERENCES customers (id);"}, true},
+		{"add foreign key not valid", Step{Type: "table.constraint", SQL: "ALTER TABLE orders\nADD CONSTRAINT orders_customer_fkey FOREIGN KEY (customer_id) REFERENCES customers (id) NOT VALID;"}, false},
+		{"drop index", Step{Type: "table.index", SQL: "DROP INDEX IF EXISTS idx_orders_amount;"}, false},
+		{"column comment", Step{Type: "table.column.comment", SQL: "COMMENT ON COLUMN orders.amount IS 'type changed';"}, false},
+		{"view", Step{Type: "view", SQL: "CREATE OR REPLACE VIEW v AS SELECT 1;"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFullTableStep(&tt.step); got != tt.want {
+				t.Errorf("isFullTableStep(%q) = %v, want %v", tt.step.SQL, got, tt.want)
+			}
+		})
+	}
+}
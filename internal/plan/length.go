@@ -0,0 +1,42 @@
+package plan
+
+// LongStatements returns the steps whose SQL is longer than maxLength bytes, in plan order.
+// Directive steps are skipped, and a non-positive maxLength disables the check. Generated
+// statements are never split: a CHECK constraint, view, or function body has to be created by
+// a single statement, so the remedy is to shorten the desired state (e.g., by moving a long
+// IN list into a lookup table).
+func (p *Plan) LongStatements(maxLength int) []Step {
+	if maxLength <= 0 {
+		return nil
+	}
+
+	var long []Step
+	for _, group := range p.Groups {
+		for _, step := range group.Steps {
+			if step.Directive != nil || len(step.SQL) <= maxLength {
+				continue
+			}
+			long = append(long, step)
+		}
+	}
+	return long
+}
+
+// LongGroups returns the 1-based numbers of the groups whose statements are sent to the database
+// as a single call (see ExecutionGroup.ConcatenatedSQL) longer than maxLength bytes, even if
+// each statement alone is within the limit. Groups of a single statement are left to
+// LongStatements, and a non-positive maxLength disables the check.
+func (p *Plan) LongGroups(maxLength int) []int {
+	if maxLength <= 0 {
+		return nil
+	}
+
+	var long []int
+	for i, group := range p.Groups {
+		if len(group.Steps) < 2 || group.HasDirectives() || len(group.ConcatenatedSQL()) <= maxLength {
+			continue
+		}
+		long = append(long, i+1)
+	}
+	return long
+}
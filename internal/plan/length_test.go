@@ -0,0 +1,82 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/internal/diff"
+)
+
+func TestLongStatements(t *testing.T) {
+	values := make([]string, 2000)
+	for i := range values {
+		values[i] = "'code_" + strings.Repeat("x", 10) + "'"
+	}
+	longCheck := "ALTER TABLE orders ADD CONSTRAINT orders_code_check CHECK (code IN (" + strings.Join(values, ", ") + "));"
+
+	p := NewPlan([]diff.Diff{
+		{
+			Type:       diff.DiffTypeTableColumn,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.orders.code",
+			Statements: []diff.SQLStatement{{SQL: "ALTER TABLE orders ADD COLUMN code text;"}},
+		},
+		{
+			Type:       diff.DiffTypeTableConstraint,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.orders.orders_code_check",
+			Statements: []diff.SQLStatement{{SQL: longCheck}},
+		},
+	})
+
+	long := p.LongStatements(1000)
+	if len(long) != 1 {
+		t.Fatalf("expected 1 long statement, got %d", len(long))
+	}
+	if long[0].Path != "public.orders.orders_code_check" || long[0].SQL != longCheck {
+		t.Errorf("unexpected long statement: %s (%d bytes)", long[0].Path, len(long[0].SQL))
+	}
+
+	if long := p.LongStatements(len(longCheck)); len(long) != 0 {
+		t.Errorf("expected a statement at the limit to pass, got %d long statements", len(long))
+	}
+	if long := p.LongStatements(0); len(long) != 0 {
+		t.Errorf("expected a limit of 0 to disable the check, got %d long statements", len(long))
+	}
+}
+
+func TestLongGroups(t *testing.T) {
+	comment := func(table string) string {
+		return "COMMENT ON TABLE " + table + " IS '" + strings.Repeat("x", 600) + "';"
+	}
+	p := NewPlan([]diff.Diff{
+		{
+			Type:       diff.DiffTypeTableComment,
+			Operation:  diff.DiffOperationAlter,
+			Path:       "public.orders",
+			Statements: []diff.SQLStatement{{SQL: comment("orders")}},
+		},
+		{
+			Type:       diff.DiffTypeTableComment,
+			Operation:  diff.DiffOperationAlter,
+			Path:       "public.customers",
+			Statements: []diff.SQLStatement{{SQL: comment("customers")}},
+		},
+	})
+
+	if len(p.Groups) != 1 || len(p.Groups[0].Steps) != 2 {
+		t.Fatalf("expected both statements in one group, got %d groups", len(p.Groups))
+	}
+	if long := p.LongStatements(1000); len(long) != 0 {
+		t.Errorf("expected no single statement over the limit, got %d", len(long))
+	}
+	if long := p.LongGroups(1000); len(long) != 1 || long[0] != 1 {
+		t.Errorf("expected group 1 to be over the limit when concatenated, got %v", long)
+	}
+	if long := p.LongGroups(len(p.Groups[0].ConcatenatedSQL())); len(long) != 0 {
+		t.Errorf("expected a group at the limit to pass, got %v", long)
+	}
+	if long := p.LongGroups(0); len(long) != 0 {
+		t.Errorf("expected a limit of 0 to disable the check, got %v", long)
+	}
+}
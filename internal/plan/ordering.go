@@ -0,0 +1,40 @@
+package plan
+
+import "github.com/pgplex/pgschema/internal/diff"
+
+// ExplainOrdering annotates steps that the dependency sort placed after an object created earlier in the
+// plan with the statement that creates it and the reason, e.g.
+// "after CREATE TABLE customers because foreign key orders_customer_id_fkey references it".
+// Only the first step of each diff is annotated.
+func (p *Plan) ExplainOrdering() {
+	reasons := diff.ExplainOrdering(p.SourceDiffs)
+
+	// Steps keep the type, operation, and path of the diff they were generated from
+	pending := make(map[string]string)
+	for i, d := range p.SourceDiffs {
+		reason, ok := reasons[i]
+		if !ok {
+			continue
+		}
+		key := stepKey(d.Type.String(), d.Operation.String(), d.Path)
+		if _, exists := pending[key]; !exists {
+			pending[key] = reason
+		}
+	}
+
+	for gi := range p.Groups {
+		for si := range p.Groups[gi].Steps {
+			step := &p.Groups[gi].Steps[si]
+			key := stepKey(step.Type, step.Operation, step.Path)
+			if reason, ok := pending[key]; ok {
+				step.OrderReason = reason
+				delete(pending, key)
+			}
+		}
+	}
+}
+
+// stepKey identifies the diff a step was generated from
+func stepKey(objType, operation, path string) string {
+	return objType + "|" + operation + "|" + path
+}
@@ -0,0 +1,94 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgschema/internal/diff"
+	"github.com/pgplex/pgschema/ir"
+)
+
+func TestExplainOrdering(t *testing.T) {
+	newIR := ir.NewIR()
+	dbSchema := newIR.GetOrCreateSchema("public")
+	dbSchema.Tables["customers"] = &ir.Table{
+		Schema:      "public",
+		Name:        "customers",
+		Type:        ir.TableTypeBase,
+		Columns:     []*ir.Column{{Name: "id", DataType: "integer", Position: 1}},
+		Constraints: map[string]*ir.Constraint{},
+	}
+	dbSchema.Tables["orders"] = &ir.Table{
+		Schema: "public",
+		Name:   "orders",
+		Type:   ir.TableTypeBase,
+		Columns: []*ir.Column{
+			{Name: "id", DataType: "integer", Position: 1},
+			{Name: "customer_id", DataType: "integer", Position: 2},
+		},
+		Constraints: map[string]*ir.Constraint{
+			"orders_customer_id_fkey": {
+				Schema:            "public",
+				Table:             "orders",
+				Name:              "orders_customer_id_fkey",
+				Type:              ir.ConstraintTypeForeignKey,
+				Columns:           []*ir.ConstraintColumn{{Name: "customer_id", Position: 1}},
+				ReferencedSchema:  "public",
+				ReferencedTable:   "customers",
+				ReferencedColumns: []*ir.ConstraintColumn{{Name: "id", Position: 1}},
+			},
+		},
+	}
+	dbSchema.Views["order_ids"] = &ir.View{Schema: "public", Name: "order_ids", Definition: " SELECT id FROM orders;"}
+	dbSchema.Views["all_order_ids"] = &ir.View{Schema: "public", Name: "all_order_ids", Definition: " SELECT id FROM order_ids;", Materialized: true}
+	dbSchema.Functions["add_tax(numeric)"] = &ir.Function{
+		Schema:     "public",
+		Name:       "add_tax",
+		Definition: "SELECT $1 * 1.1",
+		ReturnType: "numeric",
+		Language:   "sql",
+		Parameters: []*ir.Parameter{{Name: "amount", DataType: "numeric", Mode: "IN", Position: 1}},
+	}
+	dbSchema.Functions["price(numeric)"] = &ir.Function{
+		Schema:     "public",
+		Name:       "price",
+		Definition: "SELECT add_tax($1)",
+		ReturnType: "numeric",
+		Language:   "sql",
+		Parameters: []*ir.Parameter{{Name: "amount", DataType: "numeric", Mode: "IN", Position: 1}},
+	}
+
+	p := NewPlan(diff.GenerateMigration(ir.NewIR(), newIR, "public"))
+	p.ExplainOrdering()
+
+	reasons := make(map[string]string)
+	for _, group := range p.Groups {
+		for _, step := range group.Steps {
+			if step.OrderReason != "" {
+				reasons[step.Path] = step.OrderReason
+			}
+		}
+	}
+
+	want := map[string]string{
+		"public.orders":        "after CREATE TABLE customers because foreign key orders_customer_id_fkey references it",
+		"public.all_order_ids": "after CREATE VIEW order_ids because materialized view all_order_ids selects from it",
+		"public.price":         "after CREATE FUNCTION add_tax because function price calls it",
+	}
+	for path, reason := range want {
+		if reasons[path] != reason {
+			t.Errorf("annotation of %s = %q, want %q", path, reasons[path], reason)
+		}
+	}
+	if len(reasons) != len(want) {
+		t.Errorf("expected only the sorted objects to be annotated, got %v", reasons)
+	}
+
+	// The annotation is shown above the statement in the human output only
+	if human := p.ToSQL(SQLFormatHuman); !strings.Contains(human, "-- after CREATE TABLE customers because foreign key orders_customer_id_fkey references it\nCREATE TABLE") {
+		t.Errorf("expected the annotation in the human output, got:\n%s", human)
+	}
+	if raw := p.ToSQL(SQLFormatRaw); strings.Contains(raw, "-- after") {
+		t.Errorf("expected no annotations in the raw output, got:\n%s", raw)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/pgplex/pgschema/internal/diff"
 	"github.com/pgplex/pgschema/internal/fingerprint"
 	"github.com/pgplex/pgschema/internal/version"
+	"github.com/pgplex/pgschema/ir"
 )
 
 // DirectiveType represents the different types of directives
@@ -40,6 +41,11 @@ type Step struct {
 	Type      string `json:"type,omitempty"`      // e.g., "table", "index"
 	Operation string `json:"operation,omitempty"` // e.g., "create", "alter", "drop"
 	Path      string `json:"path,omitempty"`      // e.g., "public.users"
+	// Duration estimate for steps that scan or rewrite an existing table (only with --estimate-duration)
+	EstimatedRows       int64 `json:"estimated_rows,omitempty"`
+	EstimatedDurationMs int64 `json:"estimated_duration_ms,omitempty"`
+	// Why the step comes after an object created earlier in the plan (only with --explain-ordering)
+	OrderReason string `json:"order_reason,omitempty"`
 }
 
 // ExecutionGroup represents a group of steps that should be executed together
@@ -47,6 +53,27 @@ type ExecutionGroup struct {
 	Steps []Step `json:"steps"`
 }
 
+// HasDirectives reports whether the group contains a directive step, in which case its steps
+// are executed one at a time instead of as a single call
+func (g ExecutionGroup) HasDirectives() bool {
+	for _, step := range g.Steps {
+		if step.Directive != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ConcatenatedSQL returns the SQL of a group without directives as it is sent to the database:
+// all statements joined into a single call, which runs them in an implicit transaction
+func (g ExecutionGroup) ConcatenatedSQL() string {
+	sqlStatements := make([]string, 0, len(g.Steps))
+	for _, step := range g.Steps {
+		sqlStatements = append(sqlStatements, step.SQL)
+	}
+	return strings.Join(sqlStatements, ";\n") + ";"
+}
+
 // Plan represents the migration plan between two DDL states
 type Plan struct {
 	// Version information
@@ -62,11 +89,25 @@ type Plan struct {
 	// Groups is the ordered list of execution groups
 	Groups []ExecutionGroup `json:"groups"`
 
+	// Warnings reported while generating the plan, such as changes that drop data
+	Warnings []string `json:"warnings,omitempty"`
+
+	// SearchPath the plan was generated with; references to its schemas are left unqualified, so
+	// the plan has to run with the same search_path
+	SearchPath string `json:"search_path,omitempty"`
+
 	// SourceDiffs stores original diff information for summary calculation
 	// This field is only serialized in debug mode
 	SourceDiffs []diff.Diff `json:"source_diffs,omitempty"`
 }
 
+// Options controls how diffs are arranged into execution steps and groups
+type Options struct {
+	// SafeForeignKeys batches foreign keys added to existing tables: every ADD CONSTRAINT ... NOT VALID is
+	// committed first, and each VALIDATE CONSTRAINT then runs in its own transaction at the end of the plan
+	SafeForeignKeys bool
+}
+
 // PlanSummary provides counts of changes by type
 type PlanSummary struct {
 	Total   int                    `json:"total"`
@@ -116,6 +157,23 @@ const (
 	SQLFormatHuman SQLFormat = "human"
 )
 
+// StatementSeparator controls how statements are separated in the SQL output
+type StatementSeparator string
+
+const (
+	// SeparatorBlankLine ends each statement with a semicolon and separates statements with a blank line
+	SeparatorBlankLine StatementSeparator = "blank-line"
+	// SeparatorSemicolon ends each statement with a semicolon, without blank lines between statements
+	SeparatorSemicolon StatementSeparator = "semicolon"
+	// SeparatorGexec wraps each statement in a dollar-quoted string selected for psql's \gexec
+	SeparatorGexec StatementSeparator = "gexec"
+)
+
+// StatementSeparators returns the supported statement separators
+func StatementSeparators() []string {
+	return []string{string(SeparatorBlankLine), string(SeparatorSemicolon), string(SeparatorGexec)}
+}
+
 // getObjectOrder returns the dependency order for database objects
 func getObjectOrder() []Type {
 	return []Type{
@@ -142,7 +200,7 @@ func getObjectOrder() []Type {
 // ========== PUBLIC METHODS ==========
 
 // groupDiffs groups diffs into execution groups with configurable online operations
-func groupDiffs(diffs []diff.Diff) []ExecutionGroup {
+func groupDiffs(diffs []diff.Diff, opts Options) []ExecutionGroup {
 	if len(diffs) == 0 {
 		return nil
 	}
@@ -156,6 +214,9 @@ func groupDiffs(diffs []diff.Diff) []ExecutionGroup {
 	newlyCreatedTables := make(map[string]bool)
 	newlyCreatedMaterializedViews := make(map[string]bool)
 
+	// Foreign key validations deferred to the end of the plan (SafeForeignKeys only)
+	var deferredValidations []Step
+
 	// Convert diffs to steps
 	for _, d := range diffs {
 		// Track creates as we encounter them (before processing dependent operations)
@@ -179,6 +240,11 @@ func groupDiffs(diffs []diff.Diff) []ExecutionGroup {
 					Directive: rewriteStep.Directive,
 				}
 
+				if opts.SafeForeignKeys && isForeignKeyValidation(d, rewriteStep) {
+					deferredValidations = append(deferredValidations, step)
+					continue
+				}
+
 				// Check if this step needs isolation (has directive or cannot run in transaction)
 				needsIsolation := step.Directive != nil || !rewriteStep.CanRunInTransaction
 
@@ -216,11 +282,34 @@ func groupDiffs(diffs []diff.Diff) []ExecutionGroup {
 		groups = append(groups, ExecutionGroup{Steps: transactionalSteps})
 	}
 
+	// Validate each deferred foreign key in its own transaction, so only one table is scanned at a time
+	// and the NOT VALID constraints are already committed
+	for _, step := range deferredValidations {
+		groups = append(groups, ExecutionGroup{Steps: []Step{step}})
+	}
+
 	return groups
 }
 
+// isForeignKeyValidation reports whether a rewrite step is the VALIDATE CONSTRAINT half of a foreign key add
+func isForeignKeyValidation(d diff.Diff, step RewriteStep) bool {
+	if d.Type != diff.DiffTypeTableConstraint || d.Operation != diff.DiffOperationCreate {
+		return false
+	}
+	constraint, ok := d.Source.(*ir.Constraint)
+	if !ok || constraint.Type != ir.ConstraintTypeForeignKey {
+		return false
+	}
+	return strings.Contains(step.SQL, "VALIDATE CONSTRAINT")
+}
+
 // NewPlan creates a new plan from a list of diffs with online operations enabled
 func NewPlan(diffs []diff.Diff) *Plan {
+	return NewPlanWithOptions(diffs, Options{})
+}
+
+// NewPlanWithOptions creates a new plan from a list of diffs using the given grouping options
+func NewPlanWithOptions(diffs []diff.Diff, opts Options) *Plan {
 	// Use environment variable for timestamp if provided, otherwise use current time
 	createdAt := time.Now().Truncate(time.Second)
 	if testTime := os.Getenv("PGSCHEMA_TEST_TIME"); testTime != "" {
@@ -233,7 +322,7 @@ func NewPlan(diffs []diff.Diff) *Plan {
 		Version:         version.PlanFormat(),
 		PgschemaVersion: version.App(),
 		CreatedAt:       createdAt,
-		Groups:          groupDiffs(diffs),
+		Groups:          groupDiffs(diffs, opts),
 		SourceDiffs:     diffs,
 	}
 
@@ -259,6 +348,16 @@ func (p *Plan) HasAnyChanges() bool {
 
 // HumanColored returns a human-readable summary of the plan with color support
 func (p *Plan) HumanColored(enableColor bool) string {
+	return p.human(enableColor, true)
+}
+
+// SummaryColored returns the change counts and the list of changed objects, without the DDL
+func (p *Plan) SummaryColored(enableColor bool) string {
+	return p.human(enableColor, false)
+}
+
+// human renders the human-readable plan, optionally followed by the DDL to be executed
+func (p *Plan) human(enableColor bool, includeDDL bool) string {
 	c := color.New(enableColor)
 	var summary strings.Builder
 
@@ -295,7 +394,7 @@ func (p *Plan) HumanColored(enableColor bool) string {
 	}
 
 	// Add DDL section if there are changes
-	if summaryData.Total > 0 {
+	if includeDDL && summaryData.Total > 0 {
 		summary.WriteString(c.Bold("DDL to be executed:") + "\n")
 		summary.WriteString(strings.Repeat("-", 50) + "\n\n")
 		migrationSQL := p.ToSQL(SQLFormatHuman)
@@ -314,6 +413,12 @@ func (p *Plan) HumanColored(enableColor bool) string {
 
 // ToSQL returns the SQL statements with formatting based on the specified format
 func (p *Plan) ToSQL(format SQLFormat) string {
+	return p.ToSQLWithSeparator(format, SeparatorBlankLine)
+}
+
+// ToSQLWithSeparator returns the SQL statements with the given statement separator.
+// Each step is written whole, so semicolons inside dollar-quoted function bodies are never split on.
+func (p *Plan) ToSQLWithSeparator(format SQLFormat, separator StatementSeparator) string {
 	// Build SQL output from groups
 	var sqlOutput strings.Builder
 
@@ -324,19 +429,26 @@ func (p *Plan) ToSQL(format SQLFormat) string {
 		}
 
 		for stepIdx, step := range group.Steps {
+			if format == SQLFormatHuman && step.OrderReason != "" {
+				sqlOutput.WriteString(fmt.Sprintf("-- %s\n", step.OrderReason))
+			}
+			stepSQL := step.SQL
+			if separator == SeparatorGexec {
+				stepSQL = gexecStatement(stepSQL)
+			}
 			if step.Directive != nil {
 				// Handle directive statements
 				sqlOutput.WriteString(fmt.Sprintf("-- pgschema:%s\n", step.Directive.Type.String()))
-				sqlOutput.WriteString(step.SQL)
+				sqlOutput.WriteString(stepSQL)
 				sqlOutput.WriteString("\n")
 			} else {
 				// Handle regular SQL statements
-				sqlOutput.WriteString(step.SQL)
+				sqlOutput.WriteString(stepSQL)
 				sqlOutput.WriteString("\n")
 			}
 
 			// Add blank line between steps except for the last one in the last group
-			if stepIdx < len(group.Steps)-1 || groupIdx < len(p.Groups)-1 {
+			if separator != SeparatorSemicolon && (stepIdx < len(group.Steps)-1 || groupIdx < len(p.Groups)-1) {
 				sqlOutput.WriteString("\n")
 			}
 		}
@@ -345,6 +457,16 @@ func (p *Plan) ToSQL(format SQLFormat) string {
 	return sqlOutput.String()
 }
 
+// gexecStatement wraps a statement in a query whose result psql's \gexec executes. The statement
+// is dollar-quoted with a tag that does not occur in it, so it is passed through unchanged.
+func gexecStatement(sql string) string {
+	tag := "$pgschema$"
+	for n := 1; strings.Contains(sql+tag[:len(tag)-1], tag); n++ {
+		tag = fmt.Sprintf("$pgschema%d$", n)
+	}
+	return fmt.Sprintf("SELECT %s%s%s \\gexec", tag, sql, tag)
+}
+
 // ToJSON returns the plan as structured JSON with only changed statements
 func (p *Plan) ToJSON() (string, error) {
 	return p.ToJSONWithDebug(false)
@@ -674,6 +796,9 @@ func (p *Plan) writeTableChanges(summary *strings.Builder, c *color.Color) {
 	// Track all seen operations globally to avoid duplicates across groups
 	seenOperations := make(map[string]bool) // "path.operation.subType" -> true
 
+	// Index alters that don't rebuild the index (e.g. statistics targets)
+	inPlaceIndexAlters := p.inPlaceIndexAlters()
+
 	// Use source diffs for summary calculation
 	for _, step := range p.SourceDiffs {
 		// Normalize object type
@@ -763,7 +888,7 @@ func (p *Plan) writeTableChanges(summary *strings.Builder, c *color.Color) {
 				objectName := getObjectNameFromSource(subRes.source)
 
 				// Handle online index replacement display
-				if subRes.subType == diff.DiffTypeTableIndex.String() && subRes.operation == diff.DiffOperationAlter.String() {
+				if subRes.subType == diff.DiffTypeTableIndex.String() && subRes.operation == diff.DiffOperationAlter.String() && !inPlaceIndexAlters[subRes.path] {
 					subSymbol := c.PlanSymbol("change")
 					displaySubType := strings.TrimPrefix(subRes.subType, "table.")
 					fmt.Fprintf(summary, "    %s %s (%s - concurrent rebuild)\n", subSymbol, objectName, displaySubType)
@@ -789,6 +914,18 @@ func (p *Plan) writeTableChanges(summary *strings.Builder, c *color.Color) {
 	}
 }
 
+// inPlaceIndexAlters returns the paths of index changes that are applied without rebuilding the index
+func (p *Plan) inPlaceIndexAlters() map[string]bool {
+	paths := make(map[string]bool)
+	for _, d := range p.SourceDiffs {
+		if (d.Type == diff.DiffTypeTableIndex || d.Type == diff.DiffTypeMaterializedViewIndex) &&
+			d.Operation == diff.DiffOperationAlter && !createsIndex(d) {
+			paths[d.Path] = true
+		}
+	}
+	return paths
+}
+
 // writeViewChanges handles view-specific output with proper grouping
 func (p *Plan) writeViewChanges(summary *strings.Builder, c *color.Color) {
 	// Group all changes by view path and track operations
@@ -917,6 +1054,9 @@ func (p *Plan) writeMaterializedViewChanges(summary *strings.Builder, c *color.C
 	// Track all seen operations globally to avoid duplicates across groups
 	seenOperations := make(map[string]bool) // "path.operation.subType" -> true
 
+	// Index alters that don't rebuild the index (e.g. statistics targets)
+	inPlaceIndexAlters := p.inPlaceIndexAlters()
+
 	// Track materialized views that have "recreate" operations
 	mvsRecreating := make(map[string]bool)
 
@@ -1013,7 +1153,7 @@ func (p *Plan) writeMaterializedViewChanges(summary *strings.Builder, c *color.C
 
 			for _, subRes := range subResourceList {
 				// Handle online index replacement display
-				if subRes.subType == diff.DiffTypeMaterializedViewIndex.String() && subRes.operation == diff.DiffOperationAlter.String() {
+				if subRes.subType == diff.DiffTypeMaterializedViewIndex.String() && subRes.operation == diff.DiffOperationAlter.String() && !inPlaceIndexAlters[subRes.path] {
 					subSymbol := c.PlanSymbol("change")
 					displaySubType := strings.TrimPrefix(subRes.subType, "materialized_view.")
 					fmt.Fprintf(summary, "    %s %s (%s - concurrent rebuild)\n", subSymbol, getLastPathComponent(subRes.path), displaySubType)
@@ -1160,12 +1300,12 @@ func extractTablePathFromSubResource(subResourcePath, subResourceType string) st
 		}
 	} else if strings.HasPrefix(subResourceType, "materialized_view.") {
 		// For materialized view sub-resources, the path format is similar:
-		// - "schema.mv.resource_name" -> "schema.mv" (indexes, comments)
+		// - "schema.mv.resource_name" -> "schema.mv" (indexes, column comments)
 		// - "schema.mv" -> "schema.mv" (materialized view-level comments)
 		parts := strings.Split(subResourcePath, ".")
 
 		// Special handling for materialized view-level changes
-		if subResourceType == "materialized_view.comment" {
+		if subResourceType == "materialized_view.comment" && len(parts) < 3 {
 			// For materialized view comments, the path is already the materialized view path
 			return subResourcePath
 		}
@@ -321,3 +321,201 @@ func TestPlanDebugJSONRoundTrip(t *testing.T) {
 		t.Errorf("Group count mismatch: got %d, want %d", len(loaded2.Groups), len(loaded.Groups))
 	}
 }
+
+func TestPlanSafeForeignKeys(t *testing.T) {
+	fk := func(table, name, column, refTable string) diff.Diff {
+		constraint := &ir.Constraint{
+			Schema:            "public",
+			Table:             table,
+			Name:              name,
+			Type:              ir.ConstraintTypeForeignKey,
+			Columns:           []*ir.ConstraintColumn{{Name: column, Position: 1}},
+			ReferencedSchema:  "public",
+			ReferencedTable:   refTable,
+			ReferencedColumns: []*ir.ConstraintColumn{{Name: "id", Position: 1}},
+		}
+		return diff.Diff{
+			Type:       diff.DiffTypeTableConstraint,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public." + table + "." + name,
+			Source:     constraint,
+			Statements: []diff.SQLStatement{{SQL: fmt.Sprintf("ALTER TABLE %s\nADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (id);", table, name, column, refTable)}},
+		}
+	}
+	diffs := []diff.Diff{
+		fk("orders", "orders_customer_id_fkey", "customer_id", "customers"),
+		fk("invoices", "invoices_order_id_fkey", "order_id", "orders"),
+	}
+
+	groupSQL := func(p *Plan) [][]string {
+		var groups [][]string
+		for _, group := range p.Groups {
+			var stmts []string
+			for _, step := range group.Steps {
+				stmts = append(stmts, step.SQL)
+			}
+			groups = append(groups, stmts)
+		}
+		return groups
+	}
+
+	// By default each foreign key is added NOT VALID and validated right away, in one transaction
+	want := [][]string{{
+		"ALTER TABLE orders\nADD CONSTRAINT orders_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES customers (id) NOT VALID;",
+		"ALTER TABLE orders VALIDATE CONSTRAINT orders_customer_id_fkey;",
+		"ALTER TABLE invoices\nADD CONSTRAINT invoices_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders (id) NOT VALID;",
+		"ALTER TABLE invoices VALIDATE CONSTRAINT invoices_order_id_fkey;",
+	}}
+	if got := groupSQL(NewPlan(diffs)); !cmp.Equal(got, want) {
+		t.Errorf("default grouping mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// With SafeForeignKeys all NOT VALID adds come first, then one validation per transaction
+	want = [][]string{
+		{
+			"ALTER TABLE orders\nADD CONSTRAINT orders_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES customers (id) NOT VALID;",
+			"ALTER TABLE invoices\nADD CONSTRAINT invoices_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders (id) NOT VALID;",
+		},
+		{"ALTER TABLE orders VALIDATE CONSTRAINT orders_customer_id_fkey;"},
+		{"ALTER TABLE invoices VALIDATE CONSTRAINT invoices_order_id_fkey;"},
+	}
+	if got := groupSQL(NewPlanWithOptions(diffs, Options{SafeForeignKeys: true})); !cmp.Equal(got, want) {
+		t.Errorf("safe foreign key grouping mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+// TestPlanPartitionForeignKeyOrder checks that a new partition is created before a foreign key
+// added to its partitioned parent in the same plan, and that the foreign key is added directly
+// since PostgreSQL before 18 rejects NOT VALID foreign keys on partitioned tables.
+func TestPlanPartitionForeignKeyOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	baseSQL := `CREATE TABLE customers (id integer PRIMARY KEY);
+CREATE TABLE orders (id integer NOT NULL, customer_id integer, created_at date NOT NULL) PARTITION BY RANGE (created_at);
+CREATE TABLE orders_2024 PARTITION OF orders FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');
+`
+	oldIR := parseSQL(t, baseSQL)
+	newIR := parseSQL(t, baseSQL+`CREATE TABLE orders_2025 PARTITION OF orders FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');
+ALTER TABLE orders ADD CONSTRAINT orders_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES customers (id);
+`)
+
+	for _, tc := range []struct {
+		version  string
+		notValid bool
+	}{
+		{version: "PostgreSQL 17.2", notValid: false},
+		{version: "PostgreSQL 18.0", notValid: true},
+	} {
+		t.Run(tc.version, func(t *testing.T) {
+			oldIR.Metadata.DatabaseVersion = tc.version
+
+			var statements []string
+			for _, group := range NewPlan(diff.GenerateMigration(oldIR, newIR, "public")).Groups {
+				for _, step := range group.Steps {
+					statements = append(statements, step.SQL)
+				}
+			}
+
+			partitionIdx, fkIdx := -1, -1
+			for i, stmt := range statements {
+				if strings.Contains(stmt, "orders_2025 PARTITION OF") {
+					partitionIdx = i
+				}
+				if strings.Contains(stmt, "ADD CONSTRAINT orders_customer_id_fkey") {
+					fkIdx = i
+					if strings.Contains(stmt, "NOT VALID") != tc.notValid {
+						t.Errorf("expected NOT VALID to be %v for the foreign key on a partitioned table, got %q", tc.notValid, stmt)
+					}
+				}
+			}
+			if partitionIdx == -1 || fkIdx == -1 {
+				t.Fatalf("expected a partition and a foreign key statement, got %v", statements)
+			}
+			if partitionIdx > fkIdx {
+				t.Errorf("expected the partition to be created before the foreign key, got %v", statements)
+			}
+		})
+	}
+}
+
+func TestSummaryColored(t *testing.T) {
+	p := NewPlan([]diff.Diff{
+		{
+			Type:       diff.DiffTypeTable,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.posts",
+			Source:     &ir.Table{Schema: "public", Name: "posts"},
+			Statements: []diff.SQLStatement{{SQL: "CREATE TABLE IF NOT EXISTS posts (id integer);", CanRunInTransaction: true}},
+		},
+		{
+			Type:       diff.DiffTypeFunction,
+			Operation:  diff.DiffOperationDrop,
+			Path:       "public.touch",
+			Source:     &ir.Function{Schema: "public", Name: "touch"},
+			Statements: []diff.SQLStatement{{SQL: "DROP FUNCTION IF EXISTS touch();", CanRunInTransaction: true}},
+		},
+	})
+
+	summary := p.SummaryColored(false)
+	t.Logf("Summary output:\n%s", summary)
+
+	for _, expected := range []string{"1 to add", "1 to drop", "+ posts", "- touch"} {
+		if !strings.Contains(summary, expected) {
+			t.Errorf("expected summary to contain %q", expected)
+		}
+	}
+	for _, ddl := range []string{"DDL to be executed", "CREATE TABLE", "DROP FUNCTION"} {
+		if strings.Contains(summary, ddl) {
+			t.Errorf("expected summary to contain no DDL, found %q", ddl)
+		}
+	}
+
+	// The full human output is the summary followed by the DDL
+	if full := p.HumanColored(false); !strings.HasPrefix(full, summary) || !strings.Contains(full, "CREATE TABLE IF NOT EXISTS posts") {
+		t.Errorf("expected the human output to extend the summary with the DDL, got:\n%s", full)
+	}
+}
+
+// TestPlanStatementSeparators checks that each separator keeps function bodies with embedded
+// semicolons intact.
+func TestPlanStatementSeparators(t *testing.T) {
+	functionSQL := "CREATE OR REPLACE FUNCTION touch() RETURNS trigger\nLANGUAGE plpgsql\nAS $$\nBEGIN\n    NEW.updated_at := now();\n    RETURN NEW;\nEND;\n$$;"
+	tableSQL := "CREATE TABLE IF NOT EXISTS notes (id integer);"
+	p := NewPlan([]diff.Diff{
+		{
+			Type:       diff.DiffTypeFunction,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.touch",
+			Source:     &ir.Function{Schema: "public", Name: "touch"},
+			Statements: []diff.SQLStatement{{SQL: functionSQL}},
+		},
+		{
+			Type:       diff.DiffTypeTable,
+			Operation:  diff.DiffOperationCreate,
+			Path:       "public.notes",
+			Source:     &ir.Table{Schema: "public", Name: "notes"},
+			Statements: []diff.SQLStatement{{SQL: tableSQL}},
+		},
+	})
+
+	tests := []struct {
+		separator StatementSeparator
+		expected  string
+	}{
+		{SeparatorBlankLine, functionSQL + "\n\n" + tableSQL + "\n"},
+		{SeparatorSemicolon, functionSQL + "\n" + tableSQL + "\n"},
+		{SeparatorGexec, "SELECT $pgschema$" + functionSQL + "$pgschema$ \\gexec\n\nSELECT $pgschema$" + tableSQL + "$pgschema$ \\gexec\n"},
+	}
+	for _, tt := range tests {
+		if got := p.ToSQLWithSeparator(SQLFormatRaw, tt.separator); got != tt.expected {
+			t.Errorf("ToSQLWithSeparator(%s) = %q, want %q", tt.separator, got, tt.expected)
+		}
+	}
+
+	// The dollar quote tag is changed when the statement contains it
+	if got := gexecStatement("SELECT '$pgschema$';"); got != "SELECT $pgschema1$SELECT '$pgschema$';$pgschema1$ \\gexec" {
+		t.Errorf("gexecStatement() = %q", got)
+	}
+}
@@ -17,6 +17,10 @@ type RewriteStep struct {
 
 // generateRewrite generates rewrite steps for a diff if online operations are enabled
 func generateRewrite(d diff.Diff, newlyCreatedTables map[string]bool, newlyCreatedMaterializedViews map[string]bool) []RewriteStep {
+	if d.NoRewrite {
+		return nil
+	}
+
 	// Dispatch to specific rewrite generators based on diff type and source
 	switch d.Type {
 	case diff.DiffTypeTableIndex:
@@ -28,15 +32,29 @@ func generateRewrite(d diff.Diff, newlyCreatedTables map[string]bool, newlyCreat
 				if newlyCreatedTables[tableKey] {
 					return nil // No rewrite needed for indexes on new tables
 				}
-				return generateIndexRewrite(index)
+				// PostgreSQL rejects CREATE INDEX CONCURRENTLY on partitioned tables
+				if index.IsPartitioned {
+					return nil
+				}
+				return appendIndexStatistics(generateIndexRewrite(index), d)
 			}
 		case diff.DiffOperationAlter:
+			// Statistics target changes are applied in place without rebuilding the index
+			if !createsIndex(d) {
+				return nil
+			}
 			// For index changes, the source might be an IndexDiff or could be an Index for replacement
 			if indexDiff, ok := d.Source.(*diff.IndexDiff); ok {
-				return generateIndexChangeRewrite(indexDiff)
+				if indexDiff.New.IsPartitioned {
+					return nil
+				}
+				return appendIndexStatistics(generateIndexChangeRewrite(indexDiff), d)
 			} else if index, ok := d.Source.(*ir.Index); ok {
+				if index.IsPartitioned {
+					return nil
+				}
 				// This handles index replacements where the source is the new index
-				return generateIndexChangeRewriteFromIndex(index)
+				return appendIndexStatistics(generateIndexChangeRewriteFromIndex(index), d)
 			}
 		}
 	case diff.DiffTypeMaterializedViewIndex:
@@ -48,15 +66,19 @@ func generateRewrite(d diff.Diff, newlyCreatedTables map[string]bool, newlyCreat
 				if newlyCreatedMaterializedViews[mvKey] {
 					return nil // No rewrite needed for indexes on new materialized views
 				}
-				return generateIndexRewrite(index)
+				return appendIndexStatistics(generateIndexRewrite(index), d)
 			}
 		case diff.DiffOperationAlter:
+			// Statistics target changes are applied in place without rebuilding the index
+			if !createsIndex(d) {
+				return nil
+			}
 			// For index changes, handle similarly to table indexes
 			if indexDiff, ok := d.Source.(*diff.IndexDiff); ok {
-				return generateIndexChangeRewrite(indexDiff)
+				return appendIndexStatistics(generateIndexChangeRewrite(indexDiff), d)
 			} else if index, ok := d.Source.(*ir.Index); ok {
 				// This handles index replacements where the source is the new index
-				return generateIndexChangeRewriteFromIndex(index)
+				return appendIndexStatistics(generateIndexChangeRewriteFromIndex(index), d)
 			}
 		}
 	case diff.DiffTypeTableConstraint:
@@ -79,12 +101,14 @@ func generateRewrite(d diff.Diff, newlyCreatedTables map[string]bool, newlyCreat
 		if d.Operation == diff.DiffOperationAlter {
 			if columnDiff, ok := d.Source.(*diff.ColumnDiff); ok {
 				// Check if this is a NOT NULL addition AND this specific statement is for SET NOT NULL
+				// (or ADD CONSTRAINT ... NOT NULL for an explicitly named NOT NULL constraint)
 				// Multiple statements can be generated from the same ColumnDiff (e.g., SET NOT NULL + SET DEFAULT),
 				// so we must only rewrite the statement that actually contains SET NOT NULL
 				if columnDiff.Old.IsNullable && !columnDiff.New.IsNullable {
 					// Verify this diff's SQL actually contains SET NOT NULL
 					for _, stmt := range d.Statements {
-						if strings.Contains(stmt.SQL, "SET NOT NULL") {
+						if strings.Contains(stmt.SQL, "SET NOT NULL") ||
+							(columnDiff.New.NotNullConstraintName != "" && strings.Contains(stmt.SQL, "ADD CONSTRAINT")) {
 							return generateColumnNotNullRewrite(columnDiff, d.Path)
 						}
 					}
@@ -95,7 +119,7 @@ func generateRewrite(d diff.Diff, newlyCreatedTables map[string]bool, newlyCreat
 					// Verify this diff's SQL actually contains ADD GENERATED
 					for _, stmt := range d.Statements {
 						if strings.Contains(stmt.SQL, "ADD GENERATED") {
-							return generateColumnIdentityRewrite(columnDiff, d.Path)
+							return generateColumnIdentityRewrite(stmt.SQL, d.Path)
 						}
 					}
 				}
@@ -106,6 +130,30 @@ func generateRewrite(d diff.Diff, newlyCreatedTables map[string]bool, newlyCreat
 	return nil
 }
 
+// createsIndex reports whether an index diff builds an index (as opposed to only altering it in place)
+func createsIndex(d diff.Diff) bool {
+	for _, stmt := range d.Statements {
+		if strings.HasPrefix(stmt.SQL, "CREATE ") {
+			return true
+		}
+	}
+	return false
+}
+
+// appendIndexStatistics carries the diff's ALTER INDEX ... SET STATISTICS statements over to the
+// rewrite, so they run once the concurrently built index exists under its final name
+func appendIndexStatistics(steps []RewriteStep, d diff.Diff) []RewriteStep {
+	for _, stmt := range d.Statements {
+		if strings.HasPrefix(stmt.SQL, "ALTER INDEX ") && strings.Contains(stmt.SQL, " SET STATISTICS ") {
+			steps = append(steps, RewriteStep{
+				SQL:                 stmt.SQL,
+				CanRunInTransaction: true,
+			})
+		}
+	}
+	return steps
+}
+
 // generateIndexRewrite generates rewrite steps for CREATE INDEX operations
 func generateIndexRewrite(index *ir.Index) []RewriteStep {
 	// Generate concurrent SQL
@@ -283,7 +331,7 @@ func generateForeignKeyRewrite(constraint *ir.Constraint) []RewriteStep {
 }
 
 // generateColumnNotNullRewrite generates rewrite steps for SET NOT NULL operations
-func generateColumnNotNullRewrite(_ *diff.ColumnDiff, path string) []RewriteStep {
+func generateColumnNotNullRewrite(columnDiff *diff.ColumnDiff, path string) []RewriteStep {
 	// Parse path (schema.table.column) to extract schema, table, and column names
 	parts := strings.Split(path, ".")
 	if len(parts) != 3 {
@@ -308,9 +356,13 @@ func generateColumnNotNullRewrite(_ *diff.ColumnDiff, path string) []RewriteStep
 	validateConstraintSQL := fmt.Sprintf("ALTER TABLE %s VALIDATE CONSTRAINT %s;",
 		tableName, ir.QuoteIdentifier(constraintName))
 
-	// Step 3: Set column to NOT NULL
+	// Step 3: Set column to NOT NULL, keeping an explicit NOT NULL constraint name (PostgreSQL 18+)
 	setNotNullSQL := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;",
 		tableName, quotedColumn)
+	if columnDiff.New.NotNullConstraintName != "" {
+		setNotNullSQL = fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s NOT NULL %s;",
+			tableName, ir.QuoteIdentifier(columnDiff.New.NotNullConstraintName), quotedColumn)
+	}
 
 	// Step 4: Drop CHECK constraint
 	dropConstraintSQL := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;",
@@ -338,7 +390,7 @@ func generateColumnNotNullRewrite(_ *diff.ColumnDiff, path string) []RewriteStep
 
 // generateColumnIdentityRewrite generates rewrite steps for ADD GENERATED AS IDENTITY operations
 // It syncs the identity sequence with existing data to prevent conflicts
-func generateColumnIdentityRewrite(columnDiff *diff.ColumnDiff, path string) []RewriteStep {
+func generateColumnIdentityRewrite(addIdentitySQL string, path string) []RewriteStep {
 	// Parse path (schema.table.column) to extract schema, table, and column names
 	parts := strings.Split(path, ".")
 	if len(parts) != 3 {
@@ -350,9 +402,7 @@ func generateColumnIdentityRewrite(columnDiff *diff.ColumnDiff, path string) []R
 
 	tableName := getTableNameWithSchema(schema, table)
 
-	// Step 1: Add identity column
-	addIdentitySQL := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s ADD GENERATED %s AS IDENTITY;",
-		tableName, ir.QuoteIdentifier(column), columnDiff.New.Identity.Generation)
+	// Step 1 is the ADD GENERATED statement of the diff, which carries the sequence options
 
 	// Step 2: Sync sequence with existing data
 	setvalSQL := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', '%s'), COALESCE(MAX(%s), 0) + 1) FROM %s;",
@@ -402,8 +452,8 @@ func generateIndexSQL(index *ir.Index, isConcurrent bool) string {
 		if col.Direction != "" && col.Direction != "ASC" {
 			part += " " + col.Direction
 		}
-		if col.Operator != "" {
-			part += " " + col.Operator
+		if opclass := col.OperatorClass(); opclass != "" {
+			part += " " + opclass
 		}
 		columnParts = append(columnParts, part)
 	}
@@ -411,6 +461,11 @@ func generateIndexSQL(index *ir.Index, isConcurrent bool) string {
 	sql.WriteString(joinStrings(columnParts, ", "))
 	sql.WriteString(")")
 
+	if index.Tablespace != "" {
+		sql.WriteString(" TABLESPACE ")
+		sql.WriteString(ir.QuoteIdentifier(index.Tablespace))
+	}
+
 	if index.Where != "" {
 		sql.WriteString(" WHERE ")
 		sql.WriteString(index.Where)
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ApplyError is an error from applying the desired state SQL, along with the location of the
+// failing statement that PostgreSQL reported
+type ApplyError struct {
+	Err           error
+	Line          int    // Line of the error in the applied SQL
+	Text          string // Text of that line
+	StatementLine int    // First line of the statement containing the error
+	Statement     string // Text of that line, which names the object
+	// Locate describes where a line of the applied SQL was read from, e.g. to point into the
+	// files of an expanded \i include. Lines are reported as "line N" when it is nil.
+	Locate func(line int) string
+}
+
+// Error returns the PostgreSQL error followed by the location of the failing statement
+func (e *ApplyError) Error() string {
+	locate := e.Locate
+	if locate == nil {
+		locate = func(line int) string { return fmt.Sprintf("line %d", line) }
+	}
+	if e.StatementLine == e.Line {
+		return fmt.Sprintf("%s\n  at %s: %s", e.Err, locate(e.Line), e.Text)
+	}
+	return fmt.Sprintf("%s\n  at %s: %s\n  in statement at %s: %s", e.Err, locate(e.Line), e.Text, locate(e.StatementLine), e.Statement)
+}
+
+// Unwrap returns the PostgreSQL error
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// describeApplyError adds the location of the failing statement to an error from applying the
+// desired state SQL, when PostgreSQL reports one. The whole desired state is sent as a single
+// query, so the error position is a character offset into sql. The location is reported as the
+// line of the error and the first line of the statement containing it, which names the object.
+func describeApplyError(sql string, err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Position <= 0 {
+		return err
+	}
+	runes := []rune(sql)
+	if int(pgErr.Position) > len(runes) {
+		return err
+	}
+
+	lines := strings.Split(string(runes[:pgErr.Position-1]), "\n")
+	lineNumber := len(lines)
+	allLines := strings.Split(sql, "\n")
+
+	// The statement starts after the closest earlier line that ends one
+	start := lineNumber - 1
+	for start > 0 {
+		previous := strings.TrimSpace(allLines[start-1])
+		if strings.HasSuffix(previous, ";") {
+			break
+		}
+		start--
+	}
+	for start < lineNumber-1 && isBlankOrComment(allLines[start]) {
+		start++
+	}
+
+	return &ApplyError{
+		Err:           err,
+		Line:          lineNumber,
+		Text:          strings.TrimSpace(allLines[lineNumber-1]),
+		StatementLine: start + 1,
+		Statement:     strings.TrimSpace(allLines[start]),
+	}
+}
+
+// isBlankOrComment reports whether a line of SQL holds no code
+func isBlankOrComment(line string) bool {
+	line = strings.TrimSpace(line)
+	return line == "" || strings.HasPrefix(line, "--")
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestDescribeApplyError(t *testing.T) {
+	sql := `CREATE TABLE teams (id integer PRIMARY KEY);
+
+-- Users sign in by email
+CREATE TABLE users (
+    id integer PRIMARY KEY,
+    email citext NOT NULL
+);
+`
+	position := int32(strings.Index(sql, "citext") + 1)
+
+	err := describeApplyError(sql, &pgconn.PgError{Severity: "ERROR", Code: "42704", Message: `type "citext" does not exist`, Position: position})
+	expected := `ERROR: type "citext" does not exist (SQLSTATE 42704)
+  at line 6: email citext NOT NULL
+  in statement at line 4: CREATE TABLE users (`
+	if err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, err.Error())
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		t.Error("expected the PostgreSQL error to be wrapped")
+	}
+
+	// An error on the first line of a statement is not repeated
+	position = int32(strings.Index(sql, "teams") + 1)
+	err = describeApplyError(sql, &pgconn.PgError{Severity: "ERROR", Code: "42P07", Message: `relation "teams" already exists`, Position: position})
+	if !strings.HasSuffix(err.Error(), "\n  at line 1: CREATE TABLE teams (id integer PRIMARY KEY);") {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	// Lines are described by Locate, e.g. as lines of included files
+	var applyErr *ApplyError
+	if !errors.As(err, &applyErr) {
+		t.Fatalf("expected an *ApplyError, got %T", err)
+	}
+	applyErr.Locate = func(line int) string { return fmt.Sprintf("line %d of tables/teams.sql", line+10) }
+	if !strings.HasSuffix(err.Error(), "\n  at line 11 of tables/teams.sql: CREATE TABLE teams (id integer PRIMARY KEY);") {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	// Errors without a position are returned as-is
+	plain := &pgconn.PgError{Severity: "ERROR", Code: "42883", Message: "function missing() does not exist"}
+	if err := describeApplyError(sql, plain); err != plain {
+		t.Errorf("expected the error to be returned unchanged, got %v", err)
+	}
+}
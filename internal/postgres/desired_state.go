@@ -5,6 +5,7 @@ package postgres
 import (
 	"context"
 	"crypto/rand"
+	"database/sql"
 	"encoding/hex"
 	"fmt"
 	"regexp"
@@ -57,6 +58,75 @@ func GenerateTempSchemaName() string {
 	return fmt.Sprintf("pgschema_tmp_%s_%s", timestamp, randomSuffix)
 }
 
+// StaleTempSchemaAge is the minimum age of a pgschema_tmp_* schema before it is
+// considered leaked by a crashed process and dropped by DropStaleTempSchemas.
+// It is generous enough that a concurrently running plan is never affected.
+const StaleTempSchemaAge = 24 * time.Hour
+
+// tempSchemaNamePattern matches names produced by GenerateTempSchemaName.
+var tempSchemaNamePattern = regexp.MustCompile(`^pgschema_tmp_(\d{8}_\d{6})_[0-9a-f]{8}$`)
+
+// ParseTempSchemaTimestamp extracts the creation time encoded in a temporary schema name.
+// Returns false if the name was not produced by GenerateTempSchemaName.
+func ParseTempSchemaTimestamp(schemaName string) (time.Time, bool) {
+	matches := tempSchemaNamePattern.FindStringSubmatch(schemaName)
+	if matches == nil {
+		return time.Time{}, false
+	}
+	created, err := time.ParseInLocation("20060102_150405", matches[1], time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return created, true
+}
+
+// IsStaleTempSchema reports whether schemaName is a temporary schema created more than maxAge before now.
+func IsStaleTempSchema(schemaName string, now time.Time, maxAge time.Duration) bool {
+	created, ok := ParseTempSchemaTimestamp(schemaName)
+	if !ok {
+		return false
+	}
+	return now.Sub(created) > maxAge
+}
+
+// DropStaleTempSchemas drops pgschema_tmp_* schemas older than maxAge.
+// These are left behind when a previous plan or apply process was killed before it could clean up.
+// Returns the names of the dropped schemas.
+func DropStaleTempSchemas(ctx context.Context, db *sql.DB, maxAge time.Duration) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT nspname FROM pg_namespace WHERE nspname LIKE 'pgschema\\_tmp\\_%'")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list temporary schemas: %w", err)
+	}
+
+	var stale []string
+	now := time.Now()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan temporary schema name: %w", err)
+		}
+		if IsStaleTempSchema(name, now, maxAge) {
+			stale = append(stale, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list temporary schemas: %w", err)
+	}
+
+	var dropped []string
+	for _, name := range stale {
+		dropSchemaSQL := fmt.Sprintf("DROP SCHEMA IF EXISTS \"%s\" CASCADE", name)
+		if _, err := db.ExecContext(ctx, dropSchemaSQL); err != nil {
+			return dropped, fmt.Errorf("failed to drop stale temporary schema %s: %w", name, err)
+		}
+		dropped = append(dropped, name)
+	}
+
+	return dropped, nil
+}
+
 // stripSchemaQualifications removes schema qualifications from SQL statements for the specified target schema.
 //
 // Purpose:
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTempSchemaTimestamp(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaName string
+		want       time.Time
+		wantOK     bool
+	}{
+		{
+			name:       "generated name",
+			schemaName: "pgschema_tmp_20251030_154501_a3f9d2e1",
+			want:       time.Date(2025, 10, 30, 15, 45, 1, 0, time.Local),
+			wantOK:     true,
+		},
+		{
+			name:       "user schema",
+			schemaName: "public",
+		},
+		{
+			name:       "missing random suffix",
+			schemaName: "pgschema_tmp_20251030_154501",
+		},
+		{
+			name:       "lookalike with extra suffix",
+			schemaName: "pgschema_tmp_20251030_154501_a3f9d2e1_backup",
+		},
+		{
+			name:       "invalid date",
+			schemaName: "pgschema_tmp_20251399_154501_a3f9d2e1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseTempSchemaTimestamp(tt.schemaName)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseTempSchemaTimestamp(%q) ok = %v, want %v", tt.schemaName, ok, tt.wantOK)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("ParseTempSchemaTimestamp(%q) = %v, want %v", tt.schemaName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTempSchemaTimestampRoundTrip(t *testing.T) {
+	before := time.Now().Truncate(time.Second)
+	name := GenerateTempSchemaName()
+
+	created, ok := ParseTempSchemaTimestamp(name)
+	if !ok {
+		t.Fatalf("ParseTempSchemaTimestamp(%q) did not recognize a generated name", name)
+	}
+	if created.Before(before) || created.After(time.Now()) {
+		t.Errorf("ParseTempSchemaTimestamp(%q) = %v, want a time close to now", name, created)
+	}
+}
+
+func TestIsStaleTempSchema(t *testing.T) {
+	now := time.Date(2025, 10, 31, 16, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		schemaName string
+		want       bool
+	}{
+		{"pgschema_tmp_20251029_154501_a3f9d2e1", true},
+		{"pgschema_tmp_20251031_154501_a3f9d2e1", false},
+		{"pgschema_tmp_old", false},
+		{"public", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsStaleTempSchema(tt.schemaName, now, StaleTempSchemaAge); got != tt.want {
+			t.Errorf("IsStaleTempSchema(%q) = %v, want %v", tt.schemaName, got, tt.want)
+		}
+	}
+}
@@ -23,16 +23,17 @@ type PostgresVersion = embeddedpostgres.PostgresVersion
 // EmbeddedPostgres manages a temporary embedded PostgreSQL instance.
 // This is used by the plan command to validate desired state schemas.
 type EmbeddedPostgres struct {
-	instance    *embeddedpostgres.EmbeddedPostgres
-	db          *sql.DB
-	version     PostgresVersion
-	host        string
-	port        int
-	database    string
-	username    string
-	password    string
-	runtimePath string
-	tempSchema  string // temporary schema name with timestamp for uniqueness
+	instance       *embeddedpostgres.EmbeddedPostgres
+	db             *sql.DB
+	version        PostgresVersion
+	host           string
+	port           int
+	database       string
+	username       string
+	password       string
+	runtimePath    string
+	tempSchema     string // temporary schema name with timestamp for uniqueness
+	keepTempSchema bool   // keep the temporary schema and data directory on Stop (for debugging)
 }
 
 // EmbeddedPostgresConfig holds configuration for starting embedded PostgreSQL
@@ -41,21 +42,15 @@ type EmbeddedPostgresConfig struct {
 	Database string
 	Username string
 	Password string
+	// KeepTempSchema keeps the temporary schema and the instance's data directory
+	// after Stop so a failed desired state can be inspected
+	KeepTempSchema bool
 }
 
 // DetectPostgresVersionFromDB connects to a database and detects its version
-// This is a convenience function that opens a connection, detects the version, and closes it
-func DetectPostgresVersionFromDB(host string, port int, database, user, password string) (PostgresVersion, error) {
-	// Build connection config
-	config := &util.ConnectionConfig{
-		Host:     host,
-		Port:     port,
-		Database: database,
-		User:     user,
-		Password: password,
-		SSLMode:  "prefer",
-	}
-
+// This is a convenience function that opens a connection, detects the version, and closes it.
+// The connection uses the config's SSL mode and timeout, like every other connection to that database.
+func DetectPostgresVersionFromDB(config *util.ConnectionConfig) (PostgresVersion, error) {
 	// Connect to database
 	db, err := util.Connect(config)
 	if err != nil {
@@ -123,23 +118,25 @@ func StartEmbeddedPostgres(config *EmbeddedPostgresConfig) (*EmbeddedPostgres, e
 	}
 
 	return &EmbeddedPostgres{
-		instance:    instance,
-		db:          db,
-		version:     config.Version,
-		host:        host,
-		port:        port,
-		database:    config.Database,
-		username:    config.Username,
-		password:    config.Password,
-		runtimePath: runtimePath,
-		tempSchema:  tempSchema,
+		instance:       instance,
+		db:             db,
+		version:        config.Version,
+		host:           host,
+		port:           port,
+		database:       config.Database,
+		username:       config.Username,
+		password:       config.Password,
+		runtimePath:    runtimePath,
+		tempSchema:     tempSchema,
+		keepTempSchema: config.KeepTempSchema,
 	}, nil
 }
 
-// Stop stops and cleans up the embedded PostgreSQL instance
+// Stop stops and cleans up the embedded PostgreSQL instance.
+// Stop is safe to call more than once, so callers can defer it and also call it explicitly.
 func (ep *EmbeddedPostgres) Stop() error {
 	// Drop the temporary schema (best effort - don't fail if this errors)
-	if ep.db != nil && ep.tempSchema != "" {
+	if ep.db != nil && ep.tempSchema != "" && !ep.keepTempSchema {
 		ctx := context.Background()
 		dropSchemaSQL := fmt.Sprintf("DROP SCHEMA IF EXISTS \"%s\" CASCADE", ep.tempSchema)
 		// Ignore errors - this is best effort cleanup
@@ -149,12 +146,19 @@ func (ep *EmbeddedPostgres) Stop() error {
 	// Close database connection
 	if ep.db != nil {
 		ep.db.Close()
+		ep.db = nil
 	}
 
 	// Stop PostgreSQL instance
 	var stopErr error
 	if ep.instance != nil {
 		stopErr = ep.instance.Stop()
+		ep.instance = nil
+	}
+
+	if ep.keepTempSchema && ep.runtimePath != "" {
+		fmt.Fprintf(os.Stderr, "Keeping temporary schema %s in embedded PostgreSQL data directory %s for debugging\n", ep.tempSchema, filepath.Join(ep.runtimePath, "data"))
+		ep.runtimePath = ""
 	}
 
 	// Clean up runtime directory
@@ -163,6 +167,7 @@ func (ep *EmbeddedPostgres) Stop() error {
 			// Don't return error here - just ignore cleanup failures
 			// This can happen on Windows when files are still in use
 		}
+		ep.runtimePath = ""
 	}
 
 	if stopErr != nil {
@@ -219,7 +224,7 @@ func (ep *EmbeddedPostgres) ApplySchema(ctx context.Context, schema string, sql
 	// Note: Desired state SQL should never contain operations like CREATE INDEX CONCURRENTLY
 	// that cannot run in transactions. Those are migration details, not state declarations.
 	if _, err := util.ExecContextWithLogging(ctx, ep.db, schemaAgnosticSQL, "apply desired state SQL to temporary schema"); err != nil {
-		return fmt.Errorf("failed to apply schema SQL to temporary schema %s: %w", ep.tempSchema, err)
+		return fmt.Errorf("failed to apply schema SQL to temporary schema %s: %w", ep.tempSchema, describeApplyError(schemaAgnosticSQL, err))
 	}
 
 	return nil
@@ -235,6 +240,12 @@ func findAvailablePort() (int, error) {
 	return listener.Addr().(*net.TCPAddr).Port, nil
 }
 
+// EmbeddedVersionForMajor returns the embedded-postgres version for a PostgreSQL major version.
+// Supported versions: 14, 15, 16, 17, 18
+func EmbeddedVersionForMajor(majorVersion int) (PostgresVersion, error) {
+	return mapToEmbeddedPostgresVersion(majorVersion)
+}
+
 // mapToEmbeddedPostgresVersion maps a PostgreSQL major version to embedded-postgres version
 // Supported versions: 14, 15, 16, 17, 18
 func mapToEmbeddedPostgresVersion(majorVersion int) (PostgresVersion, error) {
@@ -5,9 +5,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/pgplex/pgschema/cmd/util"
+	"github.com/pgplex/pgschema/internal/logger"
 )
 
 // ExternalDatabase manages an external PostgreSQL database for desired state validation.
@@ -21,6 +23,7 @@ type ExternalDatabase struct {
 	password           string
 	tempSchema         string // Temporary schema name with timestamp suffix
 	targetMajorVersion int    // Expected major version (from target database)
+	keepTempSchema     bool   // Skip dropping the temporary schema on Stop (for debugging)
 }
 
 // ExternalDatabaseConfig holds configuration for connecting to an external database
@@ -30,24 +33,27 @@ type ExternalDatabaseConfig struct {
 	Database           string
 	Username           string
 	Password           string
-	TargetMajorVersion int // Expected major version to match
+	TargetMajorVersion int  // Expected major version to match
+	KeepTempSchema     bool // Keep the temporary schema after Stop for debugging
 }
 
-// NewExternalDatabase creates a new external database connection for desired state validation.
-// It validates the connection, checks version compatibility, and generates a temporary schema name.
-func NewExternalDatabase(config *ExternalDatabaseConfig) (*ExternalDatabase, error) {
-	// Build connection config
-	connConfig := &util.ConnectionConfig{
-		Host:     config.Host,
-		Port:     config.Port,
-		Database: config.Database,
-		User:     config.Username,
-		Password: config.Password,
+// ConnectionConfig returns the connection configuration used for the external database
+func (c *ExternalDatabaseConfig) ConnectionConfig() *util.ConnectionConfig {
+	return &util.ConnectionConfig{
+		Host:     c.Host,
+		Port:     c.Port,
+		Database: c.Database,
+		User:     c.Username,
+		Password: c.Password,
 		SSLMode:  "prefer",
 	}
+}
 
+// NewExternalDatabase creates a new external database connection for desired state validation.
+// It validates the connection, checks version compatibility, and generates a temporary schema name.
+func NewExternalDatabase(config *ExternalDatabaseConfig) (*ExternalDatabase, error) {
 	// Connect to database
-	db, err := util.Connect(connConfig)
+	db, err := util.Connect(config.ConnectionConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to external database: %w", err)
 	}
@@ -68,6 +74,15 @@ func NewExternalDatabase(config *ExternalDatabaseConfig) (*ExternalDatabase, err
 		)
 	}
 
+	// Sweep temporary schemas leaked by previous runs that crashed before cleanup (best effort)
+	dropped, err := DropStaleTempSchemas(context.Background(), db, StaleTempSchemaAge)
+	if err != nil {
+		logger.Get().Debug("Failed to drop stale temporary schemas", "error", err)
+	}
+	for _, name := range dropped {
+		logger.Get().Debug("Dropped stale temporary schema", "schema", name)
+	}
+
 	// Generate temporary schema name with unique timestamp
 	tempSchema := GenerateTempSchemaName()
 
@@ -80,6 +95,7 @@ func NewExternalDatabase(config *ExternalDatabaseConfig) (*ExternalDatabase, err
 		password:           config.Password,
 		tempSchema:         tempSchema,
 		targetMajorVersion: config.TargetMajorVersion,
+		keepTempSchema:     config.KeepTempSchema,
 	}, nil
 }
 
@@ -99,8 +115,14 @@ func (ed *ExternalDatabase) ApplySchema(ctx context.Context, schema string, sql
 	// Note: We use the temporary schema name (ed.tempSchema) instead of the user-provided schema name
 	// This ensures we don't interfere with existing schemas in the external database
 
+	// Drop the temporary schema if a previous ApplySchema call created it, so each call starts clean
+	dropSchemaSQL := fmt.Sprintf("DROP SCHEMA IF EXISTS \"%s\" CASCADE", ed.tempSchema)
+	if _, err := util.ExecContextWithLogging(ctx, ed.db, dropSchemaSQL, "drop temporary schema"); err != nil {
+		return fmt.Errorf("failed to drop temporary schema %s: %w", ed.tempSchema, err)
+	}
+
 	// Create the temporary schema
-	createSchemaSQL := fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS \"%s\"", ed.tempSchema)
+	createSchemaSQL := fmt.Sprintf("CREATE SCHEMA \"%s\"", ed.tempSchema)
 	if _, err := util.ExecContextWithLogging(ctx, ed.db, createSchemaSQL, "create temporary schema"); err != nil {
 		return fmt.Errorf("failed to create temporary schema %s: %w", ed.tempSchema, err)
 	}
@@ -125,7 +147,7 @@ func (ed *ExternalDatabase) ApplySchema(ctx context.Context, schema string, sql
 	// Note: Desired state SQL should never contain operations like CREATE INDEX CONCURRENTLY
 	// that cannot run in transactions. Those are migration details, not state declarations.
 	if _, err := util.ExecContextWithLogging(ctx, ed.db, schemaAgnosticSQL, "apply desired state SQL to temporary schema"); err != nil {
-		return fmt.Errorf("failed to apply schema SQL to temporary schema %s: %w", ed.tempSchema, err)
+		return fmt.Errorf("failed to apply schema SQL to temporary schema %s: %w", ed.tempSchema, describeApplyError(schemaAgnosticSQL, err))
 	}
 
 	return nil
@@ -133,9 +155,16 @@ func (ed *ExternalDatabase) ApplySchema(ctx context.Context, schema string, sql
 
 // Stop closes the connection and drops the temporary schema (best effort).
 // Errors during cleanup are logged but don't cause failures.
+// Stop is safe to call more than once, so callers can defer it and also call it explicitly.
 func (ed *ExternalDatabase) Stop() error {
-	// Drop the temporary schema (best effort - don't fail if this errors)
-	if ed.db != nil && ed.tempSchema != "" {
+	if ed.db == nil {
+		return nil
+	}
+
+	if ed.keepTempSchema {
+		fmt.Fprintf(os.Stderr, "Keeping temporary schema %s in plan database %s for debugging\n", ed.tempSchema, ed.database)
+	} else if ed.tempSchema != "" {
+		// Drop the temporary schema (best effort - don't fail if this errors)
 		ctx := context.Background()
 		dropSchemaSQL := fmt.Sprintf("DROP SCHEMA IF EXISTS \"%s\" CASCADE", ed.tempSchema)
 		// Ignore errors - this is best effort cleanup
@@ -143,11 +172,9 @@ func (ed *ExternalDatabase) Stop() error {
 	}
 
 	// Close database connection
-	if ed.db != nil {
-		return ed.db.Close()
-	}
-
-	return nil
+	db := ed.db
+	ed.db = nil
+	return db.Close()
 }
 
 // detectMajorVersion queries the database to determine its PostgreSQL major version
@@ -1,7 +1,9 @@
 package ir
 
 import (
+	"fmt"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -13,6 +15,127 @@ type IgnoreConfig struct {
 	Procedures []string `toml:"procedures,omitempty"`
 	Types      []string `toml:"types,omitempty"`
 	Sequences  []string `toml:"sequences,omitempty"`
+
+	// IncludeExtensionObjects keeps tables, views, sequences and types that belong to an extension,
+	// which are otherwise left out because CREATE EXTENSION recreates them
+	IncludeExtensionObjects bool `toml:"-"`
+
+	// compiled holds the parsed patterns of each object type once Compile has been called
+	compiled map[string][]ignorePattern
+}
+
+// patternKind identifies how an ignore pattern is matched against object names
+type patternKind int
+
+const (
+	patternGlob  patternKind = iota // Glob pattern such as temp_* (the default)
+	patternRegex                    // Regular expression written between slashes, such as /^tmp_/
+)
+
+// ignorePattern is a single parsed entry of an ignore pattern list
+type ignorePattern struct {
+	kind   patternKind
+	negate bool
+	glob   string
+	regex  *regexp.Regexp
+}
+
+// Compile parses every pattern once, so regular expressions are not recompiled for each
+// object name. It returns an error for a regular expression that does not compile.
+func (c *IgnoreConfig) Compile() error {
+	if c == nil {
+		return nil
+	}
+
+	lists := []struct {
+		objectType string
+		patterns   []string
+	}{
+		{"tables", c.Tables},
+		{"views", c.Views},
+		{"functions", c.Functions},
+		{"procedures", c.Procedures},
+		{"types", c.Types},
+		{"sequences", c.Sequences},
+	}
+
+	compiled := make(map[string][]ignorePattern, len(lists))
+	for _, list := range lists {
+		patterns, err := compileIgnorePatterns(list.objectType, list.patterns)
+		if err != nil {
+			return err
+		}
+		compiled[list.objectType] = patterns
+	}
+
+	c.compiled = compiled
+	return nil
+}
+
+// patterns returns the parsed patterns of an object type, parsing them on the fly when the
+// config was not compiled. Invalid regular expressions then fall back to a literal match.
+func (c *IgnoreConfig) patterns(objectType string, raw []string) []ignorePattern {
+	if c.compiled != nil {
+		return c.compiled[objectType]
+	}
+	var parsed []ignorePattern
+	for _, entry := range raw {
+		pattern, err := parseIgnorePattern(entry)
+		if err != nil {
+			pattern = ignorePattern{kind: patternGlob, negate: pattern.negate, glob: strings.TrimPrefix(entry, "!")}
+		}
+		parsed = append(parsed, pattern)
+	}
+	return parsed
+}
+
+// compileIgnorePatterns parses the patterns of one object type
+func compileIgnorePatterns(objectType string, patterns []string) ([]ignorePattern, error) {
+	var compiled []ignorePattern
+	for _, raw := range patterns {
+		pattern, err := parseIgnorePattern(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", objectType, raw, err)
+		}
+		compiled = append(compiled, pattern)
+	}
+	return compiled, nil
+}
+
+// parseIgnorePattern parses a single pattern. A leading ! negates the pattern, and a pattern
+// enclosed in slashes (e.g. /^tmp_/) is a regular expression instead of a glob.
+func parseIgnorePattern(raw string) (ignorePattern, error) {
+	pattern := ignorePattern{kind: patternGlob}
+	if strings.HasPrefix(raw, "!") {
+		pattern.negate = true
+		raw = raw[1:]
+	}
+
+	if len(raw) >= 2 && strings.HasPrefix(raw, "/") && strings.HasSuffix(raw, "/") {
+		regex, err := regexp.Compile(raw[1 : len(raw)-1])
+		if err != nil {
+			return pattern, err
+		}
+		pattern.kind = patternRegex
+		pattern.regex = regex
+		return pattern, nil
+	}
+
+	pattern.glob = raw
+	return pattern, nil
+}
+
+// matches reports whether the pattern matches the name, ignoring negation
+func (p ignorePattern) matches(name string) bool {
+	if p.kind == patternRegex {
+		return p.regex.MatchString(name)
+	}
+	return matchPattern(p.glob, name)
+}
+
+// ShouldIgnoreExtensionObjects reports whether objects that are members of an extension are left out
+func (c *IgnoreConfig) ShouldIgnoreExtensionObjects() bool {
+	return c == nil || !c.IncludeExtensionObjects
 }
 
 // ShouldIgnoreTable checks if a table should be ignored based on the patterns
@@ -20,7 +143,7 @@ func (c *IgnoreConfig) ShouldIgnoreTable(tableName string) bool {
 	if c == nil {
 		return false
 	}
-	return c.shouldIgnore(tableName, c.Tables)
+	return c.shouldIgnore(tableName, c.patterns("tables", c.Tables))
 }
 
 // ShouldIgnoreView checks if a view should be ignored based on the patterns
@@ -28,7 +151,7 @@ func (c *IgnoreConfig) ShouldIgnoreView(viewName string) bool {
 	if c == nil {
 		return false
 	}
-	return c.shouldIgnore(viewName, c.Views)
+	return c.shouldIgnore(viewName, c.patterns("views", c.Views))
 }
 
 // ShouldIgnoreFunction checks if a function should be ignored based on the patterns
@@ -36,7 +159,7 @@ func (c *IgnoreConfig) ShouldIgnoreFunction(functionName string) bool {
 	if c == nil {
 		return false
 	}
-	return c.shouldIgnore(functionName, c.Functions)
+	return c.shouldIgnore(functionName, c.patterns("functions", c.Functions))
 }
 
 // ShouldIgnoreProcedure checks if a procedure should be ignored based on the patterns
@@ -44,7 +167,7 @@ func (c *IgnoreConfig) ShouldIgnoreProcedure(procedureName string) bool {
 	if c == nil {
 		return false
 	}
-	return c.shouldIgnore(procedureName, c.Procedures)
+	return c.shouldIgnore(procedureName, c.patterns("procedures", c.Procedures))
 }
 
 // ShouldIgnoreType checks if a type should be ignored based on the patterns
@@ -52,7 +175,7 @@ func (c *IgnoreConfig) ShouldIgnoreType(typeName string) bool {
 	if c == nil {
 		return false
 	}
-	return c.shouldIgnore(typeName, c.Types)
+	return c.shouldIgnore(typeName, c.patterns("types", c.Types))
 }
 
 // ShouldIgnoreSequence checks if a sequence should be ignored based on the patterns
@@ -60,13 +183,13 @@ func (c *IgnoreConfig) ShouldIgnoreSequence(sequenceName string) bool {
 	if c == nil {
 		return false
 	}
-	return c.shouldIgnore(sequenceName, c.Sequences)
+	return c.shouldIgnore(sequenceName, c.patterns("sequences", c.Sequences))
 }
 
 // shouldIgnore checks if a name should be ignored based on the patterns
-// Patterns support wildcards (*) and negation (!)
+// Patterns support wildcards (*), regular expressions (/.../) and negation (!)
 // Negation patterns (starting with !) take precedence over inclusion patterns
-func (c *IgnoreConfig) shouldIgnore(name string, patterns []string) bool {
+func (c *IgnoreConfig) shouldIgnore(name string, patterns []ignorePattern) bool {
 	if len(patterns) == 0 {
 		return false
 	}
@@ -75,11 +198,11 @@ func (c *IgnoreConfig) shouldIgnore(name string, patterns []string) bool {
 
 	// First pass: check for positive matches (inclusion patterns)
 	for _, pattern := range patterns {
-		if strings.HasPrefix(pattern, "!") {
+		if pattern.negate {
 			continue // Skip negation patterns in first pass
 		}
 
-		if matchPattern(pattern, name) {
+		if pattern.matches(name) {
 			matched = true
 			break
 		}
@@ -87,12 +210,11 @@ func (c *IgnoreConfig) shouldIgnore(name string, patterns []string) bool {
 
 	// Second pass: check for negation patterns (exclusion from ignore)
 	for _, pattern := range patterns {
-		if !strings.HasPrefix(pattern, "!") {
+		if !pattern.negate {
 			continue // Skip non-negation patterns in second pass
 		}
 
-		negPattern := pattern[1:] // Remove the '!' prefix
-		if matchPattern(negPattern, name) {
+		if pattern.matches(name) {
 			// Negation pattern matches, so don't ignore this item
 			return false
 		}
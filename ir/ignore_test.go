@@ -1,6 +1,7 @@
 package ir
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -137,6 +138,60 @@ func TestIgnoreConfig_AllObjectTypes(t *testing.T) {
 	}
 }
 
+func TestIgnoreConfig_GlobAndRegexPatterns(t *testing.T) {
+	config := &IgnoreConfig{
+		Tables:    []string{"/^tmp_/", "*_audit", "!/^tmp_keep$/"},
+		Views:     []string{"/_(old|legacy)$/"},
+		Sequences: []string{"/^seq_[0-9]+$/", "*_scratch_seq"},
+	}
+	if err := config.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	tests := []struct {
+		method   func(string) bool
+		name     string
+		expected bool
+	}{
+		{config.ShouldIgnoreTable, "tmp_orders", true},
+		{config.ShouldIgnoreTable, "orders_tmp_", false},
+		{config.ShouldIgnoreTable, "orders_audit", true},
+		{config.ShouldIgnoreTable, "tmp_keep", false},
+		{config.ShouldIgnoreTable, "orders", false},
+		{config.ShouldIgnoreView, "report_old", true},
+		{config.ShouldIgnoreView, "report_legacy", true},
+		{config.ShouldIgnoreView, "old_report", false},
+		{config.ShouldIgnoreSequence, "seq_42", true},
+		{config.ShouldIgnoreSequence, "seq_abc", false},
+		{config.ShouldIgnoreSequence, "orders_scratch_seq", true},
+	}
+
+	for _, tt := range tests {
+		if result := tt.method(tt.name); result != tt.expected {
+			t.Errorf("Method returned %v for %q, want %v", result, tt.name, tt.expected)
+		}
+	}
+
+	// An uncompiled config parses its patterns on the fly with the same results
+	uncompiled := &IgnoreConfig{Tables: config.Tables}
+	if !uncompiled.ShouldIgnoreTable("tmp_orders") || uncompiled.ShouldIgnoreTable("tmp_keep") {
+		t.Error("uncompiled config should match regex patterns like a compiled one")
+	}
+}
+
+func TestIgnoreConfig_CompileInvalidRegex(t *testing.T) {
+	config := &IgnoreConfig{Views: []string{"/^report_(/"}}
+	err := config.Compile()
+	if err == nil || !strings.Contains(err.Error(), `invalid views pattern "/^report_(/"`) {
+		t.Fatalf("expected invalid regex error, got %v", err)
+	}
+
+	// Without compiling, an invalid regex falls back to a literal match
+	if !(&IgnoreConfig{Views: config.Views}).ShouldIgnoreView("/^report_(/") {
+		t.Error("invalid regex should fall back to a literal match")
+	}
+}
+
 func TestIgnoreConfig_NilConfig(t *testing.T) {
 	var config *IgnoreConfig = nil
 
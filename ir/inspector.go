@@ -37,6 +37,7 @@ type Inspector struct {
 	db           *sql.DB
 	queries      *queries.Queries
 	ignoreConfig *IgnoreConfig
+	querySlots   chan struct{} // Limits concurrent queries to the pool size; nil when the pool is unlimited
 }
 
 // NewInspector creates a new schema inspector with optional ignore configuration
@@ -51,6 +52,7 @@ func NewInspector(db *sql.DB, ignoreConfig *IgnoreConfig) *Inspector {
 // BuildIR builds the schema IR from the database for a specific schema
 func (i *Inspector) BuildIR(ctx context.Context, targetSchema string) (*IR, error) {
 	schema := NewIR()
+	i.limitConcurrencyToPool()
 
 	// Sequential prerequisites
 	if err := i.buildMetadata(ctx, schema); err != nil {
@@ -159,6 +161,15 @@ type queryGroup struct {
 	funcs []func(context.Context, *IR, string) error
 }
 
+// limitConcurrencyToPool sizes the concurrent query limit to the connection pool, so concurrent
+// groups never need more connections than the pool allows (e.g., managed databases with connection limits)
+func (i *Inspector) limitConcurrencyToPool() {
+	i.querySlots = nil
+	if maxConns := i.db.Stats().MaxOpenConnections; maxConns > 0 {
+		i.querySlots = make(chan struct{}, maxConns)
+	}
+}
+
 // executeConcurrentGroup executes a group of functions concurrently
 func (i *Inspector) executeConcurrentGroup(ctx context.Context, schema *IR, targetSchema string, group queryGroup) error {
 	var wg sync.WaitGroup
@@ -168,6 +179,10 @@ func (i *Inspector) executeConcurrentGroup(ctx context.Context, schema *IR, targ
 		wg.Add(1)
 		go func(f func(context.Context, *IR, string) error) {
 			defer wg.Done()
+			if i.querySlots != nil {
+				i.querySlots <- struct{}{}
+				defer func() { <-i.querySlots }()
+			}
 			if err := f(ctx, schema, targetSchema); err != nil {
 				errChan <- err
 			}
@@ -220,7 +235,10 @@ func (i *Inspector) buildSchemas(ctx context.Context, schema *IR, targetSchema s
 }
 
 func (i *Inspector) buildTables(ctx context.Context, schema *IR, targetSchema string) error {
-	tables, err := i.queries.GetTablesForSchema(ctx, sql.NullString{String: targetSchema, Valid: true})
+	tables, err := i.queries.GetTablesForSchema(ctx, queries.GetTablesForSchemaParams{
+		Dollar1: sql.NullString{String: targetSchema, Valid: true},
+		Column2: i.ignoreConfig.ShouldIgnoreExtensionObjects(),
+	})
 	if err != nil {
 		return err
 	}
@@ -266,6 +284,13 @@ func (i *Inspector) buildTables(ctx context.Context, schema *IR, targetSchema st
 			Indexes:     make(map[string]*Index),
 			Triggers:    make(map[string]*Trigger),
 			Policies:    make(map[string]*RLSPolicy),
+			IsUnlogged:  table.IsUnlogged,
+		}
+
+		// Storage parameters of the TOAST table are set and reported as toast.<name> on the table
+		t.StorageParameters = append(t.StorageParameters, table.TableOptions...)
+		for _, option := range table.ToastOptions {
+			t.StorageParameters = append(t.StorageParameters, "toast."+option)
 		}
 
 		dbSchema.SetTable(tableName, t)
@@ -308,13 +333,24 @@ func (i *Inspector) buildColumns(ctx context.Context, schema *IR, targetSchema s
 			DataType:   dataType,
 			IsNullable: i.safeInterfaceToString(col.IsNullable) == "YES",
 			Comment:    comment,
+			Collation:  col.CollationName.String,
+		}
+		if !column.IsNullable {
+			column.NotNullConstraintName = col.NotNullConstraintName.String
 		}
 
-		// Handle generated columns first
-		isGeneratedColumn := i.safeInterfaceToString(col.Attgenerated) == "s"
+		// Classify the column from pg_attribute rather than information_schema, which only reports
+		// identity and generation details for columns the current role owns or has privileges on.
+		// attgenerated: 's' is STORED, 'v' is VIRTUAL (PostgreSQL 18+).
+		// attidentity: 'a' is GENERATED ALWAYS, 'd' is GENERATED BY DEFAULT.
+		attgenerated := i.safeInterfaceToString(col.Attgenerated)
+		attidentity := i.safeInterfaceToString(col.Attidentity)
+		isGeneratedColumn := attgenerated == "s" || attgenerated == "v"
 		if isGeneratedColumn {
 			column.IsGenerated = true
-			if generatedExpr := i.safeInterfaceToString(col.GeneratedExpr); generatedExpr != "" {
+			column.GeneratedVirtual = attgenerated == "v"
+			if col.GeneratedExpr.Valid && col.GeneratedExpr.String != "" {
+				generatedExpr := col.GeneratedExpr.String
 				column.GeneratedExpr = &generatedExpr
 			}
 		}
@@ -345,27 +381,20 @@ func (i *Inspector) buildColumns(ctx context.Context, schema *IR, targetSchema s
 		}
 
 		// Handle identity columns
-		if i.safeInterfaceToString(col.IsIdentity) == "YES" {
+		if !isGeneratedColumn && (attidentity == "a" || attidentity == "d") {
+			generation := "ALWAYS"
+			if attidentity == "d" {
+				generation = "BY DEFAULT"
+			}
 			identity := &Identity{
-				Generation: i.safeInterfaceToString(col.IdentityGeneration),
+				Generation: generation,
 				Cycle:      i.safeInterfaceToString(col.IdentityCycle) == "YES",
 			}
 
-			if start := i.safeInterfaceToInt64(col.IdentityStart, -1); start >= 0 {
-				identity.Start = &start
-			}
-
-			if increment := i.safeInterfaceToInt64(col.IdentityIncrement, -1); increment >= 0 {
-				identity.Increment = &increment
-			}
-
-			if maximum := i.safeInterfaceToInt64(col.IdentityMaximum, -1); maximum >= 0 {
-				identity.Maximum = &maximum
-			}
-
-			if minimum := i.safeInterfaceToInt64(col.IdentityMinimum, -1); minimum >= 0 {
-				identity.Minimum = &minimum
-			}
+			identity.Start = i.safeInterfaceToInt64Ptr(col.IdentityStart)
+			identity.Increment = i.safeInterfaceToInt64Ptr(col.IdentityIncrement)
+			identity.Maximum = i.safeInterfaceToInt64Ptr(col.IdentityMaximum)
+			identity.Minimum = i.safeInterfaceToInt64Ptr(col.IdentityMinimum)
 
 			column.Identity = identity
 		}
@@ -420,9 +449,73 @@ func (i *Inspector) buildPartitions(ctx context.Context, schema *IR, targetSchem
 		table.PartitionKey = partitionKey
 	}
 
+	// Record the parent and bound of partitions in the target schema
+	children, err := i.queries.GetPartitionChildren(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if child.ChildSchema != targetSchema || !child.PartitionBound.Valid {
+			continue
+		}
+
+		dbSchema := schema.getOrCreateSchema(child.ChildSchema)
+		table, exists := dbSchema.Tables[child.ChildTable]
+		if !exists {
+			continue
+		}
+
+		bound, err := parsePartitionBound(child.PartitionBound.String)
+		if err != nil {
+			return fmt.Errorf("failed to parse partition bound of %s.%s: %w", child.ChildSchema, child.ChildTable, err)
+		}
+		bound.ParentSchema = child.ParentSchema
+		bound.ParentTable = child.ParentTable
+		table.PartitionOf = bound
+	}
+
 	return nil
 }
 
+// parsePartitionBound decodes a partition bound as returned by pg_get_expr(relpartbound), e.g.:
+//
+//	FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')
+//	FOR VALUES IN ('us', 'ca')
+//	FOR VALUES WITH (modulus 4, remainder 0)
+//	DEFAULT
+//
+// Hash bounds are decoded into their modulus and remainder since they are not value lists.
+func parsePartitionBound(expr string) (*PartitionBound, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.EqualFold(expr, "DEFAULT") {
+		return &PartitionBound{Strategy: "DEFAULT"}, nil
+	}
+
+	const prefix = "FOR VALUES "
+	if !strings.HasPrefix(strings.ToUpper(expr), prefix) {
+		return nil, fmt.Errorf("unrecognized partition bound: %s", expr)
+	}
+	values := strings.TrimSpace(expr[len(prefix):])
+	upper := strings.ToUpper(values)
+
+	switch {
+	case strings.HasPrefix(upper, "WITH"):
+		var modulus, remainder int
+		spec := strings.ToLower(strings.Join(strings.Fields(values[len("WITH"):]), " "))
+		if _, err := fmt.Sscanf(spec, "(modulus %d, remainder %d)", &modulus, &remainder); err != nil {
+			return nil, fmt.Errorf("unrecognized hash partition bound: %s", expr)
+		}
+		return &PartitionBound{Strategy: "HASH", Modulus: modulus, Remainder: remainder}, nil
+	case strings.HasPrefix(upper, "FROM"):
+		return &PartitionBound{Strategy: "RANGE", Values: values}, nil
+	case strings.HasPrefix(upper, "IN"):
+		return &PartitionBound{Strategy: "LIST", Values: values}, nil
+	default:
+		return nil, fmt.Errorf("unrecognized partition bound: %s", expr)
+	}
+}
+
 func (i *Inspector) buildConstraints(ctx context.Context, schema *IR, targetSchema string) error {
 	constraints, err := i.queries.GetConstraintsForSchema(ctx, sql.NullString{String: targetSchema, Valid: true})
 	if err != nil {
@@ -503,7 +596,18 @@ func (i *Inspector) buildConstraints(ctx context.Context, schema *IR, targetSche
 				if updateRule := i.safeInterfaceToString(constraint.UpdateRule); updateRule != "" && updateRule != "<nil>" {
 					c.UpdateRule = updateRule
 				}
-				// Handle deferrable attributes for foreign key constraints
+			}
+
+			// Handle covering (INCLUDE) columns of primary key and unique constraints
+			if (cType == ConstraintTypePrimaryKey || cType == ConstraintTypeUnique) && len(constraint.IncludeColumns) > 0 {
+				c.IncludeColumns = constraint.IncludeColumns
+			}
+
+			// Handle deferrable attributes for primary key, unique, foreign key and exclusion constraints.
+			// Exclusion constraints are emitted from their pg_get_constraintdef output, which already
+			// spells out DEFERRABLE, so for them the attributes are informational.
+			if cType == ConstraintTypePrimaryKey || cType == ConstraintTypeUnique || cType == ConstraintTypeForeignKey ||
+				cType == ConstraintTypeExclusion {
 				c.Deferrable = constraint.Deferrable
 				c.InitiallyDeferred = constraint.InitiallyDeferred
 			}
@@ -745,16 +849,22 @@ func (i *Inspector) buildIndexes(ctx context.Context, schema *IR, targetSchema s
 		}
 
 		index := &Index{
-			Schema:       schemaName,
-			Table:        tableName,
-			Name:         indexName,
-			Type:         indexType,
-			Method:       method,
-			IsPartial:    isPartial,
-			IsExpression: hasExpressions,
-			Where:        "",
-			Comment:      comment,
-			Columns:      []*IndexColumn{},
+			Schema:        schemaName,
+			Table:         tableName,
+			Name:          indexName,
+			Type:          indexType,
+			Method:        method,
+			IsPartial:     isPartial,
+			IsExpression:  hasExpressions,
+			Where:         "",
+			Comment:       comment,
+			Columns:       []*IndexColumn{},
+			IsPartitioned: indexRow.IsPartitioned,
+		}
+
+		// pg_get_indexdef() only spells out NULLS NOT DISTINCT; an explicit NULLS DISTINCT is the default
+		if isUnique && indexRow.Indexdef.Valid {
+			index.NullsNotDistinct = strings.Contains(indexRow.Indexdef.String, ") NULLS NOT DISTINCT")
 		}
 
 		// Set WHERE clause for partial indexes
@@ -786,6 +896,15 @@ func (i *Inspector) buildIndexes(ctx context.Context, schema *IR, targetSchema s
 				Direction: direction,
 				Operator:  operatorClass,
 			}
+			if idx < len(indexRow.ColumnOpclassParams) {
+				indexColumn.OperatorParams = indexRow.ColumnOpclassParams[idx]
+			}
+
+			// Only explicit statistics targets are recorded; -1 is the default
+			if idx < len(indexRow.ColumnStatistics) && indexRow.ColumnStatistics[idx] >= 0 {
+				target := int(indexRow.ColumnStatistics[idx])
+				indexColumn.StatisticsTarget = &target
+			}
 
 			index.Columns = append(index.Columns, indexColumn)
 		}
@@ -806,7 +925,10 @@ func (i *Inspector) buildIndexes(ctx context.Context, schema *IR, targetSchema s
 }
 
 func (i *Inspector) buildSequences(ctx context.Context, schema *IR, targetSchema string) error {
-	sequences, err := i.queries.GetSequencesForSchema(ctx, sql.NullString{String: targetSchema, Valid: true})
+	sequences, err := i.queries.GetSequencesForSchema(ctx, queries.GetSequencesForSchemaParams{
+		Dollar1: sql.NullString{String: targetSchema, Valid: true},
+		Column2: i.ignoreConfig.ShouldIgnoreExtensionObjects(),
+	})
 	if err != nil {
 		return err
 	}
@@ -1330,7 +1452,10 @@ func (i *Inspector) buildAggregates(ctx context.Context, schema *IR, targetSchem
 }
 
 func (i *Inspector) buildViews(ctx context.Context, schema *IR, targetSchema string) error {
-	views, err := i.queries.GetViewsForSchema(ctx, sql.NullString{String: targetSchema, Valid: true})
+	views, err := i.queries.GetViewsForSchema(ctx, queries.GetViewsForSchemaParams{
+		Dollar1: sql.NullString{String: targetSchema, Valid: true},
+		Column2: i.ignoreConfig.ShouldIgnoreExtensionObjects(),
+	})
 	if err != nil {
 		return err
 	}
@@ -1357,56 +1482,91 @@ func (i *Inspector) buildViews(ctx context.Context, schema *IR, targetSchema str
 			definition = strings.TrimSuffix(definition, ";")
 		}
 
-		// Fetch view column names from pg_attribute (ordered by attnum)
-		columns, err := i.getViewColumns(ctx, schemaName, viewName)
-		if err != nil {
-			return fmt.Errorf("failed to get columns for view %s.%s: %w", schemaName, viewName, err)
-		}
-
 		v := &View{
 			Schema:       schemaName,
 			Name:         viewName,
 			Definition:   definition,
-			Columns:      columns,
 			Comment:      comment,
 			Materialized: view.IsMaterialized.Valid && view.IsMaterialized.Bool,
 		}
 
+		// Fetch view columns from pg_attribute (ordered by attnum)
+		if err := i.buildViewColumns(ctx, v); err != nil {
+			return fmt.Errorf("failed to get columns for view %s.%s: %w", schemaName, viewName, err)
+		}
+
+		// reloptions of regular views hold check_option, security_barrier, and security_invoker
+		if !v.Materialized && len(view.ViewOptions) > 0 {
+			v.Options = view.ViewOptions
+		}
+
 		dbSchema.SetView(viewName, v)
 	}
 
 	return nil
 }
 
-// getViewColumns returns the ordered list of column names for a view or materialized view.
-// Uses pg_attribute to get column names ordered by their position (attnum).
-func (i *Inspector) getViewColumns(ctx context.Context, schemaName, viewName string) ([]string, error) {
+// buildViewColumns sets the ordered column names and types of a view or materialized view.
+// Uses pg_attribute to get columns ordered by their position (attnum). For materialized views it
+// also sets the column comments and the storage of columns whose storage differs from their type's.
+func (i *Inspector) buildViewColumns(ctx context.Context, view *View) error {
 	query := `
-		SELECT a.attname
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod),
+		       COALESCE(col_description(c.oid, a.attnum), ''),
+		       a.attstorage::text, t.typstorage::text
 		FROM pg_attribute a
 		JOIN pg_class c ON a.attrelid = c.oid
 		JOIN pg_namespace n ON c.relnamespace = n.oid
+		JOIN pg_type t ON a.atttypid = t.oid
 		WHERE n.nspname = $1
 		  AND c.relname = $2
 		  AND a.attnum > 0
 		  AND NOT a.attisdropped
 		ORDER BY a.attnum`
 
-	rows, err := i.db.QueryContext(ctx, query, schemaName, viewName)
+	rows, err := i.db.QueryContext(ctx, query, view.Schema, view.Name)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	var columns []string
 	for rows.Next() {
-		var colName string
-		if err := rows.Scan(&colName); err != nil {
-			return nil, err
+		var colName, colType, colComment, colStorage, typeStorage string
+		if err := rows.Scan(&colName, &colType, &colComment, &colStorage, &typeStorage); err != nil {
+			return err
+		}
+		view.Columns = append(view.Columns, colName)
+		view.ColumnTypes = append(view.ColumnTypes, colType)
+
+		// Column comments and storage are only diffed for materialized views
+		if !view.Materialized {
+			continue
+		}
+		if colComment != "" {
+			if view.ColumnComments == nil {
+				view.ColumnComments = make(map[string]string)
+			}
+			view.ColumnComments[colName] = colComment
+		}
+		if colStorage != typeStorage {
+			if view.ColumnStorage == nil {
+				view.ColumnStorage = make(map[string]*ViewColumnStorage)
+			}
+			view.ColumnStorage[colName] = &ViewColumnStorage{
+				Storage:     columnStorageNames[colStorage],
+				TypeStorage: columnStorageNames[typeStorage],
+			}
 		}
-		columns = append(columns, colName)
 	}
-	return columns, rows.Err()
+	return rows.Err()
+}
+
+// columnStorageNames maps pg_attribute.attstorage codes to their SET STORAGE names
+var columnStorageNames = map[string]string{
+	"p": "PLAIN",
+	"e": "EXTERNAL",
+	"m": "MAIN",
+	"x": "EXTENDED",
 }
 
 // extractWhenClauseFromTriggerDef extracts the WHEN clause from a trigger definition
@@ -1716,6 +1876,10 @@ func (i *Inspector) buildRLSPolicies(ctx context.Context, schema *IR, targetSche
 			policy.WithCheck = policyRow.WithCheck.String
 		}
 
+		if policyRow.PolicyComment.Valid {
+			policy.Comment = policyRow.PolicyComment.String
+		}
+
 		dbSchema := schema.getOrCreateSchema(schemaName)
 
 		if table, exists := dbSchema.Tables[tableName]; exists {
@@ -1727,13 +1891,19 @@ func (i *Inspector) buildRLSPolicies(ctx context.Context, schema *IR, targetSche
 }
 
 func (i *Inspector) buildTypes(ctx context.Context, schema *IR, targetSchema string) error {
-	types, err := i.queries.GetTypesForSchema(ctx, sql.NullString{String: targetSchema, Valid: true})
+	types, err := i.queries.GetTypesForSchema(ctx, queries.GetTypesForSchemaParams{
+		Dollar1: sql.NullString{String: targetSchema, Valid: true},
+		Column2: i.ignoreConfig.ShouldIgnoreExtensionObjects(),
+	})
 	if err != nil {
 		return err
 	}
 
 	// Get domains
-	domains, err := i.queries.GetDomainsForSchema(ctx, sql.NullString{String: targetSchema, Valid: true})
+	domains, err := i.queries.GetDomainsForSchema(ctx, queries.GetDomainsForSchemaParams{
+		Dollar1: sql.NullString{String: targetSchema, Valid: true},
+		Column2: i.ignoreConfig.ShouldIgnoreExtensionObjects(),
+	})
 	if err != nil {
 		return err
 	}
@@ -1877,6 +2047,9 @@ func (i *Inspector) buildTypes(ctx context.Context, schema *IR, targetSchema str
 			Default:     defaultValue,
 			Constraints: constraints,
 		}
+		if d.CollationName.Valid {
+			domainType.Collation = d.CollationName.String
+		}
 
 		dbSchema.SetType(domainName, domainType)
 	}
@@ -2284,6 +2457,17 @@ func (i *Inspector) safeInterfaceToInt64(val interface{}, defaultVal int64) int6
 	return defaultVal
 }
 
+// safeInterfaceToInt64Ptr converts an interface{} to *int64, returning nil if the value is
+// missing or not a number, so that negative values are not mistaken for missing ones.
+func (i *Inspector) safeInterfaceToInt64Ptr(val interface{}) *int64 {
+	// A value that cannot be converted yields whichever default is passed in
+	result := i.safeInterfaceToInt64(val, 0)
+	if result != i.safeInterfaceToInt64(val, 1) {
+		return nil
+	}
+	return &result
+}
+
 func (i *Inspector) safeInterfaceToBool(val interface{}, defaultVal bool) bool {
 	if val == nil {
 		return defaultVal
@@ -0,0 +1,170 @@
+package ir
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParsePartitionBound(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected PartitionBound
+	}{
+		{
+			name:     "hash remainder 0",
+			expr:     "FOR VALUES WITH (modulus 4, remainder 0)",
+			expected: PartitionBound{Strategy: "HASH", Modulus: 4, Remainder: 0},
+		},
+		{
+			name:     "hash remainder 1",
+			expr:     "FOR VALUES WITH (modulus 4, remainder 1)",
+			expected: PartitionBound{Strategy: "HASH", Modulus: 4, Remainder: 1},
+		},
+		{
+			name:     "hash remainder 2",
+			expr:     "FOR VALUES WITH (modulus 4, remainder 2)",
+			expected: PartitionBound{Strategy: "HASH", Modulus: 4, Remainder: 2},
+		},
+		{
+			name:     "hash remainder 3",
+			expr:     "FOR VALUES WITH (modulus 4, remainder 3)",
+			expected: PartitionBound{Strategy: "HASH", Modulus: 4, Remainder: 3},
+		},
+		{
+			name:     "hash upper case",
+			expr:     "FOR VALUES WITH (MODULUS 8, REMAINDER 5)",
+			expected: PartitionBound{Strategy: "HASH", Modulus: 8, Remainder: 5},
+		},
+		{
+			name:     "range",
+			expr:     "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')",
+			expected: PartitionBound{Strategy: "RANGE", Values: "FROM ('2024-01-01') TO ('2025-01-01')"},
+		},
+		{
+			name:     "list",
+			expr:     "FOR VALUES IN ('us', 'ca')",
+			expected: PartitionBound{Strategy: "LIST", Values: "IN ('us', 'ca')"},
+		},
+		{
+			name:     "default",
+			expr:     "DEFAULT",
+			expected: PartitionBound{Strategy: "DEFAULT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bound, err := parsePartitionBound(tt.expr)
+			if err != nil {
+				t.Fatalf("parsePartitionBound(%q) returned error: %v", tt.expr, err)
+			}
+			if *bound != tt.expected {
+				t.Errorf("parsePartitionBound(%q) = %+v, want %+v", tt.expr, *bound, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePartitionBound_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "FOR VALUES", "FOR VALUES WITH (modulus four, remainder 0)"} {
+		if _, err := parsePartitionBound(expr); err == nil {
+			t.Errorf("parsePartitionBound(%q) expected error", expr)
+		}
+	}
+}
+
+// countingConnector is a database/sql driver that records how many queries run at the same time
+type countingConnector struct {
+	mu        sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (c *countingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &countingConn{c}, nil
+}
+func (c *countingConnector) Driver() driver.Driver { return nil }
+
+type countingConn struct{ connector *countingConnector }
+
+func (c *countingConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *countingConn) Close() error                        { return nil }
+func (c *countingConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *countingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.connector.mu.Lock()
+	c.connector.active++
+	if c.connector.active > c.connector.maxActive {
+		c.connector.maxActive = c.connector.active
+	}
+	c.connector.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	c.connector.mu.Lock()
+	c.connector.active--
+	c.connector.mu.Unlock()
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return []string{"n"} }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+func TestExecuteConcurrentGroupRespectsPoolSize(t *testing.T) {
+	connector := &countingConnector{}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(2)
+
+	inspector := NewInspector(db, nil)
+	inspector.limitConcurrencyToPool()
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	query := func(ctx context.Context, schema *IR, targetSchema string) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		rows, err := inspector.db.QueryContext(ctx, "SELECT 1")
+		if err != nil {
+			return err
+		}
+		return rows.Close()
+	}
+
+	group := queryGroup{name: "test"}
+	for n := 0; n < 6; n++ {
+		group.funcs = append(group.funcs, query)
+	}
+	if err := inspector.executeConcurrentGroup(context.Background(), NewIR(), "public", group); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if connector.maxActive > 2 {
+		t.Errorf("expected at most 2 concurrent queries with a pool of 2, got %d", connector.maxActive)
+	}
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 concurrent build functions with a pool of 2, got %d", maxRunning)
+	}
+	if connector.maxActive < 2 {
+		t.Errorf("expected queries to run concurrently up to the pool size, got %d", connector.maxActive)
+	}
+}
@@ -1,6 +1,7 @@
 package ir
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"sync"
@@ -18,6 +19,16 @@ type Metadata struct {
 	DatabaseVersion string `json:"database_version"`
 }
 
+// MajorVersion returns the major version of the database the schema was read from
+// (e.g., 17 for "PostgreSQL 17.2"), or 0 if it is not known
+func (m Metadata) MajorVersion() int {
+	var major int
+	if _, err := fmt.Sscanf(strings.TrimPrefix(m.DatabaseVersion, "PostgreSQL "), "%d", &major); err != nil {
+		return 0
+	}
+	return major
+}
+
 // Schema represents a single database schema (namespace)
 type Schema struct {
 	Name  string `json:"name"`
@@ -44,6 +55,16 @@ type LikeClause struct {
 	Options      string `json:"options"` // e.g., "INCLUDING ALL" or "INCLUDING DEFAULTS EXCLUDING INDEXES"
 }
 
+// PartitionBound describes how a partition is attached to its partitioned parent table
+type PartitionBound struct {
+	ParentSchema string `json:"parent_schema"`
+	ParentTable  string `json:"parent_table"`
+	Strategy     string `json:"strategy"`            // RANGE, LIST, HASH, or DEFAULT for a default partition
+	Values       string `json:"values,omitempty"`    // Bound of RANGE and LIST partitions, e.g., "FROM ('2024-01-01') TO ('2025-01-01')"
+	Modulus      int    `json:"modulus,omitempty"`   // HASH partitions only
+	Remainder    int    `json:"remainder,omitempty"` // HASH partitions only
+}
+
 // Table represents a database table
 type Table struct {
 	Schema            string                 `json:"schema"`
@@ -63,6 +84,10 @@ type Table struct {
 	PartitionStrategy string                 `json:"partition_strategy,omitempty"` // RANGE, LIST, HASH
 	PartitionKey      string                 `json:"partition_key,omitempty"`      // Column(s) used for partitioning
 	LikeClauses       []LikeClause           `json:"like_clauses,omitempty"`       // LIKE clauses in CREATE TABLE
+	IsUnlogged        bool                   `json:"is_unlogged,omitempty"`        // UNLOGGED table (relpersistence = 'u')
+	PartitionOf       *PartitionBound        `json:"partition_of,omitempty"`       // Parent and bound if the table is a partition
+	StorageParameters []string               `json:"storage_parameters,omitempty"` // Sorted "key=value" reloptions; TOAST table options are prefixed with "toast."
+	Tablespace        string                 `json:"tablespace,omitempty"`         // Tablespace the table is created in; not inspected or compared
 }
 
 // Column represents a table column
@@ -79,6 +104,13 @@ type Column struct {
 	Identity       *Identity `json:"identity,omitempty"`
 	GeneratedExpr  *string   `json:"generated_expr,omitempty"`  // Expression for generated columns
 	IsGenerated    bool      `json:"is_generated,omitempty"`    // True if this is a generated column
+	Collation      string    `json:"collation,omitempty"`       // Explicit COLLATE (quoted as needed); empty for the type's default
+	// NotNullConstraintName is the explicit name of the column's NOT NULL constraint (PostgreSQL 18+);
+	// empty for nullable columns, the default <table>_<column>_not_null name, and older versions
+	NotNullConstraintName string `json:"not_null_constraint_name,omitempty"`
+	// GeneratedVirtual marks a generated column computed when read (VIRTUAL, PostgreSQL 18+)
+	// rather than stored when written (STORED)
+	GeneratedVirtual bool `json:"generated_virtual,omitempty"`
 }
 
 // Identity represents PostgreSQL identity column configuration
@@ -119,14 +151,24 @@ type TableDependency struct {
 
 // View represents a database view
 type View struct {
-	Schema       string              `json:"schema"`
-	Name         string              `json:"name"`
-	Definition   string              `json:"definition"`
-	Columns      []string            `json:"columns,omitempty"`   // Ordered list of output column names
-	Comment      string              `json:"comment,omitempty"`
-	Materialized bool                `json:"materialized,omitempty"`
-	Indexes      map[string]*Index   `json:"indexes,omitempty"`   // For materialized views only
-	Triggers     map[string]*Trigger `json:"triggers,omitempty"`  // For INSTEAD OF triggers on views
+	Schema         string                        `json:"schema"`
+	Name           string                        `json:"name"`
+	Definition     string                        `json:"definition"`
+	Columns        []string                      `json:"columns,omitempty"`      // Ordered list of output column names
+	ColumnTypes    []string                      `json:"column_types,omitempty"` // Types of the output columns, parallel to Columns
+	Comment        string                        `json:"comment,omitempty"`
+	Materialized   bool                          `json:"materialized,omitempty"`
+	Options        []string                      `json:"options,omitempty"`         // WITH (...) options of regular views, e.g., "security_barrier=true"
+	Indexes        map[string]*Index             `json:"indexes,omitempty"`         // For materialized views only
+	Triggers       map[string]*Trigger           `json:"triggers,omitempty"`        // For INSTEAD OF triggers on views
+	ColumnComments map[string]string             `json:"column_comments,omitempty"` // Column name -> comment, for materialized views only
+	ColumnStorage  map[string]*ViewColumnStorage `json:"column_storage,omitempty"`  // Column name -> storage where it differs from the type's, for materialized views only
+}
+
+// ViewColumnStorage is the storage of a materialized view column that was changed with SET STORAGE
+type ViewColumnStorage struct {
+	Storage     string `json:"storage"`      // PLAIN, EXTERNAL, MAIN, or EXTENDED
+	TypeStorage string `json:"type_storage"` // Storage of the column's type, restored when the setting is removed
 }
 
 // Function represents a database function
@@ -211,6 +253,7 @@ type Constraint struct {
 	Name                string              `json:"name"`
 	Type                ConstraintType      `json:"type"`
 	Columns             []*ConstraintColumn `json:"columns"`
+	IncludeColumns      []string            `json:"include_columns,omitempty"` // Non-key columns of PRIMARY KEY / UNIQUE (INCLUDE clause)
 	ReferencedSchema    string              `json:"referenced_schema,omitempty"`
 	ReferencedTable     string              `json:"referenced_table,omitempty"`
 	ReferencedColumns   []*ConstraintColumn `json:"referenced_columns,omitempty"`
@@ -243,16 +286,22 @@ const (
 
 // Index represents a database index
 type Index struct {
-	Schema       string         `json:"schema"`
-	Table        string         `json:"table"`
-	Name         string         `json:"name"`
-	Type         IndexType      `json:"type"`
-	Method       string         `json:"method"` // btree, hash, gin, gist, etc.
-	Columns      []*IndexColumn `json:"columns"`
-	IsPartial    bool           `json:"is_partial"`      // has a WHERE clause
-	IsExpression bool           `json:"is_expression"`   // functional/expression index
-	Where        string         `json:"where,omitempty"` // partial index condition
-	Comment      string         `json:"comment,omitempty"`
+	Schema        string         `json:"schema"`
+	Table         string         `json:"table"`
+	Name          string         `json:"name"`
+	Type          IndexType      `json:"type"`
+	Method        string         `json:"method"` // btree, hash, gin, gist, etc.
+	Columns       []*IndexColumn `json:"columns"`
+	IsPartial     bool           `json:"is_partial"`               // has a WHERE clause
+	IsExpression  bool           `json:"is_expression"`            // functional/expression index
+	Where         string         `json:"where,omitempty"`          // partial index condition
+	Comment       string         `json:"comment,omitempty"`
+	IsPartitioned bool           `json:"is_partitioned,omitempty"` // index on a partitioned table (builds indexes on all partitions)
+	Tablespace    string         `json:"tablespace,omitempty"`     // tablespace the index is created in; not inspected or compared
+
+	// NullsNotDistinct makes a unique index treat NULLs as equal (PostgreSQL 15+); the default,
+	// whether written as NULLS DISTINCT or omitted, is false
+	NullsNotDistinct bool `json:"nulls_not_distinct,omitempty"`
 }
 
 // IndexColumn represents a column within an index
@@ -261,6 +310,19 @@ type IndexColumn struct {
 	Position  int    `json:"position"`
 	Direction string `json:"direction,omitempty"` // ASC, DESC
 	Operator  string `json:"operator,omitempty"`  // operator class
+	// OperatorParams are the operator class parameters as "name=value" pairs (e.g., "siglen=32"), PostgreSQL 13+
+	OperatorParams string `json:"operator_params,omitempty"`
+
+	StatisticsTarget *int `json:"statistics_target,omitempty"` // SET STATISTICS target of an expression column; nil for the default
+}
+
+// OperatorClass returns the operator class clause of an index column, including its parameters
+// (e.g., "gist_trgm_ops (siglen=32)"), or "" for the default operator class without parameters.
+func (c *IndexColumn) OperatorClass() string {
+	if c.OperatorParams == "" {
+		return c.Operator
+	}
+	return c.Operator + " (" + c.OperatorParams + ")"
 }
 
 // IndexType represents different types of database indexes
@@ -375,6 +437,7 @@ type Type struct {
 	BaseType    string              `json:"base_type,omitempty"`   // For DOMAIN types
 	NotNull     bool                `json:"not_null,omitempty"`    // For DOMAIN types
 	Default     string              `json:"default,omitempty"`     // For DOMAIN types
+	Collation   string              `json:"collation,omitempty"`   // For DOMAIN types: explicit COLLATE (quoted as needed); empty for the base type's default
 	Constraints []*DomainConstraint `json:"constraints,omitempty"` // For DOMAIN types
 }
 
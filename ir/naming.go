@@ -0,0 +1,130 @@
+package ir
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// NamingConfig holds the regular expressions that constraint and index names must match.
+// An empty pattern disables the check for that object type.
+type NamingConfig struct {
+	PrimaryKeys       string `toml:"primary_keys,omitempty"`
+	ForeignKeys       string `toml:"foreign_keys,omitempty"`
+	UniqueConstraints string `toml:"unique_constraints,omitempty"`
+	CheckConstraints  string `toml:"check_constraints,omitempty"`
+	Exclusions        string `toml:"exclusion_constraints,omitempty"`
+	Indexes           string `toml:"indexes,omitempty"`
+}
+
+// NamingViolation describes an object whose name does not match its configured pattern
+type NamingViolation struct {
+	ObjectType string // e.g., "foreign key", "index"
+	Path       string // schema.table.name
+	Name       string
+	Pattern    string
+}
+
+// String returns a human-readable description of the violation
+func (v NamingViolation) String() string {
+	return fmt.Sprintf("%s %s does not match naming pattern %q", v.ObjectType, v.Path, v.Pattern)
+}
+
+// Validate checks that all configured patterns are valid regular expressions
+func (c *NamingConfig) Validate() error {
+	_, err := c.compile()
+	return err
+}
+
+// Lint returns the constraints and indexes in the IR whose names do not match the configured
+// patterns, sorted by path. A nil config reports no violations.
+func (c *NamingConfig) Lint(schema *IR) ([]NamingViolation, error) {
+	if c == nil || schema == nil {
+		return nil, nil
+	}
+
+	patterns, err := c.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []NamingViolation
+	check := func(objectType, schemaName, parentName, name string) {
+		re := patterns[objectType]
+		if re == nil || re.MatchString(name) {
+			return
+		}
+		violations = append(violations, NamingViolation{
+			ObjectType: objectType,
+			Path:       fmt.Sprintf("%s.%s.%s", schemaName, parentName, name),
+			Name:       name,
+			Pattern:    re.String(),
+		})
+	}
+
+	for schemaName, dbSchema := range schema.Schemas {
+		for tableName, table := range dbSchema.Tables {
+			for _, constraint := range table.Constraints {
+				check(constraintObjectType(constraint.Type), schemaName, tableName, constraint.Name)
+			}
+			for _, index := range table.Indexes {
+				check("index", schemaName, tableName, index.Name)
+			}
+		}
+		for viewName, view := range dbSchema.Views {
+			for _, index := range view.Indexes {
+				check("index", schemaName, viewName, index.Name)
+			}
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+	return violations, nil
+}
+
+// compile compiles the configured patterns, keyed by the object type reported in violations
+func (c *NamingConfig) compile() (map[string]*regexp.Regexp, error) {
+	patterns := make(map[string]*regexp.Regexp)
+	if c == nil {
+		return patterns, nil
+	}
+
+	for objectType, pattern := range map[string]string{
+		constraintObjectType(ConstraintTypePrimaryKey): c.PrimaryKeys,
+		constraintObjectType(ConstraintTypeForeignKey): c.ForeignKeys,
+		constraintObjectType(ConstraintTypeUnique):     c.UniqueConstraints,
+		constraintObjectType(ConstraintTypeCheck):      c.CheckConstraints,
+		constraintObjectType(ConstraintTypeExclusion):  c.Exclusions,
+		"index": c.Indexes,
+	} {
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s naming pattern %q: %w", objectType, pattern, err)
+		}
+		patterns[objectType] = re
+	}
+	return patterns, nil
+}
+
+// constraintObjectType returns the object type name used in naming violations for a constraint type
+func constraintObjectType(constraintType ConstraintType) string {
+	switch constraintType {
+	case ConstraintTypePrimaryKey:
+		return "primary key"
+	case ConstraintTypeForeignKey:
+		return "foreign key"
+	case ConstraintTypeUnique:
+		return "unique constraint"
+	case ConstraintTypeCheck:
+		return "check constraint"
+	case ConstraintTypeExclusion:
+		return "exclusion constraint"
+	default:
+		return "constraint"
+	}
+}
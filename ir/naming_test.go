@@ -0,0 +1,70 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+)
+
+func namingTestIR(fkName, indexName string) *IR {
+	schema := NewIR()
+	dbSchema := schema.getOrCreateSchema("public")
+	dbSchema.Tables["orders"] = &Table{
+		Schema: "public",
+		Name:   "orders",
+		Constraints: map[string]*Constraint{
+			"orders_pkey": {Schema: "public", Table: "orders", Name: "orders_pkey", Type: ConstraintTypePrimaryKey},
+			fkName:        {Schema: "public", Table: "orders", Name: fkName, Type: ConstraintTypeForeignKey},
+		},
+		Indexes: map[string]*Index{
+			indexName: {Schema: "public", Table: "orders", Name: indexName},
+		},
+	}
+	return schema
+}
+
+func TestNamingConfig_LintMatching(t *testing.T) {
+	config := &NamingConfig{ForeignKeys: "^fk_", Indexes: "^idx_"}
+
+	violations, err := config.Lint(namingTestIR("fk_orders_customer", "idx_orders_created_at"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+}
+
+func TestNamingConfig_LintNonMatching(t *testing.T) {
+	config := &NamingConfig{ForeignKeys: "^fk_", Indexes: "^idx_"}
+
+	violations, err := config.Lint(namingTestIR("orders_customer_id_fkey", "idx_orders_created_at"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %v", violations)
+	}
+
+	v := violations[0]
+	if v.ObjectType != "foreign key" || v.Path != "public.orders.orders_customer_id_fkey" || v.Pattern != "^fk_" {
+		t.Errorf("unexpected violation: %+v", v)
+	}
+	if !strings.Contains(v.String(), "public.orders.orders_customer_id_fkey") {
+		t.Errorf("expected violation message to include the object path, got %q", v.String())
+	}
+}
+
+func TestNamingConfig_InvalidPattern(t *testing.T) {
+	config := &NamingConfig{Indexes: "idx_("}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestNamingConfig_NilConfig(t *testing.T) {
+	var config *NamingConfig
+	violations, err := config.Lint(namingTestIR("orders_customer_id_fkey", "orders_idx"))
+	if err != nil || violations != nil {
+		t.Errorf("expected nil config to report nothing, got %v, %v", violations, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"unicode"
 )
 
@@ -27,6 +28,67 @@ func normalizeIR(ir *IR) {
 	}
 }
 
+// ParseSearchPath parses a search_path setting such as `"$user", public` into its schema names.
+// "$user" is dropped because the role the migration runs as is not known when planning.
+func ParseSearchPath(value string) []string {
+	var schemas []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
+			name = strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+		} else {
+			name = strings.ToLower(name)
+		}
+		if name == "" || name == "$user" {
+			continue
+		}
+		schemas = append(schemas, name)
+	}
+	return schemas
+}
+
+// NormalizeSearchPath strips qualifiers naming a schema on searchPath from column defaults, policy
+// expressions, and CHECK constraints. The inspector only strips the table's own schema, since that
+// is all pg_get_expr leaves off, so a reference to a function in another schema on the search_path
+// compares unequal depending on whether the schema file qualified it. Both states of a plan must be
+// normalized with the same search path.
+func NormalizeSearchPath(state *IR, searchPath []string) {
+	if state == nil || len(searchPath) == 0 {
+		return
+	}
+
+	for _, schema := range state.Schemas {
+		for _, table := range schema.Tables {
+			for _, column := range table.Columns {
+				if column.DefaultValue != nil {
+					value := stripSearchPathQualifiers(*column.DefaultValue, table.Schema, searchPath)
+					column.DefaultValue = &value
+				}
+			}
+			for _, policy := range table.Policies {
+				policy.Using = stripSearchPathQualifiers(policy.Using, table.Schema, searchPath)
+				policy.WithCheck = stripSearchPathQualifiers(policy.WithCheck, table.Schema, searchPath)
+			}
+			for _, constraint := range table.Constraints {
+				if constraint.Type == ConstraintTypeCheck {
+					constraint.CheckClause = stripSearchPathQualifiers(constraint.CheckClause, table.Schema, searchPath)
+				}
+			}
+		}
+	}
+}
+
+// stripSearchPathQualifiers removes the qualifiers of the search_path schemas other than the
+// object's own schema, whose qualifier normalizeIR has already removed
+func stripSearchPathQualifiers(expr, objectSchema string, searchPath []string) string {
+	for _, schema := range searchPath {
+		if schema != objectSchema {
+			expr = stripSchemaPrefixFromBody(expr, QuoteIdentifier(schema))
+		}
+	}
+	return expr
+}
+
 // normalizeSchema normalizes all objects within a schema
 func normalizeSchema(schema *Schema) {
 	if schema == nil {
@@ -70,6 +132,8 @@ func normalizeTable(table *Table) {
 		normalizeColumn(column, table.Schema)
 	}
 
+	table.StorageParameters = normalizeRelOptions(table.StorageParameters)
+
 	// Normalize policies (pass table schema for context - Issue #220)
 	for _, policy := range table.Policies {
 		normalizePolicy(policy, table.Schema)
@@ -149,6 +213,10 @@ func normalizeDefaultValue(value string, tableSchema string) string {
 			re := regexp.MustCompile(`::\Q` + tableSchema + `\E\.`)
 			value = re.ReplaceAllString(value, "::")
 		}
+		// Schemas whose names need quoting are qualified as '::"My Schema".typename'
+		if quotedSchema := QuoteIdentifier(tableSchema); tableSchema != "" && quotedSchema != tableSchema {
+			value = strings.ReplaceAll(value, "::"+quotedSchema+".", "::")
+		}
 
 		// Handle NULL::type -> NULL
 		// Example: NULL::text -> NULL
@@ -182,8 +250,11 @@ func normalizeDefaultValue(value string, tableSchema string) string {
 
 		// Handle parenthesized expressions with type casts - remove outer parentheses
 		// Example: (100)::bigint -> 100::bigint
+		// Example: (0.5)::real -> 0.5::real
+		// Negative literals keep their parentheses: -1::numeric would parse as -(1::numeric)
+		// and be stored by PostgreSQL as a unary minus expression.
 		// Pattern captures the number and the type cast separately
-		re = regexp.MustCompile(`\((\d+)\)(::(?:bigint|integer|smallint|numeric|decimal))`)
+		re = regexp.MustCompile(`\((\d+(?:\.\d+)?)\)(::(?:bigint|integer|smallint|numeric|decimal|real|double precision))`)
 		value = re.ReplaceAllString(value, "$1$2")
 	}
 
@@ -198,7 +269,7 @@ func normalizePolicy(policy *RLSPolicy, tableSchema string) {
 	}
 
 	// Normalize roles - ensure consistent ordering and case
-	policy.Roles = normalizePolicyRoles(policy.Roles)
+	policy.Roles = NormalizePolicyRoles(policy.Roles)
 
 	// Normalize expressions by removing extra whitespace
 	// For policy expressions, we want to preserve parentheses as they are part of the expected format
@@ -206,20 +277,30 @@ func normalizePolicy(policy *RLSPolicy, tableSchema string) {
 	policy.WithCheck = normalizePolicyExpression(policy.WithCheck, tableSchema)
 }
 
-// normalizePolicyRoles normalizes policy roles for consistent comparison
-func normalizePolicyRoles(roles []string) []string {
+// NormalizePolicyRoles normalizes policy roles for consistent comparison.
+// A policy without roles applies to PUBLIC, which the catalog reports as "public" (or OID 0),
+// so all of these become PUBLIC. Role keywords such as CURRENT_USER stay uppercase, other role
+// names are lowercased, and the result is sorted with duplicates removed.
+func NormalizePolicyRoles(roles []string) []string {
 	if len(roles) == 0 {
-		return roles
-	}
-
-	// Normalize role names with special handling for PUBLIC
-	normalized := make([]string, len(roles))
-	for i, role := range roles {
-		// Keep PUBLIC in uppercase, normalize others to lowercase
-		if strings.ToUpper(role) == "PUBLIC" {
-			normalized[i] = "PUBLIC"
-		} else {
-			normalized[i] = strings.ToLower(role)
+		return []string{"PUBLIC"}
+	}
+
+	seen := make(map[string]bool, len(roles))
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		role = strings.TrimSpace(role)
+		switch upper := strings.ToUpper(role); upper {
+		case "PUBLIC", "0":
+			role = "PUBLIC"
+		case "CURRENT_USER", "CURRENT_ROLE", "SESSION_USER":
+			role = upper
+		default:
+			role = strings.ToLower(role)
+		}
+		if !seen[role] {
+			seen[role] = true
+			normalized = append(normalized, role)
 		}
 	}
 
@@ -281,12 +362,53 @@ func normalizeView(view *View) {
 	// This uses the same logic as function/procedure body normalization.
 	view.Definition = stripSchemaPrefixFromBody(view.Definition, view.Schema)
 
+	view.Options = normalizeRelOptions(view.Options)
+
+	// format_type qualifies types that are not on the search_path, which differs between the
+	// temporary schema holding the desired state and the target schema
+	for idx, columnType := range view.ColumnTypes {
+		columnType = tempSchemaTypePrefixRegex.ReplaceAllString(columnType, "")
+		view.ColumnTypes[idx] = stripSchemaPrefix(columnType, view.Schema+".")
+	}
+
 	// Normalize triggers on the view (e.g., INSTEAD OF triggers)
 	for _, trigger := range view.Triggers {
 		normalizeTrigger(trigger)
 	}
 }
 
+// tempSchemaTypePrefixRegex matches the qualifier of a type in a temporary pgschema_tmp_* schema
+var tempSchemaTypePrefixRegex = regexp.MustCompile(`^pgschema_tmp_[^.]+\.`)
+
+// normalizeRelOptions lowercases and sorts reloptions (view options and table storage parameters),
+// and spells boolean values as true/false. reloptions keep the value as written, so
+// security_barrier=on and security_barrier=true must compare equal.
+func normalizeRelOptions(options []string) []string {
+	if len(options) == 0 {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(options))
+	for _, option := range options {
+		key, value, hasValue := strings.Cut(option, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch {
+		case key == "check_option":
+			// local or cascaded
+		case !hasValue:
+			value = "true"
+		case value == "on" || value == "yes" || value == "1":
+			value = "true"
+		case value == "off" || value == "no" || value == "0":
+			value = "false"
+		}
+		normalized = append(normalized, key+"="+value)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
 // normalizeFunction normalizes function signature and definition
 func normalizeFunction(function *Function) {
 	if function == nil {
@@ -295,6 +417,7 @@ func normalizeFunction(function *Function) {
 
 	// lowercase LANGUAGE plpgsql is more common in modern usage
 	function.Language = strings.ToLower(function.Language)
+	function.Volatility = normalizeVolatility(function.Volatility)
 	// Normalize return type to handle PostgreSQL-specific formats
 	function.ReturnType = normalizeFunctionReturnType(function.ReturnType)
 	// Strip current schema qualifier from return type for consistent comparison.
@@ -313,7 +436,7 @@ func normalizeFunction(function *Function) {
 			}
 			// Normalize default values (pass function schema for context)
 			if param.DefaultValue != nil {
-				normalized := normalizeDefaultValue(*param.DefaultValue, function.Schema)
+				normalized := normalizeParameterDefault(*param.DefaultValue, param.DataType, function.Schema)
 				param.DefaultValue = &normalized
 			}
 		}
@@ -324,6 +447,35 @@ func normalizeFunction(function *Function) {
 	function.Definition = stripSchemaPrefixFromBody(function.Definition, function.Schema)
 }
 
+// normalizeVolatility normalizes a function's volatility category. PostgreSQL defaults to
+// VOLATILE when no category is declared, so an unspecified volatility is treated as VOLATILE
+// to match what the catalog reports.
+func normalizeVolatility(volatility string) string {
+	volatility = strings.ToUpper(strings.TrimSpace(volatility))
+	if volatility == "" {
+		return "VOLATILE"
+	}
+	return volatility
+}
+
+// parameterLiteralCastRegex matches a numeric literal with a type cast, optionally parenthesized
+// Example: 0::numeric, (-1)::integer, (2.5)::double precision
+var parameterLiteralCastRegex = regexp.MustCompile(`^\(?(-?\d+(?:\.\d+)?)\)?::(.+)$`)
+
+// normalizeParameterDefault normalizes a routine parameter's default expression. On top of the
+// column default normalization, a numeric literal cast to the parameter's own type is reduced to
+// the bare literal, since PostgreSQL coerces the default to that type anyway.
+// Example: p numeric DEFAULT 0::numeric -> p numeric DEFAULT 0
+func normalizeParameterDefault(value, dataType, schema string) string {
+	value = normalizeDefaultValue(value, schema)
+	if match := parameterLiteralCastRegex.FindStringSubmatch(value); match != nil {
+		if normalizePostgreSQLType(match[2]) == dataType {
+			return match[1]
+		}
+	}
+	return value
+}
+
 // normalizeFunctionDefinition normalizes function body whitespace
 // PostgreSQL stores function bodies with specific whitespace that may differ from source
 func normalizeFunctionDefinition(def string) string {
@@ -438,7 +590,7 @@ func normalizeProcedure(procedure *Procedure) {
 			}
 			// Normalize default values (pass procedure schema for context)
 			if param.DefaultValue != nil {
-				normalized := normalizeDefaultValue(*param.DefaultValue, procedure.Schema)
+				normalized := normalizeParameterDefault(*param.DefaultValue, param.DataType, procedure.Schema)
 				param.DefaultValue = &normalized
 			}
 		}
@@ -643,6 +795,18 @@ func normalizeIndex(index *Index) {
 	if index.IsPartial && index.Where != "" {
 		index.Where = normalizeIndexWhereClause(index.Where)
 	}
+
+	// Strip same-schema qualifiers from expression columns, since pg_get_indexdef() qualifies
+	// functions that are not on the search_path of the inspecting session.
+	// Example: public.normalize_email(email) -> normalize_email(email) (when the table is in public)
+	if index.IsExpression {
+		for _, column := range index.Columns {
+			// Plain column references are left alone; expressions are always parenthesized or calls
+			if strings.Contains(column.Name, "(") {
+				column.Name = stripSchemaPrefixFromBody(column.Name, index.Schema)
+			}
+		}
+	}
 }
 
 // normalizeIndexWhereClause normalizes WHERE clauses in partial indexes
@@ -879,8 +1043,13 @@ var postgresTypeNormalization = map[string]string{
 	"pg_catalog.float8":  "double precision",
 	"pg_catalog.bool":    "boolean",
 	"pg_catalog.numeric": "numeric",
+	"int":                "integer",
+	"decimal":            "numeric",
 
 	// Character types
+	// udt_name "char" is the single-byte internal type, which must stay quoted: unquoted char is character(1)
+	"char":               `"char"`,
+	"pg_catalog.char":    `"char"`,
 	"bpchar":             "character",
 	"character varying":  "varchar", // Prefer short form
 	"pg_catalog.text":    "text",
@@ -891,6 +1060,7 @@ var postgresTypeNormalization = map[string]string{
 	"timestamp with time zone":    "timestamptz",
 	"timestamp without time zone": "timestamp",
 	"time with time zone":         "timetz",
+	"time without time zone":      "time",
 	"timestamptz":                 "timestamptz",
 	"timetz":                      "timetz",
 	"pg_catalog.timestamptz":      "timestamptz",
@@ -909,7 +1079,7 @@ var postgresTypeNormalization = map[string]string{
 	"_float8":      "double precision[]",
 	"_bool":        "boolean[]",
 	"_varchar":     "varchar[]", // Prefer short form
-	"_char":        "character[]",
+	"_char":        `"char"[]`,
 	"_bpchar":      "character[]",
 	"_numeric":     "numeric[]",
 	"_uuid":        "uuid[]",
@@ -936,6 +1106,7 @@ var postgresTypeNormalization = map[string]string{
 	"bool[]":        "boolean[]",
 	"varchar[]":     "varchar[]",
 	"bpchar[]":      "character[]",
+	"char[]":        `"char"[]`,
 	"numeric[]":     "numeric[]",
 	"uuid[]":        "uuid[]",
 	"json[]":        "json[]",
@@ -967,6 +1138,25 @@ var postgresTypeNormalization = map[string]string{
 	"bigserial":   "bigserial",
 }
 
+// castTypeRegex matches a "::type" cast whose type is a key of postgresTypeNormalization, followed by
+// a non-word character or the end of the expression. Longer names are tried first.
+var castTypeRegex = func() *regexp.Regexp {
+	names := make([]string, 0, len(postgresTypeNormalization))
+	for name := range postgresTypeNormalization {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	for i, name := range names {
+		names[i] = regexp.QuoteMeta(name)
+	}
+	return regexp.MustCompile(`::(` + strings.Join(names, "|") + `)(\W|$)`)
+}()
+
 // normalizePostgreSQLType normalizes PostgreSQL internal type names to standard SQL types.
 // This function handles both expressions (with type casts) and direct type names.
 func normalizePostgreSQLType(input string) string {
@@ -979,10 +1169,12 @@ func normalizePostgreSQLType(input string) string {
 		// Handle expressions with type casts
 		expr := input
 
-		// Replace PostgreSQL internal type names with standard SQL types in type casts
-		for pgType, sqlType := range postgresTypeNormalization {
-			expr = strings.ReplaceAll(expr, "::"+pgType, "::"+sqlType)
-		}
+		// Replace PostgreSQL internal type names with standard SQL types in type casts.
+		// Only whole type names are replaced, so ::boolean is not mistaken for ::bool.
+		expr = castTypeRegex.ReplaceAllStringFunc(expr, func(match string) string {
+			sub := castTypeRegex.FindStringSubmatch(match)
+			return "::" + postgresTypeNormalization[sub[1]] + sub[2]
+		})
 
 		// Handle pg_catalog prefix removal for unmapped types in type casts
 		// Look for patterns like "::pg_catalog.sometype"
@@ -998,15 +1190,50 @@ func normalizePostgreSQLType(input string) string {
 
 	// Check if we have a direct mapping
 	if normalized, exists := postgresTypeNormalization[typeName]; exists {
-		return normalized
+		return applyTypeNormalizers(normalized)
 	}
 
-	// Remove pg_catalog prefix for unmapped types
+	// Remove pg_catalog prefix, then map the bare name (e.g., pg_catalog.int4[] -> integer[])
 	if after, found := strings.CutPrefix(typeName, "pg_catalog."); found {
-		return after
+		if normalized, exists := postgresTypeNormalization[after]; exists {
+			return applyTypeNormalizers(normalized)
+		}
+		return applyTypeNormalizers(after)
 	}
 
 	// Return as-is if no mapping found
+	return applyTypeNormalizers(typeName)
+}
+
+// TypeNormalizer rewrites a type name into its canonical spelling.
+// It must return the type name unchanged for types it does not handle.
+type TypeNormalizer func(typeName string) string
+
+var (
+	typeNormalizersMu sync.RWMutex
+	typeNormalizers   []TypeNormalizer
+)
+
+// RegisterTypeNormalizer registers a normalizer for type names the built-in normalization does not
+// know about, such as extension types with custom modifiers (e.g., PostGIS geometry(Point,4326)).
+// Registered normalizers run in registration order after the built-in normalization of column,
+// parameter and return types. It is meant to be called during program initialization.
+func RegisterTypeNormalizer(normalizer TypeNormalizer) {
+	if normalizer == nil {
+		return
+	}
+	typeNormalizersMu.Lock()
+	defer typeNormalizersMu.Unlock()
+	typeNormalizers = append(typeNormalizers, normalizer)
+}
+
+// applyTypeNormalizers runs the registered type normalizers over a type name
+func applyTypeNormalizers(typeName string) string {
+	typeNormalizersMu.RLock()
+	defer typeNormalizersMu.RUnlock()
+	for _, normalizer := range typeNormalizers {
+		typeName = normalizer(typeName)
+	}
 	return typeName
 }
 
@@ -1018,18 +1245,26 @@ func normalizeConstraint(constraint *Constraint) {
 
 	// Only normalize CHECK and EXCLUDE constraints - other constraint types are already consistent
 	if constraint.Type == ConstraintTypeCheck && constraint.CheckClause != "" {
-		constraint.CheckClause = normalizeCheckClause(constraint.CheckClause)
+		// Strip same-schema qualifiers from function calls, since pg_get_constraintdef() qualifies
+		// functions that are not on the search_path of the inspecting session.
+		// Example: public.is_valid_email(email) -> is_valid_email(email) (when the table is in public)
+		clause := stripSchemaPrefixFromBody(constraint.CheckClause, constraint.Schema)
+		constraint.CheckClause = normalizeCheckClause(clause)
 	}
 	if constraint.Type == ConstraintTypeExclusion && constraint.ExclusionDefinition != "" {
-		constraint.ExclusionDefinition = normalizeExclusionDefinition(constraint.ExclusionDefinition)
+		// Like CHECK clauses, functions and operator classes in the table's own schema are qualified
+		// when that schema is not on the search_path of the inspecting session
+		definition := stripSchemaPrefixFromBody(constraint.ExclusionDefinition, constraint.Schema)
+		constraint.ExclusionDefinition = normalizeExclusionDefinition(definition)
 	}
 }
 
 // normalizeExclusionDefinition normalizes EXCLUDE constraint definitions.
 //
 // pg_get_constraintdef() returns the full definition like:
-// "EXCLUDE USING gist (range_col WITH &&)"
-// We keep it as-is since both desired and current state come from pg_get_constraintdef().
+// "EXCLUDE USING gist (range_col WITH &&) WHERE (NOT cancelled)"
+// Both desired and current state come from pg_get_constraintdef(), which already spells out the
+// access method, operators, and predicate canonically, so only surrounding whitespace is trimmed.
 func normalizeExclusionDefinition(definition string) string {
 	return strings.TrimSpace(definition)
 }
@@ -1,6 +1,8 @@
 package ir
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -196,3 +198,521 @@ func TestNormalizeCheckClause(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeDefaultValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "jsonb object literal",
+			input:    "'{}'::jsonb",
+			expected: "'{}'",
+		},
+		{
+			name:     "jsonb literal with content",
+			input:    `'{"enabled": true}'::jsonb`,
+			expected: `'{"enabled": true}'`,
+		},
+		{
+			name:     "empty text array literal",
+			input:    "'{}'::text[]",
+			expected: "'{}'",
+		},
+		{
+			name:     "empty ARRAY constructor keeps its cast",
+			input:    "ARRAY[]::text[]",
+			expected: "ARRAY[]::text[]",
+		},
+		{
+			name:     "ARRAY constructor with text elements",
+			input:    "ARRAY['a'::text, 'b'::text]",
+			expected: "ARRAY['a', 'b']",
+		},
+		{
+			name:     "parenthesized integer cast to numeric",
+			input:    "(0)::numeric",
+			expected: "0::numeric",
+		},
+		{
+			name:     "parenthesized decimal cast to real",
+			input:    "(0.5)::real",
+			expected: "0.5::real",
+		},
+		{
+			name:     "parenthesized decimal cast to double precision",
+			input:    "(1.25)::double precision",
+			expected: "1.25::double precision",
+		},
+		{
+			name:     "negative literal cast keeps parentheses",
+			input:    "('-1'::integer)::numeric",
+			expected: "(-1)::numeric",
+		},
+		{
+			name:     "quoted numeric literal",
+			input:    "'100'::bigint",
+			expected: "100",
+		},
+		{
+			name:     "interval cast is preserved",
+			input:    "'1 year'::interval",
+			expected: "'1 year'::interval",
+		},
+		{
+			name:     "same-schema enum cast is unqualified",
+			input:    "'active'::public.order_status",
+			expected: "'active'::order_status",
+		},
+		{
+			name:     "enum cast from the temporary plan schema is unqualified",
+			input:    "'active'::pgschema_tmp_20240101_120000_abcd1234.order_status",
+			expected: "'active'::order_status",
+		},
+		{
+			name:     "unqualified enum cast is unchanged",
+			input:    "'active'::order_status",
+			expected: "'active'::order_status",
+		},
+		{
+			name:     "other-schema enum cast keeps its qualifier",
+			input:    "'active'::billing.order_status",
+			expected: "'active'::billing.order_status",
+		},
+		{
+			name:     "enum array default",
+			input:    "'{active,pending}'::public.order_status[]",
+			expected: "'{active,pending}'::order_status[]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := normalizeDefaultValue(tt.input, "public")
+			if result != tt.expected {
+				t.Errorf("normalizeDefaultValue(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeRelOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{
+			name:     "no options",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "sorted by name",
+			input:    []string{"security_invoker=true", "check_option=local"},
+			expected: []string{"check_option=local", "security_invoker=true"},
+		},
+		{
+			name:     "boolean spellings",
+			input:    []string{"security_barrier=on", "security_invoker=off"},
+			expected: []string{"security_barrier=true", "security_invoker=false"},
+		},
+		{
+			name:     "check option value kept",
+			input:    []string{"check_option=CASCADED"},
+			expected: []string{"check_option=cascaded"},
+		},
+		{
+			name:     "table storage parameters",
+			input:    []string{"toast.autovacuum_enabled=off", "fillfactor=70"},
+			expected: []string{"fillfactor=70", "toast.autovacuum_enabled=false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := normalizeRelOptions(tt.input)
+			if strings.Join(result, ",") != strings.Join(tt.expected, ",") || len(result) != len(tt.expected) {
+				t.Errorf("normalizeRelOptions(%v) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizePolicyRoles(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{
+			name:     "no roles means PUBLIC",
+			input:    nil,
+			expected: []string{"PUBLIC"},
+		},
+		{
+			name:     "explicit PUBLIC from the catalog",
+			input:    []string{"public"},
+			expected: []string{"PUBLIC"},
+		},
+		{
+			name:     "PUBLIC as OID 0",
+			input:    []string{"0"},
+			expected: []string{"PUBLIC"},
+		},
+		{
+			name:     "multiple named roles",
+			input:    []string{"Reporting", "app_user", "admin", "app_user"},
+			expected: []string{"admin", "app_user", "reporting"},
+		},
+		{
+			name:     "CURRENT_USER keyword",
+			input:    []string{"current_user", "admin"},
+			expected: []string{"CURRENT_USER", "admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NormalizePolicyRoles(tt.input)
+			if strings.Join(result, ",") != strings.Join(tt.expected, ",") || len(result) != len(tt.expected) {
+				t.Errorf("NormalizePolicyRoles(%v) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegisterTypeNormalizer(t *testing.T) {
+	// Restore the registry so other tests are unaffected
+	typeNormalizersMu.Lock()
+	saved := typeNormalizers
+	typeNormalizers = nil
+	typeNormalizersMu.Unlock()
+	defer func() {
+		typeNormalizersMu.Lock()
+		typeNormalizers = saved
+		typeNormalizersMu.Unlock()
+	}()
+
+	// Normalizer for a fake extension type whose subtype modifier may be spelled in any case,
+	// similar to PostGIS geometry(Point,4326)
+	RegisterTypeNormalizer(func(typeName string) string {
+		if !strings.HasPrefix(typeName, "fakegeo(") {
+			return typeName
+		}
+		inner := strings.TrimSuffix(strings.TrimPrefix(typeName, "fakegeo("), ")")
+		parts := strings.Split(inner, ",")
+		for i, part := range parts {
+			parts[i] = strings.TrimSpace(part)
+		}
+		if len(parts) > 0 && parts[0] != "" {
+			parts[0] = strings.ToUpper(parts[0][:1]) + strings.ToLower(parts[0][1:])
+		}
+		return "fakegeo(" + strings.Join(parts, ",") + ")"
+	})
+
+	spellings := []string{"fakegeo(Point,4326)", "fakegeo(POINT, 4326)", "fakegeo(point,4326)"}
+	for _, spelling := range spellings {
+		if got := normalizePostgreSQLType(spelling); got != "fakegeo(Point,4326)" {
+			t.Errorf("normalizePostgreSQLType(%q) = %q, want %q", spelling, got, "fakegeo(Point,4326)")
+		}
+	}
+
+	// Columns inspected with different spellings compare equal, so there is no perpetual diff
+	oldColumn := &Column{Name: "location", DataType: normalizePostgreSQLType("fakegeo(POINT, 4326)")}
+	newColumn := &Column{Name: "location", DataType: normalizePostgreSQLType("fakegeo(point,4326)")}
+	if oldColumn.DataType != newColumn.DataType {
+		t.Errorf("expected equal column types, got %q and %q", oldColumn.DataType, newColumn.DataType)
+	}
+
+	// Built-in normalization still applies and other types pass through untouched
+	if got := normalizePostgreSQLType("int4"); got != "integer" {
+		t.Errorf("normalizePostgreSQLType(%q) = %q, want %q", "int4", got, "integer")
+	}
+	if got := normalizePostgreSQLType("ltree"); got != "ltree" {
+		t.Errorf("normalizePostgreSQLType(%q) = %q, want %q", "ltree", got, "ltree")
+	}
+}
+
+func TestNormalizeConstraintStripsSameSchemaFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		input    string
+		expected string
+	}{
+		{
+			name:     "same-schema function is unqualified",
+			schema:   "public",
+			input:    "CHECK (public.is_valid_email(email))",
+			expected: "CHECK (is_valid_email(email))",
+		},
+		{
+			name:     "unqualified function is unchanged",
+			schema:   "public",
+			input:    "CHECK (is_valid_email(email))",
+			expected: "CHECK (is_valid_email(email))",
+		},
+		{
+			name:     "other-schema function keeps its qualifier",
+			schema:   "public",
+			input:    "CHECK (util.is_valid_email(email))",
+			expected: "CHECK (util.is_valid_email(email))",
+		},
+		{
+			name:     "string literals are left alone",
+			schema:   "public",
+			input:    "CHECK (public.is_valid_email(email) AND email <> 'public.example'::text)",
+			expected: "CHECK (is_valid_email(email) AND email <> 'public.example'::text)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			constraint := &Constraint{Schema: tt.schema, Table: "users", Name: "users_email_check", Type: ConstraintTypeCheck, CheckClause: tt.input}
+			normalizeConstraint(constraint)
+			if constraint.CheckClause != tt.expected {
+				t.Errorf("normalizeConstraint() = %v, want %v", constraint.CheckClause, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeExclusionDefinitionStripsSameSchemaQualifiers(t *testing.T) {
+	constraint := &Constraint{
+		Schema: "app",
+		Table:  "bookings",
+		Name:   "bookings_no_overlap",
+		Type:   ConstraintTypeExclusion,
+		ExclusionDefinition: "  EXCLUDE USING gist (app.booking_period(starts, ends) WITH &&, " +
+			"room app.room_ops WITH =) WHERE ((status <> 'app.cancelled'::text))",
+	}
+	normalizeConstraint(constraint)
+
+	expected := "EXCLUDE USING gist (booking_period(starts, ends) WITH &&, room room_ops WITH =) WHERE ((status <> 'app.cancelled'::text))"
+	if constraint.ExclusionDefinition != expected {
+		t.Errorf("normalizeConstraint() = %q, want %q", constraint.ExclusionDefinition, expected)
+	}
+}
+
+func TestNormalizeDefaultValueQuotedSchemaEnum(t *testing.T) {
+	result := normalizeDefaultValue(`'active'::"Billing".order_status`, "Billing")
+	if result != "'active'::order_status" {
+		t.Errorf("normalizeDefaultValue() = %q, want %q", result, "'active'::order_status")
+	}
+}
+
+func TestNormalizeIndexWhereClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "simple comparison from pg_get_expr",
+			input:    "(status = 'active'::text)",
+			expected: "(status = 'active'::text)",
+		},
+		{
+			name:     "simple comparison without parentheses",
+			input:    "status = 'active'::text",
+			expected: "(status = 'active'::text)",
+		},
+		{
+			name:     "AND of comparisons from pg_get_expr",
+			input:    "((status = 'active'::text) AND (deleted_at IS NULL))",
+			expected: "(status = 'active'::text) AND (deleted_at IS NULL)",
+		},
+		{
+			name:     "ANY array converted to IN",
+			input:    "(status = ANY (ARRAY['a'::text, 'b'::text]))",
+			expected: "status IN ('a'::text, 'b'::text)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeIndexWhereClause(tt.input)
+			if got != tt.expected {
+				t.Errorf("normalizeIndexWhereClause(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+			// The emitted predicate is normalized again when it is read back, so it must be stable
+			if again := normalizeIndexWhereClause(got); again != got {
+				t.Errorf("normalizeIndexWhereClause is not idempotent: %q -> %q", got, again)
+			}
+		})
+	}
+}
+
+func TestNormalizeIndexExpressionColumns(t *testing.T) {
+	index := &Index{
+		Schema:       "public",
+		Table:        "users",
+		Name:         "idx_users_email",
+		IsExpression: true,
+		Columns: []*IndexColumn{
+			{Name: "public.normalize_email(email)", Position: 1},
+			{Name: "lower((email)::text)", Position: 2},
+			{Name: "((data ->> 'public.name'::text))", Position: 3},
+			{Name: `"public.id"`, Position: 4},
+		},
+	}
+
+	normalizeIndex(index)
+
+	expected := []string{"normalize_email(email)", "lower((email)::text)", "((data ->> 'public.name'::text))", `"public.id"`}
+	for i, column := range index.Columns {
+		if column.Name != expected[i] {
+			t.Errorf("column %d = %q, want %q", i+1, column.Name, expected[i])
+		}
+	}
+}
+
+func TestNormalizeParameterDefault(t *testing.T) {
+	tests := []struct {
+		value    string
+		dataType string
+		expected string
+	}{
+		{"0", "numeric", "0"},
+		{"0::numeric", "numeric", "0"},
+		{"(0)::numeric", "numeric", "0"},
+		{"(-1)::integer", "integer", "-1"},
+		{"'10'::bigint", "bigint", "10"},
+		{"2.5::double precision", "double precision", "2.5"},
+		{"0::numeric", "integer", "0::numeric"},
+		{"'1 day'::interval", "interval", "'1 day'::interval"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeParameterDefault(tt.value, tt.dataType, "public"); got != tt.expected {
+			t.Errorf("normalizeParameterDefault(%q, %q) = %q, want %q", tt.value, tt.dataType, got, tt.expected)
+		}
+	}
+}
+
+func TestNormalizeVolatility(t *testing.T) {
+	tests := map[string]string{
+		"":          "VOLATILE",
+		"VOLATILE":  "VOLATILE",
+		"stable":    "STABLE",
+		"IMMUTABLE": "IMMUTABLE",
+	}
+
+	for value, expected := range tests {
+		if got := normalizeVolatility(value); got != expected {
+			t.Errorf("normalizeVolatility(%q) = %q, want %q", value, got, expected)
+		}
+	}
+}
+
+func TestNormalizePostgreSQLTypeAliases(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"int2", "smallint"},
+		{"int4", "integer"},
+		{"int8", "bigint"},
+		{"int", "integer"},
+		{"float4", "real"},
+		{"float8", "double precision"},
+		{"bool", "boolean"},
+		{"decimal", "numeric"},
+		{"bpchar", "character"},
+		{"char", `"char"`},
+		{"char[]", `"char"[]`},
+		{"_char", `"char"[]`},
+		{"name", "name"},
+		{"oid", "oid"},
+		{"time without time zone", "time"},
+		{"timestamp without time zone", "timestamp"},
+		{"pg_catalog.int4", "integer"},
+		{"pg_catalog.int8", "bigint"},
+		{"pg_catalog.float8", "double precision"},
+		{"pg_catalog.bool", "boolean"},
+		{"pg_catalog.char", `"char"`},
+		{"pg_catalog.name", "name"},
+		{"pg_catalog.oid", "oid"},
+		{"pg_catalog.int4[]", "integer[]"},
+		{"pg_catalog.varchar", "varchar"},
+		// Casts only replace whole type names
+		{"flag::boolean", "flag::boolean"},
+		{"range::int4range", "range::int4range"},
+		{"id::int4", "id::integer"},
+		{"(id)::pg_catalog.int8", "(id)::bigint"},
+		{"ids::int4[]", "ids::integer[]"},
+		{"code::bpchar(3)", "code::character(3)"},
+		{"kind::char = 'a'::char", `kind::"char" = 'a'::"char"`},
+	}
+
+	for _, tt := range tests {
+		if got := normalizePostgreSQLType(tt.input); got != tt.expected {
+			t.Errorf("normalizePostgreSQLType(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseSearchPath(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{`"$user", public`, []string{"public"}},
+		{"app, Extensions", []string{"app", "extensions"}},
+		{`"Tenant", pg_catalog`, []string{"Tenant", "pg_catalog"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := ParseSearchPath(tt.input); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseSearchPath(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestNormalizeSearchPath(t *testing.T) {
+	buildIR := func() *IR {
+		state := NewIR()
+		schema := state.GetOrCreateSchema("app")
+		defaultValue := "extensions.uuid_generate_v4()"
+		schema.Tables["accounts"] = &Table{
+			Schema:  "app",
+			Name:    "accounts",
+			Columns: []*Column{{Name: "id", DataType: "uuid", DefaultValue: &defaultValue}},
+			Constraints: map[string]*Constraint{
+				"accounts_owner_check": {Name: "accounts_owner_check", Type: ConstraintTypeCheck, CheckClause: "CHECK (public.is_owner(owner))"},
+			},
+			Policies: map[string]*RLSPolicy{
+				"tenant_isolation": {Name: "tenant_isolation", Using: "(tenant_id = extensions.current_tenant())"},
+			},
+		}
+		return state
+	}
+
+	// With the default search path, only public references can be left unqualified
+	state := buildIR()
+	NormalizeSearchPath(state, ParseSearchPath(`"$user", public`))
+	table := state.Schemas["app"].Tables["accounts"]
+	if got := *table.Columns[0].DefaultValue; got != "extensions.uuid_generate_v4()" {
+		t.Errorf("default = %q, want the extensions qualifier kept", got)
+	}
+	if got := table.Constraints["accounts_owner_check"].CheckClause; got != "CHECK (is_owner(owner))" {
+		t.Errorf("check clause = %q, want the public qualifier stripped", got)
+	}
+
+	// A search path without public but with extensions makes the opposite references resolvable
+	state = buildIR()
+	NormalizeSearchPath(state, ParseSearchPath("app, extensions"))
+	table = state.Schemas["app"].Tables["accounts"]
+	if got := *table.Columns[0].DefaultValue; got != "uuid_generate_v4()" {
+		t.Errorf("default = %q, want the extensions qualifier stripped", got)
+	}
+	if got := table.Policies["tenant_isolation"].Using; got != "(tenant_id = current_tenant())" {
+		t.Errorf("policy expression = %q, want the extensions qualifier stripped", got)
+	}
+	if got := table.Constraints["accounts_owner_check"].CheckClause; got != "CHECK (public.is_owner(owner))" {
+		t.Errorf("check clause = %q, want the public qualifier kept", got)
+	}
+}
@@ -44,14 +44,22 @@ SELECT
     t.table_schema,
     t.table_name,
     t.table_type,
-    COALESCE(d.description, '') AS table_comment
+    COALESCE(d.description, '') AS table_comment,
+    COALESCE(c.relpersistence = 'u', false) AS is_unlogged,
+    COALESCE(c.reloptions, '{}')::text[] AS table_options,
+    COALESCE(tc.reloptions, '{}')::text[] AS toast_options
 FROM information_schema.tables t
 LEFT JOIN pg_namespace n ON n.nspname = t.table_schema
 LEFT JOIN pg_class c ON c.relname = t.table_name AND c.relnamespace = n.oid
+LEFT JOIN pg_class tc ON tc.oid = c.reltoastrelid
 LEFT JOIN pg_description d ON d.objoid = c.oid AND d.classoid = 'pg_class'::regclass AND d.objsubid = 0
 WHERE
     t.table_schema = $1
     AND t.table_type IN ('BASE TABLE', 'VIEW')
+    AND (NOT $2::boolean OR NOT EXISTS (
+        SELECT 1 FROM pg_depend dep
+        WHERE dep.classid = 'pg_class'::regclass AND dep.objid = c.oid AND dep.deptype = 'e'
+    ))  -- Exclude tables that are extension members when $2 is true
 ORDER BY t.table_name;
 
 -- GetColumns retrieves all columns for all tables
@@ -81,10 +89,13 @@ WITH column_base AS (
                 END
             WHEN dt.typtype = 'b' AND dt.typelem <> 0 THEN
                 -- Array types: apply same schema qualification logic to element type
+                -- Preserve the element typmod of extension types (e.g., geometry(Point,4326)[])
                 CASE
                     WHEN en.nspname = 'pg_catalog' THEN et.typname || '[]'
-                    WHEN en.nspname = c.table_schema THEN et.typname || '[]'
-                    ELSE en.nspname || '.' || et.typname || '[]'
+                    WHEN en.nspname = c.table_schema THEN
+                        et.typname || COALESCE(substring(format_type(a.atttypid, a.atttypmod) FROM '\([^)]*\)'), '') || '[]'
+                    ELSE
+                        en.nspname || '.' || et.typname || COALESCE(substring(format_type(a.atttypid, a.atttypmod) FROM '\([^)]*\)'), '') || '[]'
                 END
             WHEN dt.typtype = 'b' THEN
                 -- Non-array base types: qualify if not in pg_catalog or table's schema
@@ -105,7 +116,21 @@ WITH column_base AS (
         c.identity_maximum,
         c.identity_minimum,
         c.identity_cycle,
+        a.attidentity,
         a.attgenerated,
+        -- Explicit collation only: NULL when the column uses its type's default collation
+        CASE
+            WHEN a.attcollation <> 0 AND a.attcollation <> dt.typcollation THEN
+                CASE WHEN colln.nspname IN ('pg_catalog', c.table_schema) THEN quote_ident(coll.collname)
+                     ELSE quote_ident(colln.nspname) || '.' || quote_ident(coll.collname)
+                END
+        END AS collation_name,
+        -- Explicitly named NOT NULL constraint: PostgreSQL 18+ records NOT NULL constraints in
+        -- pg_constraint; NULL for the default <table>_<column>_not_null name and older versions.
+        -- Constraints inherited from a parent or partitioned table carry the parent's name.
+        (SELECT nn.conname FROM pg_constraint nn
+         WHERE nn.conrelid = cl.oid AND nn.contype = 'n' AND nn.conkey[1] = a.attnum AND nn.conislocal
+           AND nn.conname <> c.table_name || '_' || c.column_name || '_not_null') AS not_null_constraint_name,
         ad.adbin,
         ad.adrelid
     FROM information_schema.columns c
@@ -118,6 +143,8 @@ WITH column_base AS (
     LEFT JOIN pg_namespace dn ON dt.typnamespace = dn.oid
     LEFT JOIN pg_type et ON dt.typelem = et.oid
     LEFT JOIN pg_namespace en ON et.typnamespace = en.oid
+    LEFT JOIN pg_collation coll ON coll.oid = a.attcollation
+    LEFT JOIN pg_namespace colln ON colln.oid = coll.collnamespace
     WHERE
         c.table_schema NOT IN ('information_schema', 'pg_catalog', 'pg_toast')
         AND c.table_schema NOT LIKE 'pg_temp_%'
@@ -145,7 +172,10 @@ SELECT
     cb.identity_maximum,
     cb.identity_minimum,
     cb.identity_cycle,
+    cb.attidentity,
     cb.attgenerated,
+    cb.collation_name,
+    cb.not_null_constraint_name,
     -- Use LATERAL join to guarantee execution order:
     -- 1. set_config sets search_path to only the table's schema
     -- 2. pg_get_expr then uses that search_path
@@ -160,11 +190,11 @@ LEFT JOIN LATERAL (
         -- same-schema function qualifiers while preserving type qualifiers (Issue #218)
         set_config('search_path', 'pg_catalog', true) as dummy,
         CASE
-            WHEN cb.attgenerated = 's' THEN NULL  -- Generated columns don't have defaults
+            WHEN cb.attgenerated IN ('s', 'v') THEN NULL  -- Generated columns don't have defaults
             ELSE COALESCE(pg_get_expr(cb.adbin, cb.adrelid), cb.column_default)
         END as column_default,
         CASE
-            WHEN cb.attgenerated = 's' THEN pg_get_expr(cb.adbin, cb.adrelid)
+            WHEN cb.attgenerated IN ('s', 'v') THEN pg_get_expr(cb.adbin, cb.adrelid)
             ELSE NULL
         END as generated_expr
 ) ge ON true
@@ -197,10 +227,13 @@ WITH column_base AS (
                 END
             WHEN dt.typtype = 'b' AND dt.typelem <> 0 THEN
                 -- Array types: apply same schema qualification logic to element type
+                -- Preserve the element typmod of extension types (e.g., geometry(Point,4326)[])
                 CASE
                     WHEN en.nspname = 'pg_catalog' THEN et.typname || '[]'
-                    WHEN en.nspname = c.table_schema THEN et.typname || '[]'
-                    ELSE en.nspname || '.' || et.typname || '[]'
+                    WHEN en.nspname = c.table_schema THEN
+                        et.typname || COALESCE(substring(format_type(a.atttypid, a.atttypmod) FROM '\([^)]*\)'), '') || '[]'
+                    ELSE
+                        en.nspname || '.' || et.typname || COALESCE(substring(format_type(a.atttypid, a.atttypmod) FROM '\([^)]*\)'), '') || '[]'
                 END
             WHEN dt.typtype = 'b' THEN
                 -- Non-array base types: qualify if not in pg_catalog or table's schema
@@ -221,7 +254,21 @@ WITH column_base AS (
         c.identity_maximum,
         c.identity_minimum,
         c.identity_cycle,
+        a.attidentity,
         a.attgenerated,
+        -- Explicit collation only: NULL when the column uses its type's default collation
+        CASE
+            WHEN a.attcollation <> 0 AND a.attcollation <> dt.typcollation THEN
+                CASE WHEN colln.nspname IN ('pg_catalog', c.table_schema) THEN quote_ident(coll.collname)
+                     ELSE quote_ident(colln.nspname) || '.' || quote_ident(coll.collname)
+                END
+        END AS collation_name,
+        -- Explicitly named NOT NULL constraint: PostgreSQL 18+ records NOT NULL constraints in
+        -- pg_constraint; NULL for the default <table>_<column>_not_null name and older versions.
+        -- Constraints inherited from a parent or partitioned table carry the parent's name.
+        (SELECT nn.conname FROM pg_constraint nn
+         WHERE nn.conrelid = cl.oid AND nn.contype = 'n' AND nn.conkey[1] = a.attnum AND nn.conislocal
+           AND nn.conname <> c.table_name || '_' || c.column_name || '_not_null') AS not_null_constraint_name,
         ad.adbin,
         ad.adrelid,
         cl.oid AS table_oid
@@ -235,6 +282,8 @@ WITH column_base AS (
     LEFT JOIN pg_namespace dn ON dt.typnamespace = dn.oid
     LEFT JOIN pg_type et ON dt.typelem = et.oid
     LEFT JOIN pg_namespace en ON et.typnamespace = en.oid
+    LEFT JOIN pg_collation coll ON coll.oid = a.attcollation
+    LEFT JOIN pg_namespace colln ON colln.oid = coll.collnamespace
     WHERE
         c.table_schema = $1
 )
@@ -260,7 +309,10 @@ SELECT
     cb.identity_maximum,
     cb.identity_minimum,
     cb.identity_cycle,
+    cb.attidentity,
     cb.attgenerated,
+    cb.collation_name,
+    cb.not_null_constraint_name,
     -- Use LATERAL join to guarantee execution order:
     -- 1. set_config sets search_path to only pg_catalog
     -- 2. pg_get_expr then uses that search_path and includes schema qualifiers for user types
@@ -287,11 +339,11 @@ LEFT JOIN LATERAL (
         -- same-schema function qualifiers while preserving type qualifiers (Issue #218)
         set_config('search_path', 'pg_catalog', true) as dummy,
         CASE
-            WHEN cb.attgenerated = 's' THEN NULL  -- Generated columns don't have defaults
+            WHEN cb.attgenerated IN ('s', 'v') THEN NULL  -- Generated columns don't have defaults
             ELSE COALESCE(pg_get_expr(cb.adbin, cb.adrelid), cb.column_default)
         END as column_default,
         CASE
-            WHEN cb.attgenerated = 's' THEN pg_get_expr(cb.adbin, cb.adrelid)
+            WHEN cb.attgenerated IN ('s', 'v') THEN pg_get_expr(cb.adbin, cb.adrelid)
             ELSE NULL
         END as generated_expr
 ) ge ON true
@@ -337,7 +389,16 @@ SELECT
     END AS update_rule,
     c.condeferrable AS deferrable,
     c.condeferred AS initially_deferred,
-    c.convalidated AS is_valid
+    c.convalidated AS is_valid,
+    -- Non-key (INCLUDE) columns of the index backing a primary key or unique constraint
+    CASE WHEN c.contype IN ('p', 'u') THEN ARRAY(
+        SELECT ia.attname::text
+        FROM pg_index ix
+        CROSS JOIN LATERAL unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+        JOIN pg_attribute ia ON ia.attrelid = ix.indrelid AND ia.attnum = k.attnum
+        WHERE ix.indexrelid = c.conindid AND k.ord > ix.indnkeyatts
+        ORDER BY k.ord
+    ) ELSE '{}'::text[] END AS include_columns
 FROM pg_constraint c
 JOIN pg_class cl ON c.conrelid = cl.oid
 JOIN pg_namespace n ON cl.relnamespace = n.oid
@@ -348,6 +409,10 @@ LEFT JOIN pg_attribute fa ON fa.attrelid = c.confrelid AND fa.attnum = c.confkey
 WHERE n.nspname NOT IN ('information_schema', 'pg_catalog', 'pg_toast')
     AND n.nspname NOT LIKE 'pg_temp_%'
     AND n.nspname NOT LIKE 'pg_toast_temp_%'
+    -- CHECK constraints propagated from a partitioned parent belong to the parent
+    AND NOT (c.contype = 'c' AND NOT c.conislocal AND cl.relispartition)
+    -- Key and foreign key constraints cloned from a partitioned parent's constraint belong to the parent
+    AND c.conparentid = 0
 ORDER BY n.nspname, cl.relname, c.contype, c.conname, a.attnum;
 
 -- GetIndexes retrieves all indexes including regular and unique indexes created with CREATE INDEX
@@ -450,12 +515,33 @@ WITH index_base AS (
         ) as column_directions,
         ARRAY(
             SELECT CASE
-                WHEN opc.opcdefault THEN ''  -- Omit default operator classes
+                -- Omit default operator classes, unless they are needed to spell out parameters
+                WHEN opc.opcdefault AND a.attoptions IS NULL THEN ''
                 ELSE COALESCE(opc.opcname, '')
             END
             FROM generate_series(1, idx.indnatts) k
             LEFT JOIN pg_opclass opc ON opc.oid = idx.indclass[k-1]
-        ) as column_opclasses
+            LEFT JOIN pg_attribute a ON a.attrelid = idx.indexrelid AND a.attnum = k
+            ORDER BY k
+        ) as column_opclasses,
+        -- Operator class parameters (PostgreSQL 13+) are stored as the index column's attoptions
+        ARRAY(
+            SELECT COALESCE(array_to_string(a.attoptions, ', '), '')
+            FROM generate_series(1, idx.indnatts) k
+            LEFT JOIN pg_attribute a ON a.attrelid = idx.indexrelid AND a.attnum = k
+            ORDER BY k
+        ) as column_opclass_params,
+        -- Statistics targets can only be set on expression columns (indkey = 0); -1 means the default
+        ARRAY(
+            SELECT CASE
+                WHEN idx.indkey[k-1] = 0 THEN COALESCE(a.attstattarget, -1)::int8
+                ELSE -1::int8
+            END
+            FROM generate_series(1, idx.indnatts) k
+            LEFT JOIN pg_attribute a ON a.attrelid = idx.indexrelid AND a.attnum = k
+            ORDER BY k
+        ) as column_statistics,
+        (i.relkind = 'I') as is_partitioned
     FROM pg_index idx
     JOIN pg_class i ON i.oid = idx.indexrelid
     JOIN pg_class t ON t.oid = idx.indrelid
@@ -469,6 +555,8 @@ WITH index_base AS (
             WHERE c.conindid = idx.indexrelid
             AND c.contype IN ('u', 'p', 'x')
         )
+        -- Skip indexes attached to a partitioned index; they are created by the parent index
+        AND NOT i.relispartition
         AND n.nspname = $1
 )
 SELECT
@@ -490,7 +578,10 @@ SELECT
     ib.num_columns,
     ib.column_definitions,
     ib.column_directions,
-    ib.column_opclasses
+    ib.column_opclasses,
+    ib.column_opclass_params,
+    ib.column_statistics,
+    ib.is_partitioned
 FROM index_base ib
 CROSS JOIN LATERAL (
     SELECT
@@ -780,18 +871,23 @@ ORDER BY n.nspname, c.relname;
 -- GetRLSPoliciesForSchema retrieves all row level security policies for a specific schema
 -- name: GetRLSPoliciesForSchema :many
 SELECT 
-    schemaname,
-    tablename,
-    policyname,
-    permissive,
-    roles,
-    cmd,
-    qual,
-    with_check
-FROM pg_policies
+    p.schemaname,
+    p.tablename,
+    p.policyname,
+    p.permissive,
+    p.roles,
+    p.cmd,
+    p.qual,
+    p.with_check,
+    COALESCE(d.description, '') AS policy_comment
+FROM pg_policies p
+JOIN pg_namespace n ON n.nspname = p.schemaname
+JOIN pg_class c ON c.relnamespace = n.oid AND c.relname = p.tablename
+JOIN pg_policy pol ON pol.polrelid = c.oid AND pol.polname = p.policyname
+LEFT JOIN pg_description d ON d.objoid = pol.oid AND d.classoid = 'pg_policy'::regclass AND d.objsubid = 0
 WHERE 
-    schemaname = $1
-ORDER BY schemaname, tablename, policyname;
+    p.schemaname = $1
+ORDER BY p.schemaname, p.tablename, p.policyname;
 
 -- GetDomains retrieves all user-defined domains
 -- name: GetDomains :many
@@ -801,9 +897,19 @@ SELECT
     format_type(t.typbasetype, t.typtypmod) AS base_type,
     t.typnotnull AS not_null,
     t.typdefault AS default_value,
-    COALESCE(d.description, '') AS domain_comment
+    COALESCE(d.description, '') AS domain_comment,
+    -- Explicit collation only: NULL when the domain uses its base type's default collation
+    CASE
+        WHEN t.typcollation <> 0 AND t.typcollation <> bt.typcollation THEN
+            CASE WHEN colln.nspname IN ('pg_catalog', n.nspname) THEN quote_ident(coll.collname)
+                 ELSE quote_ident(colln.nspname) || '.' || quote_ident(coll.collname)
+            END
+    END AS collation_name
 FROM pg_type t
 JOIN pg_namespace n ON t.typnamespace = n.oid
+JOIN pg_type bt ON bt.oid = t.typbasetype
+LEFT JOIN pg_collation coll ON coll.oid = t.typcollation
+LEFT JOIN pg_namespace colln ON colln.oid = coll.collnamespace
 LEFT JOIN pg_description d ON d.objoid = t.oid AND d.classoid = 'pg_type'::regclass
 WHERE t.typtype = 'd'  -- Domain types only
     AND n.nspname NOT IN ('information_schema', 'pg_catalog', 'pg_toast')
@@ -913,7 +1019,16 @@ SELECT
     END AS update_rule,
     c.condeferrable AS deferrable,
     c.condeferred AS initially_deferred,
-    c.convalidated AS is_valid
+    c.convalidated AS is_valid,
+    -- Non-key (INCLUDE) columns of the index backing a primary key or unique constraint
+    CASE WHEN c.contype IN ('p', 'u') THEN ARRAY(
+        SELECT ia.attname::text
+        FROM pg_index ix
+        CROSS JOIN LATERAL unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+        JOIN pg_attribute ia ON ia.attrelid = ix.indrelid AND ia.attnum = k.attnum
+        WHERE ix.indexrelid = c.conindid AND k.ord > ix.indnkeyatts
+        ORDER BY k.ord
+    ) ELSE '{}'::text[] END AS include_columns
 FROM pg_constraint c
 JOIN pg_class cl ON c.conrelid = cl.oid
 JOIN pg_namespace n ON cl.relnamespace = n.oid
@@ -922,6 +1037,10 @@ LEFT JOIN pg_class fcl ON c.confrelid = fcl.oid
 LEFT JOIN pg_namespace fn ON fcl.relnamespace = fn.oid
 LEFT JOIN pg_attribute fa ON fa.attrelid = c.confrelid AND fa.attnum = c.confkey[array_position(c.conkey, a.attnum)]
 WHERE n.nspname = $1
+    -- CHECK constraints propagated from a partitioned parent belong to the parent
+    AND NOT (c.contype = 'c' AND NOT c.conislocal AND cl.relispartition)
+    -- Key and foreign key constraints cloned from a partitioned parent's constraint belong to the parent
+    AND c.conparentid = 0
 ORDER BY n.nspname, cl.relname, c.contype, c.conname, a.attnum;
 
 -- GetSequencesForSchema retrieves all sequences for a specific schema
@@ -959,6 +1078,10 @@ LEFT JOIN (
       AND col.column_default LIKE '%nextval%'
 ) col_table ON col_table.sequence_name = s.sequencename
 WHERE s.schemaname = $1
+    AND (NOT $2::boolean OR NOT EXISTS (
+        SELECT 1 FROM pg_depend dep
+        WHERE dep.classid = 'pg_class'::regclass AND dep.objid = c.oid AND dep.deptype = 'e'
+    ))  -- Exclude sequences that are extension members when $2 is true
 ORDER BY s.schemaname, s.sequencename;
 
 -- GetFunctionsForSchema retrieves all user-defined functions for a specific schema
@@ -1073,6 +1196,7 @@ WITH view_definitions AS (
         c.oid AS view_oid,
         COALESCE(d.description, '') AS view_comment,
         (c.relkind = 'm') AS is_materialized,
+        COALESCE(c.reloptions, '{}')::text[] AS view_options,
         n.nspname AS view_schema
     FROM pg_class c
     JOIN pg_namespace n ON c.relnamespace = n.oid
@@ -1080,6 +1204,10 @@ WITH view_definitions AS (
     WHERE
         c.relkind IN ('v', 'm') -- views and materialized views
         AND n.nspname = $1
+        AND (NOT $2::boolean OR NOT EXISTS (
+            SELECT 1 FROM pg_depend dep
+            WHERE dep.classid = 'pg_class'::regclass AND dep.objid = c.oid AND dep.deptype = 'e'
+        ))  -- Exclude views that are extension members when $2 is true
 )
 SELECT
     vd.table_schema,
@@ -1090,7 +1218,8 @@ SELECT
     -- This ensures cross-schema table references are qualified with schema names
     sp.view_def AS view_definition,
     vd.view_comment,
-    vd.is_materialized
+    vd.is_materialized,
+    vd.view_options
 FROM view_definitions vd
 CROSS JOIN LATERAL (
     SELECT
@@ -1146,6 +1275,10 @@ LEFT JOIN pg_class c ON t.typrelid = c.oid
 WHERE t.typtype IN ('e', 'c')  -- ENUM and composite types only
     AND n.nspname = $1
     AND (t.typtype = 'e' OR (t.typtype = 'c' AND c.relkind = 'c'))  -- For composite types, only include true composite types (not table types)
+    AND (NOT $2::boolean OR NOT EXISTS (
+        SELECT 1 FROM pg_depend dep
+        WHERE dep.classid = 'pg_type'::regclass AND dep.objid = t.oid AND dep.deptype = 'e'
+    ))  -- Exclude types that are extension members when $2 is true
 ORDER BY n.nspname, t.typname;
 
 -- GetDomainsForSchema retrieves all user-defined domains for a specific schema
@@ -1156,12 +1289,26 @@ SELECT
     format_type(t.typbasetype, t.typtypmod) AS base_type,
     t.typnotnull AS not_null,
     t.typdefault AS default_value,
-    COALESCE(d.description, '') AS domain_comment
+    COALESCE(d.description, '') AS domain_comment,
+    -- Explicit collation only: NULL when the domain uses its base type's default collation
+    CASE
+        WHEN t.typcollation <> 0 AND t.typcollation <> bt.typcollation THEN
+            CASE WHEN colln.nspname IN ('pg_catalog', n.nspname) THEN quote_ident(coll.collname)
+                 ELSE quote_ident(colln.nspname) || '.' || quote_ident(coll.collname)
+            END
+    END AS collation_name
 FROM pg_type t
 JOIN pg_namespace n ON t.typnamespace = n.oid
+JOIN pg_type bt ON bt.oid = t.typbasetype
+LEFT JOIN pg_collation coll ON coll.oid = t.typcollation
+LEFT JOIN pg_namespace colln ON colln.oid = coll.collnamespace
 LEFT JOIN pg_description d ON d.objoid = t.oid AND d.classoid = 'pg_type'::regclass
 WHERE t.typtype = 'd'  -- Domain types only
     AND n.nspname = $1
+    AND (NOT $2::boolean OR NOT EXISTS (
+        SELECT 1 FROM pg_depend dep
+        WHERE dep.classid = 'pg_type'::regclass AND dep.objid = t.oid AND dep.deptype = 'e'
+    ))  -- Exclude domains that are extension members when $2 is true
 ORDER BY n.nspname, t.typname;
 
 -- GetDomainConstraintsForSchema retrieves constraints for domains in a specific schema
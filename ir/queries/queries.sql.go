@@ -280,10 +280,13 @@ WITH column_base AS (
                 END
             WHEN dt.typtype = 'b' AND dt.typelem <> 0 THEN
                 -- Array types: apply same schema qualification logic to element type
+                -- Preserve the element typmod of extension types (e.g., geometry(Point,4326)[])
                 CASE
                     WHEN en.nspname = 'pg_catalog' THEN et.typname || '[]'
-                    WHEN en.nspname = c.table_schema THEN et.typname || '[]'
-                    ELSE en.nspname || '.' || et.typname || '[]'
+                    WHEN en.nspname = c.table_schema THEN
+                        et.typname || COALESCE(substring(format_type(a.atttypid, a.atttypmod) FROM '\([^)]*\)'), '') || '[]'
+                    ELSE
+                        en.nspname || '.' || et.typname || COALESCE(substring(format_type(a.atttypid, a.atttypmod) FROM '\([^)]*\)'), '') || '[]'
                 END
             WHEN dt.typtype = 'b' THEN
                 -- Non-array base types: qualify if not in pg_catalog or table's schema
@@ -304,7 +307,21 @@ WITH column_base AS (
         c.identity_maximum,
         c.identity_minimum,
         c.identity_cycle,
+        a.attidentity,
         a.attgenerated,
+        -- Explicit collation only: NULL when the column uses its type's default collation
+        CASE
+            WHEN a.attcollation <> 0 AND a.attcollation <> dt.typcollation THEN
+                CASE WHEN colln.nspname IN ('pg_catalog', c.table_schema) THEN quote_ident(coll.collname)
+                     ELSE quote_ident(colln.nspname) || '.' || quote_ident(coll.collname)
+                END
+        END AS collation_name,
+        -- Explicitly named NOT NULL constraint: PostgreSQL 18+ records NOT NULL constraints in
+        -- pg_constraint; NULL for the default <table>_<column>_not_null name and older versions.
+        -- Constraints inherited from a parent or partitioned table carry the parent's name.
+        (SELECT nn.conname FROM pg_constraint nn
+         WHERE nn.conrelid = cl.oid AND nn.contype = 'n' AND nn.conkey[1] = a.attnum AND nn.conislocal
+           AND nn.conname <> c.table_name || '_' || c.column_name || '_not_null') AS not_null_constraint_name,
         ad.adbin,
         ad.adrelid
     FROM information_schema.columns c
@@ -317,6 +334,8 @@ WITH column_base AS (
     LEFT JOIN pg_namespace dn ON dt.typnamespace = dn.oid
     LEFT JOIN pg_type et ON dt.typelem = et.oid
     LEFT JOIN pg_namespace en ON et.typnamespace = en.oid
+    LEFT JOIN pg_collation coll ON coll.oid = a.attcollation
+    LEFT JOIN pg_namespace colln ON colln.oid = coll.collnamespace
     WHERE
         c.table_schema NOT IN ('information_schema', 'pg_catalog', 'pg_toast')
         AND c.table_schema NOT LIKE 'pg_temp_%'
@@ -344,7 +363,10 @@ SELECT
     cb.identity_maximum,
     cb.identity_minimum,
     cb.identity_cycle,
+    cb.attidentity,
     cb.attgenerated,
+    cb.collation_name,
+    cb.not_null_constraint_name,
     -- Use LATERAL join to guarantee execution order:
     -- 1. set_config sets search_path to only the table's schema
     -- 2. pg_get_expr then uses that search_path
@@ -359,11 +381,11 @@ LEFT JOIN LATERAL (
         -- same-schema function qualifiers while preserving type qualifiers (Issue #218)
         set_config('search_path', 'pg_catalog', true) as dummy,
         CASE
-            WHEN cb.attgenerated = 's' THEN NULL  -- Generated columns don't have defaults
+            WHEN cb.attgenerated IN ('s', 'v') THEN NULL  -- Generated columns don't have defaults
             ELSE COALESCE(pg_get_expr(cb.adbin, cb.adrelid), cb.column_default)
         END as column_default,
         CASE
-            WHEN cb.attgenerated = 's' THEN pg_get_expr(cb.adbin, cb.adrelid)
+            WHEN cb.attgenerated IN ('s', 'v') THEN pg_get_expr(cb.adbin, cb.adrelid)
             ELSE NULL
         END as generated_expr
 ) ge ON true
@@ -391,7 +413,10 @@ type GetColumnsRow struct {
 	IdentityMaximum        interface{}    `db:"identity_maximum" json:"identity_maximum"`
 	IdentityMinimum        interface{}    `db:"identity_minimum" json:"identity_minimum"`
 	IdentityCycle          interface{}    `db:"identity_cycle" json:"identity_cycle"`
+	Attidentity            interface{}    `db:"attidentity" json:"attidentity"`
 	Attgenerated           interface{}    `db:"attgenerated" json:"attgenerated"`
+	CollationName          sql.NullString `db:"collation_name" json:"collation_name"`
+	NotNullConstraintName  sql.NullString `db:"not_null_constraint_name" json:"not_null_constraint_name"`
 	GeneratedExpr          sql.NullString `db:"generated_expr" json:"generated_expr"`
 }
 
@@ -426,7 +451,10 @@ func (q *Queries) GetColumns(ctx context.Context) ([]GetColumnsRow, error) {
 			&i.IdentityMaximum,
 			&i.IdentityMinimum,
 			&i.IdentityCycle,
+			&i.Attidentity,
 			&i.Attgenerated,
+			&i.CollationName,
+			&i.NotNullConstraintName,
 			&i.GeneratedExpr,
 		); err != nil {
 			return nil, err
@@ -468,10 +496,13 @@ WITH column_base AS (
                 END
             WHEN dt.typtype = 'b' AND dt.typelem <> 0 THEN
                 -- Array types: apply same schema qualification logic to element type
+                -- Preserve the element typmod of extension types (e.g., geometry(Point,4326)[])
                 CASE
                     WHEN en.nspname = 'pg_catalog' THEN et.typname || '[]'
-                    WHEN en.nspname = c.table_schema THEN et.typname || '[]'
-                    ELSE en.nspname || '.' || et.typname || '[]'
+                    WHEN en.nspname = c.table_schema THEN
+                        et.typname || COALESCE(substring(format_type(a.atttypid, a.atttypmod) FROM '\([^)]*\)'), '') || '[]'
+                    ELSE
+                        en.nspname || '.' || et.typname || COALESCE(substring(format_type(a.atttypid, a.atttypmod) FROM '\([^)]*\)'), '') || '[]'
                 END
             WHEN dt.typtype = 'b' THEN
                 -- Non-array base types: qualify if not in pg_catalog or table's schema
@@ -492,7 +523,21 @@ WITH column_base AS (
         c.identity_maximum,
         c.identity_minimum,
         c.identity_cycle,
+        a.attidentity,
         a.attgenerated,
+        -- Explicit collation only: NULL when the column uses its type's default collation
+        CASE
+            WHEN a.attcollation <> 0 AND a.attcollation <> dt.typcollation THEN
+                CASE WHEN colln.nspname IN ('pg_catalog', c.table_schema) THEN quote_ident(coll.collname)
+                     ELSE quote_ident(colln.nspname) || '.' || quote_ident(coll.collname)
+                END
+        END AS collation_name,
+        -- Explicitly named NOT NULL constraint: PostgreSQL 18+ records NOT NULL constraints in
+        -- pg_constraint; NULL for the default <table>_<column>_not_null name and older versions.
+        -- Constraints inherited from a parent or partitioned table carry the parent's name.
+        (SELECT nn.conname FROM pg_constraint nn
+         WHERE nn.conrelid = cl.oid AND nn.contype = 'n' AND nn.conkey[1] = a.attnum AND nn.conislocal
+           AND nn.conname <> c.table_name || '_' || c.column_name || '_not_null') AS not_null_constraint_name,
         ad.adbin,
         ad.adrelid,
         cl.oid AS table_oid
@@ -506,6 +551,8 @@ WITH column_base AS (
     LEFT JOIN pg_namespace dn ON dt.typnamespace = dn.oid
     LEFT JOIN pg_type et ON dt.typelem = et.oid
     LEFT JOIN pg_namespace en ON et.typnamespace = en.oid
+    LEFT JOIN pg_collation coll ON coll.oid = a.attcollation
+    LEFT JOIN pg_namespace colln ON colln.oid = coll.collnamespace
     WHERE
         c.table_schema = $1
 )
@@ -531,7 +578,10 @@ SELECT
     cb.identity_maximum,
     cb.identity_minimum,
     cb.identity_cycle,
+    cb.attidentity,
     cb.attgenerated,
+    cb.collation_name,
+    cb.not_null_constraint_name,
     -- Use LATERAL join to guarantee execution order:
     -- 1. set_config sets search_path to only pg_catalog
     -- 2. pg_get_expr then uses that search_path and includes schema qualifiers for user types
@@ -558,11 +608,11 @@ LEFT JOIN LATERAL (
         -- same-schema function qualifiers while preserving type qualifiers (Issue #218)
         set_config('search_path', 'pg_catalog', true) as dummy,
         CASE
-            WHEN cb.attgenerated = 's' THEN NULL  -- Generated columns don't have defaults
+            WHEN cb.attgenerated IN ('s', 'v') THEN NULL  -- Generated columns don't have defaults
             ELSE COALESCE(pg_get_expr(cb.adbin, cb.adrelid), cb.column_default)
         END as column_default,
         CASE
-            WHEN cb.attgenerated = 's' THEN pg_get_expr(cb.adbin, cb.adrelid)
+            WHEN cb.attgenerated IN ('s', 'v') THEN pg_get_expr(cb.adbin, cb.adrelid)
             ELSE NULL
         END as generated_expr
 ) ge ON true
@@ -590,7 +640,10 @@ type GetColumnsForSchemaRow struct {
 	IdentityMaximum        interface{}    `db:"identity_maximum" json:"identity_maximum"`
 	IdentityMinimum        interface{}    `db:"identity_minimum" json:"identity_minimum"`
 	IdentityCycle          interface{}    `db:"identity_cycle" json:"identity_cycle"`
+	Attidentity            interface{}    `db:"attidentity" json:"attidentity"`
 	Attgenerated           interface{}    `db:"attgenerated" json:"attgenerated"`
+	CollationName          sql.NullString `db:"collation_name" json:"collation_name"`
+	NotNullConstraintName  sql.NullString `db:"not_null_constraint_name" json:"not_null_constraint_name"`
 	GeneratedExpr          sql.NullString `db:"generated_expr" json:"generated_expr"`
 }
 
@@ -625,7 +678,10 @@ func (q *Queries) GetColumnsForSchema(ctx context.Context, tableSchema sql.NullS
 			&i.IdentityMaximum,
 			&i.IdentityMinimum,
 			&i.IdentityCycle,
+			&i.Attidentity,
 			&i.Attgenerated,
+			&i.CollationName,
+			&i.NotNullConstraintName,
 			&i.GeneratedExpr,
 		); err != nil {
 			return nil, err
@@ -796,7 +852,16 @@ SELECT
     END AS update_rule,
     c.condeferrable AS deferrable,
     c.condeferred AS initially_deferred,
-    c.convalidated AS is_valid
+    c.convalidated AS is_valid,
+    -- Non-key (INCLUDE) columns of the index backing a primary key or unique constraint
+    CASE WHEN c.contype IN ('p', 'u') THEN ARRAY(
+        SELECT ia.attname::text
+        FROM pg_index ix
+        CROSS JOIN LATERAL unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+        JOIN pg_attribute ia ON ia.attrelid = ix.indrelid AND ia.attnum = k.attnum
+        WHERE ix.indexrelid = c.conindid AND k.ord > ix.indnkeyatts
+        ORDER BY k.ord
+    ) ELSE '{}'::text[] END AS include_columns
 FROM pg_constraint c
 JOIN pg_class cl ON c.conrelid = cl.oid
 JOIN pg_namespace n ON cl.relnamespace = n.oid
@@ -807,6 +872,10 @@ LEFT JOIN pg_attribute fa ON fa.attrelid = c.confrelid AND fa.attnum = c.confkey
 WHERE n.nspname NOT IN ('information_schema', 'pg_catalog', 'pg_toast')
     AND n.nspname NOT LIKE 'pg_temp_%'
     AND n.nspname NOT LIKE 'pg_toast_temp_%'
+    -- CHECK constraints propagated from a partitioned parent belong to the parent
+    AND NOT (c.contype = 'c' AND NOT c.conislocal AND cl.relispartition)
+    -- Key and foreign key constraints cloned from a partitioned parent's constraint belong to the parent
+    AND c.conparentid = 0
 ORDER BY n.nspname, cl.relname, c.contype, c.conname, a.attnum
 `
 
@@ -828,6 +897,7 @@ type GetConstraintsRow struct {
 	Deferrable             bool           `db:"deferrable" json:"deferrable"`
 	InitiallyDeferred      bool           `db:"initially_deferred" json:"initially_deferred"`
 	IsValid                bool           `db:"is_valid" json:"is_valid"`
+	IncludeColumns         []string       `db:"include_columns" json:"include_columns"`
 }
 
 // GetConstraints retrieves all table constraints
@@ -858,6 +928,7 @@ func (q *Queries) GetConstraints(ctx context.Context) ([]GetConstraintsRow, erro
 			&i.Deferrable,
 			&i.InitiallyDeferred,
 			&i.IsValid,
+			pq.Array(&i.IncludeColumns),
 		); err != nil {
 			return nil, err
 		}
@@ -911,7 +982,16 @@ SELECT
     END AS update_rule,
     c.condeferrable AS deferrable,
     c.condeferred AS initially_deferred,
-    c.convalidated AS is_valid
+    c.convalidated AS is_valid,
+    -- Non-key (INCLUDE) columns of the index backing a primary key or unique constraint
+    CASE WHEN c.contype IN ('p', 'u') THEN ARRAY(
+        SELECT ia.attname::text
+        FROM pg_index ix
+        CROSS JOIN LATERAL unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+        JOIN pg_attribute ia ON ia.attrelid = ix.indrelid AND ia.attnum = k.attnum
+        WHERE ix.indexrelid = c.conindid AND k.ord > ix.indnkeyatts
+        ORDER BY k.ord
+    ) ELSE '{}'::text[] END AS include_columns
 FROM pg_constraint c
 JOIN pg_class cl ON c.conrelid = cl.oid
 JOIN pg_namespace n ON cl.relnamespace = n.oid
@@ -920,6 +1000,10 @@ LEFT JOIN pg_class fcl ON c.confrelid = fcl.oid
 LEFT JOIN pg_namespace fn ON fcl.relnamespace = fn.oid
 LEFT JOIN pg_attribute fa ON fa.attrelid = c.confrelid AND fa.attnum = c.confkey[array_position(c.conkey, a.attnum)]
 WHERE n.nspname = $1
+    -- CHECK constraints propagated from a partitioned parent belong to the parent
+    AND NOT (c.contype = 'c' AND NOT c.conislocal AND cl.relispartition)
+    -- Key and foreign key constraints cloned from a partitioned parent's constraint belong to the parent
+    AND c.conparentid = 0
 ORDER BY n.nspname, cl.relname, c.contype, c.conname, a.attnum
 `
 
@@ -941,6 +1025,7 @@ type GetConstraintsForSchemaRow struct {
 	Deferrable             bool           `db:"deferrable" json:"deferrable"`
 	InitiallyDeferred      bool           `db:"initially_deferred" json:"initially_deferred"`
 	IsValid                bool           `db:"is_valid" json:"is_valid"`
+	IncludeColumns         []string       `db:"include_columns" json:"include_columns"`
 }
 
 // GetConstraintsForSchema retrieves all table constraints for a specific schema
@@ -971,6 +1056,7 @@ func (q *Queries) GetConstraintsForSchema(ctx context.Context, dollar_1 sql.Null
 			&i.Deferrable,
 			&i.InitiallyDeferred,
 			&i.IsValid,
+			pq.Array(&i.IncludeColumns),
 		); err != nil {
 			return nil, err
 		}
@@ -1167,9 +1253,19 @@ SELECT
     format_type(t.typbasetype, t.typtypmod) AS base_type,
     t.typnotnull AS not_null,
     t.typdefault AS default_value,
-    COALESCE(d.description, '') AS domain_comment
+    COALESCE(d.description, '') AS domain_comment,
+    -- Explicit collation only: NULL when the domain uses its base type's default collation
+    CASE
+        WHEN t.typcollation <> 0 AND t.typcollation <> bt.typcollation THEN
+            CASE WHEN colln.nspname IN ('pg_catalog', n.nspname) THEN quote_ident(coll.collname)
+                 ELSE quote_ident(colln.nspname) || '.' || quote_ident(coll.collname)
+            END
+    END AS collation_name
 FROM pg_type t
 JOIN pg_namespace n ON t.typnamespace = n.oid
+JOIN pg_type bt ON bt.oid = t.typbasetype
+LEFT JOIN pg_collation coll ON coll.oid = t.typcollation
+LEFT JOIN pg_namespace colln ON colln.oid = coll.collnamespace
 LEFT JOIN pg_description d ON d.objoid = t.oid AND d.classoid = 'pg_type'::regclass
 WHERE t.typtype = 'd'  -- Domain types only
     AND n.nspname NOT IN ('information_schema', 'pg_catalog', 'pg_toast')
@@ -1185,6 +1281,7 @@ type GetDomainsRow struct {
 	NotNull       bool           `db:"not_null" json:"not_null"`
 	DefaultValue  sql.NullString `db:"default_value" json:"default_value"`
 	DomainComment sql.NullString `db:"domain_comment" json:"domain_comment"`
+	CollationName sql.NullString `db:"collation_name" json:"collation_name"`
 }
 
 // GetDomains retrieves all user-defined domains
@@ -1204,6 +1301,7 @@ func (q *Queries) GetDomains(ctx context.Context) ([]GetDomainsRow, error) {
 			&i.NotNull,
 			&i.DefaultValue,
 			&i.DomainComment,
+			&i.CollationName,
 		); err != nil {
 			return nil, err
 		}
@@ -1225,15 +1323,34 @@ SELECT
     format_type(t.typbasetype, t.typtypmod) AS base_type,
     t.typnotnull AS not_null,
     t.typdefault AS default_value,
-    COALESCE(d.description, '') AS domain_comment
+    COALESCE(d.description, '') AS domain_comment,
+    -- Explicit collation only: NULL when the domain uses its base type's default collation
+    CASE
+        WHEN t.typcollation <> 0 AND t.typcollation <> bt.typcollation THEN
+            CASE WHEN colln.nspname IN ('pg_catalog', n.nspname) THEN quote_ident(coll.collname)
+                 ELSE quote_ident(colln.nspname) || '.' || quote_ident(coll.collname)
+            END
+    END AS collation_name
 FROM pg_type t
 JOIN pg_namespace n ON t.typnamespace = n.oid
+JOIN pg_type bt ON bt.oid = t.typbasetype
+LEFT JOIN pg_collation coll ON coll.oid = t.typcollation
+LEFT JOIN pg_namespace colln ON colln.oid = coll.collnamespace
 LEFT JOIN pg_description d ON d.objoid = t.oid AND d.classoid = 'pg_type'::regclass
 WHERE t.typtype = 'd'  -- Domain types only
     AND n.nspname = $1
+    AND (NOT $2::boolean OR NOT EXISTS (
+        SELECT 1 FROM pg_depend dep
+        WHERE dep.classid = 'pg_type'::regclass AND dep.objid = t.oid AND dep.deptype = 'e'
+    ))  -- Exclude domains that are extension members when $2 is true
 ORDER BY n.nspname, t.typname
 `
 
+type GetDomainsForSchemaParams struct {
+	Dollar1 sql.NullString `db:"dollar_1" json:"dollar_1"`
+	Column2 bool           `db:"column_2" json:"column_2"`
+}
+
 type GetDomainsForSchemaRow struct {
 	DomainSchema  string         `db:"domain_schema" json:"domain_schema"`
 	DomainName    string         `db:"domain_name" json:"domain_name"`
@@ -1241,11 +1358,12 @@ type GetDomainsForSchemaRow struct {
 	NotNull       bool           `db:"not_null" json:"not_null"`
 	DefaultValue  sql.NullString `db:"default_value" json:"default_value"`
 	DomainComment sql.NullString `db:"domain_comment" json:"domain_comment"`
+	CollationName sql.NullString `db:"collation_name" json:"collation_name"`
 }
 
 // GetDomainsForSchema retrieves all user-defined domains for a specific schema
-func (q *Queries) GetDomainsForSchema(ctx context.Context, dollar_1 sql.NullString) ([]GetDomainsForSchemaRow, error) {
-	rows, err := q.db.QueryContext(ctx, getDomainsForSchema, dollar_1)
+func (q *Queries) GetDomainsForSchema(ctx context.Context, arg GetDomainsForSchemaParams) ([]GetDomainsForSchemaRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDomainsForSchema, arg.Dollar1, arg.Column2)
 	if err != nil {
 		return nil, err
 	}
@@ -1260,6 +1378,7 @@ func (q *Queries) GetDomainsForSchema(ctx context.Context, dollar_1 sql.NullStri
 			&i.NotNull,
 			&i.DefaultValue,
 			&i.DomainComment,
+			&i.CollationName,
 		); err != nil {
 			return nil, err
 		}
@@ -1699,12 +1818,33 @@ WITH index_base AS (
         ) as column_directions,
         ARRAY(
             SELECT CASE
-                WHEN opc.opcdefault THEN ''  -- Omit default operator classes
+                -- Omit default operator classes, unless they are needed to spell out parameters
+                WHEN opc.opcdefault AND a.attoptions IS NULL THEN ''
                 ELSE COALESCE(opc.opcname, '')
             END
             FROM generate_series(1, idx.indnatts) k
             LEFT JOIN pg_opclass opc ON opc.oid = idx.indclass[k-1]
-        ) as column_opclasses
+            LEFT JOIN pg_attribute a ON a.attrelid = idx.indexrelid AND a.attnum = k
+            ORDER BY k
+        ) as column_opclasses,
+        -- Operator class parameters (PostgreSQL 13+) are stored as the index column's attoptions
+        ARRAY(
+            SELECT COALESCE(array_to_string(a.attoptions, ', '), '')
+            FROM generate_series(1, idx.indnatts) k
+            LEFT JOIN pg_attribute a ON a.attrelid = idx.indexrelid AND a.attnum = k
+            ORDER BY k
+        ) as column_opclass_params,
+        -- Statistics targets can only be set on expression columns (indkey = 0); -1 means the default
+        ARRAY(
+            SELECT CASE
+                WHEN idx.indkey[k-1] = 0 THEN COALESCE(a.attstattarget, -1)::int8
+                ELSE -1::int8
+            END
+            FROM generate_series(1, idx.indnatts) k
+            LEFT JOIN pg_attribute a ON a.attrelid = idx.indexrelid AND a.attnum = k
+            ORDER BY k
+        ) as column_statistics,
+        (i.relkind = 'I') as is_partitioned
     FROM pg_index idx
     JOIN pg_class i ON i.oid = idx.indexrelid
     JOIN pg_class t ON t.oid = idx.indrelid
@@ -1718,6 +1858,8 @@ WITH index_base AS (
             WHERE c.conindid = idx.indexrelid
             AND c.contype IN ('u', 'p', 'x')
         )
+        -- Skip indexes attached to a partitioned index; they are created by the parent index
+        AND NOT i.relispartition
         AND n.nspname = $1
 )
 SELECT
@@ -1739,7 +1881,10 @@ SELECT
     ib.num_columns,
     ib.column_definitions,
     ib.column_directions,
-    ib.column_opclasses
+    ib.column_opclasses,
+    ib.column_opclass_params,
+    ib.column_statistics,
+    ib.is_partitioned
 FROM index_base ib
 CROSS JOIN LATERAL (
     SELECT
@@ -1753,21 +1898,24 @@ ORDER BY ib.schemaname, ib.tablename, ib.indexname
 `
 
 type GetIndexesForSchemaRow struct {
-	Schemaname        string         `db:"schemaname" json:"schemaname"`
-	Tablename         string         `db:"tablename" json:"tablename"`
-	Indexname         string         `db:"indexname" json:"indexname"`
-	IsUnique          bool           `db:"is_unique" json:"is_unique"`
-	IsPrimary         bool           `db:"is_primary" json:"is_primary"`
-	IsPartial         sql.NullBool   `db:"is_partial" json:"is_partial"`
-	Method            string         `db:"method" json:"method"`
-	Indexdef          sql.NullString `db:"indexdef" json:"indexdef"`
-	PartialPredicate  sql.NullString `db:"partial_predicate" json:"partial_predicate"`
-	HasExpressions    sql.NullBool   `db:"has_expressions" json:"has_expressions"`
-	IndexComment      sql.NullString `db:"index_comment" json:"index_comment"`
-	NumColumns        int16          `db:"num_columns" json:"num_columns"`
-	ColumnDefinitions []string       `db:"column_definitions" json:"column_definitions"`
-	ColumnDirections  []string       `db:"column_directions" json:"column_directions"`
-	ColumnOpclasses   []string       `db:"column_opclasses" json:"column_opclasses"`
+	Schemaname          string         `db:"schemaname" json:"schemaname"`
+	Tablename           string         `db:"tablename" json:"tablename"`
+	Indexname           string         `db:"indexname" json:"indexname"`
+	IsUnique            bool           `db:"is_unique" json:"is_unique"`
+	IsPrimary           bool           `db:"is_primary" json:"is_primary"`
+	IsPartial           sql.NullBool   `db:"is_partial" json:"is_partial"`
+	Method              string         `db:"method" json:"method"`
+	Indexdef            sql.NullString `db:"indexdef" json:"indexdef"`
+	PartialPredicate    sql.NullString `db:"partial_predicate" json:"partial_predicate"`
+	HasExpressions      sql.NullBool   `db:"has_expressions" json:"has_expressions"`
+	IndexComment        sql.NullString `db:"index_comment" json:"index_comment"`
+	NumColumns          int16          `db:"num_columns" json:"num_columns"`
+	ColumnDefinitions   []string       `db:"column_definitions" json:"column_definitions"`
+	ColumnDirections    []string       `db:"column_directions" json:"column_directions"`
+	ColumnOpclasses     []string       `db:"column_opclasses" json:"column_opclasses"`
+	ColumnOpclassParams []string       `db:"column_opclass_params" json:"column_opclass_params"`
+	ColumnStatistics    []int64        `db:"column_statistics" json:"column_statistics"`
+	IsPartitioned       bool           `db:"is_partitioned" json:"is_partitioned"`
 }
 
 // GetIndexesForSchema retrieves all indexes for a specific schema
@@ -1799,6 +1947,9 @@ func (q *Queries) GetIndexesForSchema(ctx context.Context, dollar_1 sql.NullStri
 			pq.Array(&i.ColumnDefinitions),
 			pq.Array(&i.ColumnDirections),
 			pq.Array(&i.ColumnOpclasses),
+			pq.Array(&i.ColumnOpclassParams),
+			pq.Array(&i.ColumnStatistics),
+			&i.IsPartitioned,
 		); err != nil {
 			return nil, err
 		}
@@ -2253,29 +2404,35 @@ func (q *Queries) GetRLSPolicies(ctx context.Context) ([]GetRLSPoliciesRow, erro
 
 const getRLSPoliciesForSchema = `-- name: GetRLSPoliciesForSchema :many
 SELECT 
-    schemaname,
-    tablename,
-    policyname,
-    permissive,
-    roles,
-    cmd,
-    qual,
-    with_check
-FROM pg_policies
+    p.schemaname,
+    p.tablename,
+    p.policyname,
+    p.permissive,
+    p.roles,
+    p.cmd,
+    p.qual,
+    p.with_check,
+    COALESCE(d.description, '') AS policy_comment
+FROM pg_policies p
+JOIN pg_namespace n ON n.nspname = p.schemaname
+JOIN pg_class c ON c.relnamespace = n.oid AND c.relname = p.tablename
+JOIN pg_policy pol ON pol.polrelid = c.oid AND pol.polname = p.policyname
+LEFT JOIN pg_description d ON d.objoid = pol.oid AND d.classoid = 'pg_policy'::regclass AND d.objsubid = 0
 WHERE 
-    schemaname = $1
-ORDER BY schemaname, tablename, policyname
+    p.schemaname = $1
+ORDER BY p.schemaname, p.tablename, p.policyname
 `
 
 type GetRLSPoliciesForSchemaRow struct {
-	Schemaname sql.NullString `db:"schemaname" json:"schemaname"`
-	Tablename  sql.NullString `db:"tablename" json:"tablename"`
-	Policyname sql.NullString `db:"policyname" json:"policyname"`
-	Permissive sql.NullString `db:"permissive" json:"permissive"`
-	Roles      []string       `db:"roles" json:"roles"`
-	Cmd        sql.NullString `db:"cmd" json:"cmd"`
-	Qual       sql.NullString `db:"qual" json:"qual"`
-	WithCheck  sql.NullString `db:"with_check" json:"with_check"`
+	Schemaname    sql.NullString `db:"schemaname" json:"schemaname"`
+	Tablename     sql.NullString `db:"tablename" json:"tablename"`
+	Policyname    sql.NullString `db:"policyname" json:"policyname"`
+	Permissive    sql.NullString `db:"permissive" json:"permissive"`
+	Roles         []string       `db:"roles" json:"roles"`
+	Cmd           sql.NullString `db:"cmd" json:"cmd"`
+	Qual          sql.NullString `db:"qual" json:"qual"`
+	WithCheck     sql.NullString `db:"with_check" json:"with_check"`
+	PolicyComment sql.NullString `db:"policy_comment" json:"policy_comment"`
 }
 
 // GetRLSPoliciesForSchema retrieves all row level security policies for a specific schema
@@ -2297,6 +2454,7 @@ func (q *Queries) GetRLSPoliciesForSchema(ctx context.Context, dollar_1 sql.Null
 			&i.Cmd,
 			&i.Qual,
 			&i.WithCheck,
+			&i.PolicyComment,
 		); err != nil {
 			return nil, err
 		}
@@ -2646,9 +2804,18 @@ LEFT JOIN (
       AND col.column_default LIKE '%nextval%'
 ) col_table ON col_table.sequence_name = s.sequencename
 WHERE s.schemaname = $1
+    AND (NOT $2::boolean OR NOT EXISTS (
+        SELECT 1 FROM pg_depend dep
+        WHERE dep.classid = 'pg_class'::regclass AND dep.objid = c.oid AND dep.deptype = 'e'
+    ))  -- Exclude sequences that are extension members when $2 is true
 ORDER BY s.schemaname, s.sequencename
 `
 
+type GetSequencesForSchemaParams struct {
+	Dollar1 sql.NullString `db:"dollar_1" json:"dollar_1"`
+	Column2 bool           `db:"column_2" json:"column_2"`
+}
+
 type GetSequencesForSchemaRow struct {
 	SequenceSchema sql.NullString `db:"sequence_schema" json:"sequence_schema"`
 	SequenceName   sql.NullString `db:"sequence_name" json:"sequence_name"`
@@ -2666,8 +2833,8 @@ type GetSequencesForSchemaRow struct {
 // GetSequencesForSchema retrieves all sequences for a specific schema
 // Method 1: Try to find dependency relationship (for proper SERIAL columns)
 // Method 2: Find sequences used in column defaults (for nextval() patterns)
-func (q *Queries) GetSequencesForSchema(ctx context.Context, dollar_1 sql.NullString) ([]GetSequencesForSchemaRow, error) {
-	rows, err := q.db.QueryContext(ctx, getSequencesForSchema, dollar_1)
+func (q *Queries) GetSequencesForSchema(ctx context.Context, arg GetSequencesForSchemaParams) ([]GetSequencesForSchemaRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSequencesForSchema, arg.Dollar1, arg.Column2)
 	if err != nil {
 		return nil, err
 	}
@@ -2760,27 +2927,43 @@ SELECT
     t.table_schema,
     t.table_name,
     t.table_type,
-    COALESCE(d.description, '') AS table_comment
+    COALESCE(d.description, '') AS table_comment,
+    COALESCE(c.relpersistence = 'u', false) AS is_unlogged,
+    COALESCE(c.reloptions, '{}')::text[] AS table_options,
+    COALESCE(tc.reloptions, '{}')::text[] AS toast_options
 FROM information_schema.tables t
 LEFT JOIN pg_namespace n ON n.nspname = t.table_schema
 LEFT JOIN pg_class c ON c.relname = t.table_name AND c.relnamespace = n.oid
+LEFT JOIN pg_class tc ON tc.oid = c.reltoastrelid
 LEFT JOIN pg_description d ON d.objoid = c.oid AND d.classoid = 'pg_class'::regclass AND d.objsubid = 0
 WHERE
     t.table_schema = $1
     AND t.table_type IN ('BASE TABLE', 'VIEW')
+    AND (NOT $2::boolean OR NOT EXISTS (
+        SELECT 1 FROM pg_depend dep
+        WHERE dep.classid = 'pg_class'::regclass AND dep.objid = c.oid AND dep.deptype = 'e'
+    ))  -- Exclude tables that are extension members when $2 is true
 ORDER BY t.table_name
 `
 
+type GetTablesForSchemaParams struct {
+	Dollar1 sql.NullString `db:"dollar_1" json:"dollar_1"`
+	Column2 bool           `db:"column_2" json:"column_2"`
+}
+
 type GetTablesForSchemaRow struct {
 	TableSchema  interface{}    `db:"table_schema" json:"table_schema"`
 	TableName    interface{}    `db:"table_name" json:"table_name"`
 	TableType    interface{}    `db:"table_type" json:"table_type"`
 	TableComment sql.NullString `db:"table_comment" json:"table_comment"`
+	IsUnlogged   bool           `db:"is_unlogged" json:"is_unlogged"`
+	TableOptions []string       `db:"table_options" json:"table_options"`
+	ToastOptions []string       `db:"toast_options" json:"toast_options"`
 }
 
 // GetTablesForSchema retrieves all tables in a specific schema with metadata
-func (q *Queries) GetTablesForSchema(ctx context.Context, dollar_1 sql.NullString) ([]GetTablesForSchemaRow, error) {
-	rows, err := q.db.QueryContext(ctx, getTablesForSchema, dollar_1)
+func (q *Queries) GetTablesForSchema(ctx context.Context, arg GetTablesForSchemaParams) ([]GetTablesForSchemaRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTablesForSchema, arg.Dollar1, arg.Column2)
 	if err != nil {
 		return nil, err
 	}
@@ -2793,6 +2976,9 @@ func (q *Queries) GetTablesForSchema(ctx context.Context, dollar_1 sql.NullStrin
 			&i.TableName,
 			&i.TableType,
 			&i.TableComment,
+			&i.IsUnlogged,
+			pq.Array(&i.TableOptions),
+			pq.Array(&i.ToastOptions),
 		); err != nil {
 			return nil, err
 		}
@@ -3029,9 +3215,18 @@ LEFT JOIN pg_class c ON t.typrelid = c.oid
 WHERE t.typtype IN ('e', 'c')  -- ENUM and composite types only
     AND n.nspname = $1
     AND (t.typtype = 'e' OR (t.typtype = 'c' AND c.relkind = 'c'))  -- For composite types, only include true composite types (not table types)
+    AND (NOT $2::boolean OR NOT EXISTS (
+        SELECT 1 FROM pg_depend dep
+        WHERE dep.classid = 'pg_type'::regclass AND dep.objid = t.oid AND dep.deptype = 'e'
+    ))  -- Exclude types that are extension members when $2 is true
 ORDER BY n.nspname, t.typname
 `
 
+type GetTypesForSchemaParams struct {
+	Dollar1 sql.NullString `db:"dollar_1" json:"dollar_1"`
+	Column2 bool           `db:"column_2" json:"column_2"`
+}
+
 type GetTypesForSchemaRow struct {
 	TypeSchema  string         `db:"type_schema" json:"type_schema"`
 	TypeName    string         `db:"type_name" json:"type_name"`
@@ -3040,8 +3235,8 @@ type GetTypesForSchemaRow struct {
 }
 
 // GetTypesForSchema retrieves all user-defined types for a specific schema
-func (q *Queries) GetTypesForSchema(ctx context.Context, dollar_1 sql.NullString) ([]GetTypesForSchemaRow, error) {
-	rows, err := q.db.QueryContext(ctx, getTypesForSchema, dollar_1)
+func (q *Queries) GetTypesForSchema(ctx context.Context, arg GetTypesForSchemaParams) ([]GetTypesForSchemaRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTypesForSchema, arg.Dollar1, arg.Column2)
 	if err != nil {
 		return nil, err
 	}
@@ -3185,6 +3380,7 @@ WITH view_definitions AS (
         c.oid AS view_oid,
         COALESCE(d.description, '') AS view_comment,
         (c.relkind = 'm') AS is_materialized,
+        COALESCE(c.reloptions, '{}')::text[] AS view_options,
         n.nspname AS view_schema
     FROM pg_class c
     JOIN pg_namespace n ON c.relnamespace = n.oid
@@ -3192,6 +3388,10 @@ WITH view_definitions AS (
     WHERE
         c.relkind IN ('v', 'm') -- views and materialized views
         AND n.nspname = $1
+        AND (NOT $2::boolean OR NOT EXISTS (
+            SELECT 1 FROM pg_depend dep
+            WHERE dep.classid = 'pg_class'::regclass AND dep.objid = c.oid AND dep.deptype = 'e'
+        ))  -- Exclude views that are extension members when $2 is true
 )
 SELECT
     vd.table_schema,
@@ -3202,7 +3402,8 @@ SELECT
     -- This ensures cross-schema table references are qualified with schema names
     sp.view_def AS view_definition,
     vd.view_comment,
-    vd.is_materialized
+    vd.is_materialized,
+    vd.view_options
 FROM view_definitions vd
 CROSS JOIN LATERAL (
     SELECT
@@ -3212,20 +3413,26 @@ CROSS JOIN LATERAL (
 ORDER BY vd.table_schema, vd.table_name
 `
 
+type GetViewsForSchemaParams struct {
+	Dollar1 sql.NullString `db:"dollar_1" json:"dollar_1"`
+	Column2 bool           `db:"column_2" json:"column_2"`
+}
+
 type GetViewsForSchemaRow struct {
 	TableSchema    string         `db:"table_schema" json:"table_schema"`
 	TableName      string         `db:"table_name" json:"table_name"`
 	ViewDefinition sql.NullString `db:"view_definition" json:"view_definition"`
 	ViewComment    sql.NullString `db:"view_comment" json:"view_comment"`
 	IsMaterialized sql.NullBool   `db:"is_materialized" json:"is_materialized"`
+	ViewOptions    []string       `db:"view_options" json:"view_options"`
 }
 
 // GetViewsForSchema retrieves all views and materialized views for a specific schema
 // IMPORTANT: Uses LATERAL join with set_config to temporarily set search_path to only the view's schema
 // This ensures pg_get_viewdef() includes schema qualifiers for cross-schema references
 // The LATERAL join guarantees set_config executes before pg_get_viewdef in the same row context
-func (q *Queries) GetViewsForSchema(ctx context.Context, dollar_1 sql.NullString) ([]GetViewsForSchemaRow, error) {
-	rows, err := q.db.QueryContext(ctx, getViewsForSchema, dollar_1)
+func (q *Queries) GetViewsForSchema(ctx context.Context, arg GetViewsForSchemaParams) ([]GetViewsForSchemaRow, error) {
+	rows, err := q.db.QueryContext(ctx, getViewsForSchema, arg.Dollar1, arg.Column2)
 	if err != nil {
 		return nil, err
 	}
@@ -3239,6 +3446,7 @@ func (q *Queries) GetViewsForSchema(ctx context.Context, dollar_1 sql.NullString
 			&i.ViewDefinition,
 			&i.ViewComment,
 			&i.IsMaterialized,
+			pq.Array(&i.ViewOptions),
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,118 @@
+package ir
+
+import (
+	"sort"
+	"strings"
+)
+
+// UnusedSequences returns the sequences that are not owned by a column, not drawn from by any
+// column default, and not mentioned by any expression, view, or routine body, sorted by schema
+// and name. Such sequences are typically left behind by a SERIAL column that was converted to an
+// identity column or dropped after its sequence was unowned. A mention anywhere, including in a
+// string literal, counts as a use, so a sequence used by dynamic SQL is not reported.
+func (c *IR) UnusedSequences() []*Sequence {
+	var texts []string
+	for _, schema := range c.Schemas {
+		texts = append(texts, schemaExpressions(schema)...)
+	}
+
+	var unused []*Sequence
+	for _, schema := range c.Schemas {
+		for _, seq := range schema.Sequences {
+			if seq.OwnedByTable != "" {
+				continue
+			}
+			used := false
+			for _, text := range texts {
+				if mentionsIdentifier(text, seq.Name) {
+					used = true
+					break
+				}
+			}
+			if !used {
+				unused = append(unused, seq)
+			}
+		}
+	}
+
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].Schema != unused[j].Schema {
+			return unused[i].Schema < unused[j].Schema
+		}
+		return unused[i].Name < unused[j].Name
+	})
+	return unused
+}
+
+// schemaExpressions returns the expressions and bodies of the objects in a schema that may
+// reference a sequence
+func schemaExpressions(schema *Schema) []string {
+	var texts []string
+	for _, table := range schema.Tables {
+		for _, column := range table.Columns {
+			if column.DefaultValue != nil {
+				texts = append(texts, *column.DefaultValue)
+			}
+			if column.GeneratedExpr != nil {
+				texts = append(texts, *column.GeneratedExpr)
+			}
+		}
+		for _, constraint := range table.Constraints {
+			texts = append(texts, constraint.CheckClause)
+		}
+		for _, trigger := range table.Triggers {
+			texts = append(texts, trigger.Condition)
+		}
+		for _, policy := range table.Policies {
+			texts = append(texts, policy.Using, policy.WithCheck)
+		}
+	}
+	for _, view := range schema.Views {
+		texts = append(texts, view.Definition)
+	}
+	for _, function := range schema.Functions {
+		texts = append(texts, function.Definition)
+		texts = append(texts, parameterDefaults(function.Parameters)...)
+	}
+	for _, procedure := range schema.Procedures {
+		texts = append(texts, procedure.Definition)
+		texts = append(texts, parameterDefaults(procedure.Parameters)...)
+	}
+	for _, typ := range schema.Types {
+		texts = append(texts, typ.Default)
+		for _, constraint := range typ.Constraints {
+			texts = append(texts, constraint.Definition)
+		}
+	}
+	return texts
+}
+
+// parameterDefaults returns the default expressions of routine parameters
+func parameterDefaults(params []*Parameter) []string {
+	var defaults []string
+	for _, param := range params {
+		if param.DefaultValue != nil {
+			defaults = append(defaults, *param.DefaultValue)
+		}
+	}
+	return defaults
+}
+
+// mentionsIdentifier reports whether text contains name as a whole identifier. Lowercase names
+// match case-insensitively, like unquoted identifiers.
+func mentionsIdentifier(text, name string) bool {
+	if name == strings.ToLower(name) {
+		text = strings.ToLower(text)
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], name)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(name)
+		if (start == 0 || !isIdentChar(text[start-1])) && (end == len(text) || !isIdentChar(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
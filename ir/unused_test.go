@@ -0,0 +1,40 @@
+package ir
+
+import (
+	"testing"
+)
+
+func TestUnusedSequences(t *testing.T) {
+	schema := NewIR()
+	dbSchema := schema.getOrCreateSchema("public")
+	nextval := "nextval('invoice_numbers'::regclass)"
+	dbSchema.Tables["invoices"] = &Table{
+		Schema: "public",
+		Name:   "invoices",
+		Columns: []*Column{
+			{Name: "id", DataType: "integer", DefaultValue: &nextval},
+		},
+	}
+	dbSchema.Functions["next_ticket()"] = &Function{
+		Schema:     "public",
+		Name:       "next_ticket",
+		Definition: "SELECT NEXTVAL('Ticket_Numbers')",
+	}
+	for _, seq := range []*Sequence{
+		{Schema: "public", Name: "orders_id_seq", OwnedByTable: "orders", OwnedByColumn: "id"},
+		{Schema: "public", Name: "invoice_numbers"},
+		{Schema: "public", Name: "ticket_numbers"},
+		{Schema: "public", Name: "legacy_users_id_seq"},
+		{Schema: "public", Name: "invoice"}, // Only a prefix of a used sequence's name
+	} {
+		dbSchema.Sequences[seq.Name] = seq
+	}
+
+	var names []string
+	for _, seq := range schema.UnusedSequences() {
+		names = append(names, seq.Name)
+	}
+	if len(names) != 2 || names[0] != "invoice" || names[1] != "legacy_users_id_seq" {
+		t.Errorf("expected invoice and legacy_users_id_seq to be unused, got %v", names)
+	}
+}
@@ -0,0 +1 @@
+COMMENT ON COLUMN order_notes.notes IS 'Free-form notes';
@@ -0,0 +1,8 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    notes text
+);
+
+CREATE MATERIALIZED VIEW public.order_notes AS SELECT id, notes FROM public.orders;
+
+COMMENT ON COLUMN public.order_notes.notes IS 'Free-form notes';
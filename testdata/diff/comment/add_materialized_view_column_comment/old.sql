@@ -0,0 +1,6 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    notes text
+);
+
+CREATE MATERIALIZED VIEW public.order_notes AS SELECT id, notes FROM public.orders;
@@ -0,0 +1,10 @@
+CREATE TABLE public.documents (
+    id integer PRIMARY KEY,
+    tenant_id integer
+);
+
+ALTER TABLE public.documents ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON public.documents USING (tenant_id = 1);
+
+COMMENT ON POLICY tenant_isolation ON public.documents IS 'Rows are visible to their tenant only';
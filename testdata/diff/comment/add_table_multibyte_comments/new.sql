@@ -0,0 +1,10 @@
+-- PostgreSQL truncates the constraint name to 63 bytes on a character boundary
+CREATE TABLE public.größen (
+    id integer PRIMARY KEY,
+    längé integer,
+    CONSTRAINT prüfung_längé_ist_positiv_und_kleiner_als_tausend_für_alle_zeilen CHECK (längé > 0)
+);
+
+COMMENT ON TABLE public.größen IS 'Größen — 日本語 😀';
+
+COMMENT ON COLUMN public.größen.längé IS 'Länge in Zentimetern';
@@ -0,0 +1 @@
+-- Empty schema (no tables)
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS größen (
+    id integer,
+    längé integer,
+    CONSTRAINT größen_pkey PRIMARY KEY (id),
+    CONSTRAINT prüfung_längé_ist_positiv_und_kleiner_als_tausend_für_alle_ CHECK (längé > 0)
+);
+
+COMMENT ON TABLE größen IS 'Größen — 日本語 😀';
+
+COMMENT ON COLUMN größen.längé IS 'Länge in Zentimetern';
@@ -0,0 +1,7 @@
+CREATE TABLE public.users (
+    id bigint NOT NULL,
+    email text,
+    name text
+);
+
+COMMENT ON COLUMN public.users.email IS 'Login email';
@@ -0,0 +1 @@
+COMMENT ON COLUMN users.email IS 'Login email';
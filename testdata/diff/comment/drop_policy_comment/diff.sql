@@ -0,0 +1 @@
+COMMENT ON POLICY tenant_isolation ON documents IS NULL;
@@ -0,0 +1,7 @@
+CREATE DOMAIN public.tags AS text[] COLLATE "C";
+
+CREATE DOMAIN public.code AS varchar(10) COLLATE "C" NOT NULL;
+
+CREATE DOMAIN public.label AS text;
+
+CREATE DOMAIN public.intarray AS integer[];
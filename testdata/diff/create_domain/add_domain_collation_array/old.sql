@@ -0,0 +1 @@
+-- Empty schema (no domains)
\ No newline at end of file
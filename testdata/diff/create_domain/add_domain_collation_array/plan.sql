@@ -0,0 +1,8 @@
+CREATE DOMAIN code AS character varying(10) COLLATE "C"
+  NOT NULL;
+
+CREATE DOMAIN intarray AS integer[];
+
+CREATE DOMAIN label AS text;
+
+CREATE DOMAIN tags AS text[] COLLATE "C";
@@ -0,0 +1 @@
+CREATE DOMAIN public.code AS text;
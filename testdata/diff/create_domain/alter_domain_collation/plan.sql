@@ -0,0 +1,3 @@
+DROP DOMAIN IF EXISTS code RESTRICT;
+
+CREATE DOMAIN code AS text COLLATE "C";
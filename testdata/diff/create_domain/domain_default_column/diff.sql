@@ -0,0 +1,3 @@
+ALTER TABLE reviews ADD COLUMN default_rating user_rating;
+
+ALTER TABLE reviews ADD COLUMN override_rating user_rating DEFAULT 7;
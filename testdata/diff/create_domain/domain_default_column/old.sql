@@ -0,0 +1,11 @@
+CREATE DOMAIN user_rating AS integer
+  DEFAULT 3
+  CHECK (VALUE >= 1 AND VALUE <= 10);
+
+-- rating relies on the domain default, score overrides it
+CREATE TABLE reviews (
+    id integer NOT NULL,
+    rating user_rating,
+    score user_rating DEFAULT 5,
+    CONSTRAINT reviews_pkey PRIMARY KEY (id)
+);
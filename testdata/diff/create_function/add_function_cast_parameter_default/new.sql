@@ -0,0 +1,6 @@
+CREATE FUNCTION apply_discount(price numeric, discount numeric DEFAULT 0::numeric)
+RETURNS numeric
+LANGUAGE sql
+AS $$
+    SELECT price - discount;
+$$;
@@ -0,0 +1 @@
+-- Empty schema (no functions)
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE OR REPLACE FUNCTION apply_discount(
+    price numeric,
+    discount numeric DEFAULT 0
+)
+RETURNS numeric
+LANGUAGE sql
+VOLATILE
+AS $$
+    SELECT price - discount;
+$$;
@@ -0,0 +1,16 @@
+CREATE FUNCTION add_one(x integer)
+RETURNS integer
+LANGUAGE sql
+IMMUTABLE
+AS $$
+    SELECT x + 1;
+$$;
+
+-- An explicit VOLATILE matches the default volatility, so this function is unchanged
+CREATE FUNCTION add_two(x integer)
+RETURNS integer
+LANGUAGE sql
+VOLATILE
+AS $$
+    SELECT x + 2;
+$$;
@@ -0,0 +1,22 @@
+CREATE FUNCTION public.normalize_email(value text)
+RETURNS text
+LANGUAGE sql
+IMMUTABLE
+AS $$
+    SELECT lower(trim(value));
+$$;
+
+CREATE TABLE public.users (
+    id integer PRIMARY KEY,
+    email text,
+    data jsonb
+);
+
+CREATE INDEX idx_users_lower_email ON public.users (lower(email));
+
+-- The same-schema function qualifier is dropped, and the table is created after the function
+CREATE INDEX idx_users_normalized_email ON public.users (public.normalize_email(email));
+
+CREATE INDEX idx_users_data_email ON public.users ((data ->> 'email'));
+
+CREATE INDEX idx_users_data_city ON public.users ((data #>> '{address,city}'));
@@ -0,0 +1,24 @@
+CREATE OR REPLACE FUNCTION normalize_email(
+    value text
+)
+RETURNS text
+LANGUAGE sql
+IMMUTABLE
+AS $$
+    SELECT lower(trim(value));
+$$;
+
+CREATE TABLE IF NOT EXISTS users (
+    id integer,
+    email text,
+    data jsonb,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_data_city ON users ((data #>> '{address,city}'::text[]));
+
+CREATE INDEX IF NOT EXISTS idx_users_data_email ON users ((data ->> 'email'::text));
+
+CREATE INDEX IF NOT EXISTS idx_users_lower_email ON users (lower(email));
+
+CREATE INDEX IF NOT EXISTS idx_users_normalized_email ON users (normalize_email(email));
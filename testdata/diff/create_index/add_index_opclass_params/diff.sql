@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS documents (
+    id integer,
+    search tsvector,
+    CONSTRAINT documents_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_documents_search ON documents USING gist (search tsvector_ops (siglen=32));
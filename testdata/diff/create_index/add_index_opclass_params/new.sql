@@ -0,0 +1,7 @@
+CREATE TABLE public.documents (
+    id integer PRIMARY KEY,
+    search tsvector
+);
+
+-- Operator class parameters are kept with the index column
+CREATE INDEX idx_documents_search ON public.documents USING gist (search tsvector_ops (siglen = 32));
@@ -0,0 +1,8 @@
+CREATE TABLE public.accounts (
+    id integer PRIMARY KEY,
+    status text,
+    deleted_at timestamptz
+);
+
+-- Compound predicate written without the parentheses and casts PostgreSQL adds
+CREATE INDEX idx_accounts_active ON public.accounts (id) WHERE status = 'active' AND deleted_at IS NULL;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS accounts (
+    id integer,
+    status text,
+    deleted_at timestamptz,
+    CONSTRAINT accounts_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_accounts_active ON accounts (id) WHERE (status = 'active'::text) AND (deleted_at IS NULL);
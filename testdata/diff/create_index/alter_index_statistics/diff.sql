@@ -0,0 +1,7 @@
+ALTER INDEX idx_users_lower_email ALTER COLUMN 1 SET STATISTICS 1000;
+
+ALTER INDEX idx_users_name_lower_email ALTER COLUMN 2 SET STATISTICS -1;
+
+CREATE INDEX IF NOT EXISTS idx_users_upper_name ON users (upper(name));
+
+ALTER INDEX idx_users_upper_name ALTER COLUMN 1 SET STATISTICS 500;
@@ -0,0 +1,16 @@
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    email text,
+    name text
+);
+
+CREATE INDEX idx_users_lower_email ON public.users (lower(email));
+
+ALTER INDEX public.idx_users_lower_email ALTER COLUMN 1 SET STATISTICS 1000;
+
+-- Statistics target reset to the default
+CREATE INDEX idx_users_name_lower_email ON public.users (name, lower(email));
+
+CREATE INDEX idx_users_upper_name ON public.users (upper(name));
+
+ALTER INDEX public.idx_users_upper_name ALTER COLUMN 1 SET STATISTICS 500;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    email text,
+    name text
+);
+
+CREATE INDEX idx_users_lower_email ON public.users (lower(email));
+
+CREATE INDEX idx_users_name_lower_email ON public.users (name, lower(email));
+
+ALTER INDEX public.idx_users_name_lower_email ALTER COLUMN 2 SET STATISTICS 100;
\ No newline at end of file
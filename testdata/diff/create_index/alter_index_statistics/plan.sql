@@ -0,0 +1,19 @@
+ALTER INDEX idx_users_lower_email ALTER COLUMN 1 SET STATISTICS 1000;
+
+ALTER INDEX idx_users_name_lower_email ALTER COLUMN 2 SET STATISTICS -1;
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_users_upper_name ON users (upper(name));
+
+-- pgschema:wait
+SELECT 
+    COALESCE(i.indisvalid, false) as done,
+    CASE 
+        WHEN p.blocks_total > 0 THEN p.blocks_done * 100 / p.blocks_total
+        ELSE 0
+    END as progress
+FROM pg_class c
+LEFT JOIN pg_index i ON c.oid = i.indexrelid
+LEFT JOIN pg_stat_progress_create_index p ON c.oid = p.index_relid
+WHERE c.relname = 'idx_users_upper_name';
+
+ALTER INDEX idx_users_upper_name ALTER COLUMN 1 SET STATISTICS 500;
@@ -0,0 +1,5 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    notes text,
+    summary text
+);
@@ -0,0 +1,9 @@
+CREATE MATERIALIZED VIEW IF NOT EXISTS order_notes AS
+ SELECT id,
+    notes,
+    summary
+   FROM orders;
+
+COMMENT ON COLUMN order_notes.notes IS 'Free-form notes';
+
+ALTER MATERIALIZED VIEW order_notes ALTER COLUMN summary SET STORAGE EXTERNAL;
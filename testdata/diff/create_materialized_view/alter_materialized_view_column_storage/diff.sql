@@ -0,0 +1,5 @@
+COMMENT ON COLUMN order_notes.notes IS NULL;
+
+ALTER MATERIALIZED VIEW order_notes ALTER COLUMN notes SET STORAGE EXTERNAL;
+
+ALTER MATERIALIZED VIEW order_notes ALTER COLUMN summary SET STORAGE EXTENDED;
@@ -0,0 +1,10 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    notes text,
+    summary text
+);
+
+-- Column comments and storage change without recreating the materialized view
+CREATE MATERIALIZED VIEW public.order_notes AS SELECT id, notes, summary FROM public.orders;
+
+ALTER MATERIALIZED VIEW public.order_notes ALTER COLUMN notes SET STORAGE EXTERNAL;
@@ -0,0 +1,11 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    notes text,
+    summary text
+);
+
+CREATE MATERIALIZED VIEW public.order_notes AS SELECT id, notes, summary FROM public.orders;
+
+COMMENT ON COLUMN public.order_notes.notes IS 'Free-form notes';
+
+ALTER MATERIALIZED VIEW public.order_notes ALTER COLUMN summary SET STORAGE EXTERNAL;
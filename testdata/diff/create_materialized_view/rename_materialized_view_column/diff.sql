@@ -0,0 +1 @@
+ALTER MATERIALIZED VIEW order_notes RENAME COLUMN notes TO remarks;
@@ -0,0 +1,9 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    notes text
+);
+
+-- Renaming an output column is done in place and keeps its comment
+CREATE MATERIALIZED VIEW public.order_notes AS SELECT id, notes AS remarks FROM public.orders;
+
+COMMENT ON COLUMN public.order_notes.remarks IS 'Free-form notes';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS documents (
+    id integer,
+    tenant_id integer,
+    CONSTRAINT documents_pkey PRIMARY KEY (id)
+);
+
+ALTER TABLE documents ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_guard ON documents AS RESTRICTIVE TO PUBLIC USING (tenant_id = 1);
+
+CREATE POLICY tenant_isolation ON documents TO PUBLIC USING (tenant_id = 1);
@@ -0,0 +1,12 @@
+CREATE TABLE public.documents (
+    id integer PRIMARY KEY,
+    tenant_id integer
+);
+
+ALTER TABLE public.documents ENABLE ROW LEVEL SECURITY;
+
+-- Explicit AS PERMISSIVE is the default and is not repeated in generated DDL
+CREATE POLICY tenant_isolation ON public.documents AS PERMISSIVE USING (tenant_id = 1);
+
+-- Restrictive policies keep their AS RESTRICTIVE clause
+CREATE POLICY tenant_guard ON public.documents AS RESTRICTIVE USING (tenant_id = 1);
@@ -0,0 +1,5 @@
+CREATE POLICY documents_public_read ON documents FOR SELECT TO PUBLIC USING (visibility = 2);
+
+DROP POLICY IF EXISTS documents_tenant_guard ON documents;
+
+CREATE POLICY documents_tenant_guard ON documents AS RESTRICTIVE FOR SELECT TO PUBLIC USING (tenant_id = 1);
@@ -0,0 +1,26 @@
+CREATE TABLE documents (
+    id integer PRIMARY KEY,
+    owner_id integer NOT NULL,
+    tenant_id integer NOT NULL,
+    visibility integer NOT NULL
+);
+
+ALTER TABLE documents ENABLE ROW LEVEL SECURITY;
+
+-- Two permissive SELECT policies: a row is visible if either one matches
+CREATE POLICY documents_owner_read ON documents
+    FOR SELECT
+    TO PUBLIC
+    USING (owner_id = 1);
+
+CREATE POLICY documents_public_read ON documents
+    FOR SELECT
+    TO PUBLIC
+    USING (visibility = 2);
+
+-- Changed from permissive to restrictive (requires recreation): every visible row must also match
+CREATE POLICY documents_tenant_guard ON documents
+    AS RESTRICTIVE
+    FOR SELECT
+    TO PUBLIC
+    USING (tenant_id = 1);
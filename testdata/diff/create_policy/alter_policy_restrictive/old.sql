@@ -0,0 +1,18 @@
+CREATE TABLE documents (
+    id integer PRIMARY KEY,
+    owner_id integer NOT NULL,
+    tenant_id integer NOT NULL,
+    visibility integer NOT NULL
+);
+
+ALTER TABLE documents ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY documents_owner_read ON documents
+    FOR SELECT
+    TO PUBLIC
+    USING (owner_id = 1);
+
+CREATE POLICY documents_tenant_guard ON documents
+    FOR SELECT
+    TO PUBLIC
+    USING (tenant_id = 1);
@@ -0,0 +1 @@
+CREATE SEQUENCE IF NOT EXISTS order_seq;
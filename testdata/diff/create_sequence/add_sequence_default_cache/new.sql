@@ -0,0 +1,2 @@
+-- CACHE 1 is the default, so it matches a sequence created without CACHE
+CREATE SEQUENCE public.order_seq CACHE 1;
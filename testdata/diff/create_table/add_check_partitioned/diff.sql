@@ -0,0 +1,2 @@
+ALTER TABLE measurements
+ADD CONSTRAINT measurements_peaktemp_check CHECK (peaktemp < 1000);
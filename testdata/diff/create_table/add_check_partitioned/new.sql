@@ -0,0 +1,9 @@
+CREATE TABLE public.measurements (
+    id integer NOT NULL,
+    logdate date NOT NULL,
+    peaktemp integer,
+    CONSTRAINT measurements_peaktemp_check CHECK (peaktemp < 1000)
+) PARTITION BY RANGE (logdate);
+
+CREATE TABLE public.measurements_2024 PARTITION OF public.measurements
+    FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');
@@ -0,0 +1,4 @@
+ALTER TABLE measurements
+ADD CONSTRAINT measurements_peaktemp_check CHECK (peaktemp < 1000) NOT VALID;
+
+ALTER TABLE measurements VALIDATE CONSTRAINT measurements_peaktemp_check;
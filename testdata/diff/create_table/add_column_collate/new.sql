@@ -0,0 +1,5 @@
+CREATE TABLE public.users (
+    id integer PRIMARY KEY,
+    label text COLLATE "C",
+    name text COLLATE "C" NOT NULL DEFAULT ''
+);
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN name text COLLATE "C" DEFAULT '' NOT NULL;
+
+ALTER TABLE users ALTER COLUMN label TYPE text COLLATE "C";
@@ -0,0 +1,11 @@
+CREATE TYPE order_status AS ENUM (
+    'pending',
+    'active'
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id integer,
+    status order_status DEFAULT 'active'::order_status,
+    fallback order_status DEFAULT 'pending'::order_status,
+    CONSTRAINT orders_pkey PRIMARY KEY (id)
+);
@@ -0,0 +1,10 @@
+CREATE TYPE public.order_status AS ENUM (
+    'pending',
+    'active'
+);
+
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    status public.order_status DEFAULT 'active'::public.order_status,
+    fallback order_status DEFAULT 'pending'
+);
@@ -0,0 +1,5 @@
+ALTER TABLE books
+ADD CONSTRAINT books_author_id_fkey FOREIGN KEY (author_id) REFERENCES authors (id) DEFERRABLE INITIALLY DEFERRED;
+
+ALTER TABLE books
+ADD CONSTRAINT books_isbn_key UNIQUE (isbn) DEFERRABLE INITIALLY DEFERRED;
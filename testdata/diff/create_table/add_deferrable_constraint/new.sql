@@ -0,0 +1,13 @@
+CREATE TABLE public.authors (
+    id integer PRIMARY KEY
+);
+
+CREATE TABLE public.books (
+    id integer PRIMARY KEY,
+    author_id integer,
+    isbn text,
+    CONSTRAINT books_isbn_key UNIQUE (isbn) DEFERRABLE INITIALLY DEFERRED
+);
+
+ALTER TABLE public.books
+ADD CONSTRAINT books_author_id_fkey FOREIGN KEY (author_id) REFERENCES public.authors (id) DEFERRABLE INITIALLY DEFERRED;
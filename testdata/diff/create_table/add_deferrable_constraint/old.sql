@@ -0,0 +1,9 @@
+CREATE TABLE public.authors (
+    id integer PRIMARY KEY
+);
+
+CREATE TABLE public.books (
+    id integer PRIMARY KEY,
+    author_id integer,
+    isbn text
+);
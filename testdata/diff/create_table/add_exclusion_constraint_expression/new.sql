@@ -0,0 +1,18 @@
+CREATE FUNCTION public.booking_period(starts timestamp, ends timestamp)
+RETURNS tsrange
+LANGUAGE sql
+IMMUTABLE
+AS $$
+    SELECT tsrange(starts, ends);
+$$;
+
+-- Spacing, keyword case, and the same-schema function qualifier do not matter
+CREATE TABLE public.bookings (
+    id integer PRIMARY KEY,
+    starts timestamp NOT NULL,
+    ends timestamp NOT NULL,
+    cancelled boolean NOT NULL DEFAULT false,
+    CONSTRAINT bookings_no_overlap EXCLUDE USING GIST (
+        public.booking_period(starts,ends)   WITH   &&
+    )   where (not cancelled)
+);
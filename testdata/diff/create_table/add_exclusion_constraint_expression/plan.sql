@@ -0,0 +1,19 @@
+CREATE OR REPLACE FUNCTION booking_period(
+    starts timestamp,
+    ends timestamp
+)
+RETURNS tsrange
+LANGUAGE sql
+IMMUTABLE
+AS $$
+    SELECT tsrange(starts, ends);
+$$;
+
+CREATE TABLE IF NOT EXISTS bookings (
+    id integer,
+    starts timestamp NOT NULL,
+    ends timestamp NOT NULL,
+    cancelled boolean DEFAULT false NOT NULL,
+    CONSTRAINT bookings_pkey PRIMARY KEY (id),
+    CONSTRAINT bookings_no_overlap EXCLUDE USING gist (booking_period(starts, ends) WITH &&) WHERE ((NOT cancelled))
+);
@@ -0,0 +1,18 @@
+CREATE TABLE public.countries (
+    code text PRIMARY KEY
+);
+
+CREATE TABLE public.regions (
+    country_code text,
+    region_code text,
+    PRIMARY KEY (country_code, region_code)
+);
+
+-- Foreign keys without a referenced column list reference the primary key
+CREATE TABLE public.offices (
+    id integer PRIMARY KEY,
+    country_code text REFERENCES public.countries,
+    region_country text,
+    region_code text,
+    FOREIGN KEY (region_country, region_code) REFERENCES public.regions
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS countries (
+    code text,
+    CONSTRAINT countries_pkey PRIMARY KEY (code)
+);
+
+CREATE TABLE IF NOT EXISTS regions (
+    country_code text,
+    region_code text,
+    CONSTRAINT regions_pkey PRIMARY KEY (country_code, region_code)
+);
+
+CREATE TABLE IF NOT EXISTS offices (
+    id integer,
+    country_code text,
+    region_country text,
+    region_code text,
+    CONSTRAINT offices_pkey PRIMARY KEY (id),
+    CONSTRAINT offices_country_code_fkey FOREIGN KEY (country_code) REFERENCES countries (code),
+    CONSTRAINT offices_region_country_region_code_fkey FOREIGN KEY (region_country, region_code) REFERENCES regions (country_code, region_code)
+);
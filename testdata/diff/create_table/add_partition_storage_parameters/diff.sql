@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS events (
+    created_at date NOT NULL
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE IF NOT EXISTS events_2024 PARTITION OF events
+    FOR VALUES FROM ('2024-01-01') TO ('2025-01-01') WITH (autovacuum_enabled=false);
+
+CREATE TABLE IF NOT EXISTS events_2025 PARTITION OF events
+    FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');
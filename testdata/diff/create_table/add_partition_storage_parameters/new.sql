@@ -0,0 +1,10 @@
+CREATE TABLE public.events (
+    created_at date NOT NULL
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE public.events_2024 PARTITION OF public.events
+    FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')
+    WITH (autovacuum_enabled = false);
+
+CREATE TABLE public.events_2025 PARTITION OF public.events
+    FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');
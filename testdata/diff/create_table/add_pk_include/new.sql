@@ -0,0 +1,13 @@
+CREATE TABLE public.customers (
+    id integer NOT NULL,
+    email text NOT NULL,
+    name text,
+    CONSTRAINT customers_email_key UNIQUE (email) INCLUDE (name)
+);
+
+CREATE TABLE public.orders (
+    id integer NOT NULL,
+    customer_id integer NOT NULL,
+    created_at timestamp NOT NULL,
+    CONSTRAINT orders_pkey PRIMARY KEY (id) INCLUDE (customer_id, created_at)
+);
@@ -0,0 +1,6 @@
+CREATE TABLE public.customers (
+    id integer NOT NULL,
+    email text NOT NULL,
+    name text,
+    CONSTRAINT customers_email_key UNIQUE (email)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS orders (
+    id integer,
+    customer_id integer NOT NULL,
+    created_at timestamp NOT NULL,
+    CONSTRAINT orders_pkey PRIMARY KEY (id) INCLUDE (customer_id, created_at)
+);
+
+ALTER TABLE customers DROP CONSTRAINT customers_email_key;
+
+ALTER TABLE customers
+ADD CONSTRAINT customers_email_key UNIQUE (email) INCLUDE (name);
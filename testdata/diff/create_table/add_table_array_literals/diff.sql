@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS tickets (
+    id integer NOT NULL,
+    priority integer NOT NULL,
+    tags text[] DEFAULT ARRAY['new', 'triage'],
+    weights integer[] DEFAULT ARRAY[1, 2, 3],
+    CONSTRAINT tickets_priority_check CHECK (priority IN (1, 2, 3)),
+    CONSTRAINT tickets_tags_check CHECK (tags <@ ARRAY['new'::text, 'triage'::text, 'done'::text])
+);
@@ -0,0 +1,9 @@
+-- PostgreSQL stores array literals with explicit element casts, e.g. ARRAY['new'::text, 'triage'::text]
+CREATE TABLE public.tickets (
+    id integer NOT NULL,
+    priority integer NOT NULL,
+    tags text[] DEFAULT ARRAY['new', 'triage'],
+    weights integer[] DEFAULT ARRAY[1,2,3],
+    CONSTRAINT tickets_priority_check CHECK (priority = ANY(ARRAY[1,2,3])),
+    CONSTRAINT tickets_tags_check CHECK (tags <@ ARRAY['new', 'triage', 'done'])
+);
@@ -0,0 +1,6 @@
+-- Desired state: the type modifier is kept on both the column and the array column
+CREATE TABLE public.labels (
+    id integer PRIMARY KEY,
+    name typmods.label(20),
+    aliases typmods.label(20)[]
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS labels (
+    id integer,
+    name typmods.label(20),
+    aliases typmods.label(20)[],
+    CONSTRAINT labels_pkey PRIMARY KEY (id)
+);
@@ -0,0 +1,16 @@
+-- Setup: a base type outside pg_catalog that takes a type modifier, like PostGIS geometry(Point,4326)
+-- or pgvector vector(384), built from internal functions so that no third-party extension is needed.
+-- Drop and recreate the schema for idempotency (setup runs for both old.sql and new.sql)
+DROP SCHEMA IF EXISTS typmods CASCADE;
+CREATE SCHEMA typmods;
+
+CREATE TYPE typmods.label;
+CREATE FUNCTION typmods.label_in(cstring) RETURNS typmods.label LANGUAGE internal IMMUTABLE STRICT AS 'textin';
+CREATE FUNCTION typmods.label_out(typmods.label) RETURNS cstring LANGUAGE internal IMMUTABLE STRICT AS 'textout';
+CREATE TYPE typmods.label (
+    INPUT = typmods.label_in,
+    OUTPUT = typmods.label_out,
+    TYPMOD_IN = varchartypmodin,
+    TYPMOD_OUT = varchartypmodout,
+    LIKE = text
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS catalog_types (
+    id integer,
+    total bigint,
+    ratio real,
+    active boolean,
+    kind "char",
+    kinds "char"[],
+    label name,
+    ref oid
+);
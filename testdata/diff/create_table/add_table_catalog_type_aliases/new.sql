@@ -0,0 +1,10 @@
+CREATE TABLE public.catalog_types (
+    id pg_catalog.int4,
+    total int8,
+    ratio float4,
+    active bool,
+    kind "char",
+    kinds pg_catalog."char"[],
+    label pg_catalog.name,
+    ref oid
+);
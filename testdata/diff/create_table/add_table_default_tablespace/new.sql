@@ -0,0 +1,10 @@
+CREATE TABLE public.orders (
+    id integer
+);
+
+-- New tables and indexes are created in the default tablespace; existing ones are left alone
+CREATE TABLE public.customers (
+    email text
+);
+
+CREATE INDEX idx_customers_email ON public.customers (email);
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS customers (
+    email text
+) TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS idx_customers_email ON customers (email) TABLESPACE pg_default;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS line_items (
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    price numeric NOT NULL,
+    quantity integer NOT NULL,
+    total numeric GENERATED ALWAYS AS ((price * quantity)) STORED
+);
@@ -0,0 +1,15 @@
+CREATE TABLE public.orders (
+    id bigint GENERATED ALWAYS AS IDENTITY,
+    amount integer NOT NULL,
+    tax integer GENERATED ALWAYS AS (amount / 10) STORED
+);
+
+-- Identity and generation expression are copied only when requested
+CREATE TABLE public.orders_archive (
+    LIKE public.orders INCLUDING IDENTITY INCLUDING GENERATED
+);
+
+-- Without options, identity becomes a plain NOT NULL column and the generated column a regular one
+CREATE TABLE public.orders_staging (
+    LIKE public.orders
+);
\ No newline at end of file
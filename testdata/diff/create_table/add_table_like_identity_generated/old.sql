@@ -0,0 +1,5 @@
+CREATE TABLE public.orders (
+    id bigint GENERATED ALWAYS AS IDENTITY,
+    amount integer NOT NULL,
+    tax integer GENERATED ALWAYS AS (amount / 10) STORED
+);
\ No newline at end of file
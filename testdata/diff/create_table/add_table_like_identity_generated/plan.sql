@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS orders_archive (
+    id bigint GENERATED ALWAYS AS IDENTITY,
+    amount integer NOT NULL,
+    tax integer GENERATED ALWAYS AS ((amount / 10)) STORED
+);
+
+CREATE TABLE IF NOT EXISTS orders_staging (
+    id bigint NOT NULL,
+    amount integer NOT NULL,
+    tax integer
+);
@@ -0,0 +1,10 @@
+CREATE TABLE public.users (
+    id integer PRIMARY KEY,
+    email text NOT NULL,
+    active boolean
+);
+
+CREATE VIEW public.active_users AS SELECT id, email FROM public.users WHERE active;
+
+-- LIKE also accepts a view; the table gets a plain copy of the view's columns
+CREATE TABLE public.active_users_archive (LIKE public.active_users);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS active_users_archive (
+    id integer,
+    email text
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id integer,
+    email text NOT NULL,
+    active boolean,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+
+CREATE OR REPLACE VIEW active_users AS
+ SELECT id,
+    email
+   FROM users
+  WHERE active;
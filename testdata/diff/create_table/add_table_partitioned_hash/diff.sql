@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS orders (
+    id integer NOT NULL,
+    customer_id integer NOT NULL,
+    amount integer
+) PARTITION BY HASH (customer_id);
+
+CREATE TABLE IF NOT EXISTS orders_p0 PARTITION OF orders
+    FOR VALUES WITH (MODULUS 4, REMAINDER 0);
+
+CREATE TABLE IF NOT EXISTS orders_p1 PARTITION OF orders
+    FOR VALUES WITH (MODULUS 4, REMAINDER 1);
+
+CREATE TABLE IF NOT EXISTS orders_p2 PARTITION OF orders
+    FOR VALUES WITH (MODULUS 4, REMAINDER 2);
+
+CREATE TABLE IF NOT EXISTS orders_p3 PARTITION OF orders
+    FOR VALUES WITH (MODULUS 4, REMAINDER 3);
@@ -0,0 +1,17 @@
+CREATE TABLE public.orders (
+    id integer NOT NULL,
+    customer_id integer NOT NULL,
+    amount integer
+) PARTITION BY HASH (customer_id);
+
+CREATE TABLE public.orders_p0 PARTITION OF public.orders
+    FOR VALUES WITH (MODULUS 4, REMAINDER 0);
+
+CREATE TABLE public.orders_p1 PARTITION OF public.orders
+    FOR VALUES WITH (MODULUS 4, REMAINDER 1);
+
+CREATE TABLE public.orders_p2 PARTITION OF public.orders
+    FOR VALUES WITH (MODULUS 4, REMAINDER 2);
+
+CREATE TABLE public.orders_p3 PARTITION OF public.orders
+    FOR VALUES WITH (MODULUS 4, REMAINDER 3);
@@ -0,0 +1,12 @@
+CREATE SEQUENCE IF NOT EXISTS order_numbers AS integer START WITH 1000;
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id integer DEFAULT nextval('order_numbers'::regclass) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id SERIAL,
+    line_no SMALLSERIAL,
+    big_id BIGSERIAL,
+    CONSTRAINT orders_pkey PRIMARY KEY (id)
+);
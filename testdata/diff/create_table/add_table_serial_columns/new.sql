@@ -0,0 +1,12 @@
+CREATE TABLE public.orders (
+    id serial PRIMARY KEY,
+    line_no smallserial,
+    big_id bigserial
+);
+
+-- A column drawing from a sequence of its own is not a SERIAL column
+CREATE SEQUENCE public.order_numbers AS integer START WITH 1000;
+
+CREATE TABLE public.invoices (
+    id integer NOT NULL DEFAULT nextval('public.order_numbers'::regclass)
+);
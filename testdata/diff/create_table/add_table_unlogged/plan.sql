@@ -0,0 +1,5 @@
+CREATE UNLOGGED TABLE IF NOT EXISTS session_cache (
+    id integer,
+    payload text,
+    CONSTRAINT session_cache_pkey PRIMARY KEY (id)
+);
@@ -0,0 +1,7 @@
+-- Legacy schema files may still declare WITHOUT OIDS, which PostgreSQL accepts as a no-op
+CREATE TABLE public.events (
+    id integer PRIMARY KEY,
+    payload text
+) WITHOUT OIDS;
+
+ALTER TABLE public.events SET WITHOUT OIDS;
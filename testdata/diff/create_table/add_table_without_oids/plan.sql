@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS events (
+    id integer,
+    payload text,
+    CONSTRAINT events_pkey PRIMARY KEY (id)
+);
@@ -0,0 +1,4 @@
+-- The default keeps its schema qualifier and is not mistaken for a SERIAL column
+CREATE TABLE public.orders (
+    id integer NOT NULL DEFAULT nextval('shared.orders_id_seq'::regclass)
+);
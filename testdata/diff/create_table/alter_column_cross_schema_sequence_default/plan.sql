@@ -0,0 +1 @@
+ALTER TABLE orders ALTER COLUMN id SET DEFAULT nextval('shared.orders_id_seq'::regclass);
@@ -0,0 +1,6 @@
+-- Setup: a sequence in another schema named like the implicit SERIAL sequence of orders.id
+-- Drop and recreate the schema for idempotency (setup runs for both old.sql and new.sql)
+DROP SCHEMA IF EXISTS shared CASCADE;
+CREATE SCHEMA shared;
+
+CREATE SEQUENCE shared.orders_id_seq;
@@ -0,0 +1 @@
+ALTER TABLE products ALTER COLUMN code TYPE varchar(20) COLLATE "C";
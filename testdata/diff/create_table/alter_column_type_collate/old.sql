@@ -0,0 +1,4 @@
+CREATE TABLE public.products (
+    id integer PRIMARY KEY,
+    code varchar(10) COLLATE "C"
+);
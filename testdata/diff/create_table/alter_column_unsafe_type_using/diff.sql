@@ -0,0 +1,3 @@
+ALTER TABLE users ALTER COLUMN age TYPE integer USING age::integer;
+
+ALTER TABLE users ALTER COLUMN zip_code TYPE integer USING zip_code::integer;
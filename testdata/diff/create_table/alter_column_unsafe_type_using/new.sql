@@ -0,0 +1,6 @@
+-- pgschema:using users.age = NULLIF(age, '')::integer
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    age integer,
+    zip_code integer
+);
@@ -0,0 +1,5 @@
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    age text,
+    zip_code text
+);
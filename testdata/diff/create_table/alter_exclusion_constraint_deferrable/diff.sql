@@ -0,0 +1,4 @@
+ALTER TABLE reservations DROP CONSTRAINT reservations_no_overlap;
+
+ALTER TABLE reservations
+ADD CONSTRAINT reservations_no_overlap EXCLUDE USING gist (during WITH &&) DEFERRABLE INITIALLY DEFERRED;
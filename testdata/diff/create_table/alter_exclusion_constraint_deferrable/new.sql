@@ -0,0 +1,7 @@
+-- Only foreign keys can be altered in place, so the exclusion constraint is dropped and re-added
+CREATE TABLE public.reservations (
+    id integer PRIMARY KEY,
+    room integer NOT NULL,
+    during tsrange NOT NULL,
+    CONSTRAINT reservations_no_overlap EXCLUDE USING gist (during WITH &&) DEFERRABLE INITIALLY DEFERRED
+);
@@ -0,0 +1 @@
+ALTER TABLE employees ALTER CONSTRAINT employees_department_id_fkey DEFERRABLE INITIALLY DEFERRED;
@@ -0,0 +1,9 @@
+CREATE TABLE public.departments (
+    id integer PRIMARY KEY
+);
+
+CREATE TABLE public.employees (
+    id integer PRIMARY KEY,
+    department_id integer,
+    CONSTRAINT employees_department_id_fkey FOREIGN KEY (department_id) REFERENCES public.departments (id)
+);
@@ -0,0 +1 @@
+ALTER TABLE orders ALTER COLUMN id SET INCREMENT BY 5 SET START WITH 10;
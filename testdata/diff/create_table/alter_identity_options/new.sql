@@ -0,0 +1,4 @@
+-- Changing the sequence options alters the identity in place instead of recreating it
+CREATE TABLE public.orders (
+    id integer GENERATED ALWAYS AS IDENTITY (START WITH 10 INCREMENT BY 5)
+);
@@ -0,0 +1,3 @@
+CREATE TABLE public.orders (
+    id integer GENERATED ALWAYS AS IDENTITY
+);
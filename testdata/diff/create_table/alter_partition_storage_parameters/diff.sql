@@ -0,0 +1 @@
+ALTER TABLE events_2024 SET (autovacuum_enabled=false);
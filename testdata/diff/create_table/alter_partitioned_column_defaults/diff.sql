@@ -0,0 +1,5 @@
+ALTER TABLE measurements ADD COLUMN note text;
+
+ALTER TABLE measurements ALTER COLUMN status SET DEFAULT 'pending'::text;
+
+ALTER TABLE measurements_2025 ALTER COLUMN status SET DEFAULT 'archived'::text;
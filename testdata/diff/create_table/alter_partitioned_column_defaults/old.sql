@@ -0,0 +1,10 @@
+CREATE TABLE public.measurements (
+    created_at date NOT NULL,
+    status text NOT NULL
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE public.measurements_2024 PARTITION OF public.measurements
+    FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');
+
+CREATE TABLE public.measurements_2025 PARTITION OF public.measurements
+    FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS attachments (
+    id integer,
+    content bytea,
+    CONSTRAINT attachments_pkey PRIMARY KEY (id)
+) WITH (autovacuum_vacuum_scale_factor=0.05, toast.autovacuum_vacuum_scale_factor=0.1);
+
+ALTER TABLE archive RESET (toast.autovacuum_enabled);
+
+ALTER TABLE documents SET (fillfactor=70, toast.autovacuum_enabled=false);
@@ -0,0 +1,14 @@
+CREATE TABLE public.documents (
+    id integer PRIMARY KEY,
+    body text
+) WITH (fillfactor = 70, toast.autovacuum_enabled = false);
+
+CREATE TABLE public.archive (
+    id integer PRIMARY KEY,
+    body text
+);
+
+CREATE TABLE public.attachments (
+    id integer PRIMARY KEY,
+    content bytea
+) WITH (autovacuum_vacuum_scale_factor = 0.05, toast.autovacuum_vacuum_scale_factor = 0.1);
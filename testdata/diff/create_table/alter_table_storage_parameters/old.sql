@@ -0,0 +1,9 @@
+CREATE TABLE public.documents (
+    id integer PRIMARY KEY,
+    body text
+) WITH (fillfactor = 80);
+
+CREATE TABLE public.archive (
+    id integer PRIMARY KEY,
+    body text
+) WITH (toast.autovacuum_enabled = false);
@@ -0,0 +1,4 @@
+CREATE UNLOGGED TABLE public.events (
+    id integer PRIMARY KEY,
+    payload text
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE events SET UNLOGGED;
@@ -0,0 +1,6 @@
+-- Desired state: add PostGIS columns with subtype and SRID (string-valued typmod)
+CREATE TABLE public.places (
+    id bigserial PRIMARY KEY,
+    location geometry(Point,4326),
+    waypoints geometry(Point,4326)[]
+);
@@ -0,0 +1,4 @@
+-- Initial state: table without geometry columns
+CREATE TABLE public.places (
+    id bigserial PRIMARY KEY
+);
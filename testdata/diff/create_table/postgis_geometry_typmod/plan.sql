@@ -0,0 +1,3 @@
+ALTER TABLE places ADD COLUMN location geometry(Point,4326);
+
+ALTER TABLE places ADD COLUMN waypoints geometry(Point,4326)[];
@@ -0,0 +1,4 @@
+-- Setup: Requires PostGIS extension
+-- This test is skipped in CI (embedded-postgres doesn't include PostGIS).
+-- To run manually, install PostGIS and remove from skipListRequiresExtension.
+CREATE EXTENSION IF NOT EXISTS postgis;
@@ -0,0 +1,3 @@
+ALTER TABLE employees RENAME CONSTRAINT employees_department_id_fkey TO fk_employees_department;
+
+ALTER TABLE employees RENAME CONSTRAINT employees_salary_check TO employees_salary_positive;
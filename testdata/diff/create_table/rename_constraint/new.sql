@@ -0,0 +1,14 @@
+CREATE TABLE public.departments (
+    id integer NOT NULL,
+    name text NOT NULL,
+    CONSTRAINT departments_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE public.employees (
+    id integer NOT NULL,
+    department_id integer NOT NULL,
+    salary integer NOT NULL,
+    CONSTRAINT employees_pkey PRIMARY KEY (id),
+    CONSTRAINT employees_salary_positive CHECK (salary > 0),
+    CONSTRAINT fk_employees_department FOREIGN KEY (department_id) REFERENCES public.departments(id)
+);
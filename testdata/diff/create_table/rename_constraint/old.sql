@@ -0,0 +1,14 @@
+CREATE TABLE public.departments (
+    id integer NOT NULL,
+    name text NOT NULL,
+    CONSTRAINT departments_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE public.employees (
+    id integer NOT NULL,
+    department_id integer NOT NULL,
+    salary integer NOT NULL,
+    CONSTRAINT employees_pkey PRIMARY KEY (id),
+    CONSTRAINT employees_salary_check CHECK (salary > 0),
+    CONSTRAINT employees_department_id_fkey FOREIGN KEY (department_id) REFERENCES public.departments(id)
+);
@@ -0,0 +1,15 @@
+CREATE TABLE public.accounts (
+    id integer PRIMARY KEY
+);
+
+-- PostgreSQL cannot reorder existing columns, so columns are compared by name
+CREATE TABLE public.users (
+    created_at timestamptz DEFAULT now(),
+    email text NOT NULL,
+    account_id integer REFERENCES public.accounts (id),
+    id integer NOT NULL,
+    PRIMARY KEY (account_id, id),
+    CONSTRAINT users_email_check CHECK (email <> '')
+);
+
+CREATE INDEX idx_users_email_created ON public.users (email, created_at);
@@ -0,0 +1,14 @@
+CREATE TABLE public.accounts (
+    id integer PRIMARY KEY
+);
+
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    account_id integer REFERENCES public.accounts (id),
+    email text NOT NULL,
+    created_at timestamptz DEFAULT now(),
+    PRIMARY KEY (account_id, id),
+    CONSTRAINT users_email_check CHECK (email <> '')
+);
+
+CREATE INDEX idx_users_email_created ON public.users (email, created_at);
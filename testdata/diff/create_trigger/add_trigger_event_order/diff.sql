@@ -0,0 +1,4 @@
+CREATE OR REPLACE TRIGGER orders_audit
+    AFTER INSERT OR DELETE ON orders
+    FOR EACH ROW
+    EXECUTE FUNCTION audit();
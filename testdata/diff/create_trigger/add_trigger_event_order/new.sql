@@ -0,0 +1,16 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY
+);
+
+CREATE OR REPLACE FUNCTION public.audit()
+RETURNS trigger AS $$
+BEGIN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Events are compared regardless of the order they are declared in
+CREATE TRIGGER orders_audit
+    AFTER DELETE OR INSERT ON public.orders
+    FOR EACH ROW
+    EXECUTE FUNCTION public.audit();
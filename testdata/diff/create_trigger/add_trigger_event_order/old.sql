@@ -0,0 +1,10 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY
+);
+
+CREATE OR REPLACE FUNCTION public.audit()
+RETURNS trigger AS $$
+BEGIN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
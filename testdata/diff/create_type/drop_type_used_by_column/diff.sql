@@ -0,0 +1,3 @@
+ALTER TABLE people DROP COLUMN home;
+
+DROP TYPE IF EXISTS address RESTRICT;
@@ -0,0 +1,4 @@
+CREATE TABLE public.people (
+    id integer NOT NULL,
+    name text
+);
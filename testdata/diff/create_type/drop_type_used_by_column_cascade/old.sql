@@ -0,0 +1,10 @@
+CREATE TYPE public.address AS (
+    street text,
+    city text
+);
+
+CREATE TABLE public.people (
+    id integer NOT NULL,
+    name text,
+    home public.address
+);
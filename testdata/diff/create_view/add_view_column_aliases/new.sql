@@ -0,0 +1,11 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    amount numeric,
+    archived boolean
+);
+
+-- The column list names the view's output columns
+CREATE VIEW public.order_amounts (order_id, total) AS SELECT id, amount FROM public.orders;
+
+-- In a set operation, the listed names apply to the leading branch
+CREATE VIEW public.all_ids (ref) AS SELECT id FROM public.orders WHERE archived UNION SELECT id FROM public.orders;
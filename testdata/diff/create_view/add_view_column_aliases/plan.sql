@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS orders (
+    id integer,
+    amount numeric,
+    archived boolean,
+    CONSTRAINT orders_pkey PRIMARY KEY (id)
+);
+
+CREATE OR REPLACE VIEW all_ids AS
+ SELECT orders.id AS ref
+   FROM orders
+  WHERE orders.archived
+UNION
+ SELECT orders.id
+   FROM orders;
+
+CREATE OR REPLACE VIEW order_amounts AS
+ SELECT id AS order_id,
+    amount AS total
+   FROM orders;
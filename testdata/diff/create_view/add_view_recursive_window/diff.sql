@@ -0,0 +1,30 @@
+CREATE OR REPLACE VIEW employee_hierarchy AS
+ WITH RECURSIVE org_chart AS (
+         SELECT e.id,
+            e.name,
+            e.manager_id,
+            1 AS depth
+           FROM employees e
+          WHERE e.manager_id IS NULL
+        UNION ALL
+         SELECT e.id,
+            e.name,
+            e.manager_id,
+            oc.depth + 1 AS depth
+           FROM employees e
+             JOIN org_chart oc ON e.manager_id = oc.id
+        )
+ SELECT id,
+    name,
+    manager_id,
+    depth
+   FROM org_chart;
+
+CREATE OR REPLACE VIEW employee_salary_rank AS
+ SELECT id,
+    name,
+    department,
+    salary,
+    rank() OVER (PARTITION BY department ORDER BY salary DESC) AS salary_rank,
+    sum(salary) OVER (PARTITION BY department) AS department_total
+   FROM employees;
@@ -0,0 +1,7 @@
+CREATE TABLE public.employees (
+    id integer PRIMARY KEY,
+    name text NOT NULL,
+    manager_id integer,
+    department text NOT NULL,
+    salary numeric(10,2) NOT NULL
+);
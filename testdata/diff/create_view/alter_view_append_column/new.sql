@@ -0,0 +1,8 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    total integer,
+    status text
+);
+
+-- Appending a column keeps the existing columns, so the view is replaced in place
+CREATE VIEW public.order_totals AS SELECT id, total, status FROM public.orders;
@@ -0,0 +1,7 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    total integer,
+    status text
+);
+
+CREATE VIEW public.order_totals AS SELECT id, total FROM public.orders;
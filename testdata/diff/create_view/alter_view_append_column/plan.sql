@@ -0,0 +1,5 @@
+CREATE OR REPLACE VIEW order_totals AS
+ SELECT id,
+    total,
+    status
+   FROM orders;
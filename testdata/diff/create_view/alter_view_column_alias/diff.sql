@@ -0,0 +1,6 @@
+DROP VIEW IF EXISTS order_amounts RESTRICT;
+
+CREATE OR REPLACE VIEW order_amounts AS
+ SELECT id AS order_id,
+    amount AS amount_total
+   FROM orders;
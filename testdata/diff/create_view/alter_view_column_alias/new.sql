@@ -0,0 +1,7 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    amount numeric
+);
+
+-- Renaming a listed column cannot be done with CREATE OR REPLACE VIEW, so the view is recreated
+CREATE VIEW public.order_amounts (order_id, amount_total) AS SELECT id, amount FROM public.orders;
@@ -0,0 +1,6 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    amount numeric
+);
+
+CREATE VIEW public.order_amounts (order_id, total) AS SELECT id, amount FROM public.orders;
@@ -0,0 +1,8 @@
+CREATE TABLE public.orders (
+    id integer PRIMARY KEY,
+    total integer,
+    status text
+);
+
+-- Changing the type of an existing column cannot be done with CREATE OR REPLACE VIEW, so the view is recreated
+CREATE VIEW public.order_totals AS SELECT id, total::bigint AS total FROM public.orders;
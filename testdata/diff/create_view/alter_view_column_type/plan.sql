@@ -0,0 +1,6 @@
+DROP VIEW IF EXISTS order_totals RESTRICT;
+
+CREATE OR REPLACE VIEW order_totals AS
+ SELECT id,
+    total::bigint AS total
+   FROM orders;
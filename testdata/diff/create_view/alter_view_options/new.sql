@@ -0,0 +1,17 @@
+CREATE TABLE public.users (
+    id integer PRIMARY KEY,
+    name text
+);
+
+CREATE VIEW public.user_names AS
+SELECT id, name FROM users;
+
+ALTER VIEW public.user_names SET (security_barrier = true);
+
+CREATE VIEW public.user_ids AS
+SELECT id FROM users;
+
+CREATE VIEW public.named_users AS
+SELECT id, name FROM users
+WHERE name IS NOT NULL
+WITH CASCADED CHECK OPTION;
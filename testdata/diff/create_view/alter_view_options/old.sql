@@ -0,0 +1,10 @@
+CREATE TABLE public.users (
+    id integer PRIMARY KEY,
+    name text
+);
+
+CREATE VIEW public.user_names AS
+SELECT id, name FROM users;
+
+CREATE VIEW public.user_ids WITH (security_barrier = true) AS
+SELECT id FROM users;
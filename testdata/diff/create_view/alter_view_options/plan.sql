@@ -0,0 +1,9 @@
+CREATE OR REPLACE VIEW named_users WITH (check_option=cascaded) AS
+ SELECT id,
+    name
+   FROM users
+  WHERE name IS NOT NULL;
+
+ALTER VIEW user_ids RESET (security_barrier);
+
+ALTER VIEW user_names SET (security_barrier=true);
@@ -0,0 +1,4 @@
+CREATE TABLE public.users (
+    id integer PRIMARY KEY,
+    name text
+);
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- pg_buffercache installs a view (pg_buffercache) into the public schema.
+-- Extension members are not managed by pgschema and must not be dropped.
+CREATE EXTENSION IF NOT EXISTS pg_buffercache;
+
+CREATE TABLE public.users (
+    id integer PRIMARY KEY,
+    name text
+);
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_users_orders ON orders (user_id);
@@ -0,0 +1,8 @@
+CREATE TABLE public.orders (
+    id integer NOT NULL,
+    user_id integer
+);
+
+CREATE INDEX idx_users_orders ON public.orders (user_id);
+
+CREATE INDEX idx_orders_id ON public.orders (id);
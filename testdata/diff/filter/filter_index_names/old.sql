@@ -0,0 +1,4 @@
+CREATE TABLE public.orders (
+    id integer NOT NULL,
+    user_id integer
+);
@@ -0,0 +1,17 @@
+CREATE TABLE public.users (
+    id integer NOT NULL
+);
+
+CREATE TABLE public.users_audit (
+    id integer NOT NULL
+);
+
+CREATE TABLE public.orders (
+    id integer NOT NULL
+);
+
+CREATE FUNCTION users_count()
+RETURNS bigint
+LANGUAGE sql
+STABLE
+AS $$ SELECT count(*) FROM users; $$;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS users (
+    id integer NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS users_audit (
+    id integer NOT NULL
+);
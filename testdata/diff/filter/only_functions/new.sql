@@ -0,0 +1,10 @@
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    email text
+);
+
+CREATE FUNCTION add_one(x integer)
+RETURNS integer
+LANGUAGE sql
+IMMUTABLE
+AS $$ SELECT x + 1; $$;
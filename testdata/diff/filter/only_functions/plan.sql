@@ -0,0 +1,7 @@
+CREATE OR REPLACE FUNCTION add_one(
+    x integer
+)
+RETURNS integer
+LANGUAGE sql
+IMMUTABLE
+AS $$ SELECT x + 1; $$;
@@ -0,0 +1,14 @@
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    email text,
+    name text
+);
+
+CREATE INDEX users_email_idx ON public.users (email);
+
+-- The index of a new table is created with the table, so --only indexes leaves it out too
+CREATE TABLE public.orders (
+    id integer NOT NULL
+);
+
+CREATE INDEX orders_id_idx ON public.orders (id);
@@ -0,0 +1,7 @@
+CREATE TABLE public.measurements (
+    id integer NOT NULL,
+    logdate date NOT NULL,
+    peaktemp integer
+) PARTITION BY RANGE (logdate);
+
+CREATE INDEX idx_measurements_logdate ON public.measurements (logdate);
\ No newline at end of file
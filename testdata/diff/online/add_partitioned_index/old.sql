@@ -0,0 +1,5 @@
+CREATE TABLE public.measurements (
+    id integer NOT NULL,
+    logdate date NOT NULL,
+    peaktemp integer
+) PARTITION BY RANGE (logdate);
\ No newline at end of file
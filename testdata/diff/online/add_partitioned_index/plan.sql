@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_measurements_logdate ON measurements (logdate);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_documents_search;
+
+CREATE INDEX IF NOT EXISTS idx_documents_search ON documents USING gist (search tsvector_ops (siglen=64));
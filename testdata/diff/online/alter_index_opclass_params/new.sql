@@ -0,0 +1,7 @@
+CREATE TABLE public.documents (
+    id integer PRIMARY KEY,
+    search tsvector
+);
+
+-- Changing operator class parameters rebuilds the index
+CREATE INDEX idx_documents_search ON public.documents USING gist (search tsvector_ops (siglen = 64));
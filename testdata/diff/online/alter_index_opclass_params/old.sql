@@ -0,0 +1,6 @@
+CREATE TABLE public.documents (
+    id integer PRIMARY KEY,
+    search tsvector
+);
+
+CREATE INDEX idx_documents_search ON public.documents USING gist (search tsvector_ops (siglen = 32));
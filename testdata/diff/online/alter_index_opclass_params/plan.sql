@@ -0,0 +1,17 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_documents_search_pgschema_new ON documents USING gist (search tsvector_ops (siglen=64));
+
+-- pgschema:wait
+SELECT 
+    COALESCE(i.indisvalid, false) as done,
+    CASE 
+        WHEN p.blocks_total > 0 THEN p.blocks_done * 100 / p.blocks_total
+        ELSE 0
+    END as progress
+FROM pg_class c
+LEFT JOIN pg_index i ON c.oid = i.indexrelid
+LEFT JOIN pg_stat_progress_create_index p ON c.oid = p.index_relid
+WHERE c.relname = 'idx_documents_search_pgschema_new';
+
+DROP INDEX idx_documents_search;
+
+ALTER INDEX idx_documents_search_pgschema_new RENAME TO idx_documents_search;
@@ -0,0 +1,24 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'manager_role') THEN
+        CREATE ROLE manager_role;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'analyst_role') THEN
+        CREATE ROLE analyst_role;
+    END IF;
+END $$;
+
+CREATE TABLE employees (id serial PRIMARY KEY);
+
+GRANT SELECT ON employees TO manager_role WITH GRANT OPTION;
+
+-- manager_role passes SELECT on to analyst_role, so the grant depends on manager_role's grant option
+DO $$
+BEGIN
+    EXECUTE format('GRANT manager_role TO %I', current_user);
+    EXECUTE format('GRANT USAGE ON SCHEMA %I TO manager_role', current_schema());
+END $$;
+
+SET ROLE manager_role;
+GRANT SELECT ON employees TO analyst_role;
+RESET ROLE;
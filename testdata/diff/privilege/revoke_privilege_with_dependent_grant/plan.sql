@@ -0,0 +1,3 @@
+REVOKE SELECT ON TABLE employees FROM manager_role CASCADE;
+
+GRANT SELECT ON TABLE employees TO analyst_role;
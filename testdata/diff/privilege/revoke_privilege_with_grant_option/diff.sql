@@ -0,0 +1 @@
+REVOKE SELECT ON TABLE employees FROM manager_role CASCADE;
@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// FixtureOptions holds the plan flags a diff test case runs with, read from the optional
+// options.json next to its old.sql and new.sql. The keys are the flag names, e.g.
+//
+//	{"cascade-drops": ["types"], "allow-unsafe-type-changes": true}
+type FixtureOptions struct {
+	AllowUnsafeTypeChanges bool     `json:"allow-unsafe-type-changes"`
+	CascadeDrops           []string `json:"cascade-drops"`
+	DefaultTablespace      string   `json:"default-tablespace"`
+	Only                   []string `json:"only"`
+	Filters                []string `json:"filter"`
+	CommentOnly            bool     `json:"comment-only"`
+}
+
+// LoadFixtureOptions reads options.json from the test case directory, returning the zero options
+// (the flag defaults) when the file does not exist
+func LoadFixtureOptions(t *testing.T, dir string) FixtureOptions {
+	var options FixtureOptions
+	content, err := os.ReadFile(filepath.Join(dir, "options.json"))
+	if os.IsNotExist(err) {
+		return options
+	}
+	if err != nil {
+		t.Fatalf("Failed to read options.json: %v", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&options); err != nil {
+		t.Fatalf("Failed to parse options.json: %v", err)
+	}
+	return options
+}
@@ -17,12 +17,20 @@ var skipListPG14_15 = []string{
 	"create_view/add_view",
 	"create_view/alter_view",
 	"create_view/drop_view",
+	"create_view/add_view_column_aliases",
+	"create_view/alter_view_column_alias",
+	"create_view/alter_view_append_column",
+	"create_view/alter_view_column_type",
+	"create_table/add_table_like_view",
 	"dependency/table_to_view",
 
 	// Materialized view tests - same pg_get_viewdef() issue
 	"create_materialized_view/add_materialized_view",
 	"create_materialized_view/alter_materialized_view",
 	"create_materialized_view/drop_materialized_view",
+	"create_materialized_view/add_materialized_view_column_storage",
+	"create_materialized_view/alter_materialized_view_column_storage",
+	"create_materialized_view/rename_materialized_view_column",
 	"dependency/table_to_materialized_view",
 	"dependency/issue_300_function_table_composite_type",
 
@@ -33,6 +41,7 @@ var skipListPG14_15 = []string{
 	// Comment tests - fingerprint includes view definitions
 	"comment/add_index_comment",
 	"comment/add_view_comment",
+	"comment/add_materialized_view_column_comment",
 
 	// Index tests - fingerprint includes view definitions
 	"create_index/drop_index",
@@ -59,6 +68,7 @@ var skipListPG14_15 = []string{
 // against a database with the required extensions installed.
 var skipListRequiresExtension = []string{
 	"create_table/issue_295_pgvector_typmod",
+	"create_table/postgis_geometry_typmod",
 }
 
 // skipListForVersion maps PostgreSQL major versions to their skip lists.